@@ -0,0 +1,463 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/codewandler/dex/internal/config"
+	"github.com/codewandler/dex/internal/gitlab"
+	"github.com/codewandler/dex/internal/homer"
+	"github.com/codewandler/dex/internal/loki"
+	"github.com/codewandler/dex/internal/prometheus"
+	"github.com/codewandler/dex/internal/slack"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+// configPromptValue is the value a secret flag takes when given without "=value"
+// (or as "-"), meaning "prompt for it without echo"
+const configPromptValue = "-"
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage dex configuration",
+	Long: `Manage the dex configuration file (~/.dex/config.json).
+
+The per-integration subcommands validate credentials with a test call before
+saving them. If validation fails, nothing is written.`,
+}
+
+var configSlackCmd = &cobra.Command{
+	Use:   "slack",
+	Short: "Set and validate Slack tokens",
+	Long: `Set the Slack bot and/or user token.
+
+Tokens are read from a hidden prompt unless given as --flag=value. With no
+flags, the bot token is prompted for, followed by the optional user token
+(press Enter to keep the current one). Each token is validated with
+auth.test before the config is saved.
+
+Examples:
+  dex config slack                  # Prompt for bot and user token
+  dex config slack --bot-token      # Prompt for the bot token only
+  dex config slack --user-token     # Prompt for the user token only`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigFileOrExit()
+		reader := bufio.NewReader(os.Stdin)
+
+		botToken, _ := cmd.Flags().GetString("bot-token")
+		userToken, _ := cmd.Flags().GetString("user-token")
+		botSet := cmd.Flags().Changed("bot-token")
+		userSet := cmd.Flags().Changed("user-token")
+		if !botSet && !userSet {
+			botToken, userToken = configPromptValue, configPromptValue
+			botSet, userSet = true, true
+		}
+
+		if botSet {
+			botToken = resolveSecretFlag(reader, botToken, "Bot Token (xoxb-...)")
+			if botToken == "" {
+				configFail("bot token required")
+			}
+			client, err := slack.NewClient(botToken)
+			if err != nil {
+				configFail("failed to create client: %v", err)
+			}
+			resp, err := client.TestAuth()
+			if err != nil {
+				configFail("bot token rejected by Slack: %v", err)
+			}
+			setupSuccess.Printf("✓ Bot token valid: %s (%s)\n", resp.User, resp.Team)
+			cfg.Slack.BotToken = botToken
+		}
+
+		if userSet {
+			userToken = resolveSecretFlag(reader, userToken, "User Token (xoxp-..., Enter to keep current)")
+			if userToken != "" {
+				client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, userToken)
+				if err != nil {
+					configFail("failed to create client: %v", err)
+				}
+				resp, err := client.TestUserAuth()
+				if err != nil {
+					configFail("user token rejected by Slack: %v", err)
+				}
+				setupSuccess.Printf("✓ User token valid: @%s (%s)\n", resp.User, resp.Team)
+				cfg.Slack.UserToken = userToken
+			}
+		}
+
+		saveConfigOrExit(cfg)
+	},
+}
+
+var configGitLabCmd = &cobra.Command{
+	Use:   "gitlab",
+	Short: "Set and validate GitLab URL and token",
+	Long: `Set the GitLab URL and personal access token.
+
+The token is read from a hidden prompt unless given as --token=value. The
+token is validated against the GitLab API before the config is saved.
+
+Examples:
+  dex config gitlab                                  # Prompt for token, keep URL
+  dex config gitlab --url https://gitlab.example.com # Prompt for token
+  dex config gitlab --url https://gitlab.example.com --token`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigFileOrExit()
+		reader := bufio.NewReader(os.Stdin)
+
+		url, _ := cmd.Flags().GetString("url")
+		token, _ := cmd.Flags().GetString("token")
+		if url == "" {
+			url = cfg.GitLab.URL
+		}
+		if url == "" {
+			url = "https://gitlab.com"
+		}
+		url = strings.TrimSuffix(url, "/")
+		if !cmd.Flags().Changed("token") {
+			token = configPromptValue
+		}
+
+		setupDim.Println("Create a Personal Access Token at: " + url + "/-/user_settings/personal_access_tokens")
+		setupDim.Println("Required scopes: api, read_user")
+		token = resolveSecretFlag(reader, token, "Personal Access Token")
+		if token == "" {
+			configFail("token required")
+		}
+
+		client, err := gitlab.NewClient(url, token)
+		if err != nil {
+			configFail("failed to create client: %v", err)
+		}
+		user, err := client.TestAuth()
+		if err != nil {
+			configFail("authentication against %s failed: %v", url, err)
+		}
+		setupSuccess.Printf("✓ Token valid: @%s (%s)\n", user.Username, url)
+
+		cfg.GitLab.URL = url
+		cfg.GitLab.Token = token
+		saveConfigOrExit(cfg)
+	},
+}
+
+var configPrometheusCmd = &cobra.Command{
+	Use:   "prometheus <url>",
+	Short: "Set and validate the Prometheus URL",
+	Long: `Set the Prometheus URL after verifying the server is reachable.
+
+Examples:
+  dex config prometheus http://prometheus:9090`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigFileOrExit()
+		url := strings.TrimSuffix(args[0], "/")
+
+		if err := prometheus.NewClient(url).TestConnection(); err != nil {
+			configFail("connection to %s failed: %v", url, err)
+		}
+		setupSuccess.Printf("✓ Prometheus reachable (%s)\n", url)
+
+		cfg.Prometheus.URL = url
+		saveConfigOrExit(cfg)
+	},
+}
+
+var configLokiCmd = &cobra.Command{
+	Use:   "loki <url>",
+	Short: "Set and validate the Loki URL",
+	Long: `Set the Loki URL after verifying the server is reachable.
+
+Examples:
+  dex config loki http://loki:3100`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigFileOrExit()
+		url := strings.TrimSuffix(args[0], "/")
+
+		client, err := loki.NewClient(url)
+		if err != nil {
+			configFail("failed to create client: %v", err)
+		}
+		if err := client.TestConnection(); err != nil {
+			configFail("connection to %s failed: %v", url, err)
+		}
+		setupSuccess.Printf("✓ Loki reachable (%s)\n", url)
+
+		cfg.Loki.URL = url
+		saveConfigOrExit(cfg)
+	},
+}
+
+var configHomerCmd = &cobra.Command{
+	Use:   "homer",
+	Short: "Set and validate Homer URL and credentials",
+	Long: `Set the default Homer URL, username and password.
+
+The password is read from a hidden prompt unless given as --password=value.
+The credentials are validated by logging in before the config is saved.
+
+Examples:
+  dex config homer --url https://homer.example.com --username admin
+  dex config homer --password       # Prompt for a new password, keep URL and user`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg := loadConfigFileOrExit()
+		reader := bufio.NewReader(os.Stdin)
+
+		url, _ := cmd.Flags().GetString("url")
+		username, _ := cmd.Flags().GetString("username")
+		password, _ := cmd.Flags().GetString("password")
+		if url == "" {
+			url = cfg.Homer.URL
+		}
+		if username == "" {
+			username = cfg.Homer.Username
+		}
+		if url == "" {
+			configFail("--url required (no Homer URL configured yet)")
+		}
+		if username == "" {
+			username = promptString(reader, "Username", "")
+		}
+		if username == "" {
+			configFail("username required")
+		}
+		url = strings.TrimSuffix(url, "/")
+		if !cmd.Flags().Changed("password") {
+			password = configPromptValue
+		}
+		password = resolveSecretFlag(reader, password, "Password")
+
+		client := homer.NewClient(url)
+		if err := client.TestConnection(); err != nil {
+			configFail("connection to %s failed: %v", url, err)
+		}
+		if err := client.Authenticate(username, password); err != nil {
+			configFail("login as %s failed: %v", username, err)
+		}
+		setupSuccess.Printf("✓ Logged in as %s (%s)\n", username, url)
+
+		cfg.Homer.URL = url
+		cfg.Homer.Username = username
+		cfg.Homer.Password = password
+		saveConfigOrExit(cfg)
+	},
+}
+
+var configExportCmd = &cobra.Command{
+	Use:   "export <file>",
+	Short: "Write the full config to a file for backup or another machine",
+	Long: `Export the dex config file to <file> ("-" for stdout).
+
+Credentials (tokens, passwords, OAuth client secrets and stored OAuth tokens)
+are left out unless --include-secrets is given. Environment variable overrides
+are not included; only what is stored in ~/.dex/config.json is exported.
+
+Examples:
+  dex config export dex-config.json                     # Without secrets
+  dex config export dex-config.json --include-secrets   # Full backup
+  dex config export - | jq .gitlab`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		includeSecrets, _ := cmd.Flags().GetBool("include-secrets")
+
+		cfg := loadConfigFileOrExit()
+		if !includeSecrets {
+			cfg = cfg.Redacted()
+		}
+		data, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			setupError.Fprintf(os.Stderr, "Failed to encode config: %v\n", err)
+			os.Exit(1)
+		}
+		data = append(data, '\n')
+
+		if args[0] == "-" {
+			os.Stdout.Write(data)
+			return
+		}
+		// Secrets may be included, so keep the export as private as the config itself
+		if err := os.WriteFile(args[0], data, 0600); err != nil {
+			setupError.Fprintf(os.Stderr, "Failed to write %s: %v\n", args[0], err)
+			os.Exit(1)
+		}
+		if includeSecrets {
+			setupSuccess.Printf("✓ Exported config with secrets to %s\n", args[0])
+		} else {
+			setupSuccess.Printf("✓ Exported config to %s (secrets redacted, use --include-secrets to keep them)\n", args[0])
+		}
+	},
+}
+
+var configImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Load a config exported with 'dex config export'",
+	Long: `Import a config file ("-" for stdin) into ~/.dex/config.json.
+
+The file is validated first: unknown keys, wrongly typed values and trailing
+data are rejected and nothing is written. By default the imported values are
+merged into the current config key by key; empty values never clear existing
+ones, so importing a redacted export keeps the secrets already configured.
+With --replace the current config is replaced entirely.
+
+Examples:
+  dex config import dex-config.json
+  dex config import dex-config.json --replace`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		replace, _ := cmd.Flags().GetBool("replace")
+
+		var (
+			data []byte
+			err  error
+		)
+		if args[0] == "-" {
+			data, err = io.ReadAll(os.Stdin)
+		} else {
+			data, err = os.ReadFile(args[0])
+		}
+		if err != nil {
+			configFail("failed to read %s: %v", args[0], err)
+		}
+
+		imported, err := config.Parse(data)
+		if err != nil {
+			configFail("%s: %v", args[0], err)
+		}
+
+		cfg := imported
+		if !replace {
+			cfg, err = config.Merge(loadConfigFileOrExit(), imported)
+			if err != nil {
+				configFail("failed to merge config: %v", err)
+			}
+		}
+
+		saveConfigOrExit(cfg)
+		if replace {
+			setupSuccess.Printf("✓ Replaced config with %s\n", args[0])
+		} else {
+			setupSuccess.Printf("✓ Merged %s into config\n", args[0])
+		}
+	},
+}
+
+var configPathCmd = &cobra.Command{
+	Use:   "path",
+	Short: "Show where dex reads its config and keeps local data",
+	Long: `Print the resolved locations of the config file, the GitLab and Slack
+indexes and the Slack mention status cache, and whether each exists.
+
+Environment variables override values from the config file; this command
+only shows the files involved.
+
+Examples:
+  dex config path
+  dex config path -o json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		var locs config.Locations
+
+		dir, err := config.ConfigDir()
+		locs.Add("data-dir", dir, err)
+		path, err := config.ConfigPath()
+		locs.Add("config", path, err)
+		path, err = gitlab.IndexPath()
+		locs.Add("gitlab-index", path, err)
+		path, err = slack.IndexPath()
+		locs.Add("slack-index", path, err)
+		path, err = slack.MentionCachePath()
+		locs.Add("mention-cache", path, err)
+
+		Render(&locs)
+	},
+}
+
+// resolveSecretFlag returns the flag value, or prompts for it without echo when
+// the flag was given without a value
+func resolveSecretFlag(reader *bufio.Reader, value, prompt string) string {
+	if value != configPromptValue {
+		return strings.TrimSpace(value)
+	}
+	return promptSecret(reader, prompt)
+}
+
+// promptSecret reads a secret from the terminal without echo. When stdin is not
+// a terminal (e.g. piped), it reads a single line instead.
+func promptSecret(reader *bufio.Reader, prompt string) string {
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		input, _ := reader.ReadString('\n')
+		return strings.TrimSpace(input)
+	}
+	fmt.Fprintf(os.Stderr, "%s: ", prompt)
+	b, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// loadConfigFileOrExit loads the file-only config so env-only values aren't persisted
+func loadConfigFileOrExit() *config.Config {
+	cfg, err := config.LoadFromFile()
+	if err != nil {
+		setupError.Fprintf(os.Stderr, "Failed to load config file: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+func saveConfigOrExit(cfg *config.Config) {
+	if err := config.Save(cfg); err != nil {
+		setupError.Fprintf(os.Stderr, "Failed to save config: %v\n", err)
+		os.Exit(1)
+	}
+	if path, err := config.ConfigPath(); err == nil {
+		setupDim.Printf("Saved to %s\n", path)
+	}
+}
+
+// configFail reports a validation failure and exits without saving
+func configFail(format string, args ...any) {
+	setupError.Fprintf(os.Stderr, "✗ "+format+"\n", args...)
+	setupDim.Fprintln(os.Stderr, "Config not changed.")
+	os.Exit(1)
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configSlackCmd)
+	configCmd.AddCommand(configGitLabCmd)
+	configCmd.AddCommand(configPrometheusCmd)
+	configCmd.AddCommand(configLokiCmd)
+	configCmd.AddCommand(configHomerCmd)
+	configCmd.AddCommand(configExportCmd)
+	configCmd.AddCommand(configImportCmd)
+	configCmd.AddCommand(configPathCmd)
+
+	configSlackCmd.Flags().String("bot-token", "", "Bot token (prompted without echo if no value given)")
+	configSlackCmd.Flags().Lookup("bot-token").NoOptDefVal = configPromptValue
+	configSlackCmd.Flags().String("user-token", "", "User token (prompted without echo if no value given)")
+	configSlackCmd.Flags().Lookup("user-token").NoOptDefVal = configPromptValue
+
+	configGitLabCmd.Flags().String("url", "", "GitLab URL (default: current config or https://gitlab.com)")
+	configGitLabCmd.Flags().String("token", "", "Personal access token (prompted without echo if no value given)")
+	configGitLabCmd.Flags().Lookup("token").NoOptDefVal = configPromptValue
+
+	configHomerCmd.Flags().String("url", "", "Homer URL (default: current config)")
+	configHomerCmd.Flags().String("username", "", "Homer username (default: current config)")
+	configHomerCmd.Flags().String("password", "", "Homer password (prompted without echo if no value given)")
+	configHomerCmd.Flags().Lookup("password").NoOptDefVal = configPromptValue
+
+	configExportCmd.Flags().Bool("include-secrets", false, "Include tokens, passwords and client secrets")
+
+	configImportCmd.Flags().Bool("replace", false, "Replace the current config instead of merging into it")
+}
@@ -2,6 +2,10 @@ package cli
 
 import (
 	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/codewandler/dex/internal/gh"
 	"github.com/codewandler/dex/internal/render"
@@ -104,6 +108,7 @@ Examples:
   dex gh issue list -L 50
   dex gh issue list -R owner/repo
   dex gh issue list -o json
+  dex gh issue list --json                 # same as -o json
   dex gh issue list --after <cursor>       # next page (cursor from previous -o json output)`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := gh.NewClient()
@@ -125,6 +130,9 @@ Examples:
 		repo, _ := cmd.Flags().GetString("repo")
 
 		compact, _ := cmd.Flags().GetBool("compact")
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			outputFormat = "json"
+		}
 
 		result, err := client.IssueList(gh.IssueListOptions{
 			States:    states,
@@ -159,7 +167,8 @@ var ghIssueViewCmd = &cobra.Command{
 
 Examples:
   dex gh issue view 123
-  dex gh issue view 123 --repo owner/repo`,
+  dex gh issue view 123 --repo owner/repo
+  dex gh issue view 123 --json`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := gh.NewClient()
@@ -174,6 +183,9 @@ Examples:
 		}
 
 		repo, _ := cmd.Flags().GetString("repo")
+		if asJSON, _ := cmd.Flags().GetBool("json"); asJSON {
+			outputFormat = "json"
+		}
 
 		issue, err := client.IssueView(number, repo)
 		if err != nil {
@@ -185,6 +197,47 @@ Examples:
 	},
 }
 
+var ghIssueCommentsCmd = &cobra.Command{
+	Use:   "comments <number>",
+	Short: "List an issue's comments",
+	Long: `List the comments on a GitHub issue, oldest first, with author, time and
+the body rendered as markdown.
+
+Examples:
+  dex gh issue comments 123
+  dex gh issue comments 123 --compact
+  dex gh issue comments 123 --repo owner/repo
+  dex gh issue comments 123 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		var number int
+		if _, err := fmt.Sscanf(args[0], "%d", &number); err != nil {
+			return fmt.Errorf("invalid issue number: %s", args[0])
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		result, err := client.IssueComments(number, repo)
+		if err != nil {
+			return err
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(result, mode)
+		return nil
+	},
+}
+
 var ghIssueCreateCmd = &cobra.Command{
 	Use:   "create",
 	Short: "Create a new issue",
@@ -356,6 +409,461 @@ Examples:
 	},
 }
 
+var ghIssueSearchAndLabelCmd = &cobra.Command{
+	Use:   "search-and-label",
+	Short: "Add/remove labels on all issues matching a search",
+	Long: `Search issues in a repository and add or remove labels on every match.
+
+The search uses GitHub search syntax (label:, no:assignee, created:<2024-01-01,
+...). Nothing is changed unless --yes is given: by default the command is a dry
+run that lists each matching issue with the labels it would gain or lose.
+Issues that already have the wanted labels are left alone. A failure on one
+issue does not stop the others.
+
+Examples:
+  dex gh issue search-and-label --search "label:needs-triage crash" --add-label bug
+  dex gh issue search-and-label --search "no:label" --add-label needs-triage --yes
+  dex gh issue search-and-label --search "label:stale" -r stale -a wontfix -s closed
+  dex gh issue search-and-label --search "label:p2" -a p1 -r p2 -R owner/repo --yes`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		query, _ := cmd.Flags().GetString("search")
+		addLabels, _ := cmd.Flags().GetStringSlice("add-label")
+		removeLabels, _ := cmd.Flags().GetStringSlice("remove-label")
+		state, _ := cmd.Flags().GetString("state")
+		limit, _ := cmd.Flags().GetInt("limit")
+		repoFlag, _ := cmd.Flags().GetString("repo")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		if strings.TrimSpace(query) == "" {
+			return fmt.Errorf("--search is required")
+		}
+		if len(addLabels) == 0 && len(removeLabels) == 0 {
+			return fmt.Errorf("at least one --add-label or --remove-label is required")
+		}
+		// Dry run unless --yes; an explicit --dry-run wins over --yes
+		dryRun = !yes || (cmd.Flags().Changed("dry-run") && dryRun)
+
+		repo, err := client.RepoName(repoFlag)
+		if err != nil {
+			return err
+		}
+
+		issues, err := client.SearchIssues(gh.SearchIssuesOptions{
+			Query: query,
+			Repo:  repo,
+			State: state,
+			Limit: limit,
+		})
+		if err != nil {
+			return err
+		}
+
+		result := &gh.IssueBulkLabelResult{Repo: repo, Query: query, DryRun: dryRun, Issues: []gh.IssueLabelChange{}}
+		for _, issue := range issues {
+			change := gh.PlanIssueLabels(issue, addLabels, removeLabels)
+			if !dryRun && !change.Unchanged() {
+				if err := client.IssueEdit(gh.IssueEditOptions{
+					Number:       issue.Number,
+					AddLabels:    change.Add,
+					RemoveLabels: change.Remove,
+					Repo:         repo,
+				}); err != nil {
+					change.Error = strings.TrimSpace(err.Error())
+				}
+			}
+			result.Issues = append(result.Issues, change)
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(result, mode)
+		if len(issues) == limit {
+			fmt.Fprintf(os.Stderr, "Note: hit --limit %d; more issues may match\n", limit)
+		}
+		if failed := result.Failed(); failed > 0 {
+			return fmt.Errorf("%d issue(s) could not be updated", failed)
+		}
+		return nil
+	},
+}
+
+// PR commands
+var ghPRCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Manage GitHub pull requests",
+	Long:  `List, view, create, check out, and merge GitHub pull requests.`,
+}
+
+var ghPRListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List pull requests in a repository",
+	Long: `List pull requests in a GitHub repository.
+
+By default, lists open pull requests.
+
+Examples:
+  dex gh pr list
+  dex gh pr ls --state merged --limit 10
+  dex gh pr list --label bug --label p1
+  dex gh pr list --repo owner/repo --compact`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		state, _ := cmd.Flags().GetString("state")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		limit, _ := cmd.Flags().GetInt("limit")
+		repo, _ := cmd.Flags().GetString("repo")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		prs, err := client.PRList(gh.PRListOptions{
+			State:  state,
+			Labels: labels,
+			Limit:  limit,
+			Repo:   repo,
+		})
+		if err != nil {
+			return err
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gh.PRListResult{PRs: prs}, mode)
+		return nil
+	},
+}
+
+var ghPRViewCmd = &cobra.Command{
+	Use:   "view <number>",
+	Short: "View a specific pull request",
+	Long: `View details of a GitHub pull request: branches, size, review decision,
+mergeability, and description.
+
+Examples:
+  dex gh pr view 123
+  dex gh pr view 123 --repo owner/repo
+  dex gh pr view 123 -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		var number int
+		if _, err := fmt.Sscanf(args[0], "%d", &number); err != nil {
+			return fmt.Errorf("invalid pull request number: %s", args[0])
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+
+		pr, err := client.PRView(number, repo)
+		if err != nil {
+			return err
+		}
+
+		Render(&gh.PRResult{PRDetail: pr})
+		return nil
+	},
+}
+
+var ghPRCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a pull request from the current branch",
+	Long: `Create a GitHub pull request from the current branch. The branch must be
+pushed first.
+
+Examples:
+  dex gh pr create --title "Fix login redirect" --body "Closes #42"
+  dex gh pr create -t "WIP: new parser" --draft
+  dex gh pr create -t "Backport fix" --base release-1.2`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		title, _ := cmd.Flags().GetString("title")
+		body, _ := cmd.Flags().GetString("body")
+		base, _ := cmd.Flags().GetString("base")
+		draft, _ := cmd.Flags().GetBool("draft")
+		repo, _ := cmd.Flags().GetString("repo")
+
+		if title == "" {
+			return fmt.Errorf("--title is required")
+		}
+
+		pr, err := client.PRCreate(gh.PRCreateOptions{
+			Title: title,
+			Body:  body,
+			Base:  base,
+			Draft: draft,
+			Repo:  repo,
+		})
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Created pull request #%d: %s\n", pr.Number, pr.URL)
+		return nil
+	},
+}
+
+var ghPRCheckoutCmd = &cobra.Command{
+	Use:   "checkout <number>",
+	Short: "Check out a pull request's branch locally",
+	Long: `Check out the head branch of a GitHub pull request in the current repository.
+
+Examples:
+  dex gh pr checkout 123
+  dex gh pr checkout 123 --repo owner/repo`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		var number int
+		if _, err := fmt.Sscanf(args[0], "%d", &number); err != nil {
+			return fmt.Errorf("invalid pull request number: %s", args[0])
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+
+		return client.PRCheckout(number, repo)
+	},
+}
+
+var ghPRMergeCmd = &cobra.Command{
+	Use:   "merge <number>",
+	Short: "Merge a pull request",
+	Long: `Merge a GitHub pull request. Creates a merge commit unless --squash or
+--rebase is given.
+
+Examples:
+  dex gh pr merge 123
+  dex gh pr merge 123 --squash --delete-branch
+  dex gh pr merge 123 --rebase --repo owner/repo`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		var number int
+		if _, err := fmt.Sscanf(args[0], "%d", &number); err != nil {
+			return fmt.Errorf("invalid pull request number: %s", args[0])
+		}
+
+		squash, _ := cmd.Flags().GetBool("squash")
+		rebase, _ := cmd.Flags().GetBool("rebase")
+		deleteBranch, _ := cmd.Flags().GetBool("delete-branch")
+		repo, _ := cmd.Flags().GetString("repo")
+
+		method := "merge"
+		switch {
+		case squash && rebase:
+			return fmt.Errorf("--squash and --rebase are mutually exclusive")
+		case squash:
+			method = "squash"
+		case rebase:
+			method = "rebase"
+		}
+
+		if err := client.PRMerge(gh.PRMergeOptions{
+			Number:       number,
+			Method:       method,
+			DeleteBranch: deleteBranch,
+			Repo:         repo,
+		}); err != nil {
+			return err
+		}
+
+		fmt.Printf("Merged pull request #%d (%s)\n", number, method)
+		return nil
+	},
+}
+
+// Run commands
+var ghRunCmd = &cobra.Command{
+	Use:   "run",
+	Short: "View GitHub Actions workflow runs",
+	Long:  `List, view, and watch GitHub Actions workflow runs.`,
+}
+
+var ghRunListCmd = &cobra.Command{
+	Use:     "list",
+	Aliases: []string{"ls"},
+	Short:   "List recent workflow runs",
+	Long: `List recent GitHub Actions workflow runs, newest first.
+
+Examples:
+  dex gh run list
+  dex gh run ls --branch main --limit 5
+  dex gh run list --workflow ci.yml
+  dex gh run list --repo owner/repo -o json`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		workflow, _ := cmd.Flags().GetString("workflow")
+		branch, _ := cmd.Flags().GetString("branch")
+		limit, _ := cmd.Flags().GetInt("limit")
+		repo, _ := cmd.Flags().GetString("repo")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		runs, err := client.RunList(gh.RunListOptions{
+			Workflow: workflow,
+			Branch:   branch,
+			Limit:    limit,
+			Repo:     repo,
+		})
+		if err != nil {
+			return err
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gh.RunListResult{Runs: runs}, mode)
+		return nil
+	},
+}
+
+var ghRunViewCmd = &cobra.Command{
+	Use:   "view <run-id>",
+	Short: "View a workflow run",
+	Long: `View a GitHub Actions workflow run with its jobs. Steps are listed for jobs
+that did not succeed.
+
+Use --log-failed to also print the logs of the failed steps.
+
+Examples:
+  dex gh run view 1234567890
+  dex gh run view 1234567890 --log-failed
+  dex gh run view 1234567890 --repo owner/repo -o json`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid run ID: %s", args[0])
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		logFailed, _ := cmd.Flags().GetBool("log-failed")
+
+		run, err := client.RunView(id, repo)
+		if err != nil {
+			return err
+		}
+
+		Render(&gh.RunResult{RunDetail: run})
+
+		if logFailed {
+			if run.Succeeded() {
+				fmt.Println("\nNo failed steps.")
+				return nil
+			}
+			log, err := client.RunFailedLog(id, repo)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n%s", log)
+		}
+		return nil
+	},
+}
+
+var ghRunWatchCmd = &cobra.Command{
+	Use:   "watch <run-id>",
+	Short: "Wait for a workflow run to finish",
+	Long: `Poll a GitHub Actions workflow run until it completes, printing job state
+changes as they happen, then show the final run.
+
+Exits with status 1 if the run does not succeed (failure, cancelled, timed
+out, ...), so it can gate scripts.
+
+Examples:
+  dex gh run watch 1234567890
+  dex gh run watch 1234567890 --interval 30s
+  dex gh run watch $(dex gh run ls -L 1 -o json | jq '.runs[0].databaseId') && ./deploy.sh`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		id, err := strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid run ID: %s", args[0])
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		interval, _ := cmd.Flags().GetDuration("interval")
+		if interval < time.Second {
+			interval = time.Second
+		}
+
+		// Progress goes to stderr so -o json stays clean
+		seen := make(map[int64]string)
+		run, err := client.RunWatch(id, repo, interval, func(r *gh.RunDetail) {
+			for _, job := range r.Jobs {
+				state := job.State()
+				if seen[job.ID] != state {
+					seen[job.ID] = state
+					fmt.Fprintf(os.Stderr, "%s  %-11s  %s\n", time.Now().Format("15:04:05"), state, job.Name)
+				}
+			}
+		})
+		if err != nil {
+			return err
+		}
+
+		Render(&gh.RunResult{RunDetail: run})
+
+		if !run.Succeeded() {
+			fmt.Fprintf(os.Stderr, "Run %d concluded: %s\n", run.ID, run.Conclusion)
+			os.Exit(1)
+		}
+		return nil
+	},
+}
+
 // Label commands
 var ghLabelCmd = &cobra.Command{
 	Use:   "label",
@@ -466,11 +974,95 @@ Examples:
 	},
 }
 
+var ghLabelSyncCmd = &cobra.Command{
+	Use:   "sync <file>",
+	Short: "Create or update labels from a YAML/JSON file",
+	Long: `Apply a label set from a file to a repository.
+
+The file is a YAML or JSON list of labels (or an object with a "labels" list),
+each with name, color (hex, "#" optional; quote it in YAML) and description.
+Missing labels are created and labels whose color or description differ are
+updated (via 'gh label create --force'). Labels in the repo but not in the file
+are left alone unless --prune is given; deleting them also requires --yes.
+
+Example file:
+  - name: bug
+    color: "d73a4a"
+    description: Something isn't working
+  - name: enhancement
+    color: "a2eeef"
+
+Examples:
+  dex gh label sync labels.yaml
+  dex gh label sync labels.yaml --dry-run
+  dex gh label sync labels.json --repo owner/repo
+  dex gh label sync labels.yaml --prune --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		repo, _ := cmd.Flags().GetString("repo")
+		prune, _ := cmd.Flags().GetBool("prune")
+		yes, _ := cmd.Flags().GetBool("yes")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		desired, err := gh.LoadLabelFile(args[0])
+		if err != nil {
+			return err
+		}
+
+		existing, err := client.LabelList(gh.LabelListOptions{Limit: 1000, Repo: repo})
+		if err != nil {
+			return err
+		}
+
+		plan := gh.PlanLabelSync(desired, existing, prune)
+		if len(plan.Delete) > 0 && !yes && !dryRun {
+			names := make([]string, len(plan.Delete))
+			for i, l := range plan.Delete {
+				names[i] = l.Name
+			}
+			return fmt.Errorf("--prune would delete %d label(s): %s\nRe-run with --yes to confirm (or --dry-run to preview)",
+				len(names), strings.Join(names, ", "))
+		}
+
+		result := &gh.LabelSyncResult{Repo: repo, DryRun: dryRun, Plan: plan}
+		if !dryRun {
+			for _, l := range append(append([]gh.Label{}, plan.Create...), plan.Update...) {
+				if _, err := client.LabelCreate(gh.LabelCreateOptions{
+					Name:        l.Name,
+					Color:       l.Color,
+					Description: l.Description,
+					Repo:        repo,
+					Force:       true,
+				}); err != nil {
+					result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", l.Name, strings.TrimSpace(err.Error())))
+				}
+			}
+			for _, l := range plan.Delete {
+				if err := client.LabelDelete(gh.LabelDeleteOptions{Name: l.Name, Repo: repo}); err != nil {
+					result.Failed = append(result.Failed, fmt.Sprintf("%s: %v", l.Name, strings.TrimSpace(err.Error())))
+				}
+			}
+		}
+
+		Render(result)
+		if len(result.Failed) > 0 {
+			return fmt.Errorf("%d label(s) could not be synced", len(result.Failed))
+		}
+		return nil
+	},
+}
+
 // Release commands
 var ghReleaseCmd = &cobra.Command{
 	Use:   "release",
 	Short: "Manage GitHub releases",
-	Long:  `Create, list, and view GitHub releases.`,
+	Long:  `Create, list, view, and edit GitHub releases and upload or download their assets.`,
 }
 
 var ghReleaseListCmd = &cobra.Command{
@@ -568,7 +1160,8 @@ Examples:
   dex gh release create v1.0.0 --generate-notes
   dex gh release create v1.0.0 --notes-file CHANGELOG.md
   dex gh release create v1.0.0 --draft
-  dex gh release create v1.0.0 --prerelease --title "Beta Release"`,
+  dex gh release create v1.0.0 --prerelease --title "Beta Release"
+  dex gh release create v1.0.0 --generate-notes --asset dist/dex-linux-amd64 --asset dist/dex-darwin-arm64`,
 	Args: cobra.ExactArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
 		client := gh.NewClient()
@@ -586,6 +1179,7 @@ Examples:
 		prerelease, _ := cmd.Flags().GetBool("prerelease")
 		latest, _ := cmd.Flags().GetString("latest")
 		target, _ := cmd.Flags().GetString("target")
+		assets, _ := cmd.Flags().GetStringArray("asset")
 		repo, _ := cmd.Flags().GetString("repo")
 
 		// Validate: need either notes, notes-file, or generate-notes
@@ -602,6 +1196,7 @@ Examples:
 			Draft:         draft,
 			Prerelease:    prerelease,
 			Target:        target,
+			Assets:        assets,
 			Repo:          repo,
 		}
 
@@ -621,6 +1216,86 @@ Examples:
 	},
 }
 
+var ghReleaseUploadCmd = &cobra.Command{
+	Use:   "upload <tag> <file>...",
+	Short: "Upload assets to a release",
+	Long: `Attach local files to an existing GitHub release.
+
+A file may be given as "path#label" to show a different name on the release
+page. All files are checked before anything is uploaded.
+
+Examples:
+  dex gh release upload v1.0.0 dist/dex-linux-amd64 dist/dex-darwin-arm64
+  dex gh release upload v1.0.0 'dist/checksums.txt#Checksums'
+  dex gh release upload v1.0.0 dist/dex-linux-amd64 --clobber`,
+	Args: cobra.MinimumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		clobber, _ := cmd.Flags().GetBool("clobber")
+		repo, _ := cmd.Flags().GetString("repo")
+
+		if err := client.ReleaseUpload(gh.ReleaseUploadOptions{
+			Tag:     args[0],
+			Files:   args[1:],
+			Clobber: clobber,
+			Repo:    repo,
+		}); err != nil {
+			return err
+		}
+
+		fmt.Printf("Uploaded %d asset(s) to release %s\n", len(args)-1, args[0])
+		return nil
+	},
+}
+
+var ghReleaseDownloadCmd = &cobra.Command{
+	Use:   "download <tag>",
+	Short: "Download assets from a release",
+	Long: `Download the assets of a GitHub release.
+
+Without --pattern, all assets are downloaded. Patterns are globs matched
+against asset names and may be repeated.
+
+Examples:
+  dex gh release download v1.0.0
+  dex gh release download v1.0.0 --pattern '*linux*' --dir /tmp/dex
+  dex gh release download v1.0.0 -p '*.tar.gz' -p checksums.txt --clobber`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		client := gh.NewClient()
+
+		if !client.IsAvailable() {
+			return fmt.Errorf("gh CLI is not available or not authenticated. Run 'dex gh auth' first")
+		}
+
+		patterns, _ := cmd.Flags().GetStringArray("pattern")
+		dir, _ := cmd.Flags().GetString("dir")
+		clobber, _ := cmd.Flags().GetBool("clobber")
+		repo, _ := cmd.Flags().GetString("repo")
+
+		if err := client.ReleaseDownload(gh.ReleaseDownloadOptions{
+			Tag:      args[0],
+			Patterns: patterns,
+			Dir:      dir,
+			Clobber:  clobber,
+			Repo:     repo,
+		}); err != nil {
+			return err
+		}
+
+		if dir == "" {
+			dir = "."
+		}
+		fmt.Printf("Downloaded assets of release %s to %s\n", args[0], dir)
+		return nil
+	},
+}
+
 var ghReleaseEditCmd = &cobra.Command{
 	Use:   "edit <tag>",
 	Short: "Edit an existing release",
@@ -804,9 +1479,15 @@ func init() {
 	ghIssueListCmd.Flags().String("after", "", "Cursor for next page (from next_cursor in JSON output)")
 	ghIssueListCmd.Flags().Bool("compact", false, "Compact output: one line per issue")
 	ghIssueListCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+	ghIssueListCmd.Flags().Bool("json", false, "Print JSON (same as -o json)")
 
 	// Issue view flags
 	ghIssueViewCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+	ghIssueViewCmd.Flags().Bool("json", false, "Print JSON (same as -o json)")
+
+	// Issue comments flags
+	ghIssueCommentsCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+	ghIssueCommentsCmd.Flags().Bool("compact", false, "Compact output: one line per comment")
 
 	// Issue create flags
 	ghIssueCreateCmd.Flags().StringP("title", "t", "", "Issue title (required)")
@@ -829,14 +1510,80 @@ func init() {
 	ghIssueEditCmd.Flags().StringSliceP("remove-label", "r", nil, "Labels to remove (can be repeated)")
 	ghIssueEditCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
 
+	// Issue search-and-label flags
+	ghIssueSearchAndLabelCmd.Flags().String("search", "", "GitHub search query selecting the issues (required)")
+	ghIssueSearchAndLabelCmd.Flags().StringSliceP("add-label", "a", nil, "Labels to add (can be repeated)")
+	ghIssueSearchAndLabelCmd.Flags().StringSliceP("remove-label", "r", nil, "Labels to remove (can be repeated)")
+	ghIssueSearchAndLabelCmd.Flags().StringP("state", "s", "open", "Issue state: open or closed")
+	ghIssueSearchAndLabelCmd.Flags().IntP("limit", "L", 100, "Maximum number of issues to change")
+	ghIssueSearchAndLabelCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format (default: current repo)")
+	ghIssueSearchAndLabelCmd.Flags().Bool("dry-run", true, "Only show what would change (the default without --yes)")
+	ghIssueSearchAndLabelCmd.Flags().BoolP("yes", "y", false, "Apply the label changes")
+	ghIssueSearchAndLabelCmd.Flags().Bool("compact", false, "Print counts only")
+
 	// Add issue subcommands
 	ghIssueCmd.AddCommand(ghIssueCloseCmd)
 	ghIssueCmd.AddCommand(ghIssueCommentCmd)
+	ghIssueCmd.AddCommand(ghIssueCommentsCmd)
 	ghIssueCmd.AddCommand(ghIssueCreateCmd)
 	ghIssueCmd.AddCommand(ghIssueEditCmd)
 	ghIssueCmd.AddCommand(ghIssueListCmd)
+	ghIssueCmd.AddCommand(ghIssueSearchAndLabelCmd)
 	ghIssueCmd.AddCommand(ghIssueViewCmd)
 
+	// PR list flags
+	ghPRListCmd.Flags().StringP("state", "s", "", "Filter by state: open, closed, merged, all (default: open)")
+	ghPRListCmd.Flags().StringSliceP("label", "l", nil, "Filter by label (repeatable)")
+	ghPRListCmd.Flags().IntP("limit", "L", 30, "Maximum number of pull requests to fetch")
+	ghPRListCmd.Flags().Bool("compact", false, "Compact output: one line per pull request")
+	ghPRListCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// PR view flags
+	ghPRViewCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// PR create flags
+	ghPRCreateCmd.Flags().StringP("title", "t", "", "Pull request title (required)")
+	ghPRCreateCmd.Flags().StringP("body", "b", "", "Pull request description")
+	ghPRCreateCmd.Flags().StringP("base", "B", "", "Base branch (default: the repository's default branch)")
+	ghPRCreateCmd.Flags().BoolP("draft", "d", false, "Open as a draft pull request")
+	ghPRCreateCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// PR checkout flags
+	ghPRCheckoutCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// PR merge flags
+	ghPRMergeCmd.Flags().BoolP("squash", "s", false, "Squash the commits into one")
+	ghPRMergeCmd.Flags().BoolP("rebase", "r", false, "Rebase the commits onto the base branch")
+	ghPRMergeCmd.Flags().BoolP("delete-branch", "d", false, "Delete the head branch after merging")
+	ghPRMergeCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// Add PR subcommands
+	ghPRCmd.AddCommand(ghPRCheckoutCmd)
+	ghPRCmd.AddCommand(ghPRCreateCmd)
+	ghPRCmd.AddCommand(ghPRListCmd)
+	ghPRCmd.AddCommand(ghPRMergeCmd)
+	ghPRCmd.AddCommand(ghPRViewCmd)
+
+	// Run list flags
+	ghRunListCmd.Flags().StringP("workflow", "w", "", "Filter by workflow name or file (e.g. ci.yml)")
+	ghRunListCmd.Flags().StringP("branch", "b", "", "Filter by branch")
+	ghRunListCmd.Flags().IntP("limit", "L", 20, "Maximum number of runs to fetch")
+	ghRunListCmd.Flags().Bool("compact", false, "Compact output: one line per run")
+	ghRunListCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// Run view flags
+	ghRunViewCmd.Flags().Bool("log-failed", false, "Print the logs of failed steps")
+	ghRunViewCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// Run watch flags
+	ghRunWatchCmd.Flags().Duration("interval", 10*time.Second, "Polling interval")
+	ghRunWatchCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// Add run subcommands
+	ghRunCmd.AddCommand(ghRunListCmd)
+	ghRunCmd.AddCommand(ghRunViewCmd)
+	ghRunCmd.AddCommand(ghRunWatchCmd)
+
 	// Release list flags
 	ghReleaseListCmd.Flags().IntP("limit", "L", 30, "Maximum number of releases to fetch")
 	ghReleaseListCmd.Flags().Bool("exclude-drafts", false, "Exclude draft releases")
@@ -855,6 +1602,7 @@ func init() {
 	ghReleaseCreateCmd.Flags().BoolP("prerelease", "p", false, "Mark as prerelease")
 	ghReleaseCreateCmd.Flags().String("latest", "", "Mark as latest release (true/false)")
 	ghReleaseCreateCmd.Flags().String("target", "", "Target branch or commit SHA")
+	ghReleaseCreateCmd.Flags().StringArray("asset", nil, "Attach a local file (repeatable; \"path#label\" sets the display name)")
 	ghReleaseCreateCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
 
 	ghReleaseEditCmd.Flags().StringP("title", "t", "", "Release title")
@@ -867,10 +1615,22 @@ func init() {
 	ghReleaseEditCmd.Flags().String("target", "", "Target branch or commit SHA")
 	ghReleaseEditCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
 
+	// Release upload flags
+	ghReleaseUploadCmd.Flags().Bool("clobber", false, "Overwrite assets with the same name")
+	ghReleaseUploadCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
+	// Release download flags
+	ghReleaseDownloadCmd.Flags().StringArrayP("pattern", "p", nil, "Only download assets matching this glob (repeatable)")
+	ghReleaseDownloadCmd.Flags().StringP("dir", "D", "", "Directory to download into (default: current directory)")
+	ghReleaseDownloadCmd.Flags().Bool("clobber", false, "Overwrite existing files")
+	ghReleaseDownloadCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+
 	// Add release subcommands
 	ghReleaseCmd.AddCommand(ghReleaseCreateCmd)
+	ghReleaseCmd.AddCommand(ghReleaseDownloadCmd)
 	ghReleaseCmd.AddCommand(ghReleaseEditCmd)
 	ghReleaseCmd.AddCommand(ghReleaseListCmd)
+	ghReleaseCmd.AddCommand(ghReleaseUploadCmd)
 	ghReleaseCmd.AddCommand(ghReleaseViewCmd)
 
 	// Label list flags
@@ -889,6 +1649,11 @@ func init() {
 	ghLabelCmd.AddCommand(ghLabelListCmd)
 	ghLabelCmd.AddCommand(ghLabelCreateCmd)
 	ghLabelCmd.AddCommand(ghLabelDeleteCmd)
+	ghLabelCmd.AddCommand(ghLabelSyncCmd)
+	ghLabelSyncCmd.Flags().StringP("repo", "R", "", "Repository in owner/repo format")
+	ghLabelSyncCmd.Flags().Bool("prune", false, "Delete labels that are not in the file (requires --yes)")
+	ghLabelSyncCmd.Flags().BoolP("yes", "y", false, "Confirm deletions with --prune")
+	ghLabelSyncCmd.Flags().Bool("dry-run", false, "Show what would change without applying it")
 
 	// Repo create flags
 	ghRepoCreateCmd.Flags().StringP("description", "d", "", "Repository description")
@@ -910,8 +1675,10 @@ func init() {
 	ghCmd.AddCommand(ghCloneCmd)
 	ghCmd.AddCommand(ghIssueCmd)
 	ghCmd.AddCommand(ghLabelCmd)
+	ghCmd.AddCommand(ghPRCmd)
 	ghCmd.AddCommand(ghReleaseCmd)
 	ghCmd.AddCommand(ghRepoCmd)
+	ghCmd.AddCommand(ghRunCmd)
 	ghCmd.AddCommand(ghTestCmd)
 	rootCmd.AddCommand(ghCmd)
 }
@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -37,9 +38,15 @@ Examples:
   dex gitlab activity                    # Last 14 days (default)
   dex gitlab activity --since 7d         # Last 7 days
   dex gitlab activity --since 4h         # Last 4 hours
-  dex gitlab activity --since 30m        # Last 30 minutes`,
+  dex gitlab activity --since 30m        # Last 30 minutes
+  dex gitlab activity --project group/api --project group/web  # Only these projects
+  dex gitlab activity --project-glob 'platform/*'             # Projects directly under platform/
+  dex gitlab activity --author jdoe                            # Only jdoe's commits and MRs`,
 	Run: func(cmd *cobra.Command, args []string) {
 		sinceStr, _ := cmd.Flags().GetString("since")
+		projectRefs, _ := cmd.Flags().GetStringSlice("project")
+		projectGlob, _ := cmd.Flags().GetString("project-glob")
+		authorName, _ := cmd.Flags().GetString("author")
 		duration := parseDuration(sinceStr)
 
 		cfg, err := config.Load()
@@ -65,33 +72,152 @@ Examples:
 
 		since := time.Now().Add(-duration)
 
-		fmt.Printf("Fetching projects with activity since %s...\n", formatSinceTime(since, duration))
+		var author *gitlab.ActivityAuthor
+		if authorName != "" {
+			author, err = client.GetActivityAuthor(authorName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to look up user: %v\n", err)
+				os.Exit(1)
+			}
+		}
 
-		projects, err := client.GetActiveProjects(since)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to fetch projects: %v\n", err)
-			os.Exit(1)
+		var projects []*gogitlab.Project
+		if len(projectRefs) > 0 {
+			// Skip discovery and fetch only the named projects
+			projects, err = client.GetProjects(projectRefs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to resolve projects: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Fetching activity since %s for %d projects...\n", formatSinceTime(since, duration), len(projects))
+		} else {
+			fmt.Printf("Fetching projects with activity since %s...\n", formatSinceTime(since, duration))
+
+			projects, err = client.GetActiveProjects(since)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to fetch projects: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Found %d projects with recent activity, fetching details...\n", len(projects))
 		}
 
-		fmt.Printf("Found %d projects with recent activity, fetching details...\n", len(projects))
+		if projectGlob != "" {
+			projects, err = gitlab.FilterProjectsByPath(projects, projectGlob)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("%d projects match %s\n", len(projects), projectGlob)
+		}
 
-		activities := fetchProjectActivitiesConcurrently(client, projects, since)
+		activities := fetchProjectActivitiesConcurrently(client, projects, since, author, func(completed, total int) {
+			fmt.Printf("\r  Fetched %d/%d projects...", completed, total)
+		})
 
 		fmt.Print("\r" + strings.Repeat(" ", 80) + "\r")
 
-		output.PrintHeaderDuration(duration)
+		printActivityReport(duration, activities)
+	},
+}
 
-		if len(activities) == 0 {
-			output.PrintNoActivity()
-			return
+// printActivityReport prints the projects that have activity and a summary,
+// or the no-activity message if none do
+func printActivityReport(duration time.Duration, activities []gitlab.ProjectActivity) {
+	output.PrintHeaderDuration(duration)
+
+	var active []gitlab.ProjectActivity
+	for _, a := range activities {
+		if a.HasActivity() {
+			active = append(active, a)
+		}
+	}
+	if len(active) == 0 {
+		output.PrintNoActivity()
+		return
+	}
+
+	for _, activity := range active {
+		output.PrintProject(activity)
+	}
+
+	summary := gitlab.CalculateSummary(active)
+	output.PrintSummary(summary)
+}
+
+var gitlabUserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "GitLab user reports",
+}
+
+var gitlabUserActivityCmd = &cobra.Command{
+	Use:   "activity <username>",
+	Short: "Summarize a user's commits and MRs across indexed projects",
+	Long: `Aggregate one user's contributions over a time window across all indexed,
+non-archived projects: projects touched, commits, lines changed, and merge
+requests opened and merged.
+
+Commits are matched on the user's GitLab display name (the commits API author
+filter, which checks git author name and email). An MR counts as opened if it
+was created in the window and as merged if it was merged in the window.
+
+Requires the project index (dex gl index).
+
+Examples:
+  dex gl user activity jdoe                # Last 14 days (default)
+  dex gl user activity jdoe --since 90d    # Last quarter
+  dex gl user activity jdoe -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		sinceStr, _ := cmd.Flags().GetString("since")
+		duration := parseDuration(sinceStr)
+		if duration == 0 {
+			RenderError(fmt.Errorf("invalid --since %q (use e.g. 4h, 7d)", sinceStr))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+		if err := cfg.RequireGitLab(); err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		idx, err := gitlab.LoadIndex()
+		if err != nil || len(idx.Projects) == 0 {
+			RenderError(fmt.Errorf("no indexed projects, run 'dex gl index' first"))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		author, err := client.GetActivityAuthor(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("failed to look up user: %w", err))
 		}
 
-		for _, activity := range activities {
-			output.PrintProject(activity)
+		var projects []*gogitlab.Project
+		for _, p := range idx.Projects {
+			if p.Archived {
+				continue
+			}
+			projects = append(projects, &gogitlab.Project{
+				ID:                p.ID,
+				Name:              p.Name,
+				PathWithNamespace: p.PathWithNS,
+				WebURL:            p.WebURL,
+			})
 		}
 
-		summary := gitlab.CalculateSummary(activities)
-		output.PrintSummary(summary)
+		since := time.Now().Add(-duration)
+		activities := fetchProjectActivitiesConcurrently(client, projects, since, author, func(completed, total int) {
+			fmt.Fprintf(os.Stderr, "\r  Fetched %d/%d projects...", completed, total)
+		})
+		fmt.Fprint(os.Stderr, "\r"+strings.Repeat(" ", 80)+"\r")
+
+		Render(gitlab.NewUserActivityReport(*author, since, len(projects), activities))
 	},
 }
 
@@ -174,6 +300,99 @@ var gitlabCommitCmd = &cobra.Command{
 	Long:  `Commands for viewing GitLab commits.`,
 }
 
+var gitlabTagCmd = &cobra.Command{
+	Use:   "tag",
+	Short: "Tag commands",
+	Long:  `Commands for listing and creating GitLab tags.`,
+}
+
+var gitlabTagLsCmd = &cobra.Command{
+	Use:   "ls <project>",
+	Short: "List tags for a project",
+	Long: `List tags for a GitLab project, most recently updated first.
+
+Examples:
+  dex gl tag ls group/project              # Latest 20 tags
+  dex gl tag ls group/project --search v1. # Tags containing "v1."
+  dex gl tag ls group/project --search ^v2 # Tags starting with "v2"
+  dex gl tag ls group/project -n 50 -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		search, _ := cmd.Flags().GetString("search")
+		limit, _ := cmd.Flags().GetInt("limit")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		tags, err := client.ListProjectTags(gitlab.ListProjectTagsOptions{
+			ProjectID: args[0],
+			Search:    search,
+			Limit:     limit,
+		})
+		if err != nil {
+			RenderError(fmt.Errorf("failed to list tags: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gitlab.TagListResult{Project: args[0], Tags: tags, Total: len(tags)}, mode)
+	},
+}
+
+var gitlabTagCreateCmd = &cobra.Command{
+	Use:   "create <project> <name>",
+	Short: "Create a tag",
+	Long: `Create a tag at a branch, tag or commit SHA. With --message the tag is
+annotated. The commit SHA the tag points to is printed.
+
+Examples:
+  dex gl tag create group/project v1.4.0 --ref main
+  dex gl tag create group/project v1.4.0 --ref 3f2a9c1 -m "Release 1.4.0"`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		ref, _ := cmd.Flags().GetString("ref")
+		message, _ := cmd.Flags().GetString("message")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		if ref == "" {
+			RenderError(fmt.Errorf("--ref is required (branch, tag or commit SHA)"))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		tag, err := client.CreateTag(args[0], args[1], ref, message)
+		if err != nil {
+			RenderError(err)
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gitlab.TagCreateResult{Project: args[0], Tag: *tag}, mode)
+	},
+}
+
 var gitlabMRCmd = &cobra.Command{
 	Use:     "mr",
 	Aliases: []string{"merge-request"},
@@ -197,11 +416,18 @@ Scope options:
   created_by_me  - MRs you created
   assigned_to_me - MRs assigned to you
 
+Filters:
+  --labels         - Only MRs carrying ALL given labels (repeatable or comma-separated)
+  --author         - Only MRs by this username
+  --target-branch  - Only MRs targeting this branch
+
 Examples:
   dex gl mr ls                          # List open MRs
   dex gl mr ls --state merged           # List merged MRs
   dex gl mr ls --scope created_by_me    # MRs you created
-  dex gl mr ls --state all -n 50        # All MRs, limit 50`,
+  dex gl mr ls --state all -n 50        # All MRs, limit 50
+  dex gl mr ls --labels bug --labels backend   # MRs labelled bug AND backend
+  dex gl mr ls --author john.doe --target-branch release-1.2`,
 	Run: func(cmd *cobra.Command, args []string) {
 		state, _ := cmd.Flags().GetString("state")
 		scope, _ := cmd.Flags().GetString("scope")
@@ -209,6 +435,9 @@ Examples:
 		includeWIP, _ := cmd.Flags().GetBool("include-wip")
 		conflictsOnly, _ := cmd.Flags().GetBool("conflicts-only")
 		compact, _ := cmd.Flags().GetBool("compact")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		author, _ := cmd.Flags().GetString("author")
+		targetBranch, _ := cmd.Flags().GetString("target-branch")
 
 		cfg, err := config.Load()
 		if err != nil {
@@ -228,6 +457,9 @@ Examples:
 			Limit:         limit,
 			IncludeWIP:    includeWIP,
 			ConflictsOnly: conflictsOnly,
+			Labels:        labels,
+			Author:        author,
+			TargetBranch:  targetBranch,
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to list merge requests: %v\n", err)
@@ -252,11 +484,20 @@ Use the canonical reference format: project!iid
 Examples:
   dex gl mr show my-group/my-project!123
   dex gl mr show group/project!456
-  dex gl mr show group/project!456 --show-diff   # Include file diffs`,
+  dex gl mr show group/project!456 --show-diff   # Include file diffs
+  dex gl mr show group/project!456 --refresh     # Re-fetch, ignoring the cache
+
+Commits, changed files (with diffs) and discussions are cached under
+~/.dex/gitlab/mr-cache/<project>/<iid>.json. Each run still fetches the MR
+itself and re-fetches the rest only when its updated_at has moved. If GitLab
+is unreachable, the cached copy is shown with a warning. A fetch that fails
+part-way is shown but not cached.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		showDiff, _ := cmd.Flags().GetBool("show-diff")
 		compact, _ := cmd.Flags().GetBool("compact")
+		noCache, _ := cmd.Flags().GetBool("no-cache")
+		refresh, _ := cmd.Flags().GetBool("refresh")
 
 		projectID, mrIID, err := parseMRReference(args[0])
 		if err != nil {
@@ -277,30 +518,73 @@ Examples:
 			os.Exit(1)
 		}
 
-		mr, err := client.GetMergeRequest(projectID, mrIID)
+		var cache *gitlab.MRCache
+		if !noCache {
+			cache, _ = gitlab.DefaultMRCache()
+		}
+		if cache != nil && refresh {
+			cache.Delete(projectID, mrIID)
+		}
+
+		var headErr error
+		head := func() (*gitlab.MergeRequestDetail, error) {
+			mr, err := client.GetMergeRequest(projectID, mrIID)
+			headErr = err
+			return mr, err
+		}
+		// fill reports any failed fetch so a partial MR is shown but not cached
+		fill := func(mr *gitlab.MergeRequestDetail) error {
+			var errs []error
+
+			// Fetch commits
+			commits, err := client.GetMergeRequestCommits(projectID, mrIID)
+			if err == nil {
+				mr.Commits = commits
+			} else {
+				errs = append(errs, fmt.Errorf("commits: %w", err))
+			}
+
+			// Fetch file changes, always with diffs so the cache can serve --show-diff
+			files, err := client.GetMergeRequestChanges(projectID, mrIID, cache != nil || showDiff)
+			if err == nil {
+				mr.Files = files
+			} else {
+				errs = append(errs, fmt.Errorf("changes: %w", err))
+			}
+
+			// Fetch discussions (threaded comments)
+			discussions, err := client.GetMergeRequestDiscussions(projectID, mrIID)
+			if err == nil {
+				mr.Discussions = discussions
+			} else {
+				errs = append(errs, fmt.Errorf("discussions: %w", err))
+			}
+
+			for _, err := range errs {
+				fmt.Fprintf(os.Stderr, "Warning: failed to fetch MR %v\n", err)
+			}
+			return errors.Join(errs...)
+		}
+
+		mr, fromCache, err := cache.Resolve(projectID, mrIID, head, fill)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get merge request: %v\n", err)
 			os.Exit(1)
 		}
-
-		// Fetch commits
-		commits, err := client.GetMergeRequestCommits(projectID, mrIID)
-		if err == nil {
-			mr.Commits = commits
+		if fromCache && headErr != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not reach GitLab (%v); showing cached copy last updated %s\n",
+				headErr, mr.UpdatedAt.Local().Format("2006-01-02 15:04"))
 		}
 
-		// Fetch file changes (with diff if requested)
-		files, err := client.GetMergeRequestChanges(projectID, mrIID, showDiff)
-		if err == nil {
+		if !showDiff {
+			files := make([]gitlab.MRFile, len(mr.Files))
+			for i, f := range mr.Files {
+				f.Diff = ""
+				files[i] = f
+			}
 			mr.Files = files
 		}
 
-		// Fetch discussions (threaded comments)
-		discussions, err := client.GetMergeRequestDiscussions(projectID, mrIID)
-		if err == nil {
-			mr.Discussions = discussions
-		}
-
 		mode := render.ModeNormal
 		if compact {
 			mode = render.ModeCompact
@@ -356,13 +640,14 @@ Examples:
 }
 
 var gitlabMRCommentCmd = &cobra.Command{
-	Use:   "comment <project!iid> <message>",
+	Use:   "comment <project!iid> [message]",
 	Short: "Add a comment to a merge request",
 	Long: `Add a comment/note to a merge request.
 
 Use the canonical reference format: project!iid
 
-The message can be provided as an argument or via stdin (use - as message).
+The message can be provided as an argument, via stdin (use - as message), or
+read from a file with --body-file (taken verbatim, minus the final newline).
 
 Comment types:
   - Regular comment: just provide the message
@@ -376,6 +661,7 @@ Examples:
   dex gl mr comment my-group/my-project!123 "LGTM, approved!"
   dex gl mr comment group/project!456 "Please address the review comments"
   echo "Comment from stdin" | dex gl mr comment group/project!456 -
+  dex gl mr comment group/project!456 --body-file review.md
 
   # Reply to an existing discussion thread
   dex gl mr comment project!123 "Done, fixed!" --reply-to abc12345
@@ -384,13 +670,17 @@ Examples:
   dex gl mr comment project!123 "Use a constant here" --file src/main.go --line 42
 
   # Preview where comment will land (dry run)
-  dex gl mr comment project!123 "test" --file src/main.go --line 42 --dry-run`,
-	Args: cobra.ExactArgs(2),
+  dex gl mr comment project!123 "test" --file src/main.go --line 42 --dry-run
+
+  # Multi-line inline comment from a file
+  dex gl mr comment project!123 --body-file note.md --file src/main.go --line 42`,
+	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		replyTo, _ := cmd.Flags().GetString("reply-to")
 		filePath, _ := cmd.Flags().GetString("file")
 		lineNum, _ := cmd.Flags().GetInt("line")
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		bodyFile, _ := cmd.Flags().GetString("body-file")
 
 		projectID, mrIID, err := parseMRReference(args[0])
 		if err != nil {
@@ -399,20 +689,9 @@ Examples:
 			os.Exit(1)
 		}
 
-		message := args[1]
-
-		// Read from stdin if message is "-"
-		if message == "-" {
-			data, err := os.ReadFile("/dev/stdin")
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Failed to read from stdin: %v\n", err)
-				os.Exit(1)
-			}
-			message = strings.TrimSpace(string(data))
-		}
-
-		if message == "" {
-			fmt.Fprintf(os.Stderr, "Comment message cannot be empty\n")
+		message, err := resolveMRCommentBody(args[1:], bodyFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
@@ -481,6 +760,113 @@ Examples:
 	},
 }
 
+// resolveMRCommentBody returns the comment text from the message argument
+// ("-" reads stdin) or from bodyFile, which is used verbatim except for one
+// trailing newline. Exactly one source must be given and the text must not be
+// blank.
+func resolveMRCommentBody(args []string, bodyFile string) (string, error) {
+	var message string
+	switch {
+	case len(args) > 0 && bodyFile != "":
+		return "", fmt.Errorf("use either a message argument or --body-file, not both")
+	case bodyFile != "":
+		data, err := os.ReadFile(bodyFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read --body-file: %w", err)
+		}
+		message = strings.TrimSuffix(strings.TrimSuffix(string(data), "\n"), "\r")
+		if strings.TrimSpace(message) == "" {
+			return "", fmt.Errorf("--body-file %s is empty", bodyFile)
+		}
+		return message, nil
+	case len(args) == 0:
+		return "", fmt.Errorf("comment message required (argument, - for stdin, or --body-file)")
+	}
+
+	message = args[0]
+
+	// Read from stdin if message is "-"
+	if message == "-" {
+		data, err := os.ReadFile("/dev/stdin")
+		if err != nil {
+			return "", fmt.Errorf("failed to read from stdin: %w", err)
+		}
+		message = strings.TrimSpace(string(data))
+	}
+
+	if message == "" {
+		return "", fmt.Errorf("comment message cannot be empty")
+	}
+	return message, nil
+}
+
+var gitlabDiffCommentBatchCmd = &cobra.Command{
+	Use:   "diff-comment-batch <project!iid> <file>",
+	Short: "Post multiple inline comments on a merge request from a file",
+	Long: `Post a batch of inline comments prepared offline.
+
+The file is YAML or JSON: a list of {file, line, message} entries, or an object
+with a "comments" list. Line numbers refer to the new version of the file.
+
+Every comment is checked against the merge request diff first (the same checks
+as "dex gl mr comment --dry-run"): the file must be changed in the MR and the
+line must appear in its diff. Comments that fail are reported and skipped; the
+rest are still posted. Exits with status 1 if any comment failed.
+
+Example file:
+  - file: src/main.go
+    line: 42
+    message: Use a constant here
+  - file: src/util.go
+    line: 7
+    message: |
+      Typo in the error message.
+
+Examples:
+  dex gl diff-comment-batch my-group/my-project!123 review.yaml --dry-run
+  dex gl diff-comment-batch my-group/my-project!123 review.yaml
+  dex gl diff-comment-batch group/project!456 review.json -o json`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		projectID, mrIID, err := parseMRReference(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("invalid MR reference: %w (use format: project!iid, e.g. group/project!123)", err))
+		}
+
+		comments, err := gitlab.LoadBatchComments(args[1])
+		if err != nil {
+			RenderError(err)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		result, err := client.PostBatchComments(projectID, mrIID, comments, dryRun)
+		if err != nil {
+			RenderError(err)
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(result, mode)
+		if result.Failed() > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
 var gitlabMRCloseCmd = &cobra.Command{
 	Use:   "close <project!iid>",
 	Short: "Close a merge request",
@@ -618,25 +1004,18 @@ Examples:
 	},
 }
 
-var gitlabMRMergeCmd = &cobra.Command{
-	Use:   "merge <project!iid>",
-	Short: "Merge a merge request",
-	Long: `Merge (accept) a merge request.
+var gitlabMRUnapproveCmd = &cobra.Command{
+	Use:   "unapprove <project!iid>",
+	Short: "Revoke your approval of a merge request",
+	Long: `Revoke your approval of a merge request and show the resulting approval state.
 
 Use the canonical reference format: project!iid
 
 Examples:
-  dex gl mr merge my-group/my-project!123
-  dex gl mr merge group/project!456 --squash
-  dex gl mr merge group/project!456 --when-pipeline-succeeds
-  dex gl mr merge group/project!456 --remove-source-branch`,
+  dex gl mr unapprove my-group/my-project!123
+  dex gl mr unapprove group/project!456`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		squash, _ := cmd.Flags().GetBool("squash")
-		removeSource, _ := cmd.Flags().GetBool("remove-source-branch")
-		whenPipeline, _ := cmd.Flags().GetBool("when-pipeline-succeeds")
-		message, _ := cmd.Flags().GetString("message")
-
 		projectID, mrIID, err := parseMRReference(args[0])
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid MR reference: %v\n", err)
@@ -656,28 +1035,298 @@ Examples:
 			os.Exit(1)
 		}
 
-		if err := client.MergeMergeRequest(projectID, mrIID, gitlab.MergeMergeRequestOptions{
-			Squash:                    squash,
-			RemoveSourceBranch:        removeSource,
-			MergeWhenPipelineSucceeds: whenPipeline,
-			MergeCommitMessage:        message,
-		}); err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to merge: %v\n", err)
+		if err := client.UnapproveMergeRequest(projectID, mrIID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to unapprove merge request: %v\n", err)
 			os.Exit(1)
 		}
 
-		if whenPipeline {
-			fmt.Printf("Set %s!%d to merge when pipeline succeeds\n", projectID, mrIID)
-		} else {
-			fmt.Printf("Merged %s!%d\n", projectID, mrIID)
+		fmt.Printf("Revoked approval of %s!%d\n", projectID, mrIID)
+
+		if approvals, err := client.GetMergeRequestApprovals(projectID, mrIID); err == nil {
+			state := "not approved"
+			if approvals.Approved {
+				state = "still approved"
+			}
+			fmt.Printf("  %s, %d of %d approvals left", state, approvals.ApprovalsLeft, approvals.ApprovalsRequired)
+			if len(approvals.ApprovedBy) > 0 {
+				fmt.Printf(", approved by %s", strings.Join(approvals.ApprovedBy, ", "))
+			}
+			fmt.Println()
 		}
 	},
 }
 
-var gitlabMRCreateCmd = &cobra.Command{
-	Use:   "create <title>",
-	Short: "Create a new merge request",
-	Long: `Create a new merge request from the current branch.
+var gitlabMRApproversCmd = &cobra.Command{
+	Use:   "approvers <project!iid>",
+	Short: "Show approval rules and who can approve",
+	Long: `Show the approval rules of a merge request.
+
+Lists each rule with its required approval count, the users who already
+approved under it and the eligible approvers still outstanding. Satisfied rules
+are marked with ✓, unsatisfied ones with ✗. Useful to find out why an MR is
+not mergeable yet.
+
+Examples:
+  dex gl mr approvers my-group/my-project!123
+  dex gl mr approvers group/project!456 --compact
+  dex gl mr approvers group/project!456 -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		projectID, mrIID, err := parseMRReference(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("invalid MR reference: %w (use format: project!iid, e.g. group/project!123)", err))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		rules, err := client.GetMergeRequestApprovalRules(projectID, mrIID)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to get approval rules: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(gitlab.NewMRApprovalsResult(fmt.Sprintf("%s!%d", projectID, mrIID), rules), mode)
+	},
+}
+
+var gitlabMRConflictsCmd = &cobra.Command{
+	Use:   "conflicts <project!iid>",
+	Short: "Show the files that conflict with the target branch",
+	Long: `Show which files of a merge request conflict with its target branch.
+
+For each conflicting file the conflict regions are printed between conflict
+markers: the source branch side first, then the target branch side, with the
+surrounding context dimmed. The regions are found by merging the files both
+branches changed since their merge base, as git does; files that cannot be
+merged line by line (deleted on one side or binary) are listed without regions.
+
+Examples:
+  dex gl mr conflicts my-group/my-project!123
+  dex gl mr conflicts group/project!456 --compact
+  dex gl mr conflicts group/project!456 -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		projectID, mrIID, err := parseMRReference(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("invalid MR reference: %w (use format: project!iid, e.g. group/project!123)", err))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		conflicts, err := client.GetMergeRequestConflicts(projectID, mrIID)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to get conflicts: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(conflicts, mode)
+	},
+}
+
+var gitlabMRWipCheckCmd = &cobra.Command{
+	Use:   "wip-check <project!iid>",
+	Short: "Check whether a merge request is ready to merge (exit 1 if not)",
+	Long: `Check whether a merge request is ready to merge and exit with status 1 if
+it is not. Meant as a gate in merge scripts and for agents.
+
+Checks:
+  state        the MR is open
+  draft        the MR is not marked as draft/WIP
+  conflicts    no conflicts with the target branch
+  pipeline     the head pipeline succeeded (MRs without a pipeline pass)
+  discussions  no unresolved threads
+  approvals    no approvals left
+
+Examples:
+  dex gl mr wip-check my-group/my-project!123
+  dex gl mr wip-check group/project!456 --compact
+  dex gl mr wip-check group/project!456 -o json
+  dex gl mr wip-check group/project!456 --compact && dex gl mr merge group/project!456`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		projectID, mrIID, err := parseMRReference(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("invalid MR reference: %w (use format: project!iid, e.g. group/project!123)", err))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		result, err := client.CheckMergeReadiness(projectID, mrIID)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to check merge request: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(result, mode)
+		if !result.Ready {
+			os.Exit(1)
+		}
+	},
+}
+
+var gitlabMRReviewersCmd = &cobra.Command{
+	Use:   "reviewers",
+	Short: "Merge request reviewer helpers",
+}
+
+var gitlabMRReviewersSuggestCmd = &cobra.Command{
+	Use:   "suggest <project!iid>",
+	Short: "Suggest reviewers from the history of the changed files",
+	Long: `Suggest reviewers for a merge request based on who recently committed to
+the files it changes.
+
+For each changed file (up to --max-files) the commit history on the target
+branch is fetched. Authors are ranked by a recency-weighted commit count
+(a commit from 90 days ago counts half as much as one from today). The MR
+author and already assigned reviewers are excluded.
+
+Examples:
+  dex gl mr reviewers suggest my-group/my-project!123
+  dex gl mr reviewers suggest group/project!456 --since 180d -n 3
+  dex gl mr reviewers suggest group/project!456 -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		compact, _ := cmd.Flags().GetBool("compact")
+		limit, _ := cmd.Flags().GetInt("limit")
+		maxFiles, _ := cmd.Flags().GetInt("max-files")
+		sinceStr, _ := cmd.Flags().GetString("since")
+
+		projectID, mrIID, err := parseMRReference(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("invalid MR reference: %w (use format: project!iid, e.g. group/project!123)", err))
+		}
+
+		opts := gitlab.SuggestReviewersOptions{Limit: limit, MaxFiles: maxFiles}
+		if sinceStr != "" {
+			d := parseDuration(sinceStr)
+			if d == 0 {
+				RenderError(fmt.Errorf("invalid --since %q (e.g. 90d, 365d)", sinceStr))
+			}
+			opts.Since = time.Now().Add(-d)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		result, err := client.SuggestReviewers(projectID, mrIID, opts)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to suggest reviewers: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(result, mode)
+	},
+}
+
+var gitlabMRMergeCmd = &cobra.Command{
+	Use:   "merge <project!iid>",
+	Short: "Merge a merge request",
+	Long: `Merge (accept) a merge request.
+
+Use the canonical reference format: project!iid
+
+Examples:
+  dex gl mr merge my-group/my-project!123
+  dex gl mr merge group/project!456 --squash
+  dex gl mr merge group/project!456 --when-pipeline-succeeds
+  dex gl mr merge group/project!456 --remove-source-branch`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		squash, _ := cmd.Flags().GetBool("squash")
+		removeSource, _ := cmd.Flags().GetBool("remove-source-branch")
+		whenPipeline, _ := cmd.Flags().GetBool("when-pipeline-succeeds")
+		message, _ := cmd.Flags().GetString("message")
+
+		projectID, mrIID, err := parseMRReference(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid MR reference: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Use format: project!iid (e.g., group/project!123)\n")
+			os.Exit(1)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create GitLab client: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.MergeMergeRequest(projectID, mrIID, gitlab.MergeMergeRequestOptions{
+			Squash:                    squash,
+			RemoveSourceBranch:        removeSource,
+			MergeWhenPipelineSucceeds: whenPipeline,
+			MergeCommitMessage:        message,
+		}); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to merge: %v\n", err)
+			os.Exit(1)
+		}
+
+		if whenPipeline {
+			fmt.Printf("Set %s!%d to merge when pipeline succeeds\n", projectID, mrIID)
+		} else {
+			fmt.Printf("Merged %s!%d\n", projectID, mrIID)
+		}
+	},
+}
+
+var gitlabMRCreateCmd = &cobra.Command{
+	Use:   "create <title>",
+	Short: "Create a new merge request",
+	Long: `Create a new merge request from the current branch.
 
 By default, detects the current git branch as source and the project from
 the git remote. Target branch defaults to 'main'.
@@ -865,9 +1514,12 @@ With --file, shows the raw diff content for that file.
 With --parsed, shows a table with line numbers and line types.
 With --line, shows a specific line with context (requires --file).
 With --search, finds lines matching a regex pattern (requires --file).
+With --stat, shows added/deleted line counts per file and in total
+(restricted to one file with --file).
 
 Examples:
   dex gl mr diff project!123                        # List all changed files
+  dex gl mr diff project!123 --stat                 # Per-file +/- summary
   dex gl mr diff project!123 --file src/main.go     # Show raw diff
   dex gl mr diff project!123 -f src/main.go -p      # Show parsed with line numbers
   dex gl mr diff project!123 -f src/main.go -l 42   # Inspect line 42 with context
@@ -879,6 +1531,7 @@ Examples:
 		lineNum, _ := cmd.Flags().GetInt("line")
 		searchPattern, _ := cmd.Flags().GetString("search")
 		contextLines, _ := cmd.Flags().GetInt("context")
+		stat, _ := cmd.Flags().GetBool("stat")
 
 		projectID, mrIID, err := parseMRReference(args[0])
 		if err != nil {
@@ -906,6 +1559,11 @@ Examples:
 			os.Exit(1)
 		}
 
+		if stat && filePath == "" {
+			Render(gitlab.NewMRDiffStatResult(fmt.Sprintf("%s!%d", projectID, mrIID), files))
+			return
+		}
+
 		if filePath == "" {
 			// No file specified: list all changed files
 			fmt.Printf("Changed files in %s!%d:\n\n", projectID, mrIID)
@@ -922,7 +1580,7 @@ Examples:
 				}
 				fmt.Printf("  %s %s\n", status, f.NewPath)
 			}
-			fmt.Printf("\nUse --file <path> to view a specific file's diff.\n")
+			fmt.Printf("\nUse --file <path> to view a specific file's diff, or --stat for line counts.\n")
 			return
 		}
 
@@ -944,6 +1602,11 @@ Examples:
 			os.Exit(1)
 		}
 
+		if stat {
+			Render(gitlab.NewMRDiffStatResult(fmt.Sprintf("%s!%d", projectID, mrIID), []gitlab.MRFile{*targetFile}))
+			return
+		}
+
 		diff := gitlab.ParseUnifiedDiff(targetFile.Diff)
 
 		// Handle --line flag: inspect a specific line with context
@@ -1627,13 +2290,113 @@ Examples:
 	},
 }
 
+var gitlabProjSearchCmd = &cobra.Command{
+	Use:   "search <term>",
+	Short: "Search indexed projects by path, name, topics and description",
+	Long: `Search the local project index and print ranked matches.
+
+Every word of the term must match the project's path, name, topics or
+description (case-insensitive). Path and name hits rank above topic hits,
+which rank above description hits; exact and prefix matches rank above
+substring matches. Run 'dex gl index' first to populate the index.
+
+Examples:
+  dex gl proj search billing                 # Path, name, topic or description
+  dex gl proj search "payment gateway"       # All words must match
+  dex gl proj search kafka -n 5 --compact    # Top 5, one line each
+  dex gl proj search sip -o json`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		limit, _ := cmd.Flags().GetInt("limit")
+		compact, _ := cmd.Flags().GetBool("compact")
+		term := strings.Join(args, " ")
+
+		idx, err := gitlab.LoadIndex()
+		if err != nil || len(idx.Projects) == 0 {
+			RenderError(fmt.Errorf("no project index found, run 'dex gl index' first"))
+		}
+
+		matches := idx.SearchProjects(term)
+		total := len(matches)
+		if limit > 0 && len(matches) > limit {
+			matches = matches[:limit]
+		}
+		if matches == nil {
+			matches = []gitlab.ProjectMatch{}
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gitlab.ProjectSearchResult{Term: term, Matches: matches, Total: total}, mode)
+	},
+}
+
+var gitlabProjMembersCmd = &cobra.Command{
+	Use:   "members <id|path>",
+	Short: "List project members and their access level",
+	Long: `List everyone with access to a project, including members inherited from
+parent groups, sorted by access level (Owner, Maintainer, Developer, Reporter,
+Guest). Useful to find who to ask for access or reviews.
+
+Examples:
+  dex gl proj members group/project
+  dex gl proj members group/project --min-level maintainer
+  dex gl proj members 123 --compact
+  dex gl proj members group/project -o json`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		minLevelName, _ := cmd.Flags().GetString("min-level")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		minLevel := 0
+		if minLevelName != "" {
+			level, err := gitlab.ParseAccessLevel(minLevelName)
+			if err != nil {
+				RenderError(fmt.Errorf("invalid --min-level: %w", err))
+			}
+			minLevel = level
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		members, err := client.ListProjectMembers(args[0], minLevel)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to list members: %w", err))
+		}
+
+		result := &gitlab.ProjectMembersResult{Project: args[0], Members: members}
+		if minLevel > 0 {
+			result.MinLevel = gitlab.AccessLevelName(minLevel)
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(result, mode)
+	},
+}
+
 var gitlabShowCmd = &cobra.Command{
 	Use:   "show <id|path>",
 	Short: "Show project details",
 	Long: `Display detailed information about a GitLab project.
 
 Looks up in local cache first, falls back to API if not found.
-When fetched from API, the project is added to the cache.
+When fetched from API, the project is added to the cache. The cache lookup
+also accepts a case-insensitive path or a unique project name; use
+'dex gl proj search' to find a project by topic or description.
 
 Examples:
   dex gl proj show 123                    # By project ID
@@ -1695,6 +2458,83 @@ Examples:
 	},
 }
 
+var gitlabProjArchiveCmd = &cobra.Command{
+	Use:   "archive <id|path>",
+	Short: "Archive a project (read-only)",
+	Long: `Archive a GitLab project. An archived project is read-only: no pushes,
+issues or merge requests, but it stays visible and can be unarchived.
+
+Requires --yes. Needs Maintainer or Owner access on the project.
+
+Examples:
+  dex gl proj archive group/old-project --yes
+  dex gl proj archive 123 -y`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		runGitLabProjArchive(cmd, args[0], true)
+	},
+}
+
+var gitlabProjUnarchiveCmd = &cobra.Command{
+	Use:   "unarchive <id|path>",
+	Short: "Unarchive a project",
+	Long: `Unarchive a GitLab project, making it writable again.
+
+Requires --yes. Needs Maintainer or Owner access on the project.
+
+Examples:
+  dex gl proj unarchive group/old-project --yes`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		runGitLabProjArchive(cmd, args[0], false)
+	},
+}
+
+// runGitLabProjArchive archives or unarchives a project and keeps the local
+// index in sync
+func runGitLabProjArchive(cmd *cobra.Command, ref string, archive bool) {
+	action := "archive"
+	if !archive {
+		action = "unarchive"
+	}
+
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes {
+		RenderError(fmt.Errorf("refusing to %s %s without --yes", action, ref))
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		RenderError(fmt.Errorf("configuration error: %w", err))
+	}
+	if err := cfg.RequireGitLab(); err != nil {
+		RenderError(fmt.Errorf("configuration error: %w", err))
+	}
+
+	client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+	if err != nil {
+		RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+	}
+
+	var project *gogitlab.Project
+	if archive {
+		project, err = client.ArchiveProject(ref)
+	} else {
+		project, err = client.UnarchiveProject(ref)
+	}
+	if err != nil {
+		RenderError(err)
+	}
+
+	if err := gitlab.SetIndexedProjectArchived(project.ID, archive); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to update project index: %v\n", err)
+	}
+
+	fmt.Printf("Project %s %sd.\n", project.PathWithNamespace, action)
+}
+
 // completeProjectNames provides shell completion for project names from the index
 func completeProjectNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 	// Only complete first argument
@@ -1708,13 +2548,21 @@ func completeProjectNames(cmd *cobra.Command, args []string, toComplete string)
 	}
 
 	var completions []string
-	toCompleteLower := strings.ToLower(toComplete)
-
-	for _, p := range idx.Projects {
-		// Match against path (most common use case)
-		if strings.Contains(strings.ToLower(p.PathWithNS), toCompleteLower) {
+	if toComplete == "" {
+		for _, p := range idx.Projects {
 			completions = append(completions, p.PathWithNS+"\t"+p.Name)
 		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// A keyword only one project matches completes straight to its path
+	if p := idx.FindProjectByKeyword(toComplete); p != nil && !strings.HasPrefix(p.PathWithNS, toComplete) {
+		return []string{p.PathWithNS + "\t" + p.Name}, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	// Path and name hits first, then topic and description hits
+	for _, m := range idx.SearchProjects(toComplete) {
+		completions = append(completions, m.Project.PathWithNS+"\t"+m.Project.Name)
 	}
 
 	return completions, cobra.ShellCompDirectiveNoFileComp
@@ -1816,6 +2664,384 @@ func parseMRReference(ref string) (string, int, error) {
 	return project, iid, nil
 }
 
+// parseIssueReference parses an issue reference like "group/project#123"
+// Returns the project path and issue IID
+func parseIssueReference(ref string) (string, int, error) {
+	// Strip shell escape artifacts, as for '!' in MR references
+	ref = strings.ReplaceAll(ref, `\#`, "#")
+
+	// Find the last # which separates project from IID
+	idx := strings.LastIndex(ref, "#")
+	if idx == -1 {
+		return "", 0, fmt.Errorf("missing '#' separator")
+	}
+
+	project := ref[:idx]
+	iidStr := ref[idx+1:]
+
+	if project == "" {
+		return "", 0, fmt.Errorf("empty project path")
+	}
+
+	iid, err := strconv.Atoi(iidStr)
+	if err != nil {
+		return "", 0, fmt.Errorf("invalid IID: %s", iidStr)
+	}
+
+	return project, iid, nil
+}
+
+// ── Issue commands ────────────────────────────────────────────────────────────
+
+var gitlabIssueCmd = &cobra.Command{
+	Use:   "issue",
+	Short: "Issue commands",
+	Long:  `Commands for listing and managing GitLab issues.`,
+}
+
+var gitlabIssueBoardCmd = &cobra.Command{
+	Use:   "board <project>",
+	Short: "Show issues grouped by label as a board",
+	Long: `Show a project's issues as a lightweight kanban board.
+
+Issues are bucketed into columns by the first label that starts with
+--label-prefix (scoped labels like status::todo, status::doing by default).
+Issues without a matching label are shown in a "(none)" column.
+
+Columns are sorted alphabetically unless --columns gives an explicit order
+(label values without the prefix). Issues whose value is not listed fall back
+to "(none)".
+
+Examples:
+  dex gl issue board group/project
+  dex gl issue board group/project --label-prefix priority::
+  dex gl issue board group/project --columns todo,doing,review,done
+  dex gl issue board group/project --assignee john.doe --compact`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		projectID := args[0]
+		prefix, _ := cmd.Flags().GetString("label-prefix")
+		columns, _ := cmd.Flags().GetStringSlice("columns")
+		state, _ := cmd.Flags().GetString("state")
+		assignee, _ := cmd.Flags().GetString("assignee")
+		labels, _ := cmd.Flags().GetStringSlice("label")
+		limit, _ := cmd.Flags().GetInt("limit")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		if prefix == "" {
+			RenderError(fmt.Errorf("--label-prefix must not be empty"))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+		if err := cfg.RequireGitLab(); err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		issues, err := client.ListIssues(projectID, gitlab.ListIssuesOptions{
+			State:    state,
+			Labels:   labels,
+			Assignee: assignee,
+			Limit:    limit,
+		})
+		if err != nil {
+			RenderError(fmt.Errorf("failed to list issues: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(gitlab.BuildIssueBoard(projectID, prefix, issues, columns), mode)
+	},
+}
+
+var gitlabIssueLsCmd = &cobra.Command{
+	Use:   "ls [project]",
+	Short: "List issues",
+	Long: `List issues of a project, or across all visible projects when no project
+is given.
+
+State options:
+  opened  - Open issues (default)
+  closed  - Closed issues
+  all     - All issues
+
+Scope options:
+  all            - All visible issues (default)
+  created_by_me  - Issues you created
+  assigned_to_me - Issues assigned to you
+
+Examples:
+  dex gl issue ls                              # Open issues everywhere
+  dex gl issue ls group/project                # Open issues of one project
+  dex gl issue ls --scope assigned_to_me       # Issues assigned to you
+  dex gl issue ls group/project --labels bug   # Only issues labelled bug
+  dex gl issue ls --state closed -n 50         # Closed issues, limit 50`,
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeProjectNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		state, _ := cmd.Flags().GetString("state")
+		scope, _ := cmd.Flags().GetString("scope")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		limit, _ := cmd.Flags().GetInt("limit")
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		projectID := ""
+		if len(args) > 0 {
+			projectID = args[0]
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+		if err := cfg.RequireGitLab(); err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		issues, err := client.ListIssues(projectID, gitlab.ListIssuesOptions{
+			State:  state,
+			Scope:  scope,
+			Labels: labels,
+			Limit:  limit,
+		})
+		if err != nil {
+			RenderError(fmt.Errorf("failed to list issues: %w", err))
+		}
+		if issues == nil {
+			issues = []gitlab.Issue{}
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gitlab.IssueListResult{Issues: issues, Total: len(issues)}, mode)
+	},
+}
+
+var gitlabIssueShowCmd = &cobra.Command{
+	Use:   "show <project#iid>",
+	Short: "Show issue details",
+	Long: `Display an issue with its description and comments.
+
+Use the canonical reference format: project#iid
+
+Examples:
+  dex gl issue show my-group/my-project#42
+  dex gl issue show group/project#42 --compact   # Header only`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		compact, _ := cmd.Flags().GetBool("compact")
+
+		projectID, issueIID, err := parseIssueReference(args[0])
+		if err != nil {
+			RenderError(fmt.Errorf("invalid issue reference: %w (use format: project#iid, e.g. group/project#42)", err))
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+		if err := cfg.RequireGitLab(); err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create GitLab client: %w", err))
+		}
+
+		issue, err := client.GetIssue(projectID, issueIID)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to get issue: %w", err))
+		}
+
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&gitlab.IssueDetailResult{IssueDetail: *issue}, mode)
+	},
+}
+
+var gitlabIssueCreateCmd = &cobra.Command{
+	Use:   "create <title>",
+	Short: "Create a new issue",
+	Long: `Create a new issue.
+
+The project is detected from the git remote unless --project is given.
+
+Examples:
+  dex gl issue create "Login fails with SSO"
+  dex gl issue create "Flaky test" --project group/project --labels bug,ci
+  dex gl issue create "Rotate keys" --description "Details here" --confidential`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		title := args[0]
+		project, _ := cmd.Flags().GetString("project")
+		description, _ := cmd.Flags().GetString("description")
+		labels, _ := cmd.Flags().GetStringSlice("labels")
+		confidential, _ := cmd.Flags().GetBool("confidential")
+
+		if project == "" {
+			proj, err := getGitLabProjectFromRemote()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to detect project from git remote: %v\n", err)
+				fmt.Fprintf(os.Stderr, "Use --project to specify the project path\n")
+				os.Exit(1)
+			}
+			project = proj
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create GitLab client: %v\n", err)
+			os.Exit(1)
+		}
+
+		issue, err := client.CreateIssue(project, gitlab.CreateIssueOptions{
+			Title:        title,
+			Description:  description,
+			Labels:       labels,
+			Confidential: confidential,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create issue: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Created %s#%d: %s\n", project, issue.IID, issue.Title)
+		fmt.Printf("  %s\n", issue.WebURL)
+	},
+}
+
+var gitlabIssueCommentCmd = &cobra.Command{
+	Use:   "comment <project#iid> <message>",
+	Short: "Add a comment to an issue",
+	Long: `Add a comment to an issue.
+
+Use the canonical reference format: project#iid
+
+The message can be provided as an argument or via stdin (use - as message).
+
+Examples:
+  dex gl issue comment my-group/my-project#42 "Reproduced on staging"
+  echo "Comment from stdin" | dex gl issue comment group/project#42 -`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectID, issueIID, err := parseIssueReference(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid issue reference: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Use format: project#iid (e.g., group/project#42)\n")
+			os.Exit(1)
+		}
+
+		message := args[1]
+
+		// Read from stdin if message is "-"
+		if message == "-" {
+			data, err := os.ReadFile("/dev/stdin")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read from stdin: %v\n", err)
+				os.Exit(1)
+			}
+			message = strings.TrimSpace(string(data))
+		}
+
+		if message == "" {
+			fmt.Fprintf(os.Stderr, "Comment message cannot be empty\n")
+			os.Exit(1)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create GitLab client: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := client.CreateIssueNote(projectID, issueIID, message); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to add comment: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Comment added to %s#%d\n", projectID, issueIID)
+	},
+}
+
+var gitlabIssueCloseCmd = &cobra.Command{
+	Use:   "close <project#iid>",
+	Short: "Close an issue",
+	Long: `Close an open issue, optionally explaining why in a comment first.
+
+Use the canonical reference format: project#iid
+
+Examples:
+  dex gl issue close my-group/my-project#42
+  dex gl issue close group/project#42 --reason "Fixed in group/project!123"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		projectID, issueIID, err := parseIssueReference(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid issue reference: %v\n", err)
+			fmt.Fprintf(os.Stderr, "Use format: project#iid (e.g., group/project#42)\n")
+			os.Exit(1)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create GitLab client: %v\n", err)
+			os.Exit(1)
+		}
+
+		reason, _ := cmd.Flags().GetString("reason")
+		if reason != "" {
+			if err := client.CreateIssueNote(projectID, issueIID, reason); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to post comment: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if err := client.CloseIssue(projectID, issueIID); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to close issue: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Closed %s#%d\n", projectID, issueIID)
+	},
+}
+
 // ── Snippet commands ──────────────────────────────────────────────────────────
 
 var gitlabSnippetCmd = &cobra.Command{
@@ -2058,15 +3284,28 @@ func init() {
 	gitlabCmd.AddCommand(gitlabIndexCmd)
 	gitlabCmd.AddCommand(gitlabProjCmd)
 	gitlabCmd.AddCommand(gitlabCommitCmd)
+	gitlabCmd.AddCommand(gitlabTagCmd)
 	gitlabCmd.AddCommand(gitlabMRCmd)
 	gitlabCmd.AddCommand(gitlabPipelineCmd)
 	gitlabCmd.AddCommand(gitlabSnippetCmd)
+	gitlabCmd.AddCommand(gitlabIssueCmd)
+	gitlabCmd.AddCommand(gitlabUserCmd)
+	gitlabCmd.AddCommand(gitlabDiffCommentBatchCmd)
+	gitlabCmd.AddCommand(gitlabBlameCmd)
+
+	gitlabUserCmd.AddCommand(gitlabUserActivityCmd)
 
 	gitlabProjCmd.AddCommand(gitlabProjLsCmd)
+	gitlabProjCmd.AddCommand(gitlabProjSearchCmd)
 	gitlabProjCmd.AddCommand(gitlabShowCmd)
+	gitlabProjCmd.AddCommand(gitlabProjMembersCmd)
+	gitlabProjCmd.AddCommand(gitlabProjArchiveCmd)
+	gitlabProjCmd.AddCommand(gitlabProjUnarchiveCmd)
 
 	gitlabCommitCmd.AddCommand(gitlabCommitLsCmd)
 	gitlabCommitCmd.AddCommand(gitlabCommitShowCmd)
+	gitlabTagCmd.AddCommand(gitlabTagLsCmd)
+	gitlabTagCmd.AddCommand(gitlabTagCreateCmd)
 
 	gitlabMRCmd.AddCommand(gitlabMRLsCmd)
 	gitlabMRCmd.AddCommand(gitlabMRShowCmd)
@@ -2077,11 +3316,22 @@ func init() {
 	gitlabMRCmd.AddCommand(gitlabMRCloseCmd)
 	gitlabMRCmd.AddCommand(gitlabMRReopenCmd)
 	gitlabMRCmd.AddCommand(gitlabMRApproveCmd)
+	gitlabMRCmd.AddCommand(gitlabMRUnapproveCmd)
+	gitlabMRCmd.AddCommand(gitlabMRApproversCmd)
+	gitlabMRCmd.AddCommand(gitlabMRConflictsCmd)
+	gitlabMRCmd.AddCommand(gitlabMRWipCheckCmd)
 	gitlabMRCmd.AddCommand(gitlabMRMergeCmd)
 	gitlabMRCmd.AddCommand(gitlabMRCreateCmd)
 	gitlabMRCmd.AddCommand(gitlabMREditCmd)
+	gitlabMRCmd.AddCommand(gitlabMRReviewersCmd)
+	gitlabMRReviewersCmd.AddCommand(gitlabMRReviewersSuggestCmd)
 
 	gitlabActivityCmd.Flags().StringP("since", "s", "14d", "Time period to look back (e.g., 4h, 30m, 7d)")
+	gitlabActivityCmd.Flags().StringSlice("project", nil, "Only report these projects, by ID or path (repeatable or comma-separated)")
+	gitlabActivityCmd.RegisterFlagCompletionFunc("project", completeProjectNames)
+	gitlabActivityCmd.Flags().String("project-glob", "", "Only scan projects whose path matches this glob (e.g. 'platform/*')")
+	gitlabActivityCmd.Flags().String("author", "", "Only show commits and MRs by this GitLab username (tags are omitted)")
+	gitlabUserActivityCmd.Flags().StringP("since", "s", "14d", "Time period to look back (e.g., 4h, 30m, 7d)")
 	gitlabIndexCmd.Flags().BoolP("force", "f", false, "Force re-index even if cache is fresh")
 	gitlabShowCmd.Flags().Bool("no-cache", false, "Always fetch from API, don't use cache")
 	gitlabShowCmd.Flags().Bool("compact", false, "Compact output (key fields + counts)")
@@ -2092,11 +3342,29 @@ func init() {
 	gitlabProjLsCmd.Flags().Bool("no-cache", false, "Fetch from API instead of using local index")
 	gitlabProjLsCmd.Flags().Bool("compact", false, "Compact output (one line per project)")
 
+	gitlabProjSearchCmd.Flags().IntP("limit", "n", 20, "Number of matches to show (0 = all)")
+	gitlabProjSearchCmd.Flags().Bool("compact", false, "Compact output (one line per project)")
+
+	gitlabProjMembersCmd.Flags().String("min-level", "", "Only members with at least this role: guest, reporter, developer, maintainer, owner")
+	gitlabProjMembersCmd.RegisterFlagCompletionFunc("min-level", cobra.FixedCompletions(
+		[]string{"guest", "reporter", "developer", "maintainer", "owner"}, cobra.ShellCompDirectiveNoFileComp))
+	gitlabProjMembersCmd.Flags().Bool("compact", false, "Compact output (one line per member)")
+
+	gitlabProjArchiveCmd.Flags().BoolP("yes", "y", false, "Confirm archiving the project")
+	gitlabProjUnarchiveCmd.Flags().BoolP("yes", "y", false, "Confirm unarchiving the project")
+
 	gitlabCommitLsCmd.Flags().StringP("since", "s", "14d", "Time period to look back (e.g., 7d, 4h)")
 	gitlabCommitLsCmd.Flags().StringP("branch", "b", "", "Filter by branch or tag")
 	gitlabCommitLsCmd.Flags().IntP("limit", "n", 20, "Number of commits to list")
 	gitlabCommitLsCmd.Flags().Bool("compact", false, "Compact output (one line per commit)")
 
+	gitlabTagLsCmd.Flags().String("search", "", "Filter tags by name (^prefix and suffix$ anchors supported)")
+	gitlabTagLsCmd.Flags().IntP("limit", "n", 20, "Number of tags to list")
+	gitlabTagLsCmd.Flags().Bool("compact", false, "Compact output (one line per tag)")
+	gitlabTagCreateCmd.Flags().StringP("ref", "r", "", "Branch, tag, or commit SHA to tag (required)")
+	gitlabTagCreateCmd.Flags().StringP("message", "m", "", "Tag message (creates an annotated tag)")
+	gitlabTagCreateCmd.Flags().Bool("compact", false, "Print only the tag name and commit SHA")
+
 	gitlabCommitShowCmd.Flags().Bool("compact", false, "Compact output (header + stats only)")
 
 	gitlabMRLsCmd.Flags().StringP("state", "s", "opened", "MR state: opened, merged, closed, all")
@@ -2105,20 +3373,30 @@ func init() {
 	gitlabMRLsCmd.Flags().Bool("include-wip", false, "Include WIP/draft MRs (excluded by default)")
 	gitlabMRLsCmd.Flags().Bool("conflicts-only", false, "Only show MRs with merge conflicts")
 	gitlabMRLsCmd.Flags().Bool("compact", false, "Compact output (one line per MR)")
+	gitlabMRLsCmd.Flags().StringSlice("labels", nil, "Only MRs with all of these labels (repeatable, comma-separated)")
+	gitlabMRLsCmd.Flags().String("author", "", "Only MRs by this author username")
+	gitlabMRLsCmd.Flags().String("target-branch", "", "Only MRs targeting this branch")
 
 	gitlabMRShowCmd.Flags().Bool("show-diff", false, "Show file diffs")
 	gitlabMRShowCmd.Flags().Bool("compact", false, "Compact output (header + counts only)")
+	gitlabMRShowCmd.Flags().Bool("no-cache", false, "Don't read or write the local MR cache")
+	gitlabMRShowCmd.Flags().Bool("refresh", false, "Re-fetch the MR and update the cache")
 
 	gitlabMRDiffCmd.Flags().StringP("file", "f", "", "File path to show diff for")
 	gitlabMRDiffCmd.Flags().BoolP("parsed", "p", false, "Show parsed diff with line numbers")
 	gitlabMRDiffCmd.Flags().IntP("line", "l", 0, "Inspect specific line with context (requires --file)")
 	gitlabMRDiffCmd.Flags().StringP("search", "s", "", "Find lines matching pattern (regex, requires --file)")
 	gitlabMRDiffCmd.Flags().IntP("context", "C", 3, "Number of context lines to show with --line")
+	gitlabMRDiffCmd.Flags().Bool("stat", false, "Show added/deleted line counts per file")
 
 	gitlabMRCommentCmd.Flags().String("reply-to", "", "Reply to an existing discussion thread (discussion ID)")
 	gitlabMRCommentCmd.Flags().String("file", "", "File path for inline comment")
 	gitlabMRCommentCmd.Flags().Int("line", 0, "Line number for inline comment")
 	gitlabMRCommentCmd.Flags().Bool("dry-run", false, "Preview where inline comment will land without posting")
+	gitlabMRCommentCmd.Flags().String("body-file", "", "Read the comment body from a file (instead of the message argument)")
+
+	gitlabDiffCommentBatchCmd.Flags().Bool("dry-run", false, "Validate every comment against the diff without posting")
+	gitlabDiffCommentBatchCmd.Flags().Bool("compact", false, "One line per comment")
 
 	gitlabMRCloseCmd.Flags().String("reason", "", "Post a comment before closing")
 	gitlabMRReopenCmd.Flags().String("reason", "", "Post a comment before reopening")
@@ -2136,6 +3414,15 @@ func init() {
 
 	gitlabMRReactCmd.Flags().Int("note", 0, "Note ID to react to (instead of MR)")
 
+	gitlabMRApproversCmd.Flags().Bool("compact", false, "One line per rule")
+	gitlabMRConflictsCmd.Flags().Bool("compact", false, "One line per conflicting file")
+	gitlabMRWipCheckCmd.Flags().Bool("compact", false, "Single READY / NOT READY line")
+
+	gitlabMRReviewersSuggestCmd.Flags().IntP("limit", "n", 5, "Number of reviewers to suggest")
+	gitlabMRReviewersSuggestCmd.Flags().Int("max-files", 30, "Maximum number of changed files to inspect")
+	gitlabMRReviewersSuggestCmd.Flags().String("since", "365d", "Only consider commits within this period (e.g., 90d, 365d)")
+	gitlabMRReviewersSuggestCmd.Flags().Bool("compact", false, "One line per reviewer")
+
 	gitlabMRMergeCmd.Flags().Bool("squash", false, "Squash commits on merge")
 	gitlabMRMergeCmd.Flags().Bool("remove-source-branch", false, "Remove source branch after merge")
 	gitlabMRMergeCmd.Flags().Bool("when-pipeline-succeeds", false, "Merge when pipeline succeeds")
@@ -2172,6 +3459,37 @@ func init() {
 	gitlabMRCreateCmd.Flags().Bool("remove-source-branch", false, "Remove source branch after merge")
 	gitlabMRCreateCmd.Flags().Bool("squash", false, "Squash commits on merge")
 
+	gitlabIssueCmd.AddCommand(gitlabIssueBoardCmd)
+
+	gitlabIssueBoardCmd.Flags().String("label-prefix", "status::", "Label prefix to group columns by")
+	gitlabIssueBoardCmd.Flags().StringSlice("columns", nil, "Column order as label values without prefix (comma-separated)")
+	gitlabIssueBoardCmd.Flags().String("state", "opened", "Issue state: opened, closed, all")
+	gitlabIssueBoardCmd.Flags().String("assignee", "", "Only issues assigned to this username")
+	gitlabIssueBoardCmd.Flags().StringSlice("label", nil, "Only issues carrying these labels (comma-separated)")
+	gitlabIssueBoardCmd.Flags().IntP("limit", "n", 200, "Maximum number of issues to fetch (0 = all)")
+	gitlabIssueBoardCmd.Flags().Bool("compact", false, "One line per issue, grouped by column")
+
+	gitlabIssueCmd.AddCommand(gitlabIssueLsCmd)
+	gitlabIssueCmd.AddCommand(gitlabIssueShowCmd)
+	gitlabIssueCmd.AddCommand(gitlabIssueCreateCmd)
+	gitlabIssueCmd.AddCommand(gitlabIssueCommentCmd)
+	gitlabIssueCmd.AddCommand(gitlabIssueCloseCmd)
+
+	gitlabIssueLsCmd.Flags().StringP("state", "s", "opened", "Issue state: opened, closed, all")
+	gitlabIssueLsCmd.Flags().String("scope", "all", "Scope: all, created_by_me, assigned_to_me")
+	gitlabIssueLsCmd.Flags().StringSlice("labels", nil, "Only issues carrying these labels (comma-separated)")
+	gitlabIssueLsCmd.Flags().IntP("limit", "n", 20, "Maximum number of issues to show")
+	gitlabIssueLsCmd.Flags().Bool("compact", false, "One line per issue")
+
+	gitlabIssueShowCmd.Flags().Bool("compact", false, "Header only, without description and comments")
+
+	gitlabIssueCreateCmd.Flags().StringP("project", "p", "", "Project path (default: from git remote)")
+	gitlabIssueCreateCmd.Flags().StringP("description", "d", "", "Issue description")
+	gitlabIssueCreateCmd.Flags().StringSlice("labels", nil, "Labels to set (comma-separated)")
+	gitlabIssueCreateCmd.Flags().Bool("confidential", false, "Create a confidential issue")
+
+	gitlabIssueCloseCmd.Flags().String("reason", "", "Post a comment before closing")
+
 	gitlabSnippetCmd.AddCommand(gitlabSnippetLsCmd)
 	gitlabSnippetCmd.AddCommand(gitlabSnippetShowCmd)
 	gitlabSnippetCmd.AddCommand(gitlabSnippetCreateCmd)
@@ -2199,8 +3517,14 @@ func init() {
 	gitlabFileMetaCmd.Flags().String("ref", "", "Branch, tag, or commit SHA (default: HEAD)")
 	gitlabFileMetaCmd.Flags().Bool("compact", false, "Compact one-line output")
 
-	gitlabFileBlameCmd.Flags().String("ref", "", "Branch, tag, or commit SHA (default: HEAD)")
-	gitlabFileBlameCmd.Flags().Bool("compact", false, "Hide authored date")
+	for _, c := range []*cobra.Command{gitlabFileBlameCmd, gitlabBlameCmd} {
+		c.Flags().String("ref", "", "Branch, tag, or commit SHA (default: HEAD)")
+		c.Flags().String("range", "", "Only blame lines start:end (1-based, inclusive; e.g. 120:160, 120:, :40)")
+		c.Flags().StringP("lines", "L", "", "Only blame lines start,end or start,+count (git blame -L style)")
+		c.Flags().Int("line", 0, "Blame one line with --context lines around it, highlighted")
+		c.Flags().IntP("context", "C", 2, "Lines of context around --line")
+		c.Flags().Bool("compact", false, "Hide authored date")
+	}
 
 	// tree command
 	gitlabCmd.AddCommand(gitlabTreeCmd)
@@ -2260,8 +3584,10 @@ func formatSinceTime(since time.Time, duration time.Duration) string {
 	return since.Format("2006-01-02")
 }
 
-// fetchProjectActivitiesConcurrently fetches activity for multiple projects in parallel
-func fetchProjectActivitiesConcurrently(client *gitlab.Client, projects []*gogitlab.Project, since time.Time) []gitlab.ProjectActivity {
+// fetchProjectActivitiesConcurrently fetches activity for multiple projects in
+// parallel. A non-nil author narrows it to that user's commits (with line
+// stats) and MRs and skips tags. progressFn is called after each project.
+func fetchProjectActivitiesConcurrently(client *gitlab.Client, projects []*gogitlab.Project, since time.Time, author *gitlab.ActivityAuthor, progressFn gitlab.ProgressFunc) []gitlab.ProjectActivity {
 	type result struct {
 		activity gitlab.ProjectActivity
 		hasData  bool
@@ -2287,6 +3613,21 @@ func fetchProjectActivitiesConcurrently(client *gitlab.Client, projects []*gogit
 				WebURL:      p.WebURL,
 			}
 
+			if author != nil {
+				commits, err := client.GetAuthorCommits(p.ID, since, author.Name)
+				if err == nil {
+					activity.Commits = commits
+				}
+
+				mrs, err := client.GetAuthorMergeRequests(p.ID, since, author.Username)
+				if err == nil {
+					activity.MergeRequests = mrs
+				}
+
+				results <- result{activity: activity, hasData: activity.HasActivity()}
+				return
+			}
+
 			commits, err := client.GetCommits(p.ID, since)
 			if err == nil {
 				activity.Commits = commits
@@ -2317,7 +3658,7 @@ func fetchProjectActivitiesConcurrently(client *gitlab.Client, projects []*gogit
 
 	for r := range results {
 		completed++
-		fmt.Printf("\r  Fetched %d/%d projects...", completed, total)
+		progressFn(completed, total)
 		if r.hasData {
 			activities = append(activities, r.activity)
 		}
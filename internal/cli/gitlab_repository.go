@@ -3,6 +3,8 @@ package cli
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 
 	"github.com/codewandler/dex/internal/config"
 	"github.com/codewandler/dex/internal/gitlab"
@@ -113,47 +115,200 @@ Examples:
 }
 
 var gitlabFileBlameCmd = &cobra.Command{
-	Use:   "blame <project> <path>",
+	Use:   "blame <project> [ref] <path>",
 	Short: "Show git blame for a file",
 	Long: `Show git blame output for a file, listing each line with its commit and author.
 
+The ref can be given as a middle argument or with --ref (default: HEAD).
+
+Use --range start:end or git-style -L start,end to blame only part of the file
+(either side may be left open, e.g. 120: or :40; -L 120,+10 takes 10 lines).
+Use --line n to blame one line with --context lines around it; the line
+itself is highlighted.
+
 Examples:
   dex gl file blame my-group/my-project src/server.go
-  dex gl file blame my-group/my-project src/server.go --ref main`,
-	Args: cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		project := args[0]
-		path := args[1]
-		ref, _ := cmd.Flags().GetString("ref")
+  dex gl file blame my-group/my-project main src/server.go
+  dex gl file blame my-group/my-project src/server.go --ref main
+  dex gl file blame my-group/my-project src/server.go --range 120:160
+  dex gl file blame my-group/my-project src/server.go -L 120,+10
+  dex gl file blame my-group/my-project src/server.go --line 142`,
+	Args: cobra.RangeArgs(2, 3),
+	Run:  runGitlabBlame,
+}
 
-		cfg, err := config.Load()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+// gitlabBlameCmd is a top-level shortcut for `dex gl file blame`.
+var gitlabBlameCmd = &cobra.Command{
+	Use:   "blame <project> [ref] <path>",
+	Short: "Show git blame for a file (shortcut for 'gl file blame')",
+	Long: `Show git blame output for a file: each line prefixed with the short commit
+SHA, author and date. No clone needed.
+
+This is a convenience shortcut for 'dex gl file blame'.
+
+Examples:
+  dex gl blame my-group/my-project src/server.go
+  dex gl blame my-group/my-project v1.2.0 src/server.go
+  dex gl blame my-group/my-project src/server.go --range 120:160
+  dex gl blame my-group/my-project src/server.go -L 120,140
+  dex gl blame my-group/my-project src/server.go --line 142 -C 5`,
+	Args: cobra.RangeArgs(2, 3),
+	Run:  runGitlabBlame,
+}
+
+func runGitlabBlame(cmd *cobra.Command, args []string) {
+	project := args[0]
+	path := args[len(args)-1]
+	ref, _ := cmd.Flags().GetString("ref")
+	if len(args) == 3 {
+		if ref != "" {
+			fmt.Fprintln(os.Stderr, "Give the ref either as an argument or with --ref, not both")
 			os.Exit(1)
 		}
-		if err := cfg.RequireGitLab(); err != nil {
-			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		ref = args[1]
+	}
+	rangeStr, _ := cmd.Flags().GetString("range")
+	lStr, _ := cmd.Flags().GetString("lines")
+	line, _ := cmd.Flags().GetInt("line")
+	context, _ := cmd.Flags().GetInt("context")
+
+	given := 0
+	for _, set := range []bool{rangeStr != "", lStr != "", cmd.Flags().Changed("line")} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		fmt.Fprintln(os.Stderr, "Use only one of --range, -L/--lines and --line")
+		os.Exit(1)
+	}
+
+	var start, end int
+	var err error
+	switch {
+	case rangeStr != "":
+		if start, end, err = parseLineRange(rangeStr); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --range: %v\n", err)
 			os.Exit(1)
 		}
-		client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to create GitLab client: %v\n", err)
+	case lStr != "":
+		if start, end, err = parseBlameLineRange(lStr); err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid -L: %v\n", err)
 			os.Exit(1)
 		}
-
-		result, err := client.GetFileBlame(project, path, ref)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	case cmd.Flags().Changed("line"):
+		if line < 1 {
+			fmt.Fprintln(os.Stderr, "Invalid --line: must be a line number >= 1")
 			os.Exit(1)
 		}
-
-		compact, _ := cmd.Flags().GetBool("compact")
-		mode := render.ModeNormal
-		if compact {
-			mode = render.ModeCompact
+		if context < 0 {
+			context = 0
+		}
+		start, end = max(1, line-context), line+context
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cfg.RequireGitLab(); err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
+	}
+	client, err := gitlab.NewClient(cfg.GitLab.URL, cfg.GitLab.Token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create GitLab client: %v\n", err)
+		os.Exit(1)
+	}
+
+	result, err := client.GetFileBlame(project, path, ref)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if given > 0 {
+		total := result.LineCount()
+		if start > total {
+			fmt.Fprintf(os.Stderr, "Error: %s has only %d lines\n", path, total)
+			os.Exit(1)
 		}
-		RenderWithMode(result, mode)
-	},
+		result.LineRange(start, end)
+		if line > 0 {
+			result.Highlight = line
+		}
+	}
+
+	compact, _ := cmd.Flags().GetBool("compact")
+	mode := render.ModeNormal
+	if compact {
+		mode = render.ModeCompact
+	}
+	RenderWithMode(result, mode)
+}
+
+// parseLineRange parses "start:end" (1-based, inclusive). Either side may be
+// omitted: "120:" runs to the end of the file (end 0), ":40" starts at line 1.
+func parseLineRange(s string) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(s, ":")
+	if !ok {
+		return 0, 0, fmt.Errorf("%q: expected start:end", s)
+	}
+	start, end := 1, 0
+	var err error
+	if startStr != "" {
+		if start, err = strconv.Atoi(startStr); err != nil || start < 1 {
+			return 0, 0, fmt.Errorf("%q: start must be a line number >= 1", s)
+		}
+	}
+	if endStr != "" {
+		if end, err = strconv.Atoi(endStr); err != nil || end < 1 {
+			return 0, 0, fmt.Errorf("%q: end must be a line number >= 1", s)
+		}
+		if end < start {
+			return 0, 0, fmt.Errorf("%q: end is before start", s)
+		}
+	}
+	return start, end, nil
+}
+
+// parseBlameLineRange parses git blame's -L forms: "start,end", "start,+count"
+// and "start" for a single line (1-based, inclusive). Either side of the comma
+// may be omitted: "120," runs to the end of the file (end 0), ",40" starts at
+// line 1.
+func parseBlameLineRange(s string) (int, int, error) {
+	startStr, endStr, ok := strings.Cut(s, ",")
+	start, end := 1, 0
+	var err error
+	if startStr != "" {
+		if start, err = strconv.Atoi(startStr); err != nil || start < 1 {
+			return 0, 0, fmt.Errorf("%q: start must be a line number >= 1", s)
+		}
+	}
+	if !ok {
+		if startStr == "" {
+			return 0, 0, fmt.Errorf("%q: expected start,end", s)
+		}
+		return start, start, nil
+	}
+	switch {
+	case strings.HasPrefix(endStr, "+"):
+		count, err := strconv.Atoi(endStr[1:])
+		if err != nil || count < 1 {
+			return 0, 0, fmt.Errorf("%q: count must be >= 1", s)
+		}
+		end = start + count - 1
+	case endStr != "":
+		if end, err = strconv.Atoi(endStr); err != nil || end < 1 {
+			return 0, 0, fmt.Errorf("%q: end must be a line number >= 1", s)
+		}
+		if end < start {
+			return 0, 0, fmt.Errorf("%q: end is before start", s)
+		}
+	case startStr == "":
+		return 0, 0, fmt.Errorf("%q: expected start,end", s)
+	}
+	return start, end, nil
 }
 
 // ── gl tree ───────────────────────────────────────────────────────────────────
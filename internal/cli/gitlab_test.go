@@ -0,0 +1,147 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codewandler/dex/internal/gitlab"
+	"github.com/fatih/color"
+)
+
+func TestParseIssueReference(t *testing.T) {
+	tests := []struct {
+		in      string
+		project string
+		iid     int
+		wantErr bool
+	}{
+		{in: "group/project#42", project: "group/project", iid: 42},
+		{in: "group/sub/project#7", project: "group/sub/project", iid: 7},
+		{in: `group/project\#42`, project: "group/project", iid: 42},
+		{in: "123#5", project: "123", iid: 5},
+		{in: "group/project", wantErr: true},
+		{in: "#42", wantErr: true},
+		{in: "group/project#", wantErr: true},
+		{in: "group/project#abc", wantErr: true},
+		{in: "group/project!42", wantErr: true},
+	}
+	for _, tt := range tests {
+		project, iid, err := parseIssueReference(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseIssueReference(%q) = %q, %d; want error", tt.in, project, iid)
+			}
+			continue
+		}
+		if err != nil || project != tt.project || iid != tt.iid {
+			t.Errorf("parseIssueReference(%q) = %q, %d, %v; want %q, %d", tt.in, project, iid, err, tt.project, tt.iid)
+		}
+	}
+}
+
+func TestResolveMRCommentBody(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	multi := write("multi.md", "## Review\n\n- first point  \n- second\n\n")
+	blank := write("blank.md", " \n\n")
+
+	tests := []struct {
+		name     string
+		args     []string
+		bodyFile string
+		want     string
+		wantErr  bool
+	}{
+		{name: "argument", args: []string{"LGTM"}, want: "LGTM"},
+		{name: "file verbatim", bodyFile: multi, want: "## Review\n\n- first point  \n- second\n"},
+		{name: "both", args: []string{"LGTM"}, bodyFile: multi, wantErr: true},
+		{name: "neither", wantErr: true},
+		{name: "missing file", bodyFile: filepath.Join(dir, "nope.md"), wantErr: true},
+		{name: "blank file", bodyFile: blank, wantErr: true},
+		{name: "empty argument", args: []string{""}, wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := resolveMRCommentBody(tt.args, tt.bodyFile)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: got %q, want error", tt.name, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("%s: got %q, %v; want %q", tt.name, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseBlameLineRange(t *testing.T) {
+	tests := []struct {
+		in         string
+		start, end int
+		wantErr    bool
+	}{
+		{in: "120,160", start: 120, end: 160},
+		{in: "120,+10", start: 120, end: 129},
+		{in: "120,+1", start: 120, end: 120},
+		{in: "120,", start: 120, end: 0},
+		{in: ",40", start: 1, end: 40},
+		{in: "42", start: 42, end: 42},
+		{in: "5,5", start: 5, end: 5},
+		{in: "", wantErr: true},
+		{in: ",", wantErr: true},
+		{in: "160,120", wantErr: true},
+		{in: "0,10", wantErr: true},
+		{in: "10,+0", wantErr: true},
+		{in: "10,+x", wantErr: true},
+		{in: "a,b", wantErr: true},
+		{in: "120:160", wantErr: true},
+	}
+	for _, tt := range tests {
+		start, end, err := parseBlameLineRange(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseBlameLineRange(%q) = %d, %d; want error", tt.in, start, end)
+			}
+			continue
+		}
+		if err != nil || start != tt.start || end != tt.end {
+			t.Errorf("parseBlameLineRange(%q) = %d, %d, %v; want %d, %d", tt.in, start, end, err, tt.start, tt.end)
+		}
+	}
+}
+
+func TestPrintActivityReport(t *testing.T) {
+	var buf bytes.Buffer
+	saved := color.Output
+	color.Output = &buf
+	t.Cleanup(func() { color.Output = saved })
+
+	// Projects left empty by the author filter are not listed
+	printActivityReport(24*time.Hour, []gitlab.ProjectActivity{
+		{ProjectPath: "platform/api"},
+		{ProjectPath: "platform/web"},
+	})
+	if out := buf.String(); !strings.Contains(out, "No activity found") || strings.Contains(out, "platform/") {
+		t.Errorf("filtered-out activity: got %q, want only the no-activity message", out)
+	}
+
+	buf.Reset()
+	printActivityReport(24*time.Hour, []gitlab.ProjectActivity{
+		{ProjectPath: "platform/api"},
+		{ProjectPath: "platform/web", MergeRequests: []gitlab.MergeRequest{{IID: 1, Title: "Fix login", CreatedAt: time.Now()}}},
+	})
+	out := buf.String()
+	if strings.Contains(out, "No activity found") || strings.Contains(out, "platform/api") || !strings.Contains(out, "platform/web") {
+		t.Errorf("mixed activity: got %q, want only platform/web", out)
+	}
+}
@@ -2,12 +2,18 @@ package cli
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"os"
+	"os/signal"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/codewandler/dex/internal/config"
@@ -24,6 +30,7 @@ var (
 	homerErrorColor   = color.New(color.FgRed)
 	homerMethodColor  = color.New(color.FgYellow, color.Bold)
 	homerWarnColor    = color.New(color.FgHiYellow)
+	homerFocusColor   = color.New(color.FgMagenta, color.Bold)
 )
 
 // getHomerClient handles the full discovery -> auth flow and returns a ready-to-use client
@@ -43,6 +50,9 @@ func getHomerClient(cmd *cobra.Command) (*homer.Client, error) {
 	// 3. Create client and authenticate
 	client := homer.NewClient(homerURL)
 	client.Debug, _ = cmd.Flags().GetBool("debug")
+	if noCache, _ := cmd.Flags().GetBool("no-session-cache"); !noCache {
+		client.Sessions, _ = homer.DefaultSessionCache()
+	}
 	if err := client.Authenticate(username, password); err != nil {
 		return nil, fmt.Errorf("authentication failed at %s: %w", homerURL, err)
 	}
@@ -140,6 +150,18 @@ var homerCmd = &cobra.Command{
 	Short:   "SIP call tracing via Homer",
 	Long:    `Commands for searching and inspecting SIP traffic via Homer.`,
 	Aliases: []string{"sip"},
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		tz, _ := cmd.Flags().GetString("tz")
+		if tz == "" {
+			return
+		}
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --tz: %v\n", err)
+			os.Exit(1)
+		}
+		homerDisplayLoc = loc
+	},
 }
 
 var homerDiscoverCmd = &cobra.Command{
@@ -218,6 +240,180 @@ Examples:
 	},
 }
 
+// homerHealthCheck is one line of `dex homer health`
+type homerHealthCheck struct {
+	Name      string `json:"name"`
+	OK        bool   `json:"ok"`
+	Detail    string `json:"detail,omitempty"`
+	LatencyMS int64  `json:"latency_ms"`
+}
+
+// homerHealthReport is the -o json shape of `dex homer health`
+type homerHealthReport struct {
+	URL       string             `json:"url"`
+	Healthy   bool               `json:"healthy"`
+	Checks    []homerHealthCheck `json:"checks"`
+	Retention *homer.Retention   `json:"retention,omitempty"`
+}
+
+var homerHealthCmd = &cobra.Command{
+	Use:   "health",
+	Short: "Check Homer connectivity, auth, retention and latency",
+	Long: `Probe the resolved Homer endpoint and print a pass/fail health summary.
+
+Checks:
+  connectivity     unauthenticated API check (/api/v3/agent/check)
+  authentication   login with the resolved credentials
+  retention        how many days back SIP data is still stored (probes 0-90 days)
+  latency          round-trip of a small authenticated search (fails above --max-latency)
+
+Exits with status 1 when any check fails, also with -o json, so it can be used
+from monitoring scripts.
+
+Examples:
+  dex homer health
+  dex homer health --url http://homer.example.com
+  dex homer health -o json --max-latency 500ms`,
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		namespace, _ := cmd.Flags().GetString("namespace")
+		output, _ := cmd.Flags().GetString("output")
+		maxLatency, _ := cmd.Flags().GetDuration("max-latency")
+
+		homerURL, err := resolveHomerURL(urlFlag, namespace)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		report := homerHealthReport{URL: homerURL}
+		add := func(c homerHealthCheck) { report.Checks = append(report.Checks, c) }
+		skip := func(name string) { add(homerHealthCheck{Name: name, Detail: "skipped"}) }
+
+		client := homer.NewClient(homerURL)
+		client.Debug, _ = cmd.Flags().GetBool("debug")
+
+		start := time.Now()
+		err = client.TestConnection()
+		conn := homerHealthCheck{Name: "connectivity", OK: err == nil, LatencyMS: time.Since(start).Milliseconds()}
+		if err != nil {
+			conn.Detail = err.Error()
+		}
+		add(conn)
+
+		if conn.OK {
+			username, password := resolveHomerCredentials(homerURL)
+			start = time.Now()
+			err = client.Authenticate(username, password)
+			auth := homerHealthCheck{Name: "authentication", OK: err == nil, LatencyMS: time.Since(start).Milliseconds()}
+			if err != nil {
+				auth.Detail = err.Error()
+			} else {
+				auth.Detail = "as " + username
+			}
+			add(auth)
+
+			if auth.OK {
+				now := time.Now()
+				ret := homerHealthCheck{Name: "retention"}
+				retention, err := client.ProbeRetention(now)
+				switch {
+				case err != nil:
+					ret.Detail = err.Error()
+				case retention.Days < 0:
+					ret.Detail = fmt.Sprintf("no SIP data in the last %d days", retention.MaxDays+1)
+					report.Retention = retention
+				default:
+					ret.OK = true
+					oldest := homerTime(retention.Oldest).Format("2006-01-02 15:04")
+					switch retention.Days {
+					case 0:
+						ret.Detail = fmt.Sprintf("< 1 day (oldest message seen %s)", oldest)
+					case retention.MaxDays:
+						ret.Detail = fmt.Sprintf("≥ %d days, the deepest probe (oldest message seen %s)", retention.Days, oldest)
+					default:
+						ret.Detail = fmt.Sprintf("≥ %d days (oldest message seen %s)", retention.Days, oldest)
+					}
+					report.Retention = retention
+				}
+				add(ret)
+
+				start = time.Now()
+				_, err = client.SearchCalls(homer.SearchParams{From: now.Add(-5 * time.Minute), To: now, Limit: 1})
+				lat := homerHealthCheck{Name: "latency", LatencyMS: time.Since(start).Milliseconds()}
+				switch {
+				case err != nil:
+					lat.Detail = err.Error()
+				case maxLatency > 0 && time.Duration(lat.LatencyMS)*time.Millisecond > maxLatency:
+					lat.Detail = fmt.Sprintf("search round-trip above %s", maxLatency)
+				default:
+					lat.OK = true
+					lat.Detail = "search round-trip"
+				}
+				add(lat)
+			} else {
+				skip("retention")
+				skip("latency")
+			}
+		} else {
+			skip("authentication")
+			skip("retention")
+			skip("latency")
+		}
+
+		report.Healthy = true
+		for _, c := range report.Checks {
+			if !c.OK {
+				report.Healthy = false
+			}
+		}
+
+		if output == "json" {
+			printHomerJSON(cmd, report)
+			if !report.Healthy {
+				os.Exit(1)
+			}
+			return
+		}
+
+		homerHeaderColor.Print("Homer health")
+		homerDimColor.Printf("  %s\n\n", homerURL)
+		for _, c := range report.Checks {
+			if c.OK {
+				homerSuccessColor.Print("  ✓ ")
+			} else if c.Detail == "skipped" {
+				homerDimColor.Print("  - ")
+			} else {
+				homerErrorColor.Print("  ✗ ")
+			}
+			fmt.Printf("%-15s", c.Name)
+			latency := ""
+			if c.Name != "retention" && c.Detail != "skipped" {
+				latency = fmt.Sprintf("%dms", c.LatencyMS)
+			}
+			fmt.Printf(" %6s", latency)
+			if c.Detail != "" {
+				if c.OK || c.Detail == "skipped" {
+					homerDimColor.Printf("  %s", c.Detail)
+				} else {
+					homerErrorColor.Printf("  %s", c.Detail)
+				}
+			}
+			fmt.Println()
+		}
+		fmt.Println()
+		if report.Healthy {
+			homerSuccessColor.Println("Healthy")
+			return
+		}
+		homerErrorColor.Println("Unhealthy")
+		os.Exit(1)
+	},
+}
+
+// homerFailedCondition is the smart input --failed adds: any 4xx-6xx response
+const homerFailedCondition = "status >= 400"
+
 var homerSearchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Search SIP calls",
@@ -229,26 +425,74 @@ Homer smart input query. Use -q for custom expressions with field validation.
 Available fields: from_user, to_user, ruri_user, user_agent (alias: ua),
   cseq, method, status, call_id (alias: sid)
 
+Operators: = and != on all fields; >, >=, <, <= on status only. A status class
+such as 4xx matches the whole range (= 4xx is status >= 400 AND status < 500).
+--failed adds status >= 400.
+
+--dedup-callid keeps one message per Call-ID (the first, or the latest with
+--dedup latest) in the output. It is applied after the fetch, so --limit still
+counts messages: raise it if calls are cut off.
+
 Examples:
   dex homer search --number "4921514174858"
   dex homer search --from-user "999%" --to-user "12345"
   dex homer search --from-user "999%" --ua "Asterisk%"
   dex homer search -q "from_user = '123' AND status = 200"
   dex homer search -q "from_user = '999%' AND (to_user = '123' OR to_user = '456')"
+  dex homer search -q "status >= 400 AND method = 'INVITE'"
+  dex homer search -q "status = 5xx"                     # Any 5xx (class wildcard)
+  dex homer search --number "4921514174858" --failed    # Same as -q "status >= 400"
   dex homer search --at "2026-02-04 17:13"
   dex homer search --number "4921514174858" -m INVITE -m BYE
-  dex homer search --number "4921514174858" -o jsonl`,
+  dex homer search --number "4921514174858" -o jsonl
+  dex homer search --number "4921514174858" -o csv > messages.csv
+  dex homer search --ua "FPBX%" --dedup-callid          # One row per call (its first message)
+  dex homer search --ua "FPBX%" --dedup latest          # One row per call (its latest message)
+  dex homer search -q "ua = 'Asterisk%' AND status = 503" --save-query asterisk-503
+  dex homer search @asterisk-503 --since 2h    # Run a saved query (see 'dex homer queries')`,
+	Args: cobra.MaximumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
+		query, _ := cmd.Flags().GetString("query")
+		queryName, _ := cmd.Flags().GetString("query-name")
+		failed, _ := cmd.Flags().GetBool("failed")
+		saveName, _ := cmd.Flags().GetString("save-query")
+		if len(args) == 1 {
+			if !strings.HasPrefix(args[0], "@") {
+				fmt.Fprintf(os.Stderr, "Unexpected argument %q (use @name to run a saved query)\n", args[0])
+				os.Exit(1)
+			}
+			if queryName != "" {
+				fmt.Fprintf(os.Stderr, "Provide either @name or --query-name, not both\n")
+				os.Exit(1)
+			}
+			queryName = args[0]
+		}
+		if queryName != "" {
+			if query != "" {
+				fmt.Fprintf(os.Stderr, "Provide either -q or a saved query, not both\n")
+				os.Exit(1)
+			}
+			saved, err := lookupHomerQuery(queryName)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			query = saved
+		}
+		if saveName != "" {
+			if err := saveHomerQuery(saveName, query); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to save query: %v\n", err)
+				os.Exit(1)
+			}
+			homerDimColor.Fprintf(os.Stderr, "  Saved query @%s\n", normalizeHomerQueryName(saveName))
+		}
+
 		client, err := getHomerClient(cmd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "%v\n", err)
 			os.Exit(1)
 		}
 
-		sinceStr, _ := cmd.Flags().GetString("since")
-		untilStr, _ := cmd.Flags().GetString("until")
-		atStr, _ := cmd.Flags().GetString("at")
-		query, _ := cmd.Flags().GetString("query")
 		number, _ := cmd.Flags().GetString("number")
 		fromUser, _ := cmd.Flags().GetString("from-user")
 		toUser, _ := cmd.Flags().GetString("to-user")
@@ -257,83 +501,29 @@ Examples:
 		methods, _ := cmd.Flags().GetStringSlice("method")
 		limit, _ := cmd.Flags().GetInt("limit")
 		output, _ := cmd.Flags().GetString("output")
+		dedupCallID, _ := cmd.Flags().GetBool("dedup-callid")
+		dedup, _ := cmd.Flags().GetString("dedup")
 
-		var from, to time.Time
-
-		if atStr != "" {
-			if cmd.Flags().Changed("since") || cmd.Flags().Changed("until") {
-				fmt.Fprintf(os.Stderr, "Cannot use --at together with --since/--until\n")
-				os.Exit(1)
-			}
-			at, err := parseTimeValue(atStr)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
-				os.Exit(1)
-			}
-			from = at.Add(-5 * time.Minute)
-			to = at.Add(5 * time.Minute)
-		} else {
-			from, err = parseTimeValue(sinceStr)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
-				os.Exit(1)
-			}
-			if untilStr == "" {
-				to = time.Now()
-			} else {
-				to, err = parseTimeValue(untilStr)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
-					os.Exit(1)
-				}
-			}
+		switch dedup {
+		case "first", "latest":
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid --dedup %q (use first or latest)\n", dedup)
+			os.Exit(1)
+		}
+		if cmd.Flags().Changed("dedup") {
+			dedupCallID = true
 		}
 
+		from, to := homerSearchWindow(cmd)
+
 		if output == "" {
-			homerDimColor.Printf("  Time range: %s → %s\n\n", from.Format("2006-01-02 15:04:05"), to.Format("2006-01-02 15:04:05"))
+			homerDimColor.Printf("  Time range: %s → %s\n\n", homerTime(from).Format("2006-01-02 15:04:05"), homerTime(to).Format("2006-01-02 15:04:05"))
 		}
 
-		// Build smartinput from flags. Each flag produces a set of OR-alternatives
-		// (e.g. with/without + prefix). The cartesian product of all sets is computed
-		// so that AND binds within each term and OR separates terms — no parentheses
-		// needed since Homer uses standard AND-before-OR precedence.
-		var criteria [][]string
-		if number != "" {
-			bare := strings.TrimPrefix(number, "+")
-			plus := "+" + bare
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.from_user = '%s'", bare),
-				fmt.Sprintf("data_header.from_user = '%s'", plus),
-				fmt.Sprintf("data_header.to_user = '%s'", bare),
-				fmt.Sprintf("data_header.to_user = '%s'", plus),
-			})
-		}
-		if fromUser != "" {
-			bare := strings.TrimPrefix(fromUser, "+")
-			plus := "+" + bare
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.from_user = '%s'", bare),
-				fmt.Sprintf("data_header.from_user = '%s'", plus),
-			})
-		}
-		if toUser != "" {
-			bare := strings.TrimPrefix(toUser, "+")
-			plus := "+" + bare
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.to_user = '%s'", bare),
-				fmt.Sprintf("data_header.to_user = '%s'", plus),
-			})
-		}
-		if ua != "" {
-			criteria = append(criteria, []string{fmt.Sprintf("data_header.user_agent = '%s'", ua)})
-		}
-		if query != "" {
-			parsed, err := homer.ParseQuery(query)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid query: %v\n", err)
-				os.Exit(1)
-			}
-			criteria = append(criteria, []string{parsed})
+		criteria, err := buildHomerCriteria(number, fromUser, toUser, ua, query, failed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid query: %v\n", err)
+			os.Exit(1)
 		}
 
 		params := homer.SearchParams{
@@ -355,10 +545,7 @@ Examples:
 
 		// Client-side method filter
 		if len(methods) > 0 {
-			methodSet := make(map[string]bool, len(methods))
-			for _, m := range methods {
-				methodSet[strings.ToUpper(m)] = true
-			}
+			methodSet := homerMethodSet(methods)
 			filtered := records[:0]
 			for _, r := range records {
 				if methodSet[strings.ToUpper(r.Method)] {
@@ -368,11 +555,15 @@ Examples:
 			records = filtered
 		}
 
+		// One row per Call-ID, applied after the fetch (and the method filter)
+		messageCount := len(records)
+		if dedupCallID {
+			records = homer.DedupByCallID(records, dedup == "latest")
+		}
+
 		// JSON/JSONL output
 		if output == "json" {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			enc.Encode(records)
+			printHomerJSON(cmd, records)
 			return
 		}
 		if output == "jsonl" {
@@ -382,6 +573,13 @@ Examples:
 			}
 			return
 		}
+		if output == "csv" {
+			if err := writeHomerSearchCSV(os.Stdout, records); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write CSV: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 
 		if len(records) == 0 {
 			homerDimColor.Println("No calls found.")
@@ -412,7 +610,11 @@ Examples:
 		lineWidth := 20 + 2 + routeWidth + 2 + maxCallIDWidth + 2 + 10 + 2 + 20 + 2 + 20 + 2 + maxUAWidth
 		line := strings.Repeat("─", lineWidth)
 		fmt.Println()
-		homerHeaderColor.Printf("  SIP Calls (%d)\n", len(records))
+		if dedupCallID {
+			homerHeaderColor.Printf("  SIP Calls (%d, %s message per Call-ID of %d)\n", len(records), dedup, messageCount)
+		} else {
+			homerHeaderColor.Printf("  SIP Calls (%d)\n", len(records))
+		}
 		fmt.Println("  " + line)
 		fmt.Println()
 
@@ -435,7 +637,7 @@ Examples:
 				toUser = "-"
 			}
 
-			fmt.Printf("  %-20s  ", r.Date.Format("2006-01-02 15:04:05"))
+			fmt.Printf("  %-20s  ", homerTime(r.Date).Format("2006-01-02 15:04:05"))
 			printRoute(r.SrcIP, r.SrcPort, r.DstIP, r.DstPort, maxSrcWidth, routeWidth)
 			fmt.Print("  ")
 			printCallID(r.CallID, maxCallIDWidth)
@@ -455,13 +657,29 @@ var homerShowCmd = &cobra.Command{
 
 Multiple Call-IDs can be provided to show a combined message flow sorted by timestamp.
 Use --raw to display the full raw SIP message bodies (headers + SDP).
+Use --sdp to display only the SDP bodies of INVITEs and the 200 OK responses to
+them (found via CSeq), skipping the SIP headers, to focus on media negotiation.
+Use --only-method (repeatable, short -m/--method) to limit the flow to some
+methods or response codes, e.g. the INVITE/200/BYE milestones of a busy call,
+and --hide-method (repeatable) to drop noise such as OPTIONS keepalives or
+100 Trying. Hiding a request method also hides the 200 responses to it (found
+via CSeq). Both are case-insensitive, apply to the ladder as well as --raw and
+--sdp, and the number of hidden messages is printed below the output.
+Use --grep-header (repeatable) with --raw to print only the request/status line
+and the headers whose name starts with the given prefix, e.g. to compare
+P-Asserted-Identity or Reason across all messages of a call.
 Default time range is 10 days (matching Homer retention).
 
 Examples:
   dex homer show abc123-def456@host
   dex homer show id1@host id2@host id3@host
   dex homer show abc123-def456@host --raw
-  dex homer show abc123-def456@host --from 2h`,
+  dex homer show abc123-def456@host --sdp
+  dex homer show abc123-def456@host --raw --grep-header P-Asserted --grep-header Reason
+  dex homer show abc123-def456@host -m INVITE -m 200 -m BYE
+  dex homer show id1@host id2@host --hide-method OPTIONS --hide-method 100
+  dex homer show abc123-def456@host --from 2h
+  dex homer show abc123-def456@host --from "2026-02-04 17:00" --to "2026-02-04 17:10"`,
 	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		client, err := getHomerClient(cmd)
@@ -473,6 +691,21 @@ Examples:
 		fromStr, _ := cmd.Flags().GetString("from")
 		toStr, _ := cmd.Flags().GetString("to")
 		raw, _ := cmd.Flags().GetBool("raw")
+		sdpOnly, _ := cmd.Flags().GetBool("sdp")
+		methods, _ := cmd.Flags().GetStringSlice("method")
+		onlyMethods, _ := cmd.Flags().GetStringSlice("only-method")
+		hideMethods, _ := cmd.Flags().GetStringSlice("hide-method")
+		grepHeaders, _ := cmd.Flags().GetStringSlice("grep-header")
+		filter := newHomerMethodFilter(append(methods, onlyMethods...), hideMethods)
+
+		if raw && sdpOnly {
+			fmt.Fprintf(os.Stderr, "Cannot use --raw together with --sdp\n")
+			os.Exit(1)
+		}
+		if len(grepHeaders) > 0 && !raw {
+			fmt.Fprintf(os.Stderr, "--grep-header filters raw messages and needs --raw\n")
+			os.Exit(1)
+		}
 
 		from, to, err := parseTimeRange(fromStr, toStr)
 		if err != nil {
@@ -504,11 +737,11 @@ Examples:
 		}
 
 		// Sort merged results by timestamp
-		sort.Slice(merged.Data, func(i, j int) bool {
+		sort.SliceStable(merged.Data, func(i, j int) bool {
 			return merged.Data[i].Date < merged.Data[j].Date
 		})
 
-		if raw {
+		if raw || sdpOnly {
 			// Fetch full transaction with raw SIP bodies
 			txnParams := homer.SearchParams{From: from, To: to}
 			txn, err := client.GetTransaction(txnParams, merged.Data)
@@ -518,31 +751,72 @@ Examples:
 			}
 
 			// Sort transaction messages by timestamp
-			sort.Slice(txn.Data.Messages, func(i, j int) bool {
+			sort.SliceStable(txn.Data.Messages, func(i, j int) bool {
 				return txn.Data.Messages[i].CreateDate < txn.Data.Messages[j].CreateDate
 			})
 
-			printed := 0
+			printed, hidden := 0, 0
 			for _, msg := range txn.Data.Messages {
 				if !msg.IsSIP() {
 					continue
 				}
-				if printed > 0 {
-					fmt.Println()
+				if !filter.keep(correlateMethodFromRaw(msg.Raw), homer.SIPTransactionMethod(msg.Raw)) {
+					hidden++
+					continue
 				}
 				proto := "UDP"
 				if msg.Protocol == 6 {
 					proto = "TCP"
 				}
-				ts := time.UnixMilli(msg.CreateDate)
+				ts := homerTime(time.UnixMilli(msg.CreateDate))
+				if sdpOnly {
+					if !isSDPOfferAnswer(msg.Raw) {
+						continue
+					}
+					method := correlateMethodFromRaw(msg.Raw)
+					if printed > 0 {
+						fmt.Println()
+					}
+					if method == "200" {
+						method = "200 OK"
+					}
+					homerMethodColor.Printf("── %s", method)
+					homerDimColor.Printf("  %s %s  %s:%d → %s:%d ──\n",
+						proto, ts.Format("2006-01-02 15:04:05.000"),
+						msg.SrcIP, msg.SrcPort, msg.DstIP, msg.DstPort)
+					body := homer.ExtractSDP(msg.Raw)
+					if body == "" {
+						homerDimColor.Println("   (no SDP body)")
+					} else {
+						for _, l := range strings.Split(body, "\n") {
+							printSDPLine(strings.TrimRight(l, "\r"))
+						}
+					}
+					printed++
+					continue
+				}
+				if printed > 0 {
+					fmt.Println()
+				}
 				homerDimColor.Printf("── %s %s  %s:%d → %s:%d ──\n",
 					proto, ts.Format("2006-01-02 15:04:05.000"),
 					msg.SrcIP, msg.SrcPort, msg.DstIP, msg.DstPort)
-				fmt.Println(msg.Raw)
+				if len(grepHeaders) > 0 {
+					fmt.Println(homer.FilterSIPHeaders(msg.Raw, grepHeaders))
+				} else {
+					fmt.Println(msg.Raw)
+				}
 				printed++
 			}
 			if printed == 0 {
-				homerDimColor.Println("No raw SIP messages available.")
+				if sdpOnly {
+					homerDimColor.Println("No INVITE or 200 OK (INVITE) messages available.")
+				} else {
+					homerDimColor.Println("No raw SIP messages available.")
+				}
+			}
+			if hidden > 0 {
+				homerDimColor.Printf("\n%d message(s) hidden by method filters\n", hidden)
 			}
 			return
 		}
@@ -552,9 +826,29 @@ Examples:
 			label = fmt.Sprintf("%d call-ids", len(args))
 		}
 
+		// Client-side method/status filter
+		shown := merged.Data
+		if filter.active() {
+			shown = nil
+			for _, msg := range merged.Data {
+				method := msg.Method
+				if method == "" {
+					method = msg.MethodText
+				}
+				if filter.keep(method, cseqMethod(msg.CSeq)) {
+					shown = append(shown, msg)
+				}
+			}
+		}
+		hidden := len(merged.Data) - len(shown)
+
 		line := strings.Repeat("─", 100)
 		fmt.Println()
-		homerHeaderColor.Printf("  SIP Message Flow - %s (%d messages)\n", label, len(merged.Data))
+		if filter.active() {
+			homerHeaderColor.Printf("  SIP Message Flow - %s (%d of %d messages)\n", label, len(shown), len(merged.Data))
+		} else {
+			homerHeaderColor.Printf("  SIP Message Flow - %s (%d messages)\n", label, len(merged.Data))
+		}
 		fmt.Println("  " + line)
 		fmt.Println()
 
@@ -563,7 +857,7 @@ Examples:
 			"TIME", "SOURCE", "", "DESTINATION", "METHOD/STATUS")
 		fmt.Println("  " + line)
 
-		for _, msg := range merged.Data {
+		for _, msg := range shown {
 			src := fmt.Sprintf("%s:%d", msg.SourceIP, int(msg.SourcePort))
 			dst := fmt.Sprintf("%s:%d", msg.DestIP, int(msg.DestPort))
 
@@ -584,19 +878,121 @@ Examples:
 			homerMethodColor.Printf("%s\n", method)
 		}
 		fmt.Println()
+		if hidden > 0 {
+			homerDimColor.Printf("  %d message(s) hidden by method filters\n\n", hidden)
+		}
 	},
 }
 
+// homerSearchWindow resolves the --since/--until/--at flags of search, calls
+// and stats. --at selects ±5 minutes around a point in time and cannot be
+// combined with the other two. Invalid values exit the process.
+func homerSearchWindow(cmd *cobra.Command) (from, to time.Time) {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+	atStr, _ := cmd.Flags().GetString("at")
+
+	if atStr != "" {
+		if cmd.Flags().Changed("since") || cmd.Flags().Changed("until") {
+			fmt.Fprintf(os.Stderr, "Cannot use --at together with --since/--until\n")
+			os.Exit(1)
+		}
+		at, err := parseHomerTimeValue(atStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
+			os.Exit(1)
+		}
+		return at.Add(-5 * time.Minute), at.Add(5 * time.Minute)
+	}
+
+	from, err := parseHomerTimeValue(sinceStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+		os.Exit(1)
+	}
+	if untilStr == "" {
+		return from, time.Now()
+	}
+	to, err = parseHomerTimeValue(untilStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
+		os.Exit(1)
+	}
+	return from, to
+}
+
+// homerMethodSet builds the lookup set for -m/--method filters. Values are
+// matched case-insensitively against the SIP method or response code.
+func homerMethodSet(methods []string) map[string]bool {
+	set := make(map[string]bool, len(methods))
+	for _, m := range methods {
+		set[strings.ToUpper(strings.TrimSpace(m))] = true
+	}
+	return set
+}
+
+// homerMethodFilter implements the --only-method/--hide-method filters of
+// homer show. Methods and response codes are matched case-insensitively.
+type homerMethodFilter struct {
+	only map[string]bool
+	hide map[string]bool
+}
+
+func newHomerMethodFilter(only, hide []string) homerMethodFilter {
+	return homerMethodFilter{only: homerMethodSet(only), hide: homerMethodSet(hide)}
+}
+
+func (f homerMethodFilter) active() bool {
+	return len(f.only) > 0 || len(f.hide) > 0
+}
+
+// keep reports whether a message is shown, given its method or response code
+// and the method of its transaction (from CSeq). A 200 response is hidden
+// along with its hidden request, e.g. --hide-method OPTIONS also drops the
+// 200 OK answering each OPTIONS.
+func (f homerMethodFilter) keep(method, txnMethod string) bool {
+	method = strings.ToUpper(method)
+	if len(f.only) > 0 && !f.only[method] {
+		return false
+	}
+	if f.hide[method] {
+		return false
+	}
+	return method != "200" || !f.hide[strings.ToUpper(txnMethod)]
+}
+
+// cseqMethod returns the method part of a CSeq value ("1 OPTIONS" → "OPTIONS")
+func cseqMethod(cseq string) string {
+	fields := strings.Fields(cseq)
+	if len(fields) != 2 {
+		return ""
+	}
+	return fields[1]
+}
+
 var homerExportCmd = &cobra.Command{
-	Use:   "export <call-id>",
+	Use:   "export <call-id> [call-id...]",
 	Short: "Export call as PCAP file",
 	Long: `Export SIP messages for a call as a PCAP file for analysis in Wireshark.
 
+Multiple Call-IDs (e.g. the legs of a correlated call) are merged into one
+capture, so Wireshark shows the whole call. The default filename is then
+derived from the first Call-ID with a "-merged" suffix.
+
+--anonymize-ips rewrites every IP address to a placeholder from the
+documentation ranges (198.51.100.x, then 203.0.113.x and 192.0.2.x;
+2001:db8::x for IPv6) before the file is written: in the packet headers and in
+SIP/SDP payloads sent over UDP (Via, Contact, c= lines). Each address keeps the
+same placeholder throughout the export, and the mapping is printed as a legend
+so the capture can be shared without leaking internal topology.
+
 Examples:
   dex homer export abc123-def456@host
   dex homer export abc123-def456@host -o trace.pcap
-  dex homer export abc123-def456@host --from 2h`,
-	Args: cobra.ExactArgs(1),
+  dex homer export abc123-def456@host --from 2h
+  dex homer export abc123-def456@host --anonymize-ips
+  dex homer export leg1-id leg2-id leg3-id`,
+	Args: cobra.MinimumNArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		client, err := getHomerClient(cmd)
 		if err != nil {
@@ -604,10 +1000,10 @@ Examples:
 			os.Exit(1)
 		}
 
-		callID := args[0]
 		output, _ := cmd.Flags().GetString("output")
 		fromStr, _ := cmd.Flags().GetString("from")
 		toStr, _ := cmd.Flags().GetString("to")
+		anonymize, _ := cmd.Flags().GetBool("anonymize-ips")
 
 		from, to, err := parseTimeRange(fromStr, toStr)
 		if err != nil {
@@ -617,57 +1013,194 @@ Examples:
 
 		if output == "" {
 			// Generate default filename from call-id
-			safe := strings.NewReplacer("@", "_", ":", "_", "/", "_").Replace(callID)
+			safe := strings.NewReplacer("@", "_", ":", "_", "/", "_").Replace(args[0])
 			if len(safe) > 40 {
 				safe = safe[:40]
 			}
+			if len(args) > 1 {
+				safe += "-merged"
+			}
 			output = safe + ".pcap"
 		}
 
-		params := homer.SearchParams{
-			From:   from,
-			To:     to,
-			CallID: callID,
+		// Search for each Call-ID and merge results
+		var merged *homer.SearchResult
+		for _, callID := range args {
+			params := homer.SearchParams{
+				From:   from,
+				To:     to,
+				CallID: callID,
+				Limit:  200,
+			}
+			result, err := client.SearchCalls(params)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get messages for %s: %v\n", callID, err)
+				os.Exit(1)
+			}
+			merged = homer.MergeSearchResults(merged, result)
+		}
+
+		if merged == nil || len(merged.Data) == 0 {
+			homerDimColor.Println("No data to export for the given call-id(s).")
+			return
 		}
 
-		data, err := client.ExportPCAP(params)
+		data, err := client.ExportPCAP(homer.SearchParams{From: from, To: to}, merged.Data)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
 			os.Exit(1)
 		}
 
 		if len(data) == 0 {
-			homerDimColor.Println("No data to export for this call-id.")
+			homerDimColor.Println("No data to export for the given call-id(s).")
 			return
 		}
 
+		var ipMapping []homer.IPMapping
+		if anonymize {
+			data, ipMapping, err = homer.AnonymizePCAP(data)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to anonymize IPs: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
 		if err := os.WriteFile(output, data, 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to write file: %v\n", err)
 			os.Exit(1)
 		}
 
 		homerSuccessColor.Printf("Exported %d bytes to %s\n", len(data), output)
+
+		if anonymize {
+			fmt.Println()
+			homerHeaderColor.Printf("  Anonymized IPs (%d)\n", len(ipMapping))
+			for _, m := range ipMapping {
+				fmt.Printf("  %-39s ", m.Placeholder)
+				homerDimColor.Printf("← %s\n", m.Original)
+			}
+		}
 	},
 }
 
-var homerEndpointsCmd = &cobra.Command{
-	Use:   "endpoints",
-	Short: "List configured Homer endpoints",
-	Long: `List all Homer endpoints configured in ~/.dex/config.json.
+var homerQueriesCmd = &cobra.Command{
+	Use:   "queries",
+	Short: "List saved search queries",
+	Long: `List the named query expressions saved with 'dex homer search --save-query'.
 
-Shows the default URL and any endpoint-specific credential overrides.
+Saved queries live under homer.queries in ~/.dex/config.json and are run with
+'dex homer search @name' or --query-name name.
 
 Examples:
-  dex homer endpoints`,
+  dex homer queries
+  dex homer queries -o json`,
 	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
 		cfg, err := config.Load()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
 			os.Exit(1)
 		}
 
-		hasDefault := cfg.Homer.URL != ""
-		hasEndpoints := len(cfg.Homer.Endpoints) > 0
+		if output == "json" {
+			queries := cfg.Homer.Queries
+			if queries == nil {
+				queries = map[string]string{}
+			}
+			printHomerJSON(cmd, queries)
+			return
+		}
+
+		if len(cfg.Homer.Queries) == 0 {
+			homerDimColor.Println("No saved queries.")
+			homerDimColor.Println("Tip: dex homer search -q \"<expression>\" --save-query <name>")
+			return
+		}
+
+		names := make([]string, 0, len(cfg.Homer.Queries))
+		nameWidth := 4
+		for name := range cfg.Homer.Queries {
+			names = append(names, name)
+			nameWidth = max(nameWidth, len(name)+1)
+		}
+		sort.Strings(names)
+
+		line := strings.Repeat("─", 80)
+		fmt.Println()
+		homerHeaderColor.Printf("  Saved Queries (%d)\n", len(names))
+		fmt.Println("  " + line)
+		fmt.Println()
+		for _, name := range names {
+			homerMethodColor.Printf("  %-*s", nameWidth, "@"+name)
+			fmt.Printf("  %s\n", cfg.Homer.Queries[name])
+		}
+		fmt.Println()
+	},
+}
+
+// normalizeHomerQueryName strips the optional @ prefix from a saved query name
+func normalizeHomerQueryName(name string) string {
+	return strings.TrimPrefix(strings.TrimSpace(name), "@")
+}
+
+// lookupHomerQuery returns the saved query expression for name (with or without @)
+func lookupHomerQuery(name string) (string, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return "", fmt.Errorf("failed to load config: %w", err)
+	}
+	name = normalizeHomerQueryName(name)
+	expr, ok := cfg.Homer.Queries[name]
+	if !ok {
+		return "", fmt.Errorf("no saved query @%s (see 'dex homer queries')", name)
+	}
+	return expr, nil
+}
+
+// saveHomerQuery validates expr with homer.ParseQuery and stores it under name
+// in the config file, replacing any existing query with that name.
+func saveHomerQuery(name, expr string) error {
+	name = normalizeHomerQueryName(name)
+	if name == "" || strings.ContainsAny(name, " \t@") {
+		return fmt.Errorf("invalid query name %q", name)
+	}
+	if strings.TrimSpace(expr) == "" {
+		return fmt.Errorf("--save-query requires -q")
+	}
+	if _, err := homer.ParseQuery(expr); err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	cfg, err := config.LoadFromFile()
+	if err != nil {
+		return err
+	}
+	if cfg.Homer.Queries == nil {
+		cfg.Homer.Queries = make(map[string]string)
+	}
+	cfg.Homer.Queries[name] = strings.TrimSpace(expr)
+	return config.Save(cfg)
+}
+
+var homerEndpointsCmd = &cobra.Command{
+	Use:   "endpoints",
+	Short: "List configured Homer endpoints",
+	Long: `List all Homer endpoints configured in ~/.dex/config.json.
+
+Shows the default URL and any endpoint-specific credential overrides.
+
+Examples:
+  dex homer endpoints`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+			os.Exit(1)
+		}
+
+		hasDefault := cfg.Homer.URL != ""
+		hasEndpoints := len(cfg.Homer.Endpoints) > 0
 
 		if !hasDefault && !hasEndpoints {
 			homerDimColor.Println("No Homer endpoints configured.")
@@ -734,7 +1267,31 @@ Examples:
   dex homer calls --ua "FPBX%" --since 30m
   dex homer calls -q "ua = 'Asterisk%'" --since 1h
   dex homer calls --at "2026-02-04 17:13"
-  dex homer calls --since 1h -o json`,
+  dex homer calls --since 1h -o json
+  dex homer calls --since 1h -o csv > calls.csv
+  dex homer calls --since 2h --active-only     # Calls without BYE/CANCEL/final failure
+  dex homer calls --since 1h --failed          # Calls with a 4xx-6xx response
+  dex homer calls --since 1h --direction inbound   # Customer calls into the platform
+  dex homer calls --since 1h -q "status = 5xx"  # Calls with a server error
+  dex homer calls --since 4h --min-duration 1h   # Suspiciously long calls
+  dex homer calls --since 1h --max-duration 1s   # Zero-length calls
+
+--active-only keeps calls that have an INVITE but no BYE, no CANCEL and no final
+error response, i.e. calls still ringing or answered and never hung up. It only
+sees the fetched messages, so treat the result as candidates for stuck sessions.
+
+--failed (status >= 400) and status conditions in -q select messages, so calls
+are built from the matching responses only.
+
+--direction inbound|outbound classifies calls with homer.internal_patterns from
+the config: number patterns ("%" wildcard) matched against caller and callee,
+and IPs/CIDRs of internal SBCs matched against the first INVITE's source and
+destination. A call is inbound when only the callee side is internal and
+outbound when only the caller side is.
+
+--min-duration and --max-duration (e.g. 30s, 1h) keep calls whose duration,
+first to last fetched message, lies within the bounds. With --min-duration,
+calls with a single message are dropped since they have no reliable end time.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		client, err := getHomerClient(cmd)
 		if err != nil {
@@ -742,9 +1299,6 @@ Examples:
 			os.Exit(1)
 		}
 
-		sinceStr, _ := cmd.Flags().GetString("since")
-		untilStr, _ := cmd.Flags().GetString("until")
-		atStr, _ := cmd.Flags().GetString("at")
 		number, _ := cmd.Flags().GetString("number")
 		fromUser, _ := cmd.Flags().GetString("from-user")
 		toUser, _ := cmd.Flags().GetString("to-user")
@@ -752,80 +1306,49 @@ Examples:
 		query, _ := cmd.Flags().GetString("query")
 		limit, _ := cmd.Flags().GetInt("limit")
 		output, _ := cmd.Flags().GetString("output")
-
-		var from, to time.Time
-
-		if atStr != "" {
-			if cmd.Flags().Changed("since") || cmd.Flags().Changed("until") {
-				fmt.Fprintf(os.Stderr, "Cannot use --at together with --since/--until\n")
+		activeOnly, _ := cmd.Flags().GetBool("active-only")
+		failed, _ := cmd.Flags().GetBool("failed")
+		direction, _ := cmd.Flags().GetString("direction")
+		minDurStr, _ := cmd.Flags().GetString("min-duration")
+		maxDurStr, _ := cmd.Flags().GetString("max-duration")
+
+		var minDur, maxDur time.Duration
+		if minDurStr != "" {
+			if minDur, err = parseLokiDuration(minDurStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --min-duration: %v\n", err)
 				os.Exit(1)
 			}
-			at, err := parseTimeValue(atStr)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
+		}
+		if maxDurStr != "" {
+			if maxDur, err = parseLokiDuration(maxDurStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --max-duration: %v\n", err)
 				os.Exit(1)
 			}
-			from = at.Add(-5 * time.Minute)
-			to = at.Add(5 * time.Minute)
-		} else {
-			from, err = parseTimeValue(sinceStr)
+		}
+		if minDur > 0 && maxDur > 0 && minDur > maxDur {
+			fmt.Fprintf(os.Stderr, "--min-duration must not exceed --max-duration\n")
+			os.Exit(1)
+		}
+
+		var matcher *homer.InternalMatcher
+		if direction != "" {
+			matcher, err = homerInternalMatcher(direction)
 			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+				fmt.Fprintf(os.Stderr, "%v\n", err)
 				os.Exit(1)
 			}
-			if untilStr == "" {
-				to = time.Now()
-			} else {
-				to, err = parseTimeValue(untilStr)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
-					os.Exit(1)
-				}
-			}
 		}
 
+		from, to := homerSearchWindow(cmd)
+
 		if output == "" {
-			homerDimColor.Printf("  Time range: %s → %s\n\n", from.Format("2006-01-02 15:04:05"), to.Format("2006-01-02 15:04:05"))
+			homerDimColor.Printf("  Time range: %s → %s\n\n", homerTime(from).Format("2006-01-02 15:04:05"), homerTime(to).Format("2006-01-02 15:04:05"))
 		}
 
-		// Build smartinput from flags (same logic as search command).
-		var criteria [][]string
-		if number != "" {
-			bare := strings.TrimPrefix(number, "+")
-			plus := "+" + bare
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.from_user = '%s'", bare),
-				fmt.Sprintf("data_header.from_user = '%s'", plus),
-				fmt.Sprintf("data_header.to_user = '%s'", bare),
-				fmt.Sprintf("data_header.to_user = '%s'", plus),
-			})
-		}
-		if fromUser != "" {
-			bare := strings.TrimPrefix(fromUser, "+")
-			plus := "+" + bare
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.from_user = '%s'", bare),
-				fmt.Sprintf("data_header.from_user = '%s'", plus),
-			})
-		}
-		if toUser != "" {
-			bare := strings.TrimPrefix(toUser, "+")
-			plus := "+" + bare
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.to_user = '%s'", bare),
-				fmt.Sprintf("data_header.to_user = '%s'", plus),
-			})
-		}
-		if ua != "" {
-			criteria = append(criteria, []string{fmt.Sprintf("data_header.user_agent = '%s'", ua)})
-		}
-		if query != "" {
-			parsed, err := homer.ParseQuery(query)
-			if err != nil {
-				fmt.Fprintf(os.Stderr, "Invalid query: %v\n", err)
-				os.Exit(1)
-			}
-			criteria = append(criteria, []string{parsed})
+		criteria, err := buildHomerCriteria(number, fromUser, toUser, ua, query, failed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid query: %v\n", err)
+			os.Exit(1)
 		}
 
 		params := homer.SearchParams{
@@ -839,11 +1362,39 @@ Examples:
 			os.Exit(1)
 		}
 
+		fetched := len(calls)
+		if activeOnly {
+			calls = homer.FilterActiveCalls(calls)
+			homerWarnColor.Fprintln(os.Stderr, "  --active-only is a heuristic: a BYE, CANCEL or final response outside the time range or the fetched messages makes a finished call look active.")
+			if output == "" {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+		if matcher != nil {
+			calls = matcher.FilterCallsByDirection(calls, direction)
+			for i := range calls {
+				calls[i].Direction = homerDirectionLabel(direction)
+			}
+			homerDimColor.Fprintln(os.Stderr, "  --direction is inferred from homer.internal_patterns (caller/callee numbers and first INVITE src/dst IPs); calls matching neither or both sides are left out.")
+			if output == "" {
+				fmt.Fprintln(os.Stderr)
+			}
+		}
+		durationFiltered := minDur > 0 || maxDur > 0
+		if durationFiltered {
+			var noEnd int
+			calls, noEnd = homer.FilterCallsByDuration(calls, minDur, maxDur)
+			if noEnd > 0 {
+				homerDimColor.Fprintf(os.Stderr, "  --min-duration dropped %d call(s) with a single message and no reliable end time.\n", noEnd)
+				if output == "" {
+					fmt.Fprintln(os.Stderr)
+				}
+			}
+		}
+
 		// JSON/JSONL output
 		if output == "json" {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			enc.Encode(calls)
+			printHomerJSON(cmd, calls)
 			return
 		}
 		if output == "jsonl" {
@@ -853,6 +1404,13 @@ Examples:
 			}
 			return
 		}
+		if output == "csv" {
+			if err := writeHomerCallsCSV(os.Stdout, calls); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write CSV: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 
 		if len(calls) == 0 {
 			homerDimColor.Println("No calls found.")
@@ -861,7 +1419,18 @@ Examples:
 
 		line := strings.Repeat("─", 110)
 		fmt.Println()
-		homerHeaderColor.Printf("  Calls (%d)\n", len(calls))
+		title := "Calls"
+		if direction != "" {
+			title = strings.ToUpper(direction[:1]) + direction[1:] + " calls"
+		}
+		if activeOnly {
+			title = "Active " + strings.ToLower(title)
+		}
+		if activeOnly || direction != "" || durationFiltered {
+			homerHeaderColor.Printf("  %s (%d of %d)\n", title, len(calls), fetched)
+		} else {
+			homerHeaderColor.Printf("  %s (%d)\n", title, len(calls))
+		}
 		fmt.Println("  " + line)
 		fmt.Println()
 
@@ -887,26 +1456,385 @@ Examples:
 		fmt.Println("  " + line)
 
 		for _, c := range calls {
-			caller := c.Caller
-			if caller == "" {
-				caller = "-"
+			printHomerCallRow(c, maxTimeWidth, maxCallIDWidth)
+		}
+		fmt.Println()
+	},
+}
+
+// homerCSVTimeLayout is the timestamp format of CSV output; spreadsheets parse
+// it as a date and --tz applies like in the tables
+const homerCSVTimeLayout = "2006-01-02 15:04:05"
+
+// writeHomerSearchCSV writes search results as CSV with a header row
+func writeHomerSearchCSV(w io.Writer, records []homer.SearchRecord) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"date", "src", "dst", "call_id", "method", "from_user", "to_user", "user_agent"})
+	for _, r := range records {
+		cw.Write([]string{
+			homerTime(r.Date).Format(homerCSVTimeLayout),
+			fmt.Sprintf("%s:%d", r.SrcIP, r.SrcPort),
+			fmt.Sprintf("%s:%d", r.DstIP, r.DstPort),
+			r.CallID,
+			r.Method,
+			r.FromUser,
+			r.ToUser,
+			r.UserAgent,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// writeHomerCallsCSV writes call summaries as CSV with a header row
+func writeHomerCallsCSV(w io.Writer, calls []homer.CallSummary) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"start", "end", "duration_seconds", "call_id", "caller", "callee", "status", "msg_count"})
+	for _, c := range calls {
+		cw.Write([]string{
+			homerTime(c.StartTime).Format(homerCSVTimeLayout),
+			homerTime(c.EndTime).Format(homerCSVTimeLayout),
+			strconv.FormatFloat(c.Duration.Seconds(), 'f', -1, 64),
+			c.CallID,
+			c.Caller,
+			c.Callee,
+			c.Status,
+			strconv.Itoa(c.MsgCount),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// printHomerCallRow prints one row of the calls table
+func printHomerCallRow(c homer.CallSummary, timeWidth, callIDWidth int) {
+	caller := c.Caller
+	if caller == "" {
+		caller = "-"
+	}
+	callee := c.Callee
+	if callee == "" {
+		callee = "-"
+	}
+
+	printCallTime(c, timeWidth)
+	fmt.Print("  ")
+	printCallID(c.CallID, callIDWidth)
+	fmt.Printf("  %-20s  %-20s  ", caller, callee)
+	formatCallStatus(c.Status)
+	fmt.Print("\n")
+}
+
+// homerWatchOverlap is how far each poll reaches back before the previous one,
+// so calls whose first message is indexed late are not missed
+const homerWatchOverlap = 30 * time.Second
+
+var homerStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Summarize call volume and outcomes over a time window",
+	Long: `Fetch calls like 'homer calls' and print aggregate numbers instead of a list:
+total calls, calls per status, answer-seizure ratio (answered / total), median
+and p95 call duration, and the most common User-Agents.
+
+Durations run from the first to the last fetched message of a call and only
+include calls with more than one message. Stats cover at most --limit calls;
+a note is printed when the limit is reached.
+
+Examples:
+  dex homer stats --since 1h
+  dex homer stats --since 24h --ua "FPBX%"
+  dex homer stats --number "31617554360" --since 7d --limit 1000
+  dex homer stats --at "2026-02-04 17:13"
+  dex homer stats --since 1h -o json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := getHomerClient(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		number, _ := cmd.Flags().GetString("number")
+		fromUser, _ := cmd.Flags().GetString("from-user")
+		toUser, _ := cmd.Flags().GetString("to-user")
+		ua, _ := cmd.Flags().GetString("ua")
+		query, _ := cmd.Flags().GetString("query")
+		limit, _ := cmd.Flags().GetInt("limit")
+		failed, _ := cmd.Flags().GetBool("failed")
+		topUA, _ := cmd.Flags().GetInt("top-ua")
+		output, _ := cmd.Flags().GetString("output")
+
+		from, to := homerSearchWindow(cmd)
+
+		criteria, err := buildHomerCriteria(number, fromUser, toUser, ua, query, failed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid query: %v\n", err)
+			os.Exit(1)
+		}
+
+		params := homer.SearchParams{
+			From:       from,
+			To:         to,
+			SmartInput: buildSmartInput(criteria),
+		}
+		calls, err := client.FetchCalls(params, number, limit)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Search failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		stats := homer.ComputeCallStats(calls, topUA)
+		stats.From, stats.To = from, to
+
+		if output == "json" {
+			printHomerJSON(cmd, stats)
+			return
+		}
+
+		fmt.Println()
+		homerHeaderColor.Printf("  Call stats  ")
+		homerDimColor.Printf("%s → %s\n", homerTime(from).Format("2006-01-02 15:04:05"), homerTime(to).Format("2006-01-02 15:04:05"))
+		fmt.Println("  " + strings.Repeat("─", 60))
+
+		if stats.Total == 0 {
+			homerDimColor.Println("  No calls found.")
+			fmt.Println()
+			return
+		}
+
+		fmt.Printf("  %-18s %d\n", "Total calls", stats.Total)
+		statuses := append([]string{}, homer.CallStatuses...)
+		if n := stats.ByStatus[""]; n > 0 {
+			statuses = append(statuses, "")
+		}
+		for _, st := range statuses {
+			n := stats.ByStatus[st]
+			if n == 0 {
+				continue
 			}
-			callee := c.Callee
-			if callee == "" {
-				callee = "-"
+			label := st
+			if label == "" {
+				label = "(unknown)"
 			}
+			c := homerDimColor
+			switch st {
+			case "answered":
+				c = homerSuccessColor
+			case "failed":
+				c = homerErrorColor
+			case "busy", "no answer", "cancelled":
+				c = homerWarnColor
+			}
+			c.Printf("    %-16s", label)
+			fmt.Printf(" %-6d", n)
+			homerDimColor.Printf("%5.1f%%\n", float64(n)/float64(stats.Total)*100)
+		}
+		fmt.Printf("  %-18s %.1f%%\n", "ASR", stats.ASR*100)
+		if stats.DurationSamples > 0 {
+			fmt.Printf("  %-18s median %s, p95 %s",
+				"Duration",
+				formatDuration(time.Duration(stats.MedianDurationSeconds*float64(time.Second))),
+				formatDuration(time.Duration(stats.P95DurationSeconds*float64(time.Second))))
+			homerDimColor.Printf("  (%d calls with an end time)\n", stats.DurationSamples)
+		} else {
+			fmt.Printf("  %-18s ", "Duration")
+			homerDimColor.Println("no calls with more than one message")
+		}
 
-			printCallTime(c, maxTimeWidth)
-			fmt.Print("  ")
-			printCallID(c.CallID, maxCallIDWidth)
-			fmt.Printf("  %-20s  %-20s  ", caller, callee)
-			formatCallStatus(c.Status)
-			fmt.Print("\n")
+		if len(stats.TopUserAgents) > 0 {
+			fmt.Println()
+			homerHeaderColor.Println("  Top user agents")
+			for _, u := range stats.TopUserAgents {
+				name := u.UserAgent
+				if name == "" {
+					name = "(none)"
+				}
+				if len(name) > 40 {
+					name = name[:37] + "..."
+				}
+				fmt.Printf("    %-40s %d\n", name, u.Calls)
+			}
+		}
+
+		if len(calls) >= limit {
+			fmt.Println()
+			homerWarnColor.Printf("  Limit of %d calls reached; stats cover only the most recent calls (raise --limit).\n", limit)
 		}
 		fmt.Println()
 	},
 }
 
+var homerWatchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Live tail of new SIP calls",
+	Long: `Poll Homer for calls and print each call once, when it first appears.
+
+The first poll looks back --since; every later poll covers the time since the
+previous poll plus a short overlap. Calls are de-duplicated by Call-ID, so only
+new calls are printed. Runs until interrupted with Ctrl-C.
+
+Examples:
+  dex homer watch
+  dex homer watch --number 4921514174858
+  dex homer watch --ua "Asterisk PBX" --interval 10s
+  dex homer watch -q "status >= 400"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		number, _ := cmd.Flags().GetString("number")
+		fromUser, _ := cmd.Flags().GetString("from-user")
+		toUser, _ := cmd.Flags().GetString("to-user")
+		ua, _ := cmd.Flags().GetString("ua")
+		query, _ := cmd.Flags().GetString("query")
+		failed, _ := cmd.Flags().GetBool("failed")
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		if interval < time.Second {
+			fmt.Fprintf(os.Stderr, "Invalid --interval: must be at least 1s\n")
+			os.Exit(1)
+		}
+		from, err := parseHomerTimeValue(sinceStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+			os.Exit(1)
+		}
+		criteria, err := buildHomerCriteria(number, fromUser, toUser, ua, query, failed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid query: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := getHomerClient(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+
+		const timeWidth, callIDWidth = 38, 40
+		fmt.Println()
+		homerHeaderColor.Printf("  Watching for new calls (every %s, Ctrl-C to stop)\n", interval)
+		fmt.Println()
+		fmt.Printf("  %-*s  %-*s  %-20s  %-20s  %s\n", timeWidth, "TIME", callIDWidth, "CALL-ID", "FROM", "TO", "STATUS")
+		fmt.Println("  " + strings.Repeat("─", timeWidth+2+callIDWidth+2+20+2+20+2+12))
+
+		seen := make(map[string]bool)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for poll := 1; ; poll++ {
+			to := time.Now()
+			params := homer.SearchParams{
+				From:       from,
+				To:         to,
+				SmartInput: buildSmartInput(criteria),
+			}
+			calls, err := client.FetchCalls(params, number, limit)
+			if err != nil {
+				homerErrorColor.Fprintf(os.Stderr, "  poll #%d failed: %v\n", poll, err)
+			} else {
+				// FetchCalls returns newest first; print in arrival order
+				sort.SliceStable(calls, func(i, j int) bool {
+					return calls[i].StartTime.Before(calls[j].StartTime)
+				})
+				newCalls := 0
+				for _, c := range calls {
+					if seen[c.CallID] {
+						continue
+					}
+					seen[c.CallID] = true
+					newCalls++
+					printHomerCallRow(c, timeWidth, callIDWidth)
+				}
+				homerDimColor.Printf("  poll #%d at %s, %d new\n", poll, homerTime(to).Format("15:04:05"), newCalls)
+				from = to.Add(-homerWatchOverlap)
+			}
+
+			select {
+			case <-ctx.Done():
+				fmt.Println()
+				return
+			case <-ticker.C:
+			}
+		}
+	},
+}
+
+// homerInternalMatcher validates a --direction value and builds the matcher
+// from homer.internal_patterns in the config
+func homerInternalMatcher(direction string) (*homer.InternalMatcher, error) {
+	if direction != homer.DirectionInbound && direction != homer.DirectionOutbound {
+		return nil, fmt.Errorf("invalid --direction %q (use inbound or outbound)", direction)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	matcher, err := homer.NewInternalMatcher(cfg.Homer.InternalPatterns)
+	if err != nil {
+		return nil, err
+	}
+	if matcher.Empty() {
+		return nil, fmt.Errorf("--direction needs internal number patterns or SBC IPs in homer.internal_patterns (~/.dex/config.json)")
+	}
+	return matcher, nil
+}
+
+// homerDirectionLabel maps an inferred direction to the IN/OUT column value
+func homerDirectionLabel(direction string) string {
+	if direction == homer.DirectionInbound {
+		return "IN"
+	}
+	return "OUT"
+}
+
+// buildHomerCriteria turns the shared call filter flags into smartinput
+// criteria for buildSmartInput. Each flag produces a set of OR-alternatives
+// (e.g. with/without + prefix).
+func buildHomerCriteria(number, fromUser, toUser, ua, query string, failed bool) ([][]string, error) {
+	var criteria [][]string
+	if number != "" {
+		bare := strings.TrimPrefix(number, "+")
+		plus := "+" + bare
+		criteria = append(criteria, []string{
+			fmt.Sprintf("data_header.from_user = '%s'", bare),
+			fmt.Sprintf("data_header.from_user = '%s'", plus),
+			fmt.Sprintf("data_header.to_user = '%s'", bare),
+			fmt.Sprintf("data_header.to_user = '%s'", plus),
+		})
+	}
+	if fromUser != "" {
+		bare := strings.TrimPrefix(fromUser, "+")
+		plus := "+" + bare
+		criteria = append(criteria, []string{
+			fmt.Sprintf("data_header.from_user = '%s'", bare),
+			fmt.Sprintf("data_header.from_user = '%s'", plus),
+		})
+	}
+	if toUser != "" {
+		bare := strings.TrimPrefix(toUser, "+")
+		plus := "+" + bare
+		criteria = append(criteria, []string{
+			fmt.Sprintf("data_header.to_user = '%s'", bare),
+			fmt.Sprintf("data_header.to_user = '%s'", plus),
+		})
+	}
+	if ua != "" {
+		criteria = append(criteria, []string{fmt.Sprintf("data_header.user_agent = '%s'", ua)})
+	}
+	if query != "" {
+		parsed, err := homer.ParseQuery(query)
+		if err != nil {
+			return nil, err
+		}
+		criteria = append(criteria, []string{parsed})
+	}
+	if failed {
+		criteria = append(criteria, []string{homerFailedCondition})
+	}
+	return criteria, nil
+}
+
 // buildSmartInput constructs a Homer smartinput expression from criteria.
 // Each criterion is a set of OR-alternatives (e.g. number with/without + prefix).
 // The cartesian product of all criteria is computed: AND within each product term,
@@ -932,9 +1860,18 @@ func buildSmartInput(criteria [][]string) string {
 		products = next
 	}
 
-	// Format: AND within each product, OR between products
+	// Format: AND within each product, OR between products. A term that is
+	// itself an OR expression (e.g. from -q) is parenthesized so it binds as a
+	// whole.
 	terms := make([]string, len(products))
 	for i, product := range products {
+		if len(product) > 1 {
+			for j, term := range product {
+				if strings.Contains(term, " OR ") {
+					product[j] = "(" + term + ")"
+				}
+			}
+		}
 		terms[i] = strings.Join(product, " AND ")
 	}
 	return strings.Join(terms, " OR ")
@@ -945,7 +1882,8 @@ func buildSmartInput(criteria [][]string) string {
 // Diff day:  "2026-02-04 23:59:00 - 2026-02-05 00:01:00 (2m)"
 // No end:    "2026-02-04 16:53:06 - <na>"
 func formatCallTime(c homer.CallSummary) string {
-	start := c.StartTime.Format("2006-01-02 15:04:05")
+	startTime, endTime := homerTime(c.StartTime), homerTime(c.EndTime)
+	start := startTime.Format("2006-01-02 15:04:05")
 
 	if c.MsgCount <= 1 {
 		return start + " - <na>"
@@ -953,11 +1891,11 @@ func formatCallTime(c homer.CallSummary) string {
 
 	dur := formatDuration(c.Duration)
 
-	if c.StartTime.Format("2006-01-02") == c.EndTime.Format("2006-01-02") {
-		return fmt.Sprintf("%s - %s (%s)", start, c.EndTime.Format("15:04:05"), dur)
+	if startTime.Format("2006-01-02") == endTime.Format("2006-01-02") {
+		return fmt.Sprintf("%s - %s (%s)", start, endTime.Format("15:04:05"), dur)
 	}
 
-	return fmt.Sprintf("%s - %s (%s)", start, c.EndTime.Format("2006-01-02 15:04:05"), dur)
+	return fmt.Sprintf("%s - %s (%s)", start, endTime.Format("2006-01-02 15:04:05"), dur)
 }
 
 // printCallTime prints the call time with coloring, padded to width.
@@ -966,7 +1904,7 @@ func printCallTime(c homer.CallSummary, width int) {
 	s := formatCallTime(c)
 	if c.MsgCount <= 1 {
 		// Print everything before <na> normally, then <na> in orange
-		prefix := c.StartTime.Format("2006-01-02 15:04:05") + " - "
+		prefix := homerTime(c.StartTime).Format("2006-01-02 15:04:05") + " - "
 		fmt.Print("  " + prefix)
 		homerWarnColor.Print("<na>")
 		if pad := width - len(s); pad > 0 {
@@ -1002,6 +1940,18 @@ func formatDuration(d time.Duration) string {
 	return fmt.Sprintf("%dh%dm", h, m)
 }
 
+// printHomerJSON writes v to stdout as indented JSON, or as a single compact line
+// when --compact is set. Field order follows the struct definitions and map keys
+// are sorted by encoding/json, so output is stable for diffing.
+func printHomerJSON(cmd *cobra.Command, v any) {
+	compact, _ := cmd.Flags().GetBool("compact")
+	enc := json.NewEncoder(os.Stdout)
+	if !compact {
+		enc.SetIndent("", "  ")
+	}
+	enc.Encode(v)
+}
+
 // printCallID prints a Call-ID with the local part in standout color and the @host part dimmed,
 // padded to the given width.
 func printCallID(callID string, width int) {
@@ -1083,6 +2033,124 @@ Examples:
 	},
 }
 
+var homerAliasSuggestCmd = &cobra.Command{
+	Use:   "alias-suggest",
+	Short: "Suggest aliases for busy unaliased endpoints",
+	Long: `Scan recent SIP traffic and suggest aliases for the busiest IP:port endpoints
+that no configured alias covers yet.
+
+Every message counts for both its source and destination endpoint. Endpoints
+matched by an existing alias (same IP or subnet, alias port 0 matching any
+port) are skipped. Names are taken from reverse DNS (with --rdns), then from the
+product in the endpoint's most common User-Agent, and finally from the address.
+
+With -o json the suggestions are printed as Homer alias objects (the shape of
+/api/v3/alias), ready to be created in Homer.
+
+Examples:
+  dex homer alias-suggest
+  dex homer alias-suggest --since 6h --top 10
+  dex homer alias-suggest --rdns
+  dex homer alias-suggest -o json > aliases.json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := getHomerClient(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		sinceStr, _ := cmd.Flags().GetString("since")
+		untilStr, _ := cmd.Flags().GetString("until")
+		limit, _ := cmd.Flags().GetInt("limit")
+		top, _ := cmd.Flags().GetInt("top")
+		rdns, _ := cmd.Flags().GetBool("rdns")
+		output, _ := cmd.Flags().GetString("output")
+
+		from, err := parseHomerTimeValue(sinceStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+			os.Exit(1)
+		}
+		to := time.Now()
+		if untilStr != "" {
+			to, err = parseHomerTimeValue(untilStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		aliases, err := client.ListAliases()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to list aliases: %v\n", err)
+			os.Exit(1)
+		}
+
+		result, err := client.SearchCalls(homer.SearchParams{From: from, To: to, Limit: limit})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Search failed: %v\n", err)
+			os.Exit(1)
+		}
+
+		var lookup func(string) string
+		if rdns {
+			lookup = func(ip string) string {
+				names, err := net.LookupAddr(ip)
+				if err != nil || len(names) == 0 {
+					return ""
+				}
+				return names[0]
+			}
+		}
+
+		suggestions := homer.SuggestAliases(result.Data, aliases, lookup)
+		if top > 0 && len(suggestions) > top {
+			suggestions = suggestions[:top]
+		}
+
+		if output == "json" {
+			payloads := make([]homer.Alias, 0, len(suggestions))
+			for _, s := range suggestions {
+				payloads = append(payloads, s.AliasPayload())
+			}
+			printHomerJSON(cmd, payloads)
+			return
+		}
+
+		homerDimColor.Printf("  Time range: %s → %s (%d messages, %d aliases)\n", homerTime(from).Format("2006-01-02 15:04:05"), homerTime(to).Format("2006-01-02 15:04:05"), len(result.Data), len(aliases))
+		if len(result.Data) >= limit {
+			homerWarnColor.Printf("  Result limit reached (%d); counts cover only part of the range. Raise --limit or narrow --since.\n", limit)
+		}
+
+		if len(suggestions) == 0 {
+			fmt.Println()
+			homerDimColor.Println("  No unaliased endpoints found.")
+			return
+		}
+
+		line := strings.Repeat("─", 80)
+		fmt.Println()
+		homerHeaderColor.Printf("  Alias Suggestions (%d)\n", len(suggestions))
+		fmt.Println("  " + line)
+		fmt.Println()
+
+		fmt.Printf("  %-20s  %-6s  %8s  %-28s  %s\n", "IP", "PORT", "MESSAGES", "SUGGESTED ALIAS", "SOURCE")
+		fmt.Println("  " + line)
+
+		for _, s := range suggestions {
+			fmt.Printf("  %-20s  %-6d  %8d  ", s.IP, s.Port, s.Messages)
+			homerSuccessColor.Printf("%-28s  ", s.Name)
+			source := s.Source
+			if s.Source == "user-agent" {
+				source = homer.FormatUserAgent(s.UserAgent)
+			}
+			homerDimColor.Printf("%s\n", source)
+		}
+		fmt.Println()
+		homerDimColor.Println("  Use -o json for Homer alias objects ready to create.")
+	},
+}
+
 var homerQosCmd = &cobra.Command{
 	Use:   "qos <call-id> [call-id...]",
 	Short: "Show RTCP call quality metrics",
@@ -1161,9 +2229,7 @@ Examples:
 
 		// JSON output
 		if output == "json" {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			enc.Encode(streams)
+			printHomerJSON(cmd, streams)
 			return
 		}
 		if output == "jsonl" {
@@ -1284,33 +2350,53 @@ func printRoute(srcIP string, srcPort int, dstIP string, dstPort int, srcWidth i
 	}
 }
 
+// homerDisplayLoc is the timezone homer output is rendered in (--tz, default local)
+var homerDisplayLoc = time.Local
+
+// homerTime converts t to the --tz display timezone
+func homerTime(t time.Time) time.Time {
+	return t.In(homerDisplayLoc)
+}
+
 // formatEpochMS converts an epoch millisecond timestamp to a human-readable string
 func formatEpochMS(ms int64) string {
 	if ms == 0 {
 		return "-"
 	}
-	return time.UnixMilli(ms).Format("2006-01-02 15:04:05")
+	return homerTime(time.UnixMilli(ms)).Format("2006-01-02 15:04:05")
 }
 
-// parseTimeRange converts --from and --to flags into time.Time values
+// parseTimeRange converts --from and --to flags into time.Time values. Each
+// accepts a duration back from now or a timestamp, like --since/--until on
+// search and calls; naive timestamps are read in the display timezone.
 func parseTimeRange(fromStr, toStr string) (time.Time, time.Time, error) {
-	to := time.Now()
-	from := to.Add(-1 * time.Hour) // default: last 1 hour
+	return parseTimeRangeRelative(fromStr, toStr, homerDisplayLoc, time.Now())
+}
 
+// parseTimeRangeRelative is parseTimeRange with an explicit location and
+// reference time. An empty --from means 1 hour before --to's default of now.
+func parseTimeRangeRelative(fromStr, toStr string, loc *time.Location, now time.Time) (time.Time, time.Time, error) {
+	to := now
+	from := now.Add(-1 * time.Hour) // default: last 1 hour
+
+	var err error
 	if fromStr != "" {
-		dur, err := parseLokiDuration(fromStr) // reuse the existing duration parser
+		from, err = parseTimeValueRelative(fromStr, loc, now)
 		if err != nil {
 			return time.Time{}, time.Time{}, fmt.Errorf("invalid --from: %w", err)
 		}
-		from = to.Add(-dur)
 	}
 
 	if toStr != "" {
-		dur, err := parseLokiDuration(toStr)
+		to, err = parseTimeValueRelative(toStr, loc, now)
 		if err != nil {
 			return time.Time{}, time.Time{}, fmt.Errorf("invalid --to: %w", err)
 		}
-		to = time.Now().Add(-dur)
+	}
+
+	if from.After(to) {
+		return time.Time{}, time.Time{}, fmt.Errorf("--from (%s) must be before --to (%s)",
+			from.In(loc).Format("2006-01-02 15:04:05"), to.In(loc).Format("2006-01-02 15:04:05"))
 	}
 
 	return from, to, nil
@@ -1322,9 +2408,27 @@ func parseTimeRange(fromStr, toStr string) (time.Time, time.Time, error) {
 // Naive timestamps (no tz suffix) use the provided location.
 // Durations are interpreted as "that long ago from now".
 func parseTimeValueInLocation(s string, loc *time.Location) (time.Time, error) {
+	return parseTimeValueRelative(s, loc, time.Now())
+}
+
+// parseTimeValueRelative is parseTimeValueInLocation with durations (and
+// "now") measured from the given reference time, so that the start and end
+// of a range share the same "now". DST warnings are printed to stderr.
+func parseTimeValueRelative(s string, loc *time.Location, now time.Time) (time.Time, error) {
+	t, warning, err := parseTimeValueAt(s, loc, now)
+	if warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", warning)
+	}
+	return t, err
+}
+
+// parseTimeValueAt implements parseTimeValueInLocation. It returns a warning
+// when a naive timestamp falls into a DST gap or overlap in loc (see
+// resolveWallClock).
+func parseTimeValueAt(s string, loc *time.Location, now time.Time) (time.Time, string, error) {
 	s = strings.TrimSpace(s)
-	if s == "" {
-		return time.Now(), nil
+	if s == "" || strings.EqualFold(s, "now") {
+		return now, "", nil
 	}
 
 	// Try timezone-aware formats first (embedded tz takes precedence)
@@ -1334,11 +2438,11 @@ func parseTimeValueInLocation(s string, loc *time.Location) (time.Time, error) {
 	}
 	for _, f := range tzFormats {
 		if t, err := time.Parse(f, s); err == nil {
-			return t, nil
+			return t, "", nil
 		}
 	}
 
-	// Try naive timestamp formats (use provided location)
+	// Try naive timestamp formats (wall clock in the provided location)
 	naiveFormats := []string{
 		"2006-01-02 15:04:05",
 		"2006-01-02 15:04",
@@ -1347,17 +2451,66 @@ func parseTimeValueInLocation(s string, loc *time.Location) (time.Time, error) {
 		"2006-01-02",
 	}
 	for _, f := range naiveFormats {
-		if t, err := time.ParseInLocation(f, s, loc); err == nil {
-			return t, nil
+		if wall, err := time.Parse(f, s); err == nil {
+			t, warning := resolveWallClock(wall, loc)
+			return t, warning, nil
 		}
 	}
 
 	// Try duration (e.g., "1h", "30m", "2d")
 	dur, err := parseLokiDuration(s)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("must be a duration (e.g., 1h, 30m, 2d) or timestamp (e.g., 2006-01-02 15:04): %s", s)
+		return time.Time{}, "", fmt.Errorf("must be a duration (e.g., 1h, 30m, 2d), timestamp (e.g., 2006-01-02 15:04) or \"now\": %s", s)
+	}
+	return now.Add(-dur), "", nil
+}
+
+// resolveWallClock converts a wall-clock time (given as UTC fields) to an
+// instant in loc, resolving DST transitions deterministically:
+//   - overlap (fall back, the wall time occurs twice): the later instant,
+//     i.e. the one after the transition
+//   - gap (spring forward, the wall time does not exist): the wall time is
+//     shifted forward by the size of the gap (02:30 → 03:30)
+//
+// In both cases a warning describing the choice is returned.
+func resolveWallClock(wall time.Time, loc *time.Location) (time.Time, string) {
+	// Offsets in effect shortly before and after the wall time. Real-world
+	// transitions are far more than 12h apart, so these bracket at most one.
+	_, offBefore := wall.Add(-12 * time.Hour).In(loc).Zone()
+	_, offAfter := wall.Add(12 * time.Hour).In(loc).Zone()
+
+	var valid []time.Time
+	for _, off := range []int{offBefore, offAfter} {
+		t := wall.Add(-time.Duration(off) * time.Second).In(loc)
+		if sameWallClock(t, wall) && (len(valid) == 0 || !valid[0].Equal(t)) {
+			valid = append(valid, t)
+		}
 	}
-	return time.Now().Add(-dur), nil
+
+	const layout = "2006-01-02 15:04:05"
+	switch len(valid) {
+	case 1:
+		return valid[0], ""
+	case 2:
+		t := valid[0]
+		if valid[1].After(t) {
+			t = valid[1]
+		}
+		return t, fmt.Sprintf("%s is ambiguous in %s (DST overlap); using the later %s",
+			wall.Format(layout), loc, t.Format("15:04:05 MST"))
+	default:
+		t := wall.Add(-time.Duration(offBefore) * time.Second).In(loc)
+		return t, fmt.Sprintf("%s does not exist in %s (DST gap); using %s",
+			wall.Format(layout), loc, t.Format("2006-01-02 15:04:05 MST"))
+	}
+}
+
+// sameWallClock reports whether t shows the same date and clock time as wall.
+func sameWallClock(t, wall time.Time) bool {
+	y1, m1, d1 := t.Date()
+	y2, m2, d2 := wall.Date()
+	return y1 == y2 && m1 == m2 && d1 == d2 &&
+		t.Hour() == wall.Hour() && t.Minute() == wall.Minute() && t.Second() == wall.Second()
 }
 
 // parseTimeValue parses a string that is either a duration or timestamp using local timezone.
@@ -1365,426 +2518,551 @@ func parseTimeValue(s string) (time.Time, error) {
 	return parseTimeValueInLocation(s, time.Local)
 }
 
-var homerAnalyzeCmd = &cobra.Command{
-	Use:   "analyze [call-id]",
-	Short: "Find all SIP legs belonging to the same call",
-	Long: `Analyze a SIP call by correlating legs via a shared header value (e.g., X-Acme-Call-ID).
-
-Starting from a seed call (by Call-ID or by from/to user), the command:
-1. Fetches the seed call's raw SIP messages
-2. Extracts the correlation header value(s) from INVITE messages
-3. Fans out to find other legs in the same time window by phone number
-4. Filters candidates that share the same correlation header value
-
-Entry point (one required):
-  Positional <call-id>     A specific SIP Call-ID as the seed
-  --from-user + --to-user  Caller/callee pair (needs --at or --since for time)
+// parseHomerTimeValue parses a homer --since/--until/--at value. Naive
+// timestamps are read in the --tz zone so input and output agree.
+func parseHomerTimeValue(s string) (time.Time, error) {
+	return parseTimeValueInLocation(s, homerDisplayLoc)
+}
 
-Examples:
-  dex homer analyze BW171313801040226178186286@62.156.74.72 \
-    -c X-Acme-Call-ID --url https://homer.example.com/
+// homerLegCorrelation holds the SIP legs found by correlating a seed call
+// via shared header values (and optional -N numbers).
+type homerLegCorrelation struct {
+	seed     homer.CallSummary
+	legs     []homer.CallSummary      // correlated legs incl. seed, sorted by start time
+	matching map[string]bool          // Call-IDs of the correlated legs
+	txn      *homer.TransactionResult // raw messages of all fan-out candidates
+	fan      *homer.SearchResult      // fan-out search records (for aliases)
+}
 
-  dex homer analyze BW171313801040226178186286@62.156.74.72 \
-    -c X-Acme-Call-ID -H X-Acme --at "2026-02-04 17:13" \
-    --url https://homer.example.com/
+// correlateHomerLegs runs the seed search, number fan-out and header
+// correlation shared by 'homer analyze' and 'homer leg-tree'. It reads the
+// correlation flags from cmd and returns nil (after printing why) when there
+// is nothing to show. Fatal errors exit the process.
+func correlateHomerLegs(cmd *cobra.Command, client *homer.Client, args []string) *homerLegCorrelation {
+	var err error
+	correlateHeaders, _ := cmd.Flags().GetStringSlice("correlate")
+	correlateRegex, _ := cmd.Flags().GetString("correlate-regex")
+	extraNumbers, _ := cmd.Flags().GetStringSlice("number")
+	fromUser, _ := cmd.Flags().GetString("from-user")
+	toUser, _ := cmd.Flags().GetString("to-user")
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+	atStr, _ := cmd.Flags().GetString("at")
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	if len(correlateHeaders) == 0 {
+		fmt.Fprintf(os.Stderr, "At least one --correlate (-c) header is required\n")
+		os.Exit(1)
+	}
 
-  dex homer analyze --from-user 4921514174858 --to-user 4934155003500 \
-    --at "2026-02-04 17:13" -c X-Acme-Call-ID --url https://homer.example.com/`,
-	Args: cobra.MaximumNArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		client, err := getHomerClient(cmd)
+	var correlateRe *regexp.Regexp
+	if correlateRegex != "" {
+		correlateRe, err = regexp.Compile(correlateRegex)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%v\n", err)
+			fmt.Fprintf(os.Stderr, "Invalid --correlate-regex: %v\n", err)
 			os.Exit(1)
 		}
-
-		correlateHeaders, _ := cmd.Flags().GetStringSlice("correlate")
-		displayHeaders, _ := cmd.Flags().GetStringSlice("header")
-		extraNumbers, _ := cmd.Flags().GetStringSlice("number")
-		fromUser, _ := cmd.Flags().GetString("from-user")
-		toUser, _ := cmd.Flags().GetString("to-user")
-		sinceStr, _ := cmd.Flags().GetString("since")
-		untilStr, _ := cmd.Flags().GetString("until")
-		atStr, _ := cmd.Flags().GetString("at")
-		limit, _ := cmd.Flags().GetInt("limit")
-		output, _ := cmd.Flags().GetString("output")
-
-		if len(correlateHeaders) == 0 {
-			fmt.Fprintf(os.Stderr, "At least one --correlate (-c) header is required\n")
+		if correlateRe.NumSubexp() > 1 {
+			fmt.Fprintf(os.Stderr, "--correlate-regex must have at most one capture group, got %d\n", correlateRe.NumSubexp())
 			os.Exit(1)
 		}
+	}
 
-		hasCallID := len(args) == 1
-		hasFromTo := fromUser != "" && toUser != ""
+	hasCallID := len(args) == 1
+	hasFromTo := fromUser != "" && toUser != ""
 
-		if !hasCallID && !hasFromTo {
-			fmt.Fprintf(os.Stderr, "Provide a Call-ID argument or both --from-user and --to-user\n")
-			os.Exit(1)
-		}
-		if hasCallID && hasFromTo {
-			fmt.Fprintf(os.Stderr, "Provide either a Call-ID argument or --from-user/--to-user, not both\n")
-			os.Exit(1)
-		}
+	if !hasCallID && !hasFromTo {
+		fmt.Fprintf(os.Stderr, "Provide a Call-ID argument or both --from-user and --to-user\n")
+		os.Exit(1)
+	}
+	if hasCallID && hasFromTo {
+		fmt.Fprintf(os.Stderr, "Provide either a Call-ID argument or --from-user/--to-user, not both\n")
+		os.Exit(1)
+	}
 
-		// --- Step 1: Find seed call ---
-		var seedParams homer.SearchParams
-		if hasCallID {
-			// Search by Call-ID, wide time window
-			var from, to time.Time
-			if atStr != "" {
-				at, err := parseTimeValue(atStr)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
-					os.Exit(1)
-				}
-				from = at.Add(-5 * time.Minute)
-				to = at.Add(5 * time.Minute)
+	// --- Step 1: Find seed call ---
+	var seedParams homer.SearchParams
+	if hasCallID {
+		// Search by Call-ID, wide time window
+		var from, to time.Time
+		if atStr != "" {
+			at, err := parseHomerTimeValue(atStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
+				os.Exit(1)
+			}
+			from = at.Add(-5 * time.Minute)
+			to = at.Add(5 * time.Minute)
+		} else {
+			from, err = parseHomerTimeValue(sinceStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+				os.Exit(1)
+			}
+			if untilStr == "" {
+				to = time.Now()
 			} else {
-				from, err = parseTimeValue(sinceStr)
+				to, err = parseHomerTimeValue(untilStr)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+					fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
 					os.Exit(1)
 				}
-				if untilStr == "" {
-					to = time.Now()
-				} else {
-					to, err = parseTimeValue(untilStr)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
-						os.Exit(1)
-					}
-				}
 			}
-			seedParams = homer.SearchParams{
-				From:   from,
-				To:     to,
-				CallID: args[0],
-				Limit:  200,
+		}
+		seedParams = homer.SearchParams{
+			From:   from,
+			To:     to,
+			CallID: args[0],
+			Limit:  200,
+		}
+	} else {
+		// Search by from/to user
+		var from, to time.Time
+		if atStr != "" {
+			if cmd.Flags().Changed("since") || cmd.Flags().Changed("until") {
+				fmt.Fprintf(os.Stderr, "Cannot use --at together with --since/--until\n")
+				os.Exit(1)
+			}
+			at, err := parseHomerTimeValue(atStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
+				os.Exit(1)
 			}
+			from = at.Add(-5 * time.Minute)
+			to = at.Add(5 * time.Minute)
 		} else {
-			// Search by from/to user
-			var from, to time.Time
-			if atStr != "" {
-				if cmd.Flags().Changed("since") || cmd.Flags().Changed("until") {
-					fmt.Fprintf(os.Stderr, "Cannot use --at together with --since/--until\n")
-					os.Exit(1)
-				}
-				at, err := parseTimeValue(atStr)
-				if err != nil {
-					fmt.Fprintf(os.Stderr, "Invalid --at: %v\n", err)
-					os.Exit(1)
-				}
-				from = at.Add(-5 * time.Minute)
-				to = at.Add(5 * time.Minute)
+			from, err = parseHomerTimeValue(sinceStr)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+				os.Exit(1)
+			}
+			if untilStr == "" {
+				to = time.Now()
 			} else {
-				from, err = parseTimeValue(sinceStr)
+				to, err = parseHomerTimeValue(untilStr)
 				if err != nil {
-					fmt.Fprintf(os.Stderr, "Invalid --since: %v\n", err)
+					fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
 					os.Exit(1)
 				}
-				if untilStr == "" {
-					to = time.Now()
-				} else {
-					to, err = parseTimeValue(untilStr)
-					if err != nil {
-						fmt.Fprintf(os.Stderr, "Invalid --until: %v\n", err)
-						os.Exit(1)
-					}
-				}
 			}
+		}
 
-			var criteria [][]string
-			bareFrom := strings.TrimPrefix(fromUser, "+")
-			plusFrom := "+" + bareFrom
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.from_user = '%s'", bareFrom),
-				fmt.Sprintf("data_header.from_user = '%s'", plusFrom),
-			})
-			bareTo := strings.TrimPrefix(toUser, "+")
-			plusTo := "+" + bareTo
-			criteria = append(criteria, []string{
-				fmt.Sprintf("data_header.to_user = '%s'", bareTo),
-				fmt.Sprintf("data_header.to_user = '%s'", plusTo),
-			})
+		var criteria [][]string
+		bareFrom := strings.TrimPrefix(fromUser, "+")
+		plusFrom := "+" + bareFrom
+		criteria = append(criteria, []string{
+			fmt.Sprintf("data_header.from_user = '%s'", bareFrom),
+			fmt.Sprintf("data_header.from_user = '%s'", plusFrom),
+		})
+		bareTo := strings.TrimPrefix(toUser, "+")
+		plusTo := "+" + bareTo
+		criteria = append(criteria, []string{
+			fmt.Sprintf("data_header.to_user = '%s'", bareTo),
+			fmt.Sprintf("data_header.to_user = '%s'", plusTo),
+		})
 
-			seedParams = homer.SearchParams{
-				From:       from,
-				To:         to,
-				SmartInput: buildSmartInput(criteria),
-				Limit:      limit,
-			}
+		seedParams = homer.SearchParams{
+			From:       from,
+			To:         to,
+			SmartInput: buildSmartInput(criteria),
+			Limit:      limit,
 		}
+	}
 
-		seedResult, err := client.SearchCalls(seedParams)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Seed search failed: %v\n", err)
-			os.Exit(1)
-		}
-		if len(seedResult.Data) == 0 {
-			homerDimColor.Println("No seed call found.")
-			return
-		}
+	seedResult, err := client.SearchCalls(seedParams)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Seed search failed: %v\n", err)
+		os.Exit(1)
+	}
+	if len(seedResult.Data) == 0 {
+		homerDimColor.Println("No seed call found.")
+		return nil
+	}
 
-		// Group seed messages by Call-ID
-		seedCalls := homer.GroupCalls(seedResult.Data, "")
-		if len(seedCalls) == 0 {
-			homerDimColor.Println("No seed call found.")
-			return
-		}
+	// Group seed messages by Call-ID
+	seedCalls := homer.GroupCalls(seedResult.Data, "")
+	if len(seedCalls) == 0 {
+		homerDimColor.Println("No seed call found.")
+		return nil
+	}
 
-		// When using --from-user/--to-user, require exactly one Call-ID
-		if hasFromTo && len(seedCalls) > 1 {
-			fmt.Fprintf(os.Stderr, "Ambiguous: found %d calls matching from/to user. Re-run with a specific Call-ID:\n\n", len(seedCalls))
-			// Sort by start time for display
-			sort.Slice(seedCalls, func(i, j int) bool {
-				return seedCalls[i].StartTime.Before(seedCalls[j].StartTime)
-			})
-			for _, c := range seedCalls {
-				fmt.Fprintf(os.Stderr, "  %s  %s  %s → %s\n",
-					c.StartTime.Format("2006-01-02 15:04:05"), c.CallID, c.Caller, c.Callee)
-			}
-			fmt.Fprintln(os.Stderr)
-			os.Exit(1)
+	// When using --from-user/--to-user, require exactly one Call-ID
+	if hasFromTo && len(seedCalls) > 1 {
+		fmt.Fprintf(os.Stderr, "Ambiguous: found %d calls matching from/to user. Re-run with a specific Call-ID:\n\n", len(seedCalls))
+		// Sort by start time for display
+		sort.Slice(seedCalls, func(i, j int) bool {
+			return seedCalls[i].StartTime.Before(seedCalls[j].StartTime)
+		})
+		for _, c := range seedCalls {
+			fmt.Fprintf(os.Stderr, "  %s  %s  %s → %s\n",
+				homerTime(c.StartTime).Format("2006-01-02 15:04:05"), c.CallID, c.Caller, c.Callee)
 		}
+		fmt.Fprintln(os.Stderr)
+		os.Exit(1)
+	}
 
-		seedCall := seedCalls[0]
+	seedCall := seedCalls[0]
+
+	// Extract caller number from seed for fan-out
+	seedFromUser := seedCall.Caller
+
+	// --- Step 2: Fan out by caller number + extra numbers ---
+	// Build a flat OR of all numbers to search for. The seed's from_user is
+	// always included. Extra --number values widen the search to find legs
+	// involving agents/extensions that don't share the caller number.
+	// Correlation header filtering (step 4) weeds out false positives.
+	margin := 30 * time.Minute
+	fanFrom := seedCall.StartTime.Add(-margin)
+	fanTo := seedCall.EndTime.Add(margin)
+
+	var fanAlternatives []string
+	if seedFromUser != "" {
+		bare := strings.TrimPrefix(seedFromUser, "+")
+		fanAlternatives = append(fanAlternatives,
+			fmt.Sprintf("data_header.from_user = '%s'", bare),
+			fmt.Sprintf("data_header.from_user = '%s'", "+"+bare),
+		)
+	}
+	for _, num := range extraNumbers {
+		bare := strings.TrimPrefix(num, "+")
+		fanAlternatives = append(fanAlternatives,
+			fmt.Sprintf("data_header.from_user = '%s'", bare),
+			fmt.Sprintf("data_header.from_user = '%s'", "+"+bare),
+			fmt.Sprintf("data_header.to_user = '%s'", bare),
+			fmt.Sprintf("data_header.to_user = '%s'", "+"+bare),
+		)
+	}
 
-		// Extract caller number from seed for fan-out
-		seedFromUser := seedCall.Caller
+	var fanCriteria [][]string
+	if len(fanAlternatives) > 0 {
+		fanCriteria = append(fanCriteria, fanAlternatives)
+	}
 
-		// --- Step 2: Fan out by caller number + extra numbers ---
-		// Build a flat OR of all numbers to search for. The seed's from_user is
-		// always included. Extra --number values widen the search to find legs
-		// involving agents/extensions that don't share the caller number.
-		// Correlation header filtering (step 4) weeds out false positives.
-		margin := 30 * time.Minute
-		fanFrom := seedCall.StartTime.Add(-margin)
-		fanTo := seedCall.EndTime.Add(margin)
+	fanParams := homer.SearchParams{
+		From:       fanFrom,
+		To:         fanTo,
+		SmartInput: buildSmartInput(fanCriteria),
+	}
 
-		var fanAlternatives []string
-		if seedFromUser != "" {
-			bare := strings.TrimPrefix(seedFromUser, "+")
-			fanAlternatives = append(fanAlternatives,
-				fmt.Sprintf("data_header.from_user = '%s'", bare),
-				fmt.Sprintf("data_header.from_user = '%s'", "+"+bare),
-			)
-		}
-		for _, num := range extraNumbers {
-			bare := strings.TrimPrefix(num, "+")
-			fanAlternatives = append(fanAlternatives,
-				fmt.Sprintf("data_header.from_user = '%s'", bare),
-				fmt.Sprintf("data_header.from_user = '%s'", "+"+bare),
-				fmt.Sprintf("data_header.to_user = '%s'", bare),
-				fmt.Sprintf("data_header.to_user = '%s'", "+"+bare),
-			)
-		}
+	fanCalls, err := client.FetchCalls(fanParams, "", limit)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Fan-out search failed: %v\n", err)
+		os.Exit(1)
+	}
 
-		var fanCriteria [][]string
-		if len(fanAlternatives) > 0 {
-			fanCriteria = append(fanCriteria, fanAlternatives)
-		}
+	// Collect all messages from fan-out calls + seed into a merged SearchResult
+	var fanRecords []homer.CallRecord
+	for _, c := range fanCalls {
+		fanRecords = append(fanRecords, c.Messages...)
+	}
+	fanResult := &homer.SearchResult{Data: fanRecords}
 
-		fanParams := homer.SearchParams{
-			From:       fanFrom,
-			To:         fanTo,
-			SmartInput: buildSmartInput(fanCriteria),
-		}
+	// Merge seed results into fan-out (seed Call-ID may not appear in phone-based search)
+	fanResult = homer.MergeSearchResults(fanResult, seedResult)
 
-		fanCalls, err := client.FetchCalls(fanParams, "", limit)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Fan-out search failed: %v\n", err)
-			os.Exit(1)
+	if len(fanResult.Data) == 0 {
+		homerDimColor.Println("  No candidate legs found.")
+		return nil
+	}
+
+	// --- Step 3: Extract correlation headers from all candidates ---
+	candidateTxn, err := client.GetTransaction(fanParams, fanResult.Data)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to get candidate raw messages: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Build map: Call-ID -> set of header values, and reverse: header value -> set of Call-IDs
+	callIDValues := make(map[string]map[string]map[string]bool) // callID -> header -> values
+	valueCallIDs := make(map[string]map[string]map[string]bool) // header -> value -> callIDs
+
+	for _, h := range correlateHeaders {
+		valueCallIDs[h] = make(map[string]map[string]bool)
+	}
+
+	for _, msg := range candidateTxn.Data.Messages {
+		if !msg.IsSIP() || msg.Raw == "" {
+			continue
+		}
+		if !strings.HasPrefix(msg.Raw, "INVITE ") {
+			continue
 		}
+		for _, h := range correlateHeaders {
+			val := homer.CorrelationKey(homer.ExtractSIPHeader(msg.Raw, h), correlateRe)
+			if val == "" {
+				continue
+			}
+			if callIDValues[msg.CallID] == nil {
+				callIDValues[msg.CallID] = make(map[string]map[string]bool)
+			}
+			if callIDValues[msg.CallID][h] == nil {
+				callIDValues[msg.CallID][h] = make(map[string]bool)
+			}
+			callIDValues[msg.CallID][h][val] = true
 
-		// Collect all messages from fan-out calls + seed into a merged SearchResult
-		var fanRecords []homer.CallRecord
-		for _, c := range fanCalls {
-			fanRecords = append(fanRecords, c.Messages...)
+			if valueCallIDs[h][val] == nil {
+				valueCallIDs[h][val] = make(map[string]bool)
+			}
+			valueCallIDs[h][val][msg.CallID] = true
 		}
-		fanResult := &homer.SearchResult{Data: fanRecords}
+	}
 
-		// Merge seed results into fan-out (seed Call-ID may not appear in phone-based search)
-		fanResult = homer.MergeSearchResults(fanResult, seedResult)
+	// --- Step 4: Find the correlation group containing the seed ---
+	// Group all Call-IDs by shared header values, then pick the group
+	// that temporally overlaps with the seed call.
+	// The seed (external leg) may not have the header itself, but the
+	// internal legs spawned from it do.
 
-		if len(fanResult.Data) == 0 {
-			homerDimColor.Println("  No candidate legs found.")
-			return
+	// Find all unique correlation values and their Call-ID sets
+	allGroups := make(map[string]map[string]bool) // "header:value" -> set of Call-IDs
+	for _, h := range correlateHeaders {
+		for val, cids := range valueCallIDs[h] {
+			key := h + ":" + val
+			allGroups[key] = cids
 		}
+	}
 
-		// --- Step 3: Extract correlation headers from all candidates ---
-		candidateTxn, err := client.GetTransaction(fanParams, fanResult.Data)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Failed to get candidate raw messages: %v\n", err)
-			os.Exit(1)
-		}
+	if len(allGroups) == 0 {
+		homerWarnColor.Println("  No correlation header values found in any candidate INVITEs")
+		homerDimColor.Printf("  Searched %d SIP messages for headers: %s\n", len(candidateTxn.Data.Messages), strings.Join(correlateHeaders, ", "))
+		return nil
+	}
 
-		// Build map: Call-ID -> set of header values, and reverse: header value -> set of Call-IDs
-		callIDValues := make(map[string]map[string]map[string]bool) // callID -> header -> values
-		valueCallIDs := make(map[string]map[string]map[string]bool) // header -> value -> callIDs
+	// Group fan-out data by Call-ID to check temporal overlap
+	allCandidateCalls := homer.GroupCalls(fanResult.Data, "")
+	candidateByCallID := make(map[string]homer.CallSummary)
+	for _, c := range allCandidateCalls {
+		candidateByCallID[c.CallID] = c
+	}
 
-		for _, h := range correlateHeaders {
-			valueCallIDs[h] = make(map[string]map[string]bool)
+	// For each correlation group, check if any member overlaps temporally with the seed
+	matchingCallIDs := make(map[string]bool)
+	matchingCallIDs[seedCall.CallID] = true
+
+	fmt.Println()
+	for groupKey, cids := range allGroups {
+		// Check temporal overlap: any Call-ID in this group starts within
+		// a small window around the seed call's start time?
+		// Internal legs are spawned within seconds of the external INVITE.
+		overlaps := false
+		for cid := range cids {
+			if c, ok := candidateByCallID[cid]; ok {
+				if c.StartTime.After(seedCall.StartTime.Add(-5*time.Second)) &&
+					c.StartTime.Before(seedCall.StartTime.Add(30*time.Second)) {
+					overlaps = true
+					break
+				}
+			}
+		}
+		if !overlaps {
+			continue
+		}
+		// This group overlaps with seed — include all its Call-IDs
+		parts := strings.SplitN(groupKey, ":", 2)
+		homerDimColor.Printf("  Correlating via %s: ", parts[0])
+		homerHeaderColor.Println(parts[1])
+		for cid := range cids {
+			matchingCallIDs[cid] = true
+		}
+	}
+	fmt.Println()
+
+	// --- Step 4b: Multi-hop number correlation ---
+	// Include fan-out legs that involve a -N number. The -N flag signals
+	// user intent: "this number is related to this call." Any fan-out
+	// leg whose FROM or TO matches a -N number is included, even if it
+	// doesn't share the correlation header.
+	if len(extraNumbers) > 0 {
+		extraNumberSet := make(map[string]bool)
+		for _, num := range extraNumbers {
+			bare := strings.TrimPrefix(num, "+")
+			if bare != "" {
+				extraNumberSet[bare] = true
+			}
 		}
 
-		for _, msg := range candidateTxn.Data.Messages {
-			if !msg.IsSIP() || msg.Raw == "" {
+		addedHop := false
+		for _, c := range allCandidateCalls {
+			if matchingCallIDs[c.CallID] {
 				continue
 			}
-			if !strings.HasPrefix(msg.Raw, "INVITE ") {
+			callerBare := strings.TrimPrefix(c.Caller, "+")
+			calleeBare := strings.TrimPrefix(c.Callee, "+")
+
+			if !extraNumberSet[callerBare] && !extraNumberSet[calleeBare] {
 				continue
 			}
-			for _, h := range correlateHeaders {
-				val := homer.ExtractSIPHeader(msg.Raw, h)
-				if val == "" {
-					continue
-				}
-				if callIDValues[msg.CallID] == nil {
-					callIDValues[msg.CallID] = make(map[string]map[string]bool)
-				}
-				if callIDValues[msg.CallID][h] == nil {
-					callIDValues[msg.CallID][h] = make(map[string]bool)
-				}
-				callIDValues[msg.CallID][h][val] = true
 
-				if valueCallIDs[h][val] == nil {
-					valueCallIDs[h][val] = make(map[string]bool)
-				}
-				valueCallIDs[h][val][msg.CallID] = true
+			if !addedHop {
+				homerDimColor.Println("  Including related legs (via -N number):")
+				addedHop = true
 			}
+			homerDimColor.Printf("    %s (%s → %s)\n", c.CallID, c.Caller, c.Callee)
+			matchingCallIDs[c.CallID] = true
 		}
+		if addedHop {
+			fmt.Println()
+		}
+	}
 
-		// --- Step 4: Find the correlation group containing the seed ---
-		// Group all Call-IDs by shared header values, then pick the group
-		// that temporally overlaps with the seed call.
-		// The seed (external leg) may not have the header itself, but the
-		// internal legs spawned from it do.
+	// --- Step 5: Display correlated legs ---
+	// Group fan-out results
+	allCalls := homer.GroupCalls(fanResult.Data, "")
 
-		// Find all unique correlation values and their Call-ID sets
-		allGroups := make(map[string]map[string]bool) // "header:value" -> set of Call-IDs
-		for _, h := range correlateHeaders {
-			for val, cids := range valueCallIDs[h] {
-				key := h + ":" + val
-				allGroups[key] = cids
-			}
+	// Filter to only matching Call-IDs
+	var correlated []homer.CallSummary
+	for _, c := range allCalls {
+		if matchingCallIDs[c.CallID] {
+			correlated = append(correlated, c)
+		}
+	}
+
+	// Also ensure seed call is included (it might not be in the fan-out results)
+	seedIncluded := false
+	for _, c := range correlated {
+		if c.CallID == seedCall.CallID {
+			seedIncluded = true
+			break
+		}
+	}
+	if !seedIncluded {
+		correlated = append(correlated, seedCall)
+	}
+
+	// Sort by start time (Call-ID breaks ties)
+	sort.Slice(correlated, func(i, j int) bool {
+		if !correlated[i].StartTime.Equal(correlated[j].StartTime) {
+			return correlated[i].StartTime.Before(correlated[j].StartTime)
 		}
+		return correlated[i].CallID < correlated[j].CallID
+	})
+
+	return &homerLegCorrelation{
+		seed:     seedCall,
+		legs:     correlated,
+		matching: matchingCallIDs,
+		txn:      candidateTxn,
+		fan:      fanResult,
+	}
+}
+
+var homerAnalyzeCmd = &cobra.Command{
+	Use:   "analyze [call-id]",
+	Short: "Find all SIP legs belonging to the same call",
+	Long: `Analyze a SIP call by correlating legs via a shared header value (e.g., X-Acme-Call-ID).
+
+Starting from a seed call (by Call-ID or by from/to user), the command:
+1. Fetches the seed call's raw SIP messages
+2. Extracts the correlation header value(s) from INVITE messages
+3. Fans out to find other legs in the same time window by phone number
+4. Filters candidates that share the same correlation header value
+
+Header values are compared exactly. When the correlation ID is embedded in a
+larger value (e.g. "X-Acme-Session: id=abc123;node=7"), --correlate-regex
+extracts it: the first capture group is the key, or the full match if the
+regexp has no group. Values that do not match are ignored.
+
+The message flow hides OPTIONS/NOTIFY/PUBLISH transactions (keepalives,
+subscriptions) and prints how many were dropped; pass --include-options to
+keep them.
+
+--focus <number> highlights the legs where that number is caller or callee,
+and the endpoints hosting it, in the leg table and the ladder; all other
+legs are dimmed.
+
+-o json prints the legs together with the ladder behind the diagram: the
+ordered endpoints and one arrow per message (timestamp, offset_ms,
+src/dst endpoint index, method, leg number, Call-ID). -o jsonl prints one leg
+per line.
+
+Entry point (one required):
+  Positional <call-id>     A specific SIP Call-ID as the seed
+  --from-user + --to-user  Caller/callee pair (needs --at or --since for time)
+
+Examples:
+  dex homer analyze BW171313801040226178186286@62.156.74.72 \
+    -c X-Acme-Call-ID --url https://homer.example.com/
 
-		if len(allGroups) == 0 {
-			homerWarnColor.Println("  No correlation header values found in any candidate INVITEs")
-			homerDimColor.Printf("  Searched %d SIP messages for headers: %s\n", len(candidateTxn.Data.Messages), strings.Join(correlateHeaders, ", "))
-			return
-		}
+  dex homer analyze BW171313801040226178186286@62.156.74.72 \
+    -c X-Acme-Call-ID -H X-Acme --at "2026-02-04 17:13" \
+    --url https://homer.example.com/
 
-		// Group fan-out data by Call-ID to check temporal overlap
-		allCandidateCalls := homer.GroupCalls(fanResult.Data, "")
-		candidateByCallID := make(map[string]homer.CallSummary)
-		for _, c := range allCandidateCalls {
-			candidateByCallID[c.CallID] = c
-		}
+  dex homer analyze --from-user 4921514174858 --to-user 4934155003500 \
+    --at "2026-02-04 17:13" -c X-Acme-Call-ID --url https://homer.example.com/
 
-		// For each correlation group, check if any member overlaps temporally with the seed
-		matchingCallIDs := make(map[string]bool)
-		matchingCallIDs[seedCall.CallID] = true
+  dex homer analyze BW171313801040226178186286@62.156.74.72 \
+    -c X-Acme-Call-ID --focus 4934155003500
 
-		fmt.Println()
-		for groupKey, cids := range allGroups {
-			// Check temporal overlap: any Call-ID in this group starts within
-			// a small window around the seed call's start time?
-			// Internal legs are spawned within seconds of the external INVITE.
-			overlaps := false
-			for cid := range cids {
-				if c, ok := candidateByCallID[cid]; ok {
-					if c.StartTime.After(seedCall.StartTime.Add(-5*time.Second)) &&
-						c.StartTime.Before(seedCall.StartTime.Add(30*time.Second)) {
-						overlaps = true
-						break
-					}
-				}
-			}
-			if !overlaps {
-				continue
-			}
-			// This group overlaps with seed — include all its Call-IDs
-			parts := strings.SplitN(groupKey, ":", 2)
-			homerDimColor.Printf("  Correlating via %s: ", parts[0])
-			homerHeaderColor.Println(parts[1])
-			for cid := range cids {
-				matchingCallIDs[cid] = true
-			}
+  dex homer analyze BW171313801040226178186286@62.156.74.72 \
+    -c X-Acme-Session --correlate-regex 'id=([^;]+)'`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := getHomerClient(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
 		}
-		fmt.Println()
 
-		// --- Step 4b: Multi-hop number correlation ---
-		// Include fan-out legs that involve a -N number. The -N flag signals
-		// user intent: "this number is related to this call." Any fan-out
-		// leg whose FROM or TO matches a -N number is included, even if it
-		// doesn't share the correlation header.
-		if len(extraNumbers) > 0 {
-			extraNumberSet := make(map[string]bool)
-			for _, num := range extraNumbers {
-				bare := strings.TrimPrefix(num, "+")
-				if bare != "" {
-					extraNumberSet[bare] = true
-				}
-			}
-
-			addedHop := false
-			for _, c := range allCandidateCalls {
-				if matchingCallIDs[c.CallID] {
-					continue
-				}
-				callerBare := strings.TrimPrefix(c.Caller, "+")
-				calleeBare := strings.TrimPrefix(c.Callee, "+")
+		displayHeaders, _ := cmd.Flags().GetStringSlice("header")
+		extraNumbers, _ := cmd.Flags().GetStringSlice("number")
+		fromUser, _ := cmd.Flags().GetString("from-user")
+		toUser, _ := cmd.Flags().GetString("to-user")
+		output, _ := cmd.Flags().GetString("output")
+		includeOptions, _ := cmd.Flags().GetBool("include-options")
+		focus, _ := cmd.Flags().GetString("focus")
+		focus = strings.TrimPrefix(strings.TrimSpace(focus), "+")
 
-				if !extraNumberSet[callerBare] && !extraNumberSet[calleeBare] {
-					continue
-				}
+		corr := correlateHomerLegs(cmd, client, args)
+		if corr == nil {
+			return
+		}
+		seedCall := corr.seed
+		correlated := corr.legs
+		matchingCallIDs := corr.matching
+		candidateTxn := corr.txn
+		fanResult := corr.fan
 
-				if !addedHop {
-					homerDimColor.Println("  Including related legs (via -N number):")
-					addedHop = true
-				}
-				homerDimColor.Printf("    %s (%s → %s)\n", c.CallID, c.Caller, c.Callee)
-				matchingCallIDs[c.CallID] = true
+		// Collect SIP messages from correlated Call-IDs, dropping keepalive
+		// transactions (OPTIONS/NOTIFY/PUBLISH and their responses) unless asked for
+		var flowMsgs []homer.TransactionMessage
+		hiddenKeepalives := 0
+		for _, msg := range candidateTxn.Data.Messages {
+			if !msg.IsSIP() || !matchingCallIDs[msg.CallID] {
+				continue
 			}
-			if addedHop {
-				fmt.Println()
+			if !includeOptions && homer.KeepaliveMethods[homer.SIPTransactionMethod(msg.Raw)] {
+				hiddenKeepalives++
+				continue
 			}
+			flowMsgs = append(flowMsgs, msg)
 		}
+		sort.Slice(flowMsgs, func(i, j int) bool {
+			return flowMsgs[i].CreateDate < flowMsgs[j].CreateDate
+		})
 
-		// --- Step 5: Display correlated legs ---
-		// Group fan-out results
-		allCalls := homer.GroupCalls(fanResult.Data, "")
+		// Determine endpoint order (left to right following INVITE chain from seed)
+		endpoints := correlateEndpointOrder(flowMsgs, seedCall.CallID)
 
-		// Filter to only matching Call-IDs
-		var correlated []homer.CallSummary
-		for _, c := range allCalls {
-			if matchingCallIDs[c.CallID] {
-				correlated = append(correlated, c)
-			}
+		// Build leg index (Call-ID -> leg number)
+		legIndex := make(map[string]int)
+		for i, c := range correlated {
+			legIndex[c.CallID] = i + 1
 		}
 
-		// Also ensure seed call is included (it might not be in the fan-out results)
-		seedIncluded := false
-		for _, c := range correlated {
-			if c.CallID == seedCall.CallID {
-				seedIncluded = true
-				break
-			}
-		}
-		if !seedIncluded {
-			correlated = append(correlated, seedCall)
+		// Compute t0 for relative time
+		var t0 time.Time
+		if len(correlated) > 0 {
+			t0 = correlated[0].StartTime
 		}
 
-		// Sort by start time
-		sort.Slice(correlated, func(i, j int) bool {
-			return correlated[i].StartTime.Before(correlated[j].StartTime)
-		})
+		arrows := buildFlowArrows(flowMsgs, endpoints, legIndex, t0)
 
 		// JSON/JSONL output
 		if output == "json" {
-			enc := json.NewEncoder(os.Stdout)
-			enc.SetIndent("", "  ")
-			enc.Encode(correlated)
+			if endpoints == nil {
+				endpoints = []string{}
+			}
+			printHomerJSON(cmd, homer.CallFlow{Legs: correlated, Endpoints: endpoints, Arrows: arrows})
 			return
 		}
 		if output == "jsonl" {
@@ -1801,57 +3079,12 @@ Examples:
 			txnByCallID[msg.CallID] = append(txnByCallID[msg.CallID], msg)
 		}
 
-		// Fix up status and duration from transaction data.
-		// The fan-out discovery may only return a subset of messages per call,
-		// so status and end time can be wrong. Transaction data has everything.
-		for i := range correlated {
-			msgs := txnByCallID[correlated[i].CallID]
-			if len(msgs) == 0 {
-				continue
-			}
-			// Derive status from highest SIP response code
-			var highestCode int
-			var latestTS int64
-			for _, m := range msgs {
-				if m.CreateDate > latestTS {
-					latestTS = m.CreateDate
-				}
-				if !m.IsSIP() || m.Raw == "" {
-					continue
-				}
-				// Response lines start with "SIP/2.0 NNN"
-				if strings.HasPrefix(m.Raw, "SIP/2.0 ") {
-					parts := strings.Fields(m.Raw)
-					if len(parts) >= 2 {
-						if code, err := strconv.Atoi(parts[1]); err == nil && code > highestCode {
-							highestCode = code
-						}
-					}
-				}
-			}
-			if highestCode > 0 {
-				switch {
-				case highestCode >= 200 && highestCode < 300:
-					correlated[i].Status = "answered"
-				case highestCode == 486:
-					correlated[i].Status = "busy"
-				case highestCode == 487:
-					correlated[i].Status = "cancelled"
-				case highestCode == 408 || highestCode == 480:
-					correlated[i].Status = "no answer"
-				case highestCode >= 400:
-					correlated[i].Status = "failed"
-				case highestCode >= 100:
-					correlated[i].Status = "ringing"
-				}
-			}
-			if latestTS > 0 {
-				endTime := time.UnixMilli(latestTS)
-				if endTime.After(correlated[i].EndTime) {
-					correlated[i].EndTime = endTime
-					correlated[i].Duration = endTime.Sub(correlated[i].StartTime)
-				}
-			}
+		refineLegStatus(correlated, txnByCallID)
+
+		// --focus: legs where the number is caller or callee (on any INVITE)
+		var focusLegs map[string]bool
+		if focus != "" {
+			focusLegs = homerFocusLegs(correlated, candidateTxn.Data.Messages, focus)
 		}
 
 		// Find first INVITE raw body per Call-ID
@@ -1905,12 +3138,6 @@ Examples:
 			}
 		}
 
-		// Compute t0 for relative time
-		var t0 time.Time
-		if len(correlated) > 0 {
-			t0 = correlated[0].StartTime
-		}
-
 		// --- Block 1: Leg overview table ---
 		maxTimeWidth := len("TIME")
 		maxCallIDWidth := len("CALL-ID")
@@ -1977,7 +3204,7 @@ Examples:
 
 		dateStr := ""
 		if len(correlated) > 0 {
-			dateStr = " - " + t0.Format("2006-01-02")
+			dateStr = " - " + homerTime(t0).Format("2006-01-02")
 		}
 		homerHeaderColor.Printf("  Correlated Legs (%d)%s\n", len(correlated), dateStr)
 		fmt.Println("  " + line)
@@ -1996,9 +3223,37 @@ Examples:
 		fmt.Println("  " + line)
 
 		for _, r := range rows {
-			fmt.Printf("  %-*s  ", maxTimeWidth, r.timeStr)
+			// With --focus, other legs are dimmed entirely
+			if focus != "" && !focusLegs[r.callID] {
+				homerDimColor.Printf("  %-*s  %-*s  %-*s  %-*s  %-*s", maxTimeWidth, r.timeStr,
+					maxCallIDWidth, r.callID, maxFromWidth, r.from, maxToWidth, r.to, maxRouteWidth, r.route)
+				for _, col := range dynColumns {
+					val := r.dynVals[col]
+					if val == "" {
+						val = "-"
+					}
+					homerDimColor.Printf("  %-*s", dynColWidths[col], val)
+				}
+				status := r.status
+				if status == "" {
+					status = "-"
+				}
+				homerDimColor.Printf("  %-12s\n", status)
+				continue
+			}
+
+			if focus != "" {
+				homerFocusColor.Print("▶ ")
+			} else {
+				fmt.Print("  ")
+			}
+			fmt.Printf("%-*s  ", maxTimeWidth, r.timeStr)
 			printCallID(r.callID, maxCallIDWidth)
-			fmt.Printf("  %-*s  %-*s  %-*s", maxFromWidth, r.from, maxToWidth, r.to, maxRouteWidth, r.route)
+			fmt.Print("  ")
+			printHomerFocusField(r.from, maxFromWidth, focus)
+			fmt.Print("  ")
+			printHomerFocusField(r.to, maxToWidth, focus)
+			fmt.Printf("  %-*s", maxRouteWidth, r.route)
 			for _, col := range dynColumns {
 				val := r.dynVals[col]
 				if val == "" {
@@ -2013,34 +3268,13 @@ Examples:
 		fmt.Println()
 
 		// --- Block 2: SIP message flow (ladder diagram) ---
-		// Collect SIP messages from correlated Call-IDs
-		var flowMsgs []homer.TransactionMessage
-		for _, msg := range candidateTxn.Data.Messages {
-			if msg.IsSIP() && matchingCallIDs[msg.CallID] {
-				flowMsgs = append(flowMsgs, msg)
-			}
-		}
 		if len(flowMsgs) == 0 {
+			if hiddenKeepalives > 0 {
+				homerDimColor.Printf("  %d OPTIONS/NOTIFY/PUBLISH message(s) hidden (use --include-options to show)\n\n", hiddenKeepalives)
+			}
 			return
 		}
 
-		sort.Slice(flowMsgs, func(i, j int) bool {
-			return flowMsgs[i].CreateDate < flowMsgs[j].CreateDate
-		})
-
-		// Determine endpoint order (left to right following INVITE chain from seed)
-		endpoints := correlateEndpointOrder(flowMsgs, seedCall.CallID)
-		epIndex := make(map[string]int)
-		for i, ep := range endpoints {
-			epIndex[ep] = i
-		}
-
-		// Build leg index (Call-ID -> leg number)
-		legIndex := make(map[string]int)
-		for i, c := range correlated {
-			legIndex[c.CallID] = i + 1
-		}
-
 		// Map endpoints to Homer aliases (IP → alias name).
 		// Skip aliases that are just the IP with or without port (Homer returns these when no real alias is configured).
 		epAliases := make(map[string]string)
@@ -2070,9 +3304,13 @@ Examples:
 		if toUser != "" {
 			notableNumbers[strings.TrimPrefix(toUser, "+")] = true
 		}
+		if focus != "" {
+			notableNumbers[focus] = true
+		}
 
 		// Scan INVITE messages: source IP hosts FromUser, destination IP hosts ToUser
 		epNumbers := make(map[string]string) // IP -> first notable number seen
+		focusEndpoints := make(map[string]bool)
 		if len(notableNumbers) > 0 {
 			for _, msg := range flowMsgs {
 				if !msg.IsSIP() || !strings.HasPrefix(msg.Raw, "INVITE ") {
@@ -2080,6 +3318,12 @@ Examples:
 				}
 				fromBare := strings.TrimPrefix(msg.FromUser, "+")
 				toBare := strings.TrimPrefix(msg.ToUser, "+")
+				if focus != "" && fromBare == focus {
+					focusEndpoints[msg.SrcIP] = true
+				}
+				if focus != "" && toBare == focus {
+					focusEndpoints[msg.DstIP] = true
+				}
 				if notableNumbers[fromBare] && epNumbers[msg.SrcIP] == "" {
 					epNumbers[msg.SrcIP] = msg.FromUser
 				}
@@ -2129,9 +3373,13 @@ Examples:
 			homerHeaderColor.Println(flowBuildLabelRow(aliasLabels, len(endpoints), flowColWidth))
 		}
 
-		// Endpoint IP labels (dim)
+		// Endpoint IP labels (dim, focused endpoints highlighted)
+		focused := make([]bool, len(endpoints))
+		for i, ep := range endpoints {
+			focused[i] = focusEndpoints[ep]
+		}
 		fmt.Printf("  %-*s", flowTimeWidth, "")
-		homerDimColor.Println(flowBuildLabelRow(endpoints, len(endpoints), flowColWidth))
+		printFlowLabelRow(endpoints, focused, flowColWidth, homerDimColor)
 
 		// Endpoint sub-labels (phone numbers, if any), centered around the pipe
 		hasSubLabels := len(epNumbers) > 0
@@ -2143,7 +3391,7 @@ Examples:
 				}
 			}
 			fmt.Printf("  %-*s", flowTimeWidth, "")
-			homerHeaderColor.Println(flowBuildLabelRow(numLabels, len(endpoints), flowColWidth))
+			printFlowLabelRow(numLabels, focused, flowColWidth, homerHeaderColor)
 		}
 
 		// Initial pipe row
@@ -2152,37 +3400,31 @@ Examples:
 		fmt.Println(pipeRow)
 
 		// Render each SIP message as a ladder arrow
-		for _, msg := range flowMsgs {
-			srcIdx, srcOK := epIndex[msg.SrcIP]
-			dstIdx, dstOK := epIndex[msg.DstIP]
-			if !srcOK || !dstOK || srcIdx == dstIdx {
-				continue
-			}
-
-			method := correlateMethodFromRaw(msg.Raw)
-			if method == "" {
-				method = msg.Method
-			}
-			if method == "" {
-				continue
-			}
-
-			msgTime := time.UnixMilli(msg.CreateDate)
-			offset := msgTime.Sub(t0)
-			timeStr := formatFlowOffset(msgTime, offset)
+		for _, a := range arrows {
+			srcIdx, dstIdx := a.SrcEndpointIndex, a.DstEndpointIndex
+			timeStr := formatFlowOffset(a.Timestamp, time.Duration(a.OffsetMS)*time.Millisecond)
 
-			arrowRow := buildFlowArrowRow(len(endpoints), flowColWidth, srcIdx, dstIdx, method)
+			arrowRow := buildFlowArrowRow(len(endpoints), flowColWidth, srcIdx, dstIdx, a.Method)
 
 			homerDimColor.Printf("  %-*s", flowTimeWidth, timeStr)
-			fmt.Print(arrowRow)
+			legColor := homerDimColor
+			switch {
+			case focus == "":
+				fmt.Print(arrowRow)
+			case focusLegs[a.CallID]:
+				homerFocusColor.Print(arrowRow)
+				legColor = homerFocusColor
+			default:
+				homerDimColor.Print(arrowRow)
+			}
 
-			if leg, ok := legIndex[msg.CallID]; ok {
-				homerDimColor.Printf("  Leg %d", leg)
+			if a.LegNumber > 0 {
+				legColor.Printf("  Leg %d", a.LegNumber)
 			}
 			fmt.Println()
 
 			// SDP annotation line (codec + port) for messages with SDP
-			if sdpMedia := homer.ExtractSDPMedia(msg.Raw); sdpMedia != "" {
+			if sdpMedia := a.SDPMedia; sdpMedia != "" {
 				sdpRow := buildFlowPipeRow(len(endpoints), flowColWidth)
 				sdpBuf := []byte(sdpRow)
 				// Center media info between source and destination endpoints
@@ -2207,13 +3449,196 @@ Examples:
 		fmt.Printf("  %-*s", flowTimeWidth, "")
 		fmt.Println(pipeRow)
 		fmt.Println()
+		if hiddenKeepalives > 0 {
+			homerDimColor.Printf("  %d OPTIONS/NOTIFY/PUBLISH message(s) hidden (use --include-options to show)\n\n", hiddenKeepalives)
+		}
+	},
+}
+
+var homerLegTreeCmd = &cobra.Command{
+	Use:   "leg-tree [call-id]",
+	Short: "Show correlated call legs as a branching tree",
+	Long: `Show the legs of a call as a tree of who INVITEd whom.
+
+Legs are found exactly like 'dex homer analyze' (seed call, number fan-out,
+correlation header). Instead of a flat table, each leg is placed under the leg
+whose INVITE reached the host that placed it, so forks, hunt groups and
+attended transfers show up as branches. Legs that cannot be attached to the
+INVITE chain are listed as separate roots after the seed.
+
+Examples:
+  dex homer leg-tree BW171313801040226178186286@62.156.74.72 -c X-Acme-Call-ID
+  dex homer leg-tree <call-id> -c X-Acme-Call-ID -N 4934155003500 --at "2026-02-04 17:13"
+  dex homer leg-tree --from-user 4921514174858 --to-user 4934155003500 \
+    --at "2026-02-04 17:13" -c X-Acme-Call-ID
+  dex homer leg-tree <call-id> -c X-Acme-Call-ID -o json`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		client, err := getHomerClient(cmd)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		output, _ := cmd.Flags().GetString("output")
+
+		corr := correlateHomerLegs(cmd, client, args)
+		if corr == nil {
+			return
+		}
+
+		txnByCallID := make(map[string][]homer.TransactionMessage)
+		for _, msg := range corr.txn.Data.Messages {
+			txnByCallID[msg.CallID] = append(txnByCallID[msg.CallID], msg)
+		}
+		refineLegStatus(corr.legs, txnByCallID)
+
+		var legMsgs []homer.TransactionMessage
+		for _, msg := range corr.txn.Data.Messages {
+			if corr.matching[msg.CallID] {
+				legMsgs = append(legMsgs, msg)
+			}
+		}
+		roots := homer.BuildLegTree(corr.legs, legMsgs, corr.seed.CallID)
+
+		if output == "json" {
+			printHomerJSON(cmd, roots)
+			return
+		}
+
+		var t0 time.Time
+		if len(corr.legs) > 0 {
+			t0 = corr.legs[0].StartTime
+		}
+
+		line := strings.Repeat("─", 100)
+		homerHeaderColor.Printf("  Leg Tree (%d legs) - %s\n", len(corr.legs), homerTime(t0).Format("2006-01-02"))
+		fmt.Println("  " + line)
+		fmt.Println()
+		for i, root := range roots {
+			if i > 0 {
+				fmt.Println()
+			}
+			printLegTreeNode(root, t0, "  ", "", true)
+		}
+		fmt.Println()
 	},
 }
 
+// printLegTreeNode prints a leg and its children as an indented tree.
+// prefix is the indentation inherited from ancestors; branch is the connector
+// drawn before this node ("" for roots).
+func printLegTreeNode(n *homer.LegNode, t0 time.Time, prefix, branch string, last bool) {
+	from := n.Leg.Caller
+	if from == "" {
+		from = "-"
+	}
+	to := n.Leg.Callee
+	if to == "" {
+		to = "-"
+	}
+
+	fmt.Print(prefix)
+	homerDimColor.Print(branch)
+	if branch == "" {
+		homerHeaderColor.Print("● ")
+	}
+	fmt.Printf("%s → %s  ", from, to)
+	formatCallStatus(n.Leg.Status)
+	homerDimColor.Printf("  %s\n", formatCorrelateTime(n.Leg, t0))
+
+	// Detail line aligned under the node text
+	childPrefix := prefix
+	switch {
+	case branch == "":
+		childPrefix += "  "
+	case last:
+		childPrefix += "    "
+	default:
+		childPrefix += "│   "
+	}
+	detailPrefix := childPrefix
+	if len(n.Children) > 0 {
+		detailPrefix += "│ "
+	} else {
+		detailPrefix += "  "
+	}
+	fmt.Print(detailPrefix)
+	printCallID(n.Leg.CallID, 0)
+	if n.SrcIP != "" {
+		homerDimColor.Printf("  %s → %s", n.SrcIP, n.DstIP)
+	}
+	fmt.Println()
+
+	for i, c := range n.Children {
+		isLast := i == len(n.Children)-1
+		connector := "├─▶ "
+		if isLast {
+			connector = "└─▶ "
+		}
+		printLegTreeNode(c, t0, childPrefix, connector, isLast)
+	}
+}
+
+// refineLegStatus fixes up status and duration of legs from transaction data.
+// The fan-out discovery may only return a subset of messages per call,
+// so status and end time can be wrong. Transaction data has everything.
+func refineLegStatus(legs []homer.CallSummary, txnByCallID map[string][]homer.TransactionMessage) {
+	for i := range legs {
+		msgs := txnByCallID[legs[i].CallID]
+		if len(msgs) == 0 {
+			continue
+		}
+		// Derive status from highest SIP response code
+		var highestCode int
+		var latestTS int64
+		for _, m := range msgs {
+			if m.CreateDate > latestTS {
+				latestTS = m.CreateDate
+			}
+			if !m.IsSIP() || m.Raw == "" {
+				continue
+			}
+			// Response lines start with "SIP/2.0 NNN"
+			if strings.HasPrefix(m.Raw, "SIP/2.0 ") {
+				parts := strings.Fields(m.Raw)
+				if len(parts) >= 2 {
+					if code, err := strconv.Atoi(parts[1]); err == nil && code > highestCode {
+						highestCode = code
+					}
+				}
+			}
+		}
+		if highestCode > 0 {
+			switch {
+			case highestCode >= 200 && highestCode < 300:
+				legs[i].Status = "answered"
+			case highestCode == 486:
+				legs[i].Status = "busy"
+			case highestCode == 487:
+				legs[i].Status = "cancelled"
+			case highestCode == 408 || highestCode == 480:
+				legs[i].Status = "no answer"
+			case highestCode >= 400:
+				legs[i].Status = "failed"
+			case highestCode >= 100:
+				legs[i].Status = "ringing"
+			}
+		}
+		if latestTS > 0 {
+			endTime := time.UnixMilli(latestTS)
+			if endTime.After(legs[i].EndTime) {
+				legs[i].EndTime = endTime
+				legs[i].Duration = endTime.Sub(legs[i].StartTime)
+			}
+		}
+	}
+}
+
 // formatCorrelateTime formats a compact relative time string for correlate output.
 // Format: "HH:MM:SS (+Xs)  duration" where offset is relative to t0.
 func formatCorrelateTime(c homer.CallSummary, t0 time.Time) string {
-	start := c.StartTime.Format("15:04:05")
+	start := homerTime(c.StartTime).Format("15:04:05")
 	offset := c.StartTime.Sub(t0)
 
 	var offsetStr string
@@ -2283,6 +3708,47 @@ func correlateEndpointOrder(msgs []homer.TransactionMessage, seedCallID string)
 	return ordered
 }
 
+// buildFlowArrows turns the sorted ladder messages into arrows between
+// endpoint indexes. Messages between unknown or identical endpoints, or
+// without a method or response code, are skipped. legIndex maps Call-IDs to
+// leg numbers; offsets are relative to t0.
+func buildFlowArrows(msgs []homer.TransactionMessage, endpoints []string, legIndex map[string]int, t0 time.Time) []homer.FlowArrow {
+	epIndex := make(map[string]int, len(endpoints))
+	for i, ep := range endpoints {
+		epIndex[ep] = i
+	}
+
+	arrows := make([]homer.FlowArrow, 0, len(msgs))
+	for _, msg := range msgs {
+		srcIdx, srcOK := epIndex[msg.SrcIP]
+		dstIdx, dstOK := epIndex[msg.DstIP]
+		if !srcOK || !dstOK || srcIdx == dstIdx {
+			continue
+		}
+
+		method := correlateMethodFromRaw(msg.Raw)
+		if method == "" {
+			method = msg.Method
+		}
+		if method == "" {
+			continue
+		}
+
+		msgTime := time.UnixMilli(msg.CreateDate)
+		arrows = append(arrows, homer.FlowArrow{
+			Timestamp:        msgTime,
+			OffsetMS:         msgTime.Sub(t0).Milliseconds(),
+			SrcEndpointIndex: srcIdx,
+			DstEndpointIndex: dstIdx,
+			Method:           method,
+			LegNumber:        legIndex[msg.CallID],
+			CallID:           msg.CallID,
+			SDPMedia:         homer.ExtractSDPMedia(msg.Raw),
+		})
+	}
+	return arrows
+}
+
 // flowBuildLabelRow builds a row of labels centered around pipe positions.
 // Each label[i] is centered at column position i*colWidth within a buffer
 // of numCols*colWidth bytes. Labels are guaranteed to have at least 1 space
@@ -2332,6 +3798,74 @@ func flowBuildLabelRow(labels []string, numCols, colWidth int) string {
 	return string(buf)
 }
 
+// printFlowLabelRow prints a label row laid out like flowBuildLabelRow, with the
+// labels of focused endpoints in the focus color and the rest in base.
+func printFlowLabelRow(labels []string, focused []bool, colWidth int, base *color.Color) {
+	row := flowBuildLabelRow(labels, len(labels), colWidth)
+
+	// Blank out unfocused labels but keep their width so placements line up
+	masked := make([]string, len(labels))
+	anyFocused := false
+	for i, label := range labels {
+		if focused[i] && label != "" {
+			masked[i] = label
+			anyFocused = true
+		} else {
+			masked[i] = strings.Repeat(" ", len(label))
+		}
+	}
+	if !anyFocused {
+		base.Println(row)
+		return
+	}
+	hi := flowBuildLabelRow(masked, len(labels), colWidth)
+
+	start := 0
+	for start < len(row) {
+		isHi := hi[start] != ' '
+		end := start + 1
+		for end < len(row) && (hi[end] != ' ') == isHi {
+			end++
+		}
+		if isHi {
+			homerFocusColor.Print(row[start:end])
+		} else {
+			base.Print(row[start:end])
+		}
+		start = end
+	}
+	fmt.Println()
+}
+
+// homerFocusLegs returns the Call-IDs of legs where number (without "+") is
+// the caller or callee, either in the leg summary or on any of its INVITEs.
+func homerFocusLegs(legs []homer.CallSummary, msgs []homer.TransactionMessage, number string) map[string]bool {
+	focus := make(map[string]bool)
+	for _, l := range legs {
+		if strings.TrimPrefix(l.Caller, "+") == number || strings.TrimPrefix(l.Callee, "+") == number {
+			focus[l.CallID] = true
+		}
+	}
+	for _, m := range msgs {
+		if !m.IsSIP() || !strings.HasPrefix(m.Raw, "INVITE ") {
+			continue
+		}
+		if strings.TrimPrefix(m.FromUser, "+") == number || strings.TrimPrefix(m.ToUser, "+") == number {
+			focus[m.CallID] = true
+		}
+	}
+	return focus
+}
+
+// printHomerFocusField prints a padded table cell, highlighted when it is the focus number
+func printHomerFocusField(value string, width int, focus string) {
+	if focus != "" && strings.TrimPrefix(value, "+") == focus {
+		homerFocusColor.Printf("%-*s", width, value)
+		return
+	}
+	fmt.Printf("%-*s", width, value)
+}
+
 // buildFlowPipeRow builds a pipe row for the ladder diagram: "|" at each column center.
 func buildFlowPipeRow(numCols, colWidth int) string {
 	buf := make([]byte, numCols*colWidth)
@@ -2429,9 +3963,39 @@ func correlateMethodFromRaw(raw string) string {
 	return ""
 }
 
+// isSDPOfferAnswer reports whether a raw SIP message is part of the INVITE
+// offer/answer exchange shown by --sdp: an INVITE, or a 200 OK whose CSeq
+// names INVITE. 200s to BYE, OPTIONS, REGISTER or UPDATE are left out.
+func isSDPOfferAnswer(raw string) bool {
+	switch correlateMethodFromRaw(raw) {
+	case "INVITE":
+		return true
+	case "200":
+		return homer.SIPTransactionMethod(raw) == "INVITE"
+	}
+	return false
+}
+
+// printSDPLine prints a single SDP line in the highlighted --sdp block.
+// Media (m=) and connection (c=) lines are emphasized since they carry the
+// negotiated address, port and payload types.
+func printSDPLine(line string) {
+	fmt.Print("   │ ")
+	switch {
+	case strings.HasPrefix(line, "m="), strings.HasPrefix(line, "c="):
+		homerSuccessColor.Println(line)
+	case strings.HasPrefix(line, "a=rtpmap:"), strings.HasPrefix(line, "a=sendrecv"),
+		strings.HasPrefix(line, "a=sendonly"), strings.HasPrefix(line, "a=recvonly"),
+		strings.HasPrefix(line, "a=inactive"):
+		homerWarnColor.Println(line)
+	default:
+		fmt.Println(line)
+	}
+}
+
 // formatFlowOffset formats "HH:MM:SS (+offset)" for the flow diagram.
 func formatFlowOffset(t time.Time, d time.Duration) string {
-	clock := t.Format("15:04:05")
+	clock := homerTime(t).Format("15:04:05")
 	if d < 0 {
 		d = 0
 	}
@@ -2453,41 +4017,74 @@ func init() {
 	homerCmd.PersistentFlags().String("url", "", "Homer URL (overrides HOMER_URL config)")
 	homerCmd.PersistentFlags().StringP("namespace", "n", "", "Kubernetes namespace for service discovery")
 	homerCmd.PersistentFlags().BoolP("debug", "d", false, "Print API endpoint and request body")
+	homerCmd.PersistentFlags().Bool("no-session-cache", false, "Log in fresh instead of reusing the session cached in ~/.dex/homer/session.json")
+	homerCmd.PersistentFlags().Bool("compact", false, "Emit -o json as a single compact line instead of indented")
+	homerCmd.PersistentFlags().String("tz", "", "Timezone for displayed and naive input timestamps (e.g. UTC, Europe/Berlin; default local)")
 
 	// Subcommands
 	homerCmd.AddCommand(homerDiscoverCmd)
+	homerCmd.AddCommand(homerHealthCmd)
 	homerCmd.AddCommand(homerSearchCmd)
 	homerCmd.AddCommand(homerShowCmd)
 	homerCmd.AddCommand(homerExportCmd)
 	homerCmd.AddCommand(homerEndpointsCmd)
+	homerCmd.AddCommand(homerQueriesCmd)
 	homerCmd.AddCommand(homerCallsCmd)
+	homerCmd.AddCommand(homerStatsCmd)
+	homerCmd.AddCommand(homerWatchCmd)
 	homerCmd.AddCommand(homerAliasesCmd)
+	homerCmd.AddCommand(homerAliasSuggestCmd)
 	homerCmd.AddCommand(homerAnalyzeCmd)
+	homerCmd.AddCommand(homerLegTreeCmd)
 	homerCmd.AddCommand(homerQosCmd)
 
+	homerQueriesCmd.Flags().StringP("output", "o", "", "Output format: json")
+
+	// Alias-suggest flags
+	homerAliasSuggestCmd.Flags().String("since", "1h", "Start of time range (duration like 1h, 30m or timestamp like 2006-01-02 15:04)")
+	homerAliasSuggestCmd.Flags().String("until", "", "End of time range (default: now)")
+	homerAliasSuggestCmd.Flags().IntP("limit", "l", 2000, "Maximum SIP messages to scan")
+	homerAliasSuggestCmd.Flags().Int("top", 20, "Number of suggestions to show (0 = all)")
+	homerAliasSuggestCmd.Flags().Bool("rdns", false, "Name endpoints from reverse DNS where available")
+	homerAliasSuggestCmd.Flags().StringP("output", "o", "", "Output format: json (Homer alias objects)")
+
+	homerHealthCmd.Flags().Duration("max-latency", 2*time.Second, "Fail the latency check above this search round-trip (0 = never)")
+	homerHealthCmd.Flags().StringP("output", "o", "", "Output format: json")
+
 	// Search flags
 	homerSearchCmd.Flags().String("since", "24h", "Start of time range (duration like 1h, 30m or timestamp like 2006-01-02 15:04)")
 	homerSearchCmd.Flags().String("until", "", "End of time range (default: now)")
 	homerSearchCmd.Flags().String("at", "", "Point in time to search around (±5 minutes)")
 	homerSearchCmd.Flags().StringP("query", "q", "", "Query expression (e.g., \"from_user = '123' AND status = 200\")")
+	homerSearchCmd.Flags().String("query-name", "", "Run a saved query by name (same as passing @name)")
+	homerSearchCmd.Flags().String("save-query", "", "Save the -q expression under this name, then run the search")
 	homerSearchCmd.Flags().String("number", "", "Phone number (searches from_user and to_user with and without + prefix)")
 	homerSearchCmd.Flags().String("from-user", "", "Filter by SIP from_user")
 	homerSearchCmd.Flags().String("to-user", "", "Filter by SIP to_user")
 	homerSearchCmd.Flags().String("ua", "", "Filter by SIP User-Agent")
+	homerSearchCmd.Flags().Bool("failed", false, "Only failure responses (same as -q \"status >= 400\")")
 	homerSearchCmd.Flags().String("call-id", "", "SIP Call-ID")
 	homerSearchCmd.Flags().StringSliceP("method", "m", nil, "Filter by SIP method (repeatable, e.g. -m INVITE -m BYE)")
 	homerSearchCmd.Flags().IntP("limit", "l", 200, "Maximum results")
-	homerSearchCmd.Flags().StringP("output", "o", "", "Output format: json or jsonl")
+	homerSearchCmd.Flags().StringP("output", "o", "", "Output format: json, jsonl or csv")
+	homerSearchCmd.Flags().Bool("dedup-callid", false, "Show one message per Call-ID (applied after --limit)")
+	homerSearchCmd.Flags().String("dedup", "first", "Which message to keep per Call-ID: first or latest (implies --dedup-callid)")
 
 	// Show flags
-	homerShowCmd.Flags().String("from", "10d", "Time range start (default: 10 days)")
-	homerShowCmd.Flags().String("to", "", "Time range end (default: now)")
+	homerShowCmd.Flags().String("from", "10d", "Time range start (duration like 2h or timestamp like 2006-01-02 15:04)")
+	homerShowCmd.Flags().String("to", "", "Time range end (duration or timestamp, default: now)")
 	homerShowCmd.Flags().Bool("raw", false, "Display raw SIP message bodies")
+	homerShowCmd.Flags().Bool("sdp", false, "Display only the SDP bodies of INVITEs and their 200 OK responses")
+	homerShowCmd.Flags().StringSliceP("method", "m", nil, "Short for --only-method (repeatable, e.g. -m INVITE -m 200)")
+	homerShowCmd.Flags().StringSlice("only-method", nil, "Only show these SIP methods or response codes (repeatable, case-insensitive)")
+	homerShowCmd.Flags().StringSlice("hide-method", nil, "Hide these SIP methods or response codes; hiding a request also hides its 200 responses (repeatable, case-insensitive)")
+	homerShowCmd.Flags().StringSlice("grep-header", nil, "With --raw, only print the request/status line and headers starting with this prefix (repeatable, case-insensitive)")
 
 	// Export flags
-	homerExportCmd.Flags().String("from", "10d", "Time range start (default: 10 days)")
-	homerExportCmd.Flags().String("to", "", "Time range end (default: now)")
+	homerExportCmd.Flags().String("from", "10d", "Time range start (duration like 2h or timestamp like 2006-01-02 15:04)")
+	homerExportCmd.Flags().String("to", "", "Time range end (duration or timestamp, default: now)")
 	homerExportCmd.Flags().StringP("output", "o", "", "Output file path (default: <call-id>.pcap)")
+	homerExportCmd.Flags().Bool("anonymize-ips", false, "Replace IPs with stable documentation-range placeholders and print the mapping")
 
 	// Calls flags
 	homerCallsCmd.Flags().String("since", "24h", "Start of time range (duration like 1h, 30m or timestamp like 2006-01-02 15:04)")
@@ -2499,10 +4096,41 @@ func init() {
 	homerCallsCmd.Flags().String("ua", "", "Filter by SIP User-Agent")
 	homerCallsCmd.Flags().StringP("query", "q", "", "Query expression (e.g., \"from_user = '123' AND status = 200\")")
 	homerCallsCmd.Flags().IntP("limit", "l", 100, "Maximum number of calls to return")
-	homerCallsCmd.Flags().StringP("output", "o", "", "Output format: json or jsonl")
+	homerCallsCmd.Flags().StringP("output", "o", "", "Output format: json, jsonl or csv")
+	homerCallsCmd.Flags().Bool("active-only", false, "Only calls that look in progress (INVITE without BYE/CANCEL/final failure; heuristic)")
+	homerCallsCmd.Flags().Bool("failed", false, "Only calls with a failure response (same as -q \"status >= 400\")")
+	homerCallsCmd.Flags().String("direction", "", "Only inbound or outbound calls, inferred from homer.internal_patterns")
+	homerCallsCmd.Flags().String("min-duration", "", "Only calls lasting at least this long (e.g. 30s, 1h); drops single-message calls")
+	homerCallsCmd.Flags().String("max-duration", "", "Only calls lasting at most this long (e.g. 1s, 5m)")
+
+	// Stats flags (same time range and filters as calls)
+	homerStatsCmd.Flags().String("since", "24h", "Start of time range (duration like 1h, 30m or timestamp like 2006-01-02 15:04)")
+	homerStatsCmd.Flags().String("until", "", "End of time range (default: now)")
+	homerStatsCmd.Flags().String("at", "", "Point in time to search around (±5 minutes)")
+	homerStatsCmd.Flags().String("number", "", "Phone number (searches from_user and to_user with and without + prefix)")
+	homerStatsCmd.Flags().String("from-user", "", "Filter by SIP from_user")
+	homerStatsCmd.Flags().String("to-user", "", "Filter by SIP to_user")
+	homerStatsCmd.Flags().String("ua", "", "Filter by SIP User-Agent")
+	homerStatsCmd.Flags().StringP("query", "q", "", "Query expression (e.g., \"from_user = '123' AND status = 200\")")
+	homerStatsCmd.Flags().Bool("failed", false, "Only calls with a failure response (same as -q \"status >= 400\")")
+	homerStatsCmd.Flags().IntP("limit", "l", 500, "Maximum number of calls to aggregate")
+	homerStatsCmd.Flags().Int("top-ua", 5, "Number of User-Agents to list (0 = all)")
+	homerStatsCmd.Flags().StringP("output", "o", "", "Output format: json")
+	homerCallsCmd.MarkFlagsMutuallyExclusive("failed", "active-only")
+
+	homerWatchCmd.Flags().Duration("interval", 5*time.Second, "Time between polls")
+	homerWatchCmd.Flags().String("since", "5m", "How far the first poll looks back (duration like 5m or timestamp)")
+	homerWatchCmd.Flags().String("number", "", "Phone number (searches from_user and to_user with and without + prefix)")
+	homerWatchCmd.Flags().String("from-user", "", "Filter by SIP from_user")
+	homerWatchCmd.Flags().String("to-user", "", "Filter by SIP to_user")
+	homerWatchCmd.Flags().String("ua", "", "Filter by SIP User-Agent")
+	homerWatchCmd.Flags().StringP("query", "q", "", "Query expression (e.g., \"from_user = '123' AND status = 200\")")
+	homerWatchCmd.Flags().Bool("failed", false, "Only calls with a failure response (same as -q \"status >= 400\")")
+	homerWatchCmd.Flags().IntP("limit", "l", 100, "Maximum number of calls fetched per poll")
 
 	// Analyze flags
 	homerAnalyzeCmd.Flags().StringSliceP("correlate", "c", nil, "SIP header to correlate legs by (exact match, repeatable, required)")
+	homerAnalyzeCmd.Flags().String("correlate-regex", "", "Regexp applied to correlation header values; the capture group (or full match) is the key")
 	homerAnalyzeCmd.Flags().StringSliceP("header", "H", nil, "SIP header prefix to show as table columns (prefix match, repeatable)")
 	homerAnalyzeCmd.Flags().StringSliceP("number", "N", nil, "Extra number to include in fan-out search (e.g., agent extension)")
 	homerAnalyzeCmd.Flags().String("from-user", "", "Seed: SIP from_user")
@@ -2511,11 +4139,25 @@ func init() {
 	homerAnalyzeCmd.Flags().String("until", "", "Time range end (default: now)")
 	homerAnalyzeCmd.Flags().String("at", "", "Point in time ±5 min")
 	homerAnalyzeCmd.Flags().IntP("limit", "l", 100, "Max calls per search")
-	homerAnalyzeCmd.Flags().StringP("output", "o", "", "Output format: json, jsonl")
+	homerAnalyzeCmd.Flags().StringP("output", "o", "", "Output format: json (legs, endpoints, arrows) or jsonl (legs)")
+	homerAnalyzeCmd.Flags().Bool("include-options", false, "Keep OPTIONS/NOTIFY/PUBLISH keepalive traffic in the message flow")
+	homerAnalyzeCmd.Flags().String("focus", "", "Highlight the legs and endpoints involving this number, dim the rest")
+
+	// Leg tree flags (same correlation inputs as analyze)
+	homerLegTreeCmd.Flags().StringSliceP("correlate", "c", nil, "SIP header to correlate legs by (exact match, repeatable, required)")
+	homerLegTreeCmd.Flags().String("correlate-regex", "", "Regexp applied to correlation header values; the capture group (or full match) is the key")
+	homerLegTreeCmd.Flags().StringSliceP("number", "N", nil, "Extra number to include in fan-out search (e.g., agent extension)")
+	homerLegTreeCmd.Flags().String("from-user", "", "Seed: SIP from_user")
+	homerLegTreeCmd.Flags().String("to-user", "", "Seed: SIP to_user")
+	homerLegTreeCmd.Flags().String("since", "10d", "Time range start (default: 10 days)")
+	homerLegTreeCmd.Flags().String("until", "", "Time range end (default: now)")
+	homerLegTreeCmd.Flags().String("at", "", "Point in time ±5 min")
+	homerLegTreeCmd.Flags().IntP("limit", "l", 100, "Max calls per search")
+	homerLegTreeCmd.Flags().StringP("output", "o", "", "Output format: json")
 
 	// QoS flags
-	homerQosCmd.Flags().String("from", "10d", "Time range start (default: 10 days)")
-	homerQosCmd.Flags().String("to", "", "Time range end (default: now)")
+	homerQosCmd.Flags().String("from", "10d", "Time range start (duration like 2h or timestamp like 2006-01-02 15:04)")
+	homerQosCmd.Flags().String("to", "", "Time range end (duration or timestamp, default: now)")
 	homerQosCmd.Flags().Int("clock", 8000, "RTP clock rate in Hz for jitter conversion")
 	homerQosCmd.Flags().Float64("latency", 20, "Assumed one-way latency in ms for MOS calculation")
 	homerQosCmd.Flags().StringP("output", "o", "", "Output format: json or jsonl")
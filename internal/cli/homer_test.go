@@ -0,0 +1,389 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codewandler/dex/internal/homer"
+)
+
+func loadTestLocation(t *testing.T, name string) *time.Location {
+	t.Helper()
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		t.Skipf("timezone data for %s not available: %v", name, err)
+	}
+	return loc
+}
+
+func TestParseTimeValueAtDST(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		wantUTC  time.Time
+		wantWarn string
+	}{
+		{
+			name:    "regular winter time",
+			input:   "2026-02-04 17:13",
+			wantUTC: time.Date(2026, 2, 4, 16, 13, 0, 0, time.UTC),
+		},
+		{
+			name:    "regular summer time",
+			input:   "2026-07-01 12:00:00",
+			wantUTC: time.Date(2026, 7, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			// 2026-03-29 02:00 CET jumps to 03:00 CEST
+			name:     "spring forward gap shifts forward",
+			input:    "2026-03-29 02:30",
+			wantUTC:  time.Date(2026, 3, 29, 1, 30, 0, 0, time.UTC), // 03:30 CEST
+			wantWarn: "does not exist",
+		},
+		{
+			name:    "just before spring forward",
+			input:   "2026-03-29 01:59:59",
+			wantUTC: time.Date(2026, 3, 29, 0, 59, 59, 0, time.UTC),
+		},
+		{
+			name:    "just after spring forward",
+			input:   "2026-03-29T03:00",
+			wantUTC: time.Date(2026, 3, 29, 1, 0, 0, 0, time.UTC),
+		},
+		{
+			// 2026-10-25 03:00 CEST falls back to 02:00 CET; 02:30 occurs twice
+			name:     "fall back overlap picks later instant",
+			input:    "2026-10-25 02:30",
+			wantUTC:  time.Date(2026, 10, 25, 1, 30, 0, 0, time.UTC), // 02:30 CET
+			wantWarn: "ambiguous",
+		},
+		{
+			name:    "just after fall back overlap",
+			input:   "2026-10-25 03:00",
+			wantUTC: time.Date(2026, 10, 25, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			name:    "explicit offset bypasses DST resolution",
+			input:   "2026-10-25T02:30:00+02:00",
+			wantUTC: time.Date(2026, 10, 25, 0, 30, 0, 0, time.UTC),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, warn, err := parseTimeValueAt(tt.input, berlin, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.wantUTC) {
+				t.Errorf("got %s (%s UTC), want %s UTC", got, got.UTC(), tt.wantUTC)
+			}
+			if tt.wantWarn == "" && warn != "" {
+				t.Errorf("unexpected warning: %s", warn)
+			}
+			if tt.wantWarn != "" && !strings.Contains(warn, tt.wantWarn) {
+				t.Errorf("warning %q does not contain %q", warn, tt.wantWarn)
+			}
+		})
+	}
+}
+
+func TestParseTimeValueAtUTCHasNoTransitions(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	got, warn, err := parseTimeValueAt("2026-03-29 02:30", time.UTC, now)
+	if err != nil || warn != "" {
+		t.Fatalf("unexpected err=%v warn=%q", err, warn)
+	}
+	if want := time.Date(2026, 3, 29, 2, 30, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestParseTimeValueAtRelative(t *testing.T) {
+	now := time.Date(2026, 3, 29, 3, 30, 0, 0, time.UTC)
+
+	for _, in := range []string{"", "now", "NOW"} {
+		got, _, err := parseTimeValueAt(in, time.Local, now)
+		if err != nil || !got.Equal(now) {
+			t.Errorf("%q: got %s, %v; want %s", in, got, err, now)
+		}
+	}
+
+	// Durations are absolute spans, unaffected by wall-clock DST shifts
+	got, _, err := parseTimeValueAt("2h", loadTestLocation(t, "Europe/Berlin"), now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := now.Add(-2 * time.Hour); !got.Equal(want) {
+		t.Errorf("2h: got %s, want %s", got, want)
+	}
+
+	if _, _, err := parseTimeValueAt("yesterday", time.UTC, now); err == nil {
+		t.Error("expected error for invalid value")
+	}
+}
+
+func TestParseTimeRangeRelative(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	now := time.Date(2026, 2, 4, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		from, to string
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{"defaults", "", "", now.Add(-time.Hour), now},
+		{"durations", "10d", "1h", now.Add(-240 * time.Hour), now.Add(-time.Hour)},
+		// Naive timestamps are wall clock in the given zone (CET = UTC+1 in February)
+		{"absolute from, duration to", "2026-02-04 17:00", "30m", time.Date(2026, 2, 4, 16, 0, 0, 0, time.UTC), now.Add(-30 * time.Minute)},
+		{"duration from, absolute to", "2h", "2026-02-04 18:10", now.Add(-2 * time.Hour), time.Date(2026, 2, 4, 17, 10, 0, 0, time.UTC)},
+		{"absolute window", "2026-02-04 17:00", "2026-02-04 17:10", time.Date(2026, 2, 4, 16, 0, 0, 0, time.UTC), time.Date(2026, 2, 4, 16, 10, 0, 0, time.UTC)},
+		{"embedded offset wins", "2026-02-04T17:00:00Z", "now", time.Date(2026, 2, 4, 17, 0, 0, 0, time.UTC), now},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			from, to, err := parseTimeRangeRelative(tt.from, tt.to, berlin, now)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+				t.Errorf("got %s → %s, want %s → %s", from.UTC(), to.UTC(), tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+
+	if _, _, err := parseTimeRangeRelative("1h", "2h", berlin, now); err == nil {
+		t.Error("expected error when --from is after --to")
+	}
+	if _, _, err := parseTimeRangeRelative("yesterday", "", berlin, now); err == nil {
+		t.Error("expected error for invalid --from")
+	}
+}
+
+func TestFormatCallTimeDisplayZone(t *testing.T) {
+	berlin := loadTestLocation(t, "Europe/Berlin")
+	defer func(loc *time.Location) { homerDisplayLoc = loc }(homerDisplayLoc)
+
+	c := homer.CallSummary{
+		StartTime: time.Date(2026, 2, 4, 22, 59, 0, 0, time.UTC),
+		EndTime:   time.Date(2026, 2, 4, 23, 1, 0, 0, time.UTC),
+		Duration:  2 * time.Minute,
+		MsgCount:  4,
+	}
+
+	homerDisplayLoc = time.UTC
+	if got, want := formatCallTime(c), "2026-02-04 22:59:00 - 23:01:00 (2m)"; got != want {
+		t.Errorf("UTC: got %q, want %q", got, want)
+	}
+
+	// In Berlin the call crosses midnight, so the end shows its date
+	homerDisplayLoc = berlin
+	if got, want := formatCallTime(c), "2026-02-04 23:59:00 - 2026-02-05 00:01:00 (2m)"; got != want {
+		t.Errorf("Berlin: got %q, want %q", got, want)
+	}
+}
+
+func TestHomerFocusLegs(t *testing.T) {
+	legs := []homer.CallSummary{
+		{CallID: "a", Caller: "+4921514174858", Callee: "4934155003500"},
+		{CallID: "b", Caller: "4934155003500", Callee: "100"},
+		{CallID: "c", Caller: "4921514174858", Callee: "200"},
+	}
+	msgs := []homer.TransactionMessage{
+		// Leg c's INVITE was re-targeted to the focus number
+		{CallID: "c", Raw: "INVITE sip:4921514174858@10.0.0.2 SIP/2.0\r\n", FromUser: "200", ToUser: "+4921514174858"},
+		{CallID: "c", Raw: "SIP/2.0 200 OK\r\n", FromUser: "200", ToUser: "4934155003500"},
+	}
+
+	got := homerFocusLegs(legs, msgs, "4921514174858")
+	if !got["a"] || got["b"] || !got["c"] {
+		t.Errorf("focus 4921514174858: got %v, want a and c", got)
+	}
+
+	got = homerFocusLegs(legs, msgs, "4934155003500")
+	if !got["a"] || !got["b"] || got["c"] {
+		t.Errorf("focus 4934155003500: got %v, want a and b (responses don't count)", got)
+	}
+}
+
+func TestCorrelateEndpointOrder(t *testing.T) {
+	msgs := []homer.TransactionMessage{
+		// An earlier leg towards the seed's caller does not come first
+		{CallID: "a", SrcIP: "10.0.0.1", DstIP: "10.0.0.2", Raw: "INVITE sip:100@10.0.0.2 SIP/2.0\r\n"},
+		{CallID: "seed", SrcIP: "10.0.0.2", DstIP: "10.0.0.3", Raw: "INVITE sip:100@10.0.0.3 SIP/2.0\r\n"},
+		{CallID: "c", SrcIP: "10.0.0.3", DstIP: "10.0.0.4", Raw: "INVITE sip:200@10.0.0.4 SIP/2.0\r\n"},
+		{CallID: "c", SrcIP: "10.0.0.9", DstIP: "10.0.0.1", Raw: "SIP/2.0 200 OK\r\n"},
+		{CallID: "q", SrcIP: "10.0.0.8", DstIP: "10.0.0.7", Profile: "5_default"}, // RTCP, ignored
+	}
+
+	got := correlateEndpointOrder(msgs, "seed")
+	want := []string{"10.0.0.2", "10.0.0.3", "10.0.0.4", "10.0.0.1", "10.0.0.9"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	// Without an INVITE on the seed, endpoints appear in message order
+	got = correlateEndpointOrder(msgs[3:4], "seed")
+	if want := []string{"10.0.0.9", "10.0.0.1"}; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("no seed INVITE: got %v, want %v", got, want)
+	}
+}
+
+func TestBuildFlowArrows(t *testing.T) {
+	t0 := time.UnixMilli(1770000000000)
+	msgs := []homer.TransactionMessage{
+		{CallID: "seed", SrcIP: "10.0.0.1", DstIP: "10.0.0.2", CreateDate: t0.UnixMilli(), Raw: "INVITE sip:100@10.0.0.2 SIP/2.0\r\n"},
+		{CallID: "seed", SrcIP: "10.0.0.2", DstIP: "10.0.0.2", CreateDate: t0.UnixMilli() + 5, Raw: "INVITE sip:100@10.0.0.2 SIP/2.0\r\n"},
+		{CallID: "b", SrcIP: "10.0.0.3", DstIP: "10.0.0.2", CreateDate: t0.UnixMilli() + 1500, Raw: "SIP/2.0 180 Ringing\r\n"},
+		{CallID: "b", SrcIP: "10.0.0.2", DstIP: "10.0.0.5", CreateDate: t0.UnixMilli() + 1600, Raw: "BYE sip:x SIP/2.0\r\n"},
+	}
+	endpoints := []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"}
+	legIndex := map[string]int{"seed": 1, "b": 2}
+
+	got := buildFlowArrows(msgs, endpoints, legIndex, t0)
+	if len(got) != 2 {
+		t.Fatalf("got %d arrows, want 2 (same-endpoint and unknown-endpoint messages skipped): %+v", len(got), got)
+	}
+	want := []homer.FlowArrow{
+		{Timestamp: t0, OffsetMS: 0, SrcEndpointIndex: 0, DstEndpointIndex: 1, Method: "INVITE", LegNumber: 1, CallID: "seed"},
+		{Timestamp: t0.Add(1500 * time.Millisecond), OffsetMS: 1500, SrcEndpointIndex: 2, DstEndpointIndex: 1, Method: "180", LegNumber: 2, CallID: "b"},
+	}
+	for i := range want {
+		if !got[i].Timestamp.Equal(want[i].Timestamp) {
+			t.Errorf("arrow %d timestamp = %v, want %v", i, got[i].Timestamp, want[i].Timestamp)
+		}
+		got[i].Timestamp = want[i].Timestamp
+		if got[i] != want[i] {
+			t.Errorf("arrow %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestBuildSmartInputParenthesizesOr(t *testing.T) {
+	got := buildSmartInput([][]string{
+		{"status >= 500 OR method = 'BYE'"},
+		{homerFailedCondition},
+	})
+	want := "(status >= 500 OR method = 'BYE') AND status >= 400"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	got = buildSmartInput([][]string{{"status >= 500 OR method = 'BYE'"}})
+	if want := "status >= 500 OR method = 'BYE'"; got != want {
+		t.Errorf("single criterion: got %q, want %q", got, want)
+	}
+}
+
+func TestWriteHomerCSV(t *testing.T) {
+	prev := homerDisplayLoc
+	homerDisplayLoc = time.UTC
+	defer func() { homerDisplayLoc = prev }()
+
+	start := time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)
+
+	var sb strings.Builder
+	records := []homer.SearchRecord{{
+		Date: start, SrcIP: "10.0.0.1", SrcPort: 5060, DstIP: "10.0.0.2", DstPort: 5080,
+		CallID: "abc@host", Method: "INVITE", FromUser: "100", ToUser: "200", UserAgent: "PBX, v1.2",
+	}}
+	if err := writeHomerSearchCSV(&sb, records); err != nil {
+		t.Fatal(err)
+	}
+	want := `date,src,dst,call_id,method,from_user,to_user,user_agent
+2026-03-02 10:15:00,10.0.0.1:5060,10.0.0.2:5080,abc@host,INVITE,100,200,"PBX, v1.2"
+`
+	if sb.String() != want {
+		t.Errorf("search CSV:\n%s\nwant:\n%s", sb.String(), want)
+	}
+
+	sb.Reset()
+	calls := []homer.CallSummary{{
+		CallID: "abc@host", StartTime: start, EndTime: start.Add(90500 * time.Millisecond), Duration: 90500 * time.Millisecond,
+		Caller: "100", Callee: "200", Status: "answered", MsgCount: 7,
+	}}
+	if err := writeHomerCallsCSV(&sb, calls); err != nil {
+		t.Fatal(err)
+	}
+	want = `start,end,duration_seconds,call_id,caller,callee,status,msg_count
+2026-03-02 10:15:00,2026-03-02 10:16:30,90.5,abc@host,100,200,answered,7
+`
+	if sb.String() != want {
+		t.Errorf("calls CSV:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
+
+func TestHomerMethodFilter(t *testing.T) {
+	msgs := []struct{ method, txn string }{
+		{"OPTIONS", "OPTIONS"},
+		{"200", "OPTIONS"},
+		{"INVITE", "INVITE"},
+		{"100", "INVITE"},
+		{"200", "INVITE"},
+		{"BYE", "BYE"},
+		{"200", "BYE"},
+	}
+	shown := func(f homerMethodFilter) string {
+		var out []string
+		for _, m := range msgs {
+			if f.keep(m.method, m.txn) {
+				out = append(out, m.method+"/"+m.txn)
+			}
+		}
+		return strings.Join(out, " ")
+	}
+
+	tests := []struct {
+		name       string
+		only, hide []string
+		want       string
+	}{
+		{"no filter", nil, nil, "OPTIONS/OPTIONS 200/OPTIONS INVITE/INVITE 100/INVITE 200/INVITE BYE/BYE 200/BYE"},
+		{"hide options drops its 200", nil, []string{"options"}, "INVITE/INVITE 100/INVITE 200/INVITE BYE/BYE 200/BYE"},
+		{"hide options and 200", nil, []string{"OPTIONS", "200"}, "INVITE/INVITE 100/INVITE BYE/BYE"},
+		{"hide 100", nil, []string{"100"}, "OPTIONS/OPTIONS 200/OPTIONS INVITE/INVITE 200/INVITE BYE/BYE 200/BYE"},
+		{"only", []string{"invite", "200"}, nil, "200/OPTIONS INVITE/INVITE 200/INVITE 200/BYE"},
+		{"only with hide", []string{"INVITE", "200"}, []string{"OPTIONS", "BYE"}, "INVITE/INVITE 200/INVITE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newHomerMethodFilter(tt.only, tt.hide)
+			if got := shown(f); got != tt.want {
+				t.Errorf("shown = %q, want %q", got, tt.want)
+			}
+			if f.active() != (len(tt.only)+len(tt.hide) > 0) {
+				t.Errorf("active() = %v", f.active())
+			}
+		})
+	}
+}
+
+func TestIsSDPOfferAnswer(t *testing.T) {
+	msg := func(firstLine, cseq string) string {
+		return firstLine + "\r\nCall-ID: abc@host\r\nCSeq: " + cseq + "\r\nContent-Length: 0\r\n\r\n"
+	}
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{msg("INVITE sip:123@example.com SIP/2.0", "1 INVITE"), true},
+		{msg("SIP/2.0 200 OK", "1 INVITE"), true},
+		{msg("SIP/2.0 200 OK", "2 BYE"), false},
+		{msg("SIP/2.0 200 OK", "7 OPTIONS"), false},
+		{msg("SIP/2.0 200 OK", "3 REGISTER"), false},
+		{msg("SIP/2.0 200 OK", "4 UPDATE"), false},
+		{msg("SIP/2.0 180 Ringing", "1 INVITE"), false},
+		{msg("BYE sip:123@example.com SIP/2.0", "2 BYE"), false},
+	}
+	for _, tt := range tests {
+		if got := isSDPOfferAnswer(tt.raw); got != tt.want {
+			first, _, _ := strings.Cut(tt.raw, "\r\n")
+			t.Errorf("isSDPOfferAnswer(%q, CSeq %q) = %v, want %v", first, homer.ExtractSIPHeader(tt.raw, "CSeq"), got, tt.want)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -42,7 +43,17 @@ var jiraAuthCmd = &cobra.Command{
 var jiraViewCmd = &cobra.Command{
 	Use:   "view <ISSUE-KEY>",
 	Short: "View a single issue",
-	Args:  cobra.ExactArgs(1),
+	Long: `View a Jira issue with its summary, type, status, priority, assignee,
+reporter, labels, parent, subtasks, links, description and comments.
+
+Descriptions and comments stored as Atlassian Document Format are rendered
+as plain text. Use -o json for the raw issue.
+
+Examples:
+  dex jira view DEV-123
+  dex jira view DEV-123 --compact
+  dex jira view DEV-123 -o json`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
@@ -66,6 +77,40 @@ var jiraViewCmd = &cobra.Command{
 	},
 }
 
+var jiraOpenCmd = &cobra.Command{
+	Use:   "open <ISSUE-KEY>",
+	Short: "Open an issue in the browser",
+	Long: `Open a Jira issue in the default web browser.
+
+Examples:
+  dex jira open DEV-123`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if _, err := jira.NormalizeIssueKey(args[0]); err != nil {
+			RenderError(err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+		defer cancel()
+
+		client, err := jira.NewClient()
+		if err != nil {
+			RenderError(err)
+		}
+
+		issueURL, err := client.IssueURL(ctx, args[0])
+		if err != nil {
+			RenderError(err)
+		}
+
+		if err := openBrowser(issueURL); err != nil {
+			RenderError(fmt.Errorf("failed to open browser: %w", err))
+		}
+
+		fmt.Printf("Opening %s\n", issueURL)
+	},
+}
+
 var jiraSearchCmd = &cobra.Command{
 	Use:   "search [JQL]",
 	Short: "Search issues with JQL query",
@@ -74,14 +119,26 @@ var jiraSearchCmd = &cobra.Command{
 Examples:
   dex jira search "project = TEL"
   dex jira search "assignee = currentUser() AND status != Done"
-  dex jira search "updated >= -7d ORDER BY updated DESC"`,
+  dex jira search "updated >= -7d ORDER BY updated DESC"
+  dex jira search --mine                       # My open issues
+  dex jira search --mine "project = TEL"       # My open issues in TEL
+  dex jira search "project = TEL" -l 50 -o json
+
+--mine adds "assignee = currentUser() AND statusCategory != Done" to the query.`,
 	Run: func(cmd *cobra.Command, args []string) {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
 
+		mine, _ := cmd.Flags().GetBool("mine")
+
 		jql := "updated >= -7d ORDER BY updated DESC"
 		if len(args) > 0 {
 			jql = strings.Join(args, " ")
+		} else if mine {
+			jql = "ORDER BY updated DESC"
+		}
+		if mine {
+			jql = jira.MineJQL(jql)
 		}
 
 		limit, _ := cmd.Flags().GetInt("limit")
@@ -91,10 +148,11 @@ Examples:
 			RenderError(err)
 		}
 
-		result, err := client.SearchIssues(ctx, jql, limit)
+		issues, err := client.Search(ctx, jql, limit)
 		if err != nil {
 			RenderError(err)
 		}
+		result := &jira.SearchResult{Issues: issues}
 
 		compact, _ := cmd.Flags().GetBool("compact")
 		mode := render.ModeNormal
@@ -436,6 +494,113 @@ Examples:
 	},
 }
 
+var jiraBulkTransitionCmd = &cobra.Command{
+	Use:   "bulk-transition <STATUS> [ISSUE-KEY...]",
+	Short: "Transition many issues at once (dry run by default)",
+	Long: `Apply the same transition to many issues, e.g. to close a sprint's tickets.
+
+Issues come from the keys after STATUS or from --jql. The transition is resolved
+per issue by name or ID, as in 'dex jira transition', because the available
+transitions depend on each issue's workflow and current status.
+
+Without --yes this is a dry run: it shows which issues have the transition and
+changes nothing. With --yes the transition is applied to each issue in turn and
+the outcome is reported per issue; the command exits 1 if any issue could not
+be transitioned.
+
+Examples:
+  dex jira bulk-transition Done DEV-101 DEV-102 DEV-103
+  dex jira bulk-transition Done --jql "sprint in openSprints() AND status = Review"
+  dex jira bulk-transition Done --jql "project = DEV AND fixVersion = 1.4" --yes
+  dex jira bulk-transition Done DEV-101 DEV-102 -o json`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+		defer cancel()
+
+		target := args[0]
+		keys := args[1:]
+		jql, _ := cmd.Flags().GetString("jql")
+		limit, _ := cmd.Flags().GetInt("limit")
+		yes, _ := cmd.Flags().GetBool("yes")
+
+		if (jql == "") == (len(keys) == 0) {
+			RenderError(fmt.Errorf("give either issue keys or --jql"))
+		}
+
+		client, err := jira.NewClient()
+		if err != nil {
+			RenderError(err)
+		}
+
+		var issues []jira.Issue
+		lookupErrs := make(map[string]error)
+		if jql != "" {
+			result, err := client.SearchIssues(ctx, jql, limit)
+			if err != nil {
+				RenderError(err)
+			}
+			issues = result.Issues
+		} else {
+			for _, key := range keys {
+				issue, err := client.GetIssue(ctx, key)
+				if err != nil {
+					// Keep going and report the key as failed
+					lookupErrs[key] = err
+					issue = &jira.Issue{Key: key}
+				}
+				issues = append(issues, *issue)
+			}
+		}
+
+		result := &jira.BulkTransitionResult{Transition: target, DryRun: !yes}
+		for _, issue := range issues {
+			item := jira.BulkTransitionItem{
+				Key:        issue.Key,
+				Summary:    issue.Fields.Summary,
+				FromStatus: issue.Fields.Status.Name,
+			}
+			if err := lookupErrs[issue.Key]; err != nil {
+				item.Outcome = jira.BulkTransitionFailed
+				item.Error = err.Error()
+				result.Issues = append(result.Issues, item)
+				continue
+			}
+
+			transitions, err := client.ListTransitions(ctx, issue.Key)
+			if err != nil {
+				item.Outcome = jira.BulkTransitionFailed
+				item.Error = err.Error()
+				result.Issues = append(result.Issues, item)
+				continue
+			}
+			t, err := jira.FindTransition(transitions, target)
+			if err != nil {
+				item.Outcome = jira.BulkTransitionUnavailable
+				item.Error = err.Error()
+				result.Issues = append(result.Issues, item)
+				continue
+			}
+			item.ToStatus = t.To.Name
+
+			if !yes {
+				item.Outcome = jira.BulkTransitionPlanned
+			} else if err := client.ApplyTransition(ctx, issue.Key, t.ID); err != nil {
+				item.Outcome = jira.BulkTransitionFailed
+				item.Error = err.Error()
+			} else {
+				item.Outcome = jira.BulkTransitionDone
+			}
+			result.Issues = append(result.Issues, item)
+		}
+
+		Render(result)
+		if yes && len(result.Issues) > result.Count(jira.BulkTransitionDone) {
+			os.Exit(1)
+		}
+	},
+}
+
 var jiraCommentCmd = &cobra.Command{
 	Use:   "comment <ISSUE-KEY> <MESSAGE>",
 	Short: "Add a comment to an issue",
@@ -685,25 +850,133 @@ Examples:
 	},
 }
 
+var jiraMyFiltersCmd = &cobra.Command{
+	Use:   "my-filters",
+	Short: "List your saved Jira filters",
+	Long: `List the saved filters you own, plus the ones you marked as favourite (★).
+
+Run one with "dex jira filter run <id>".
+
+Examples:
+  dex jira my-filters
+  dex jira my-filters --compact`,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		client, err := jira.NewClient()
+		if err != nil {
+			RenderError(err)
+		}
+
+		filters, err := client.ListMyFilters(ctx)
+		if err != nil {
+			RenderError(err)
+		}
+
+		compact, _ := cmd.Flags().GetBool("compact")
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&jira.FilterList{Filters: filters}, mode)
+	},
+}
+
+var jiraFilterCmd = &cobra.Command{
+	Use:   "filter",
+	Short: "Work with saved Jira filters",
+}
+
+var jiraFilterRunCmd = &cobra.Command{
+	Use:   "run <id|name>",
+	Short: "Run a saved filter's JQL",
+	Long: `Execute the JQL of a saved filter and show the matching issues.
+
+The filter is given by its ID or by the exact name of one of your filters
+(see "dex jira my-filters").
+
+Examples:
+  dex jira filter run 10042
+  dex jira filter run "Team backlog" -l 50`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+
+		limit, _ := cmd.Flags().GetInt("limit")
+
+		client, err := jira.NewClient()
+		if err != nil {
+			RenderError(err)
+		}
+
+		filter, err := resolveJiraFilter(ctx, client, args[0])
+		if err != nil {
+			RenderError(err)
+		}
+		if filter.JQL == "" {
+			RenderError(fmt.Errorf("filter %s has no JQL", filter.ID))
+		}
+
+		result, err := client.SearchIssues(ctx, filter.JQL, limit)
+		if err != nil {
+			RenderError(err)
+		}
+
+		compact, _ := cmd.Flags().GetBool("compact")
+		mode := render.ModeNormal
+		if compact {
+			mode = render.ModeCompact
+		}
+		RenderWithMode(&jira.FilterRunResult{Filter: filter, SearchResult: result}, mode)
+	},
+}
+
+// resolveJiraFilter fetches a filter by numeric ID, or looks it up by name
+// (case-insensitive) among the user's own and favourite filters.
+func resolveJiraFilter(ctx context.Context, client *jira.Client, ref string) (*jira.Filter, error) {
+	if _, err := strconv.Atoi(ref); err == nil {
+		return client.GetFilter(ctx, ref)
+	}
+
+	filters, err := client.ListMyFilters(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for i := range filters {
+		if strings.EqualFold(filters[i].Name, ref) {
+			return &filters[i], nil
+		}
+	}
+	return nil, fmt.Errorf("no saved filter named %q (see 'dex jira my-filters')", ref)
+}
+
 func init() {
 	jiraCmd.AddCommand(jiraAuthCmd)
 	jiraCmd.AddCommand(jiraViewCmd)
+	jiraCmd.AddCommand(jiraOpenCmd)
 	jiraCmd.AddCommand(jiraSearchCmd)
 	jiraCmd.AddCommand(jiraMyCmd)
 	jiraCmd.AddCommand(jiraLookupCmd)
 	jiraCmd.AddCommand(jiraProjectCmd)
 	jiraCmd.AddCommand(jiraProjectsCmd)
+	jiraCmd.AddCommand(jiraMyFiltersCmd)
+	jiraCmd.AddCommand(jiraFilterCmd)
+	jiraFilterCmd.AddCommand(jiraFilterRunCmd)
 	jiraCmd.AddCommand(jiraCreateCmd)
 	jiraCmd.AddCommand(jiraDeleteCmd)
 	jiraCmd.AddCommand(jiraLinkCmd)
 	jiraCmd.AddCommand(jiraUnlinkCmd)
 	jiraCmd.AddCommand(jiraUpdateCmd)
 	jiraCmd.AddCommand(jiraTransitionCmd)
+	jiraCmd.AddCommand(jiraBulkTransitionCmd)
 	jiraCmd.AddCommand(jiraCommentCmd)
 	jiraCmd.AddCommand(jiraCommentDeleteCmd)
 
 	jiraSearchCmd.Flags().IntP("limit", "l", 20, "Maximum number of results")
 	jiraSearchCmd.Flags().Bool("compact", false, "Compact one-line-per-issue output")
+	jiraSearchCmd.Flags().Bool("mine", false, "Only my open issues (assignee = currentUser() AND statusCategory != Done)")
 	jiraMyCmd.Flags().IntP("limit", "l", 20, "Maximum number of results")
 	jiraMyCmd.Flags().StringP("status", "s", "", "Filter by status (e.g., 'In Progress', 'Review')")
 	jiraMyCmd.Flags().Bool("compact", false, "Compact one-line-per-issue output")
@@ -713,6 +986,9 @@ func init() {
 	jiraProjectsCmd.Flags().BoolP("keys", "k", false, "Output only project keys (one per line)")
 	jiraProjectsCmd.Flags().BoolP("archived", "a", false, "Include archived projects")
 	jiraProjectsCmd.Flags().Bool("compact", false, "Compact one-line-per-project output")
+	jiraMyFiltersCmd.Flags().Bool("compact", false, "Compact one-line-per-filter output")
+	jiraFilterRunCmd.Flags().IntP("limit", "l", 20, "Maximum number of results")
+	jiraFilterRunCmd.Flags().Bool("compact", false, "Compact one-line-per-issue output")
 
 	jiraCreateCmd.Flags().StringP("project", "p", "", "Project key (e.g., DEV, TEL)")
 	jiraCreateCmd.Flags().StringP("type", "t", "", "Issue type (Task, Bug, Story, Sub-task)")
@@ -741,6 +1017,10 @@ func init() {
 
 	jiraTransitionCmd.Flags().BoolP("list", "l", false, "List available transitions")
 
+	jiraBulkTransitionCmd.Flags().String("jql", "", "Transition the issues matching this JQL query instead of listed keys")
+	jiraBulkTransitionCmd.Flags().IntP("limit", "l", 50, "Maximum number of issues taken from --jql")
+	jiraBulkTransitionCmd.Flags().BoolP("yes", "y", false, "Apply the transitions (default is a dry run)")
+
 	jiraCommentCmd.Flags().StringP("body", "b", "", "Comment body in markdown (alternative to positional argument)")
 }
 
@@ -217,14 +217,15 @@ Examples:
 		}
 
 		// Parse --since (start time)
-		start, err := parseTimeValueInLocation(sinceStr, loc)
+		now := time.Now()
+		start, err := parseTimeValueRelative(sinceStr, loc, now)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid --since value: %v\n", err)
 			os.Exit(1)
 		}
 
 		// Parse --until (end time), defaults to now
-		end, err := parseTimeValueInLocation(untilStr, loc)
+		end, err := parseTimeValueRelative(untilStr, loc, now)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid --until value: %v\n", err)
 			os.Exit(1)
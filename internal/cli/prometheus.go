@@ -2,11 +2,16 @@ package cli
 
 import (
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"os"
+	"regexp"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 
@@ -41,20 +46,33 @@ func getPrometheusURL(urlFlag string) (string, error) {
 		return cfg.Prometheus.URL, nil
 	}
 
-	// 3. Auto-discover
-	promDimColor.Println("No Prometheus URL configured, attempting auto-discovery...")
-	url, err := discoverPrometheusURL("")
+	// 3. Recently discovered URL that still responds, else auto-discover
+	kubeContext, _ := k8s.CurrentContext()
+	cache, _ := prometheus.DefaultDiscoveryCache(kubeContext)
+	ep, _, err := cache.Resolve(probePrometheusURL, func() (*prometheus.DiscoveredEndpoint, error) {
+		promDimColor.Println("No Prometheus URL configured, attempting auto-discovery...")
+		ep, err := discoverPrometheusEndpoint("")
+		if err == nil {
+			promDimColor.Printf("Auto-discovered Prometheus at %s\n\n", ep.URL)
+		}
+		return ep, err
+	})
 	if err != nil {
 		return "", fmt.Errorf("auto-discovery failed: %w\nTip: Use --url flag or set PROMETHEUS_URL environment variable", err)
 	}
-	promDimColor.Printf("Auto-discovered Prometheus at %s\n\n", url)
-	return url, nil
+	return ep.URL, nil
+}
+
+// probePrometheusURL checks that a Prometheus URL responds, with a short timeout
+func probePrometheusURL(url string) error {
+	return prometheus.NewProbeClient(url).TestConnection()
 }
 
-// discoverPrometheusURL finds a working Prometheus URL in the current Kubernetes cluster
-func discoverPrometheusURL(namespace string) (string, error) {
+// discoverPrometheusEndpoint finds a working Prometheus URL in the current
+// Kubernetes cluster, preferring existing port-forwards over Pod IPs
+func discoverPrometheusEndpoint(namespace string) (*prometheus.DiscoveredEndpoint, error) {
 	if _, err := k8s.NewClient(""); err != nil {
-		return "", fmt.Errorf("failed to connect to Kubernetes: %w", err)
+		return nil, fmt.Errorf("failed to connect to Kubernetes: %w", err)
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
@@ -133,32 +151,30 @@ func discoverPrometheusURL(namespace string) (string, error) {
 
 	if len(candidates) == 0 {
 		if searched == 0 && lastErr != nil {
-			return "", fmt.Errorf("failed to list pods in any namespace: %w", lastErr)
+			return nil, fmt.Errorf("failed to list pods in any namespace: %w", lastErr)
 		}
-		return "", fmt.Errorf("no Prometheus pods found in namespaces: %s", strings.Join(searchNamespaces, ", "))
+		return nil, fmt.Errorf("no Prometheus pods found in namespaces: %s", strings.Join(searchNamespaces, ", "))
 	}
 
 	// Check existing port-forwards first
 	for _, c := range candidates {
 		if info, exists := portforward.FindByNamespaceAndPod(c.namespace, c.name); exists {
 			localURL := fmt.Sprintf("http://localhost:%d", info.LocalPort)
-			probeClient := prometheus.NewProbeClient(localURL)
-			if probeClient.TestConnection() == nil {
-				return localURL, nil
+			if probePrometheusURL(localURL) == nil {
+				return &prometheus.DiscoveredEndpoint{URL: localURL, Namespace: c.namespace, Pod: c.name}, nil
 			}
 		}
 	}
 
 	// Try Pod IPs
 	for _, c := range candidates {
-		probeClient := prometheus.NewProbeClient(c.url)
-		if probeClient.TestConnection() == nil {
-			return c.url, nil
+		if probePrometheusURL(c.url) == nil {
+			return &prometheus.DiscoveredEndpoint{URL: c.url, Namespace: c.namespace, Pod: c.name}, nil
 		}
 	}
 
 	c := candidates[0]
-	return "", fmt.Errorf("found %d Prometheus pod(s) but none are reachable via Pod IP\n\nTip: Use port-forwarding instead:\n  dex k8s forward start %s -n %s\n  Then set PROMETHEUS_URL to the local endpoint shown in the output",
+	return nil, fmt.Errorf("found %d Prometheus pod(s) but none are reachable via Pod IP\n\nTip: Use port-forwarding instead:\n  dex k8s forward start %s -n %s\n  Then set PROMETHEUS_URL to the local endpoint shown in the output",
 		len(candidates), c.name, c.namespace)
 }
 
@@ -199,6 +215,192 @@ func formatSampleValue(v interface{}) string {
 	}
 }
 
+// promUnits are the accepted values of --unit
+var promUnits = []string{"bytes", "bytes/s", "seconds", "percent", "none"}
+
+// formatSampleValueUnit is formatSampleValue with the value rendered in unit:
+// bytes as IEC sizes (1.0 GiB), bytes/s as IEC sizes per second (1.0 MiB/s),
+// seconds as durations (42.3ms, 1m5s) and percent as the ratio times 100 (4.23%). NaN, ±Inf, unparsable values and
+// unit "none" or "" are left as formatSampleValue prints them.
+func formatSampleValueUnit(v interface{}, unit string) string {
+	f, err := strconv.ParseFloat(fmt.Sprint(v), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return formatSampleValue(v)
+	}
+	switch unit {
+	case "bytes", "bytes/s", "seconds", "percent":
+		return formatPromValueUnit(f, unit)
+	}
+	return formatSampleValue(v)
+}
+
+// formatPromValueUnit formats a finite value in unit; see formatSampleValueUnit
+func formatPromValueUnit(f float64, unit string) string {
+	sign := ""
+	if f < 0 {
+		sign, f = "-", -f
+	}
+
+	switch unit {
+	case "bytes":
+		if f < 1024 {
+			return sign + formatPromStat(f) + " B"
+		}
+		const units = "KMGTPE"
+		i := -1
+		// Also step up when rounding would print 1024.0 of the smaller unit
+		for f >= 1023.95 && i < len(units)-1 {
+			f /= 1024
+			i++
+		}
+		return fmt.Sprintf("%s%.1f %ciB", sign, f, units[i])
+	case "bytes/s":
+		return sign + formatPromValueUnit(f, "bytes") + "/s"
+	case "seconds":
+		if f*float64(time.Second) >= math.MaxInt64 {
+			return sign + formatPromStat(f) + "s"
+		}
+		d := time.Duration(f * float64(time.Second))
+		switch {
+		case d >= time.Minute:
+			return sign + formatDuration(d)
+		case d >= time.Second:
+			return sign + d.Round(10*time.Millisecond).String()
+		case d >= time.Millisecond:
+			return sign + d.Round(10*time.Microsecond).String()
+		case d >= time.Microsecond:
+			return sign + d.Round(10*time.Nanosecond).String()
+		}
+		return sign + d.String()
+	case "percent":
+		return sign + strconv.FormatFloat(math.Round(f*100*100)/100, 'f', -1, 64) + "%"
+	}
+	return sign + formatPromStat(f)
+}
+
+var (
+	promQuotedRe     = regexp.MustCompile(`"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|` + "`[^`]*`")
+	promIdentifierRe = regexp.MustCompile(`[a-zA-Z_:][a-zA-Z0-9_:]*`)
+	// promCounterFuncRe matches a range function applied to a metric, capturing
+	// the function and the metric name
+	promCounterFuncRe = regexp.MustCompile(`\b(rate|irate|increase|deriv)\s*\(\s*([a-zA-Z_:][a-zA-Z0-9_:]*)`)
+)
+
+// guessPromUnit infers the unit of a query's result from the base-unit suffix
+// of the metric names it uses (_bytes, _seconds, _ratio), ignoring _total,
+// _sum and _bucket suffixes and _count series. A _total counter under rate,
+// irate or deriv is per second: bytes become bytes/s and seconds (e.g. CPU
+// seconds per second) carry no unit; under increase it is a count over the
+// range and carries none either. Returns "none" when no metric carries a unit
+// or the metrics disagree.
+func guessPromUnit(query string) string {
+	query = promQuotedRe.ReplaceAllString(query, "")
+	counterFuncs := map[int]string{} // offset of a metric → range function applied to it
+	for _, m := range promCounterFuncRe.FindAllStringSubmatchIndex(query, -1) {
+		counterFuncs[m[4]] = query[m[2]:m[3]]
+	}
+
+	unit := ""
+	for _, loc := range promIdentifierRe.FindAllStringIndex(query, -1) {
+		id := query[loc[0]:loc[1]]
+		if strings.HasSuffix(id, "_count") {
+			continue
+		}
+		fn, wrapped := counterFuncs[loc[0]]
+		counter := wrapped && strings.HasSuffix(id, "_total")
+		for _, suffix := range []string{"_total", "_sum", "_bucket"} {
+			id = strings.TrimSuffix(id, suffix)
+		}
+		var u string
+		switch {
+		case strings.HasSuffix(id, "_bytes"):
+			u = "bytes"
+		case strings.HasSuffix(id, "_seconds"):
+			u = "seconds"
+		case strings.HasSuffix(id, "_ratio"):
+			u = "percent"
+		default:
+			continue
+		}
+		if counter {
+			if u != "bytes" || fn == "increase" {
+				return "none"
+			}
+			u = "bytes/s"
+		}
+		if unit != "" && unit != u {
+			return "none"
+		}
+		unit = u
+	}
+	if unit == "" {
+		return "none"
+	}
+	return unit
+}
+
+// promDisplayUnit resolves --humanize and --unit: an explicit --unit wins
+// (and implies --humanize), otherwise --humanize guesses the unit from the
+// query. Returns "" when values are printed as is.
+func promDisplayUnit(cmd *cobra.Command, query string) (string, error) {
+	humanize, _ := cmd.Flags().GetBool("humanize")
+	unit, _ := cmd.Flags().GetString("unit")
+	if unit != "" {
+		for _, u := range promUnits {
+			if unit == u {
+				return unit, nil
+			}
+		}
+		return "", fmt.Errorf("invalid --unit %q (use %s)", unit, strings.Join(promUnits, ", "))
+	}
+	if humanize {
+		return guessPromUnit(query), nil
+	}
+	return "", nil
+}
+
+// writePromSamplesCSV writes an instant vector as CSV: a value column followed by
+// one column per label name across all series (__name__ first, the rest
+// sorted). Series lacking a label get an empty cell; NaN and ±Inf are written
+// as NaN, +Inf and -Inf.
+func writePromSamplesCSV(w io.Writer, samples []prometheus.VectorSample) error {
+	seen := make(map[string]bool)
+	var labels []string
+	hasName := false
+	for _, s := range samples {
+		for k := range s.Metric {
+			if k == "__name__" {
+				hasName = true
+				continue
+			}
+			if !seen[k] {
+				seen[k] = true
+				labels = append(labels, k)
+			}
+		}
+	}
+	sort.Strings(labels)
+	if hasName {
+		labels = append([]string{"__name__"}, labels...)
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Write(append([]string{"value"}, labels...))
+	for _, s := range samples {
+		value := ""
+		if s.Value[1] != nil {
+			value = formatSampleValue(s.Value[1])
+		}
+		row := []string{value}
+		for _, k := range labels {
+			row = append(row, s.Metric[k])
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
 // autoStep computes a step duration that produces ~250 data points
 func autoStep(start, end time.Time) time.Duration {
 	span := end.Sub(start)
@@ -227,12 +429,29 @@ Examples:
   dex prom query 'up'
   dex prom query 'rate(http_requests_total[5m])'
   dex prom query 'up' --time "2026-02-04 15:00"
-  dex prom query 'up' -o json`,
+  dex prom query 'up' -o json
+  dex prom query 'up' -o csv > up.csv     # value column + one column per label
+  dex prom query 'sum by (job) (rate(http_requests_total[1h]))' --query-timeout 10s
+  dex prom query 'up' --raw-url           # Print the request URL without executing
+  dex prom query 'up' --debug             # Print the request URL to stderr, then execute
+  dex prom query 'node_memory_MemAvailable_bytes' --humanize   # 1.0 GiB
+  dex prom query 'rate(errors_total[5m]) / rate(requests_total[5m])' --unit percent
+
+With --humanize, values are shown in a readable unit guessed from the metric
+name suffix (_bytes, _seconds, _ratio); --unit bytes|seconds|percent|none sets
+it explicitly. JSON and CSV output always keep the raw numbers.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		urlFlag, _ := cmd.Flags().GetString("url")
 		timeStr, _ := cmd.Flags().GetString("time")
 		output, _ := cmd.Flags().GetString("output")
+		timeoutStr, _ := cmd.Flags().GetString("query-timeout")
+
+		unit, err := promDisplayUnit(cmd, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 
 		promURL, err := getPrometheusURL(urlFlag)
 		if err != nil {
@@ -249,10 +468,22 @@ Examples:
 			}
 		}
 
+		queryTimeout, err := parsePromQueryTimeout(timeoutStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
 		client := prometheus.NewClient(promURL)
+		client.SetQueryTimeout(queryTimeout)
+		if rawURL, _ := cmd.Flags().GetBool("raw-url"); rawURL {
+			fmt.Println(client.QueryURL(args[0], evalTime))
+			return
+		}
+		client.Debug, _ = cmd.Flags().GetBool("debug")
 		samples, err := client.Query(args[0], evalTime)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Query failed: %v\n", err)
+			printPromQueryError(err, queryTimeout)
 			os.Exit(1)
 		}
 
@@ -262,32 +493,83 @@ Examples:
 			enc.Encode(samples)
 			return
 		}
-
-		if len(samples) == 0 {
-			promDimColor.Println("No results.")
+		if output == "csv" {
+			if err := writePromSamplesCSV(os.Stdout, samples); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write CSV: %v\n", err)
+				os.Exit(1)
+			}
 			return
 		}
 
-		for _, s := range samples {
-			name := s.Metric["__name__"]
-			if name == "" {
-				name = "{}"
-			}
-			promHeaderColor.Print(name)
-			labels := formatMetricLabels(s.Metric)
-			if labels != "{}" {
-				promLabelColor.Print(labels)
-			}
-			fmt.Println()
+		printPromSamples(samples, unit)
+	},
+}
 
-			if len(s.Value) == 2 {
-				promValueColor.Printf("  %s\n", formatSampleValue(s.Value[1]))
-			}
-		}
+// printPromSamples prints an instant vector, one series name and value per
+// entry, with values in unit (see formatSampleValueUnit)
+func printPromSamples(samples []prometheus.VectorSample, unit string) {
+	if len(samples) == 0 {
+		promDimColor.Println("No results.")
+		return
+	}
 
+	for _, s := range samples {
+		name := s.Metric["__name__"]
+		if name == "" {
+			name = "{}"
+		}
+		promHeaderColor.Print(name)
+		labels := formatMetricLabels(s.Metric)
+		if labels != "{}" {
+			promLabelColor.Print(labels)
+		}
 		fmt.Println()
-		promDimColor.Printf("(%d series)\n", len(samples))
-	},
+
+		if len(s.Value) == 2 {
+			promValueColor.Printf("  %s\n", formatSampleValueUnit(s.Value[1], unit))
+		}
+	}
+
+	fmt.Println()
+	promDimColor.Printf("(%d series)\n", len(samples))
+}
+
+// promRangeWithInstant is the JSON shape of query-range --at: the range result
+// plus the instant vector at the chosen time
+type promRangeWithInstant struct {
+	Range   any                       `json:"range"`
+	At      time.Time                 `json:"at"`
+	Instant []prometheus.VectorSample `json:"instant"`
+}
+
+// parsePromQueryTimeout parses the --query-timeout flag. Empty means no timeout.
+func parsePromQueryTimeout(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	d, err := parseLokiDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid --query-timeout value: %w", err)
+	}
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid --query-timeout value: must be positive")
+	}
+	return d, nil
+}
+
+// printPromQueryError prints a query failure, calling out timeouts explicitly.
+func printPromQueryError(err error, timeout time.Duration) {
+	if !prometheus.IsTimeout(err) {
+		fmt.Fprintf(os.Stderr, "Query failed: %v\n", err)
+		return
+	}
+	if timeout > 0 {
+		promErrorColor.Fprintf(os.Stderr, "Query timed out after %s\n", timeout)
+	} else {
+		promErrorColor.Fprintf(os.Stderr, "Query timed out\n")
+	}
+	fmt.Fprintf(os.Stderr, "  %v\n", err)
+	promDimColor.Fprintln(os.Stderr, "  Narrow the selector, shorten the range, increase --step, or raise --query-timeout.")
 }
 
 // ── prom query-range ────────────────────────────────────────────────────────
@@ -301,7 +583,30 @@ Examples:
   dex prom query-range 'rate(http_requests_total[5m])' --since 1h
   dex prom query-range 'up' --since 30m --step 15s
   dex prom query-range 'up' --since "2026-02-04 15:00" --until "2026-02-04 16:00"
-  dex prom query-range 'up' -o json`,
+  dex prom query-range 'up' -o json
+  dex prom query-range 'rate(http_requests_total[5m])' --since 7d --query-timeout 30s
+  dex prom query-range 'up' --since 1h --raw-url   # Print the request URL without executing
+  dex prom query-range 'rate(http_requests_total[5m])' --since 6h --aggregate
+  dex prom query-range 'rate(http_requests_total[5m])' --since 6h --at "2026-02-04 15:42"
+  dex prom query-range 'up' --since 1h --at end
+  dex prom query-range 'process_resident_memory_bytes' --since 1h --aggregate --humanize
+  dex prom query-range 'rate(http_requests_total[5m])' --since 6h --graph
+
+With --aggregate, one summary row per series (min, max, avg, last, p95 of the
+returned samples) is printed instead of every sample. NaN and ±Inf samples are
+left out of the summary.
+
+With --at, the same query is also evaluated as an instant query at that time
+(a timestamp or duration within the range, or "end" for the range end) and the
+exact values are printed after the range. With -o json the output becomes an
+object with "range", "at" and "instant".
+
+With --graph, each series is drawn as a one-line sparkline (▁▂▃▄▅▆▇█) scaled
+to its own min and max, which are printed next to it. Steps without a sample
+are blank; long ranges average several steps per character.
+
+--humanize and --unit format values as for "dex prom query"; JSON output keeps
+the raw numbers.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		urlFlag, _ := cmd.Flags().GetString("url")
@@ -310,6 +615,21 @@ Examples:
 		stepStr, _ := cmd.Flags().GetString("step")
 		utcFlag, _ := cmd.Flags().GetBool("utc")
 		output, _ := cmd.Flags().GetString("output")
+		timeoutStr, _ := cmd.Flags().GetString("query-timeout")
+		aggregate, _ := cmd.Flags().GetBool("aggregate")
+		graph, _ := cmd.Flags().GetBool("graph")
+		atStr, _ := cmd.Flags().GetString("at")
+
+		if graph && aggregate {
+			fmt.Fprintf(os.Stderr, "Cannot use --graph together with --aggregate\n")
+			os.Exit(1)
+		}
+
+		unit, err := promDisplayUnit(cmd, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 
 		promURL, err := getPrometheusURL(urlFlag)
 		if err != nil {
@@ -322,13 +642,14 @@ Examples:
 			loc = time.UTC
 		}
 
-		start, err := parseTimeValueInLocation(sinceStr, loc)
+		now := time.Now()
+		start, err := parseTimeValueRelative(sinceStr, loc, now)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid --since value: %v\n", err)
 			os.Exit(1)
 		}
 
-		end, err := parseTimeValueInLocation(untilStr, loc)
+		end, err := parseTimeValueRelative(untilStr, loc, now)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Invalid --until value: %v\n", err)
 			os.Exit(1)
@@ -340,6 +661,22 @@ Examples:
 			os.Exit(1)
 		}
 
+		var at time.Time
+		if atStr == "end" {
+			at = end
+		} else if atStr != "" {
+			at, err = parseTimeValueRelative(atStr, loc, now)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --at value: %v\n", err)
+				os.Exit(1)
+			}
+			if at.Before(start) || at.After(end) {
+				fmt.Fprintf(os.Stderr, "Invalid --at value: %s is outside the range %s → %s\n",
+					at.Format("2006-01-02 15:04:05"), start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
+				os.Exit(1)
+			}
+		}
+
 		var step time.Duration
 		if stepStr != "" {
 			step, err = parseLokiDuration(stepStr)
@@ -351,17 +688,60 @@ Examples:
 			step = autoStep(start, end)
 		}
 
+		queryTimeout, err := parsePromQueryTimeout(timeoutStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
 		client := prometheus.NewClient(promURL)
+		client.SetQueryTimeout(queryTimeout)
+		if rawURL, _ := cmd.Flags().GetBool("raw-url"); rawURL {
+			fmt.Println(client.QueryRangeURL(args[0], start, end, step))
+			if !at.IsZero() {
+				fmt.Println(client.QueryURL(args[0], at))
+			}
+			return
+		}
+		client.Debug, _ = cmd.Flags().GetBool("debug")
 		series, err := client.QueryRange(args[0], start, end, step)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "Query failed: %v\n", err)
+			printPromQueryError(err, queryTimeout)
 			os.Exit(1)
 		}
 
+		var instant []prometheus.VectorSample
+		if !at.IsZero() {
+			instant, err = client.Query(args[0], at)
+			if err != nil {
+				printPromQueryError(err, queryTimeout)
+				os.Exit(1)
+			}
+		}
+
+		var rangeResult any = series
+		if aggregate {
+			summaries := make([]promSeriesSummary, 0, len(series))
+			for _, s := range series {
+				summaries = append(summaries, summarizePromSeries(s))
+			}
+			rangeResult = summaries
+		}
+
 		if output == "json" {
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
-			enc.Encode(series)
+			if !at.IsZero() {
+				enc.Encode(promRangeWithInstant{Range: rangeResult, At: at, Instant: instant})
+			} else {
+				enc.Encode(rangeResult)
+			}
+			return
+		}
+
+		if aggregate {
+			printPromSeriesSummaries(rangeResult.([]promSeriesSummary), unit)
+			printPromInstantAt(instant, at, utcFlag, unit)
 			return
 		}
 
@@ -370,6 +750,12 @@ Examples:
 			return
 		}
 
+		if graph {
+			printPromSparklines(series, start, end, step, unit)
+			printPromInstantAt(instant, at, utcFlag, unit)
+			return
+		}
+
 		for i, s := range series {
 			name := s.Metric["__name__"]
 			if name == "" {
@@ -397,7 +783,7 @@ Examples:
 					ts = ts.UTC()
 				}
 				promDimColor.Printf("  %s  ", ts.Format("15:04:05"))
-				promValueColor.Printf("%s\n", formatSampleValue(v[1]))
+				promValueColor.Printf("%s\n", formatSampleValueUnit(v[1], unit))
 			}
 
 			if i < len(series)-1 {
@@ -407,9 +793,691 @@ Examples:
 
 		fmt.Println()
 		promDimColor.Printf("(%d series)\n", len(series))
+		printPromInstantAt(instant, at, utcFlag, unit)
+	},
+}
+
+// printPromSparklines prints the --graph view of query-range: per series its
+// name and labels, then the sparkline with min and max.
+func printPromSparklines(series []prometheus.MatrixSeries, start, end time.Time, step time.Duration, unit string) {
+	stat := formatPromStat
+	if unit != "" {
+		stat = func(v float64) string { return formatPromValueUnit(v, unit) }
+	}
+
+	for _, s := range series {
+		promHeaderColor.Print(s.Metric["__name__"])
+		if labels := formatMetricLabels(s.Metric); labels != "{}" || s.Metric["__name__"] == "" {
+			promLabelColor.Print(labels)
+		}
+		fmt.Println()
+
+		line, lo, hi, ok := promSparkline(s, start, end, step)
+		if !ok {
+			promDimColor.Println("  (no finite samples)")
+			continue
+		}
+		promValueColor.Printf("  %s", line)
+		promDimColor.Printf("  min %s  max %s\n", stat(lo), stat(hi))
+	}
+
+	fmt.Println()
+	promDimColor.Printf("(%d series, step %s)\n", len(series), step)
+}
+
+// printPromInstantAt prints the --at section of query-range; a zero at means
+// --at was not given
+func printPromInstantAt(samples []prometheus.VectorSample, at time.Time, utc bool, unit string) {
+	if at.IsZero() {
+		return
+	}
+	if utc {
+		at = at.UTC()
+	}
+	fmt.Println()
+	promHeaderColor.Printf("Instant values at %s\n", at.Format("2006-01-02 15:04:05"))
+	printPromSamples(samples, unit)
+}
+
+// promSeriesSummary is the client-side summary of one range series.
+type promSeriesSummary struct {
+	Metric  map[string]string `json:"metric"`
+	Samples int               `json:"samples"` // finite samples the stats are computed from
+	Min     float64           `json:"min"`
+	Max     float64           `json:"max"`
+	Avg     float64           `json:"avg"`
+	Last    float64           `json:"last"`
+	P95     float64           `json:"p95"`
+}
+
+// summarizePromSeries computes min, max, avg, last and p95 (nearest rank) over
+// the finite samples of a range series. NaN, ±Inf and unparsable samples are
+// skipped; a series without finite samples has Samples == 0 and zero stats.
+func summarizePromSeries(s prometheus.MatrixSeries) promSeriesSummary {
+	sum := promSeriesSummary{Metric: s.Metric}
+	vals := make([]float64, 0, len(s.Values))
+	for _, v := range s.Values {
+		f, err := strconv.ParseFloat(fmt.Sprint(v[1]), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		vals = append(vals, f)
+	}
+	if len(vals) == 0 {
+		return sum
+	}
+
+	sum.Samples = len(vals)
+	sum.Last = vals[len(vals)-1]
+	total := 0.0
+	for _, f := range vals {
+		total += f
+	}
+	sum.Avg = total / float64(len(vals))
+
+	sort.Float64s(vals)
+	sum.Min = vals[0]
+	sum.Max = vals[len(vals)-1]
+	rank := int(math.Ceil(0.95 * float64(len(vals))))
+	sum.P95 = vals[max(rank-1, 0)]
+	return sum
+}
+
+// printPromSeriesSummaries prints the --aggregate table of query-range, with
+// values in unit if set.
+func printPromSeriesSummaries(summaries []promSeriesSummary, unit string) {
+	stat := formatPromStat
+	if unit != "" {
+		stat = func(v float64) string { return formatPromValueUnit(v, unit) }
+	}
+
+	if len(summaries) == 0 {
+		promDimColor.Println("No results.")
+		return
+	}
+
+	promHeaderColor.Printf("%12s %12s %12s %12s %12s  %s\n", "MIN", "MAX", "AVG", "LAST", "P95", "SERIES")
+	for _, s := range summaries {
+		if s.Samples == 0 {
+			promDimColor.Printf("%12s %12s %12s %12s %12s  ", "-", "-", "-", "-", "-")
+		} else {
+			promValueColor.Printf("%12s %12s %12s %12s %12s  ",
+				stat(s.Min), stat(s.Max), stat(s.Avg), stat(s.Last), stat(s.P95))
+		}
+		promHeaderColor.Print(s.Metric["__name__"])
+		if labels := formatMetricLabels(s.Metric); labels != "{}" || s.Metric["__name__"] == "" {
+			promLabelColor.Print(labels)
+		}
+		promDimColor.Printf(" (%d samples)\n", s.Samples)
+	}
+
+	fmt.Println()
+	promDimColor.Printf("(%d series)\n", len(summaries))
+}
+
+// formatPromStat formats a summary value rounded to three decimals.
+func formatPromStat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1000)/1000, 'f', -1, 64)
+}
+
+// promSparklineWidth caps the columns of a --graph sparkline; longer ranges
+// average several steps per column.
+const promSparklineWidth = 100
+
+var promSparkRunes = []rune("▁▂▃▄▅▆▇█")
+
+// promSparkRune maps v to one of eight block runes scaled to [lo, hi]. A flat
+// series (lo == hi) renders at mid height.
+func promSparkRune(v, lo, hi float64) rune {
+	if hi <= lo {
+		return promSparkRunes[len(promSparkRunes)/2-1]
+	}
+	i := int(math.Round((v - lo) / (hi - lo) * float64(len(promSparkRunes)-1)))
+	return promSparkRunes[min(max(i, 0), len(promSparkRunes)-1)]
+}
+
+// promSparkline renders a range series as one rune per step from start to end,
+// using at most promSparklineWidth columns. Steps sharing a column are
+// averaged; columns without a finite sample are spaces. It also returns the
+// min and max of the finite samples, with ok false when there are none.
+func promSparkline(s prometheus.MatrixSeries, start, end time.Time, step time.Duration) (line string, lo, hi float64, ok bool) {
+	slots := 1
+	if step > 0 {
+		slots = int(end.Sub(start)/step) + 1
+	}
+	cols := min(slots, promSparklineWidth)
+	sums := make([]float64, cols)
+	counts := make([]int, cols)
+
+	lo, hi = math.Inf(1), math.Inf(-1)
+	for _, v := range s.Values {
+		ts, isNum := v[0].(float64)
+		if !isNum {
+			continue
+		}
+		f, err := strconv.ParseFloat(fmt.Sprint(v[1]), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		slot := 0
+		if step > 0 {
+			slot = int(math.Round((ts - float64(start.Unix())) / step.Seconds()))
+		}
+		if slot < 0 || slot >= slots {
+			continue
+		}
+		col := slot * cols / slots
+		sums[col] += f
+		counts[col]++
+		lo, hi = math.Min(lo, f), math.Max(hi, f)
+	}
+	if math.IsInf(lo, 1) {
+		return strings.Repeat(" ", cols), 0, 0, false
+	}
+
+	var sb strings.Builder
+	for i := range cols {
+		if counts[i] == 0 {
+			sb.WriteByte(' ')
+			continue
+		}
+		sb.WriteRune(promSparkRune(sums[i]/float64(counts[i]), lo, hi))
+	}
+	return sb.String(), lo, hi, true
+}
+
+// ── prom tally ──────────────────────────────────────────────────────────────
+
+var promTallyCmd = &cobra.Command{
+	Use:   "tally <selector>",
+	Short: "Count series per value of a label",
+	Long: `Count the series matching a selector per value of one label, like
+count by (label) (selector), and show the counts as a sorted bar chart.
+
+The count runs server-side, so only one row per label value is transferred.
+Series without the label are counted under "(none)". Use it to find
+high-cardinality metrics and to see how series are distributed.
+
+Examples:
+  dex prom tally kube_pod_info --by node          # Pods per node
+  dex prom tally 'up{job="api"}' --by instance
+  dex prom tally '{__name__=~"http_.*"}' --by __name__ --top 10
+  dex prom tally kube_pod_info --by namespace -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		by, _ := cmd.Flags().GetString("by")
+		top, _ := cmd.Flags().GetInt("top")
+		timeStr, _ := cmd.Flags().GetString("time")
+		output, _ := cmd.Flags().GetString("output")
+		timeoutStr, _ := cmd.Flags().GetString("query-timeout")
+
+		promURL, err := getPrometheusURL(urlFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var evalTime time.Time
+		if timeStr != "" {
+			evalTime, err = parseTimeValueInLocation(timeStr, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --time value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		queryTimeout, err := parsePromQueryTimeout(timeoutStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		query := fmt.Sprintf("count by (%s) (%s)", by, args[0])
+		client := prometheus.NewClient(promURL)
+		client.SetQueryTimeout(queryTimeout)
+		samples, err := client.Query(query, evalTime)
+		if err != nil {
+			printPromQueryError(err, queryTimeout)
+			os.Exit(1)
+		}
+
+		rows, total := tallyPromSamples(samples, by)
+
+		if output == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(struct {
+				Query  string         `json:"query"`
+				Label  string         `json:"label"`
+				Total  int            `json:"total"`
+				Values []promTallyRow `json:"values"`
+			}{query, by, total, rows})
+			return
+		}
+
+		if len(rows) == 0 {
+			promDimColor.Println("No matching series.")
+			return
+		}
+
+		shown := rows
+		if top > 0 && len(shown) > top {
+			shown = shown[:top]
+		}
+
+		width := len("(none)")
+		for _, r := range shown {
+			width = max(width, len(r.Value))
+		}
+		width = min(width, 48)
+
+		promHeaderColor.Printf("%s\n\n", query)
+		for _, r := range shown {
+			name := r.Value
+			if name == "" {
+				name = "(none)"
+			}
+			if len(name) > width {
+				name = name[:width-3] + "..."
+			}
+			barLen := int(math.Round(float64(r.Count) / float64(rows[0].Count) * 30))
+			if barLen < 1 {
+				barLen = 1
+			}
+			promLabelColor.Printf("  %-*s ", width, name)
+			promSuccessColor.Printf("%-30s", strings.Repeat("█", barLen))
+			promValueColor.Printf(" %6d", r.Count)
+			promDimColor.Printf("  %5.1f%%\n", float64(r.Count)/float64(total)*100)
+		}
+
+		fmt.Println()
+		if len(shown) < len(rows) {
+			promDimColor.Printf("(top %d of %d values, %d series)\n", len(shown), len(rows), total)
+		} else {
+			promDimColor.Printf("(%d values, %d series)\n", len(rows), total)
+		}
+	},
+}
+
+// promTallyRow is the number of series for one value of the tallied label.
+type promTallyRow struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// tallyPromSamples turns the result of a count by (label) query into rows
+// sorted by count (highest first, then by value), plus the total series count.
+func tallyPromSamples(samples []prometheus.VectorSample, label string) ([]promTallyRow, int) {
+	rows := make([]promTallyRow, 0, len(samples))
+	total := 0
+	for _, s := range samples {
+		n, err := strconv.ParseFloat(fmt.Sprint(s.Value[1]), 64)
+		if err != nil {
+			continue
+		}
+		rows = append(rows, promTallyRow{Value: s.Metric[label], Count: int(n)})
+		total += int(n)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Count != rows[j].Count {
+			return rows[i].Count > rows[j].Count
+		}
+		return rows[i].Value < rows[j].Value
+	})
+	return rows, total
+}
+
+// ── prom compare-instances ──────────────────────────────────────────────────
+
+var promCompareInstancesCmd = &cobra.Command{
+	Use:   "compare-instances <selector>",
+	Short: "Compare a metric across instances and flag outliers",
+	Long: `Compare one metric across all instances (or another label) side by side to
+find the odd one out.
+
+Runs <agg> by (label) (<selector>) as an instant query, so each instance gets a
+single value even when it exports several series, and prints the values sorted
+from highest to lowest with their distance from the mean in standard deviations.
+Values more than 2σ from the mean are flagged as outliers. With few instances
+no value can reach 2σ (at least 6 are needed), so check the z column as well.
+
+Examples:
+  dex prom compare-instances 'rate(http_requests_total{job="api"}[5m])'
+  dex prom compare-instances process_resident_memory_bytes --by pod
+  dex prom compare-instances 'rate(errors_total[5m])' --agg sum -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		by, _ := cmd.Flags().GetString("by")
+		agg, _ := cmd.Flags().GetString("agg")
+		timeStr, _ := cmd.Flags().GetString("time")
+		output, _ := cmd.Flags().GetString("output")
+		timeoutStr, _ := cmd.Flags().GetString("query-timeout")
+
+		switch agg {
+		case "avg", "sum", "min", "max":
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid --agg %q (use avg, sum, min or max)\n", agg)
+			os.Exit(1)
+		}
+
+		promURL, err := getPrometheusURL(urlFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var evalTime time.Time
+		if timeStr != "" {
+			evalTime, err = parseTimeValueInLocation(timeStr, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --time value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		queryTimeout, err := parsePromQueryTimeout(timeoutStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		query := fmt.Sprintf("%s by (%s) (%s)", agg, by, args[0])
+		client := prometheus.NewClient(promURL)
+		client.SetQueryTimeout(queryTimeout)
+		samples, err := client.Query(query, evalTime)
+		if err != nil {
+			printPromQueryError(err, queryTimeout)
+			os.Exit(1)
+		}
+
+		cmp := comparePromSamples(samples, by)
+
+		if output == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(struct {
+				Query string `json:"query"`
+				Label string `json:"label"`
+				promComparison
+			}{query, by, cmp})
+			return
+		}
+
+		if len(cmp.Rows) == 0 {
+			promDimColor.Println("No matching series.")
+			return
+		}
+
+		width := len(strings.ToUpper(by))
+		for _, r := range cmp.Rows {
+			width = max(width, len(r.Label))
+		}
+		width = min(width, 48)
+
+		promHeaderColor.Printf("%s\n\n", query)
+		promDimColor.Printf("  %-*s %14s %7s\n", width, strings.ToUpper(by), "VALUE", "Z")
+		outliers := 0
+		for _, r := range cmp.Rows {
+			name := r.Label
+			if name == "" {
+				name = "(none)"
+			}
+			if len(name) > width {
+				name = name[:width-3] + "..."
+			}
+			promLabelColor.Printf("  %-*s ", width, name)
+			promValueColor.Printf("%14s", formatPromStat(r.Value))
+			if r.Outlier {
+				outliers++
+				promWarnColor.Printf(" %+7.2f  outlier\n", r.ZScore)
+			} else {
+				promDimColor.Printf(" %+7.2f\n", r.ZScore)
+			}
+		}
+
+		fmt.Println()
+		promDimColor.Printf("mean %s, stddev %s (%d values, %d outliers beyond 2σ)\n",
+			formatPromStat(cmp.Mean), formatPromStat(cmp.StdDev), len(cmp.Rows), outliers)
+	},
+}
+
+// promCompareRow is the value of one label value (usually an instance) and its
+// distance from the mean in standard deviations.
+type promCompareRow struct {
+	Label   string  `json:"label_value"`
+	Value   float64 `json:"value"`
+	ZScore  float64 `json:"z_score"`
+	Outlier bool    `json:"outlier"`
+}
+
+// promComparison is the result of compare-instances.
+type promComparison struct {
+	Mean   float64          `json:"mean"`
+	StdDev float64          `json:"stddev"`
+	Rows   []promCompareRow `json:"values"`
+}
+
+// comparePromSamples turns an aggregated instant vector into rows sorted by
+// value (highest first, then by label), with the population mean and standard
+// deviation. Rows more than 2σ from the mean are outliers; NaN, ±Inf and
+// unparsable samples are skipped.
+func comparePromSamples(samples []prometheus.VectorSample, label string) promComparison {
+	cmp := promComparison{Rows: make([]promCompareRow, 0, len(samples))}
+	for _, s := range samples {
+		f, err := strconv.ParseFloat(fmt.Sprint(s.Value[1]), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			continue
+		}
+		cmp.Rows = append(cmp.Rows, promCompareRow{Label: s.Metric[label], Value: f})
+	}
+	if len(cmp.Rows) == 0 {
+		return cmp
+	}
+
+	total := 0.0
+	for _, r := range cmp.Rows {
+		total += r.Value
+	}
+	cmp.Mean = total / float64(len(cmp.Rows))
+	variance := 0.0
+	for _, r := range cmp.Rows {
+		variance += (r.Value - cmp.Mean) * (r.Value - cmp.Mean)
+	}
+	cmp.StdDev = math.Sqrt(variance / float64(len(cmp.Rows)))
+
+	if cmp.StdDev > 0 {
+		for i := range cmp.Rows {
+			cmp.Rows[i].ZScore = (cmp.Rows[i].Value - cmp.Mean) / cmp.StdDev
+			cmp.Rows[i].Outlier = math.Abs(cmp.Rows[i].ZScore) > 2
+		}
+	}
+
+	sort.Slice(cmp.Rows, func(i, j int) bool {
+		if cmp.Rows[i].Value != cmp.Rows[j].Value {
+			return cmp.Rows[i].Value > cmp.Rows[j].Value
+		}
+		return cmp.Rows[i].Label < cmp.Rows[j].Label
+	})
+	return cmp
+}
+
+// ── prom top ────────────────────────────────────────────────────────────────
+
+var promTopCmd = &cobra.Command{
+	Use:   "top <selector>",
+	Short: "Rank series by their current value",
+	Long: `Evaluate a selector or expression as an instant query and rank the
+resulting series by value, largest first, like topk without writing it.
+
+The full result is fetched and sorted client-side. Use --bottom for the
+smallest values first. Series whose value is NaN or not a number are sorted
+last, without a rank, and counted in a note below the table.
+
+Examples:
+  dex prom top container_memory_working_set_bytes      # Top 10 series by memory
+  dex prom top 'sum by (pod) (rate(container_cpu_usage_seconds_total[5m]))' -n 5
+  dex prom top 'up' --bottom
+  dex prom top node_filesystem_avail_bytes --bottom -n 3 -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		limit, _ := cmd.Flags().GetInt("limit")
+		bottom, _ := cmd.Flags().GetBool("bottom")
+		timeStr, _ := cmd.Flags().GetString("time")
+		output, _ := cmd.Flags().GetString("output")
+		timeoutStr, _ := cmd.Flags().GetString("query-timeout")
+
+		promURL, err := getPrometheusURL(urlFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var evalTime time.Time
+		if timeStr != "" {
+			evalTime, err = parseTimeValueInLocation(timeStr, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --time value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		queryTimeout, err := parsePromQueryTimeout(timeoutStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		client := prometheus.NewClient(promURL)
+		client.SetQueryTimeout(queryTimeout)
+		samples, err := client.Query(args[0], evalTime)
+		if err != nil {
+			printPromQueryError(err, queryTimeout)
+			os.Exit(1)
+		}
+
+		rows, unranked := rankPromSamples(samples, bottom)
+		shown := rows
+		if limit > 0 && len(shown) > limit {
+			shown = shown[:limit]
+		}
+
+		if output == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(struct {
+				Query  string       `json:"query"`
+				Bottom bool         `json:"bottom"`
+				Total  int          `json:"total"`
+				Series []promTopRow `json:"series"`
+			}{args[0], bottom, len(rows), shown})
+			return
+		}
+
+		if len(rows) == 0 {
+			promDimColor.Println("No results.")
+			return
+		}
+
+		width := len("VALUE")
+		for _, r := range shown {
+			width = max(width, len(r.Value))
+		}
+
+		promHeaderColor.Printf("%s\n\n", args[0])
+		promDimColor.Printf("  %4s  %*s  %s\n", "#", width, "VALUE", "SERIES")
+		for _, r := range shown {
+			if r.Rank > 0 {
+				fmt.Printf("  %4d  ", r.Rank)
+			} else {
+				promDimColor.Printf("  %4s  ", "-")
+			}
+			promValueColor.Printf("%*s  ", width, r.Value)
+			promHeaderColor.Print(r.Metric["__name__"])
+			if labels := formatMetricLabels(r.Metric); labels != "{}" || r.Metric["__name__"] == "" {
+				promLabelColor.Print(labels)
+			}
+			fmt.Println()
+		}
+
+		fmt.Println()
+		order := "top"
+		if bottom {
+			order = "bottom"
+		}
+		if len(shown) < len(rows) {
+			promDimColor.Printf("(%s %d of %d series)\n", order, len(shown), len(rows))
+		} else {
+			promDimColor.Printf("(%d series)\n", len(rows))
+		}
+		if unranked > 0 {
+			promDimColor.Printf("(%d series with NaN or non-numeric values sorted last)\n", unranked)
+		}
 	},
 }
 
+// promTopRow is one series ranked by prom top. Value keeps the sample's text
+// so NaN and ±Inf survive JSON encoding; Rank is 0 for series without a
+// numeric value.
+type promTopRow struct {
+	Rank   int               `json:"rank,omitempty"`
+	Metric map[string]string `json:"metric"`
+	Value  string            `json:"value"`
+}
+
+// rankPromSamples sorts an instant vector by value, largest first (smallest
+// first with bottom), with ±Inf ordered as numbers. Series whose value is NaN
+// or unparsable go last, unranked, and are counted in unranked. Ties are
+// ordered by series labels.
+func rankPromSamples(samples []prometheus.VectorSample, bottom bool) (rows []promTopRow, unranked int) {
+	type ranked struct {
+		row   promTopRow
+		value float64
+		ok    bool
+		key   string
+	}
+	items := make([]ranked, 0, len(samples))
+	for _, s := range samples {
+		str := formatSampleValue(s.Value[1])
+		f, err := strconv.ParseFloat(str, 64)
+		ok := err == nil && !math.IsNaN(f)
+		if !ok {
+			unranked++
+		}
+		items = append(items, ranked{
+			row:   promTopRow{Metric: s.Metric, Value: str},
+			value: f,
+			ok:    ok,
+			key:   s.Metric["__name__"] + formatMetricLabels(s.Metric),
+		})
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		a, b := items[i], items[j]
+		if a.ok != b.ok {
+			return a.ok
+		}
+		if a.ok && a.value != b.value {
+			if bottom {
+				return a.value < b.value
+			}
+			return a.value > b.value
+		}
+		return a.key < b.key
+	})
+
+	rows = make([]promTopRow, len(items))
+	for i, it := range items {
+		rows[i] = it.row
+		if it.ok {
+			rows[i].Rank = i + 1
+		}
+	}
+	return rows, unranked
+}
+
 // ── prom labels ─────────────────────────────────────────────────────────────
 
 var promLabelsCmd = &cobra.Command{
@@ -450,7 +1518,7 @@ Examples:
 	},
 	Run: func(cmd *cobra.Command, args []string) {
 		urlFlag, _ := cmd.Flags().GetString("url")
-		match, _ := cmd.Flags().GetStringSlice("match")
+		match, _ := cmd.Flags().GetStringArray("match")
 
 		promURL, err := getPrometheusURL(urlFlag)
 		if err != nil {
@@ -497,20 +1565,123 @@ Examples:
 				return
 			}
 
-			line := strings.Repeat("─", 50)
-			fmt.Println()
-			promHeaderColor.Printf("  Values for '%s' (%d)\n", label, len(values))
-			fmt.Println("  " + line)
-			fmt.Println()
+			line := strings.Repeat("─", 50)
+			fmt.Println()
+			promHeaderColor.Printf("  Values for '%s' (%d)\n", label, len(values))
+			fmt.Println("  " + line)
+			fmt.Println()
+
+			for _, value := range values {
+				promLabelColor.Printf("  %s\n", value)
+			}
+			fmt.Println()
+		}
+	},
+}
+
+// ── prom series ─────────────────────────────────────────────────────────────
+
+var promSeriesCmd = &cobra.Command{
+	Use:   "series",
+	Short: "List series matching selectors",
+	Long: `List the time series matching one or more series selectors, as
+name{labels}, to see what exists before writing a query.
+
+Selectors are given with -m/--match (repeatable); a series is listed if it
+matches any of them. --since limits the search to series with samples in that
+window; without it Prometheus searches its whole retention, which can be slow
+on large servers. Output is sorted and capped at --limit series.
+
+Examples:
+  dex prom series -m 'up'
+  dex prom series -m 'http_requests_total{job="api"}' -m 'http_errors_total{job="api"}'
+  dex prom series -m '{__name__=~"node_cpu.*"}' --since 1h --limit 20
+  dex prom series -m 'up' -o json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		matches, _ := cmd.Flags().GetStringArray("match")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		limit, _ := cmd.Flags().GetInt("limit")
+		output, _ := cmd.Flags().GetString("output")
+
+		if len(matches) == 0 {
+			fmt.Fprintf(os.Stderr, "At least one -m/--match selector is required\n")
+			os.Exit(1)
+		}
+
+		var start, end time.Time
+		if sinceStr != "" {
+			var err error
+			end = time.Now()
+			start, err = parseTimeValueRelative(sinceStr, time.Local, end)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --since value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		promURL, err := getPrometheusURL(urlFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		client := prometheus.NewClient(promURL)
+		series, err := client.Series(matches, start, end)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get series: %v\n", err)
+			os.Exit(1)
+		}
+
+		keys := make([]string, len(series))
+		for i, s := range series {
+			keys[i] = s["__name__"] + formatMetricLabels(s)
+		}
+		sort.Sort(promSeriesByKey{series, keys})
 
-			for _, value := range values {
-				promLabelColor.Printf("  %s\n", value)
+		total := len(series)
+		if limit > 0 && total > limit {
+			series, keys = series[:limit], keys[:limit]
+		}
+
+		if output == "json" {
+			if series == nil {
+				series = []map[string]string{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(series)
+		} else if total == 0 {
+			promDimColor.Println("No series found.")
+		} else {
+			for _, s := range series {
+				promHeaderColor.Print(s["__name__"])
+				promLabelColor.Println(formatMetricLabels(s))
 			}
 			fmt.Println()
+			promDimColor.Printf("(%d series)\n", len(series))
+		}
+
+		if len(series) < total {
+			promDimColor.Fprintf(os.Stderr, "Showing %d of %d series; raise --limit or narrow the selector to see more.\n", len(series), total)
 		}
 	},
 }
 
+// promSeriesByKey sorts series label sets by their name{labels} rendering
+type promSeriesByKey struct {
+	series []map[string]string
+	keys   []string
+}
+
+func (p promSeriesByKey) Len() int           { return len(p.keys) }
+func (p promSeriesByKey) Less(i, j int) bool { return p.keys[i] < p.keys[j] }
+func (p promSeriesByKey) Swap(i, j int) {
+	p.series[i], p.series[j] = p.series[j], p.series[i]
+	p.keys[i], p.keys[j] = p.keys[j], p.keys[i]
+}
+
 // ── prom targets ────────────────────────────────────────────────────────────
 
 var promTargetsCmd = &cobra.Command{
@@ -521,11 +1692,29 @@ var promTargetsCmd = &cobra.Command{
 Examples:
   dex prom targets                  # Active targets (default)
   dex prom targets --state dropped  # Dropped targets
-  dex prom targets --state any      # All targets`,
+  dex prom targets --state any      # All targets
+  dex prom targets --job node       # Only targets of one job
+  dex prom targets --down           # Only down/unknown targets
+  dex prom targets --job api --down # Broken targets of one job
+  dex prom targets --unhealthy      # Like --down, but exit 1 if any (CI/cron probe)`,
 	Run: func(cmd *cobra.Command, args []string) {
 		urlFlag, _ := cmd.Flags().GetString("url")
 		state, _ := cmd.Flags().GetString("state")
 		output, _ := cmd.Flags().GetString("output")
+		unhealthy, _ := cmd.Flags().GetBool("unhealthy")
+		down, _ := cmd.Flags().GetBool("down")
+		job, _ := cmd.Flags().GetString("job")
+		downOnly := down || unhealthy
+
+		// Dropped targets are never scraped, so they have no health to check
+		if downOnly && state != "active" {
+			flag := "--down"
+			if unhealthy {
+				flag = "--unhealthy"
+			}
+			fmt.Fprintf(os.Stderr, "%s only applies to active targets (drop --state %s)\n", flag, state)
+			os.Exit(1)
+		}
 
 		promURL, err := getPrometheusURL(urlFlag)
 		if err != nil {
@@ -540,21 +1729,46 @@ Examples:
 			os.Exit(1)
 		}
 
+		if job != "" {
+			targets = filterPromTargets(targets, job, false)
+		}
+		total := len(targets)
+		if downOnly {
+			targets = filterPromTargets(targets, "", true)
+		}
+
 		if output == "json" {
+			if targets == nil {
+				targets = []prometheus.ActiveTarget{}
+			}
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			enc.Encode(targets)
+			exitIfUnhealthyTargets(unhealthy, targets)
 			return
 		}
 
-		if len(targets) == 0 {
-			promDimColor.Println("No targets found.")
+		if total == 0 {
+			if job != "" {
+				promDimColor.Printf("No targets found for job %q.\n", job)
+			} else {
+				promDimColor.Println("No targets found.")
+			}
+			return
+		}
+
+		if downOnly && len(targets) == 0 {
+			promSuccessColor.Printf("All %d targets healthy.\n", total)
 			return
 		}
 
 		line := strings.Repeat("─", 80)
 		fmt.Println()
-		promHeaderColor.Printf("  Scrape Targets (%d)\n", len(targets))
+		if downOnly {
+			promErrorColor.Printf("  Unhealthy Targets (%d of %d)\n", len(targets), total)
+		} else {
+			promHeaderColor.Printf("  Scrape Targets (%d)\n", len(targets))
+		}
 		fmt.Println("  " + line)
 		fmt.Println()
 
@@ -595,22 +1809,82 @@ Examples:
 
 			fmt.Println()
 		}
+		exitIfUnhealthyTargets(unhealthy, targets)
 	},
 }
 
+// filterPromTargets keeps targets whose job label is job (any job if empty),
+// and with downOnly only those whose health is not "up". Dropped targets carry
+// their job in the discovered labels.
+func filterPromTargets(targets []prometheus.ActiveTarget, job string, downOnly bool) []prometheus.ActiveTarget {
+	var out []prometheus.ActiveTarget
+	for _, t := range targets {
+		if job != "" {
+			j, ok := t.Labels["job"]
+			if !ok {
+				j = t.DiscoveredLabels["job"]
+			}
+			if j != job {
+				continue
+			}
+		}
+		if downOnly && t.Health == "up" {
+			continue
+		}
+		out = append(out, t)
+	}
+	return out
+}
+
+// exitIfUnhealthyTargets exits 1 when --unhealthy found targets, so
+// 'prom targets --unhealthy' can be used as a probe in CI or cron.
+func exitIfUnhealthyTargets(unhealthy bool, targets []prometheus.ActiveTarget) {
+	if unhealthy && len(targets) > 0 {
+		os.Exit(1)
+	}
+}
+
 // ── prom alerts ─────────────────────────────────────────────────────────────
 
 var promAlertsCmd = &cobra.Command{
 	Use:   "alerts",
 	Short: "List active alerts",
-	Long: `List active Prometheus alerts.
+	Long: `List active Prometheus alerts, firing before pending, most recently
+activated first within each state.
+
+--state limits the list to firing or pending alerts, and --severity
+(repeatable, case-insensitive) to alerts whose severity label has one of the
+given values.
+
+With --history, reconstructs when alerts were firing over a time range from the
+ALERTS metric and renders a timeline per alert. If an Alertmanager URL is
+configured (--alertmanager-url or ALERTMANAGER_URL), its active alerts are used
+to add annotations and current state.
 
 Examples:
   dex prom alerts
-  dex prom alerts -o json`,
+  dex prom alerts -o json
+  dex prom alerts --state firing --severity critical
+  dex prom alerts --history                   # What fired in the last 12h
+  dex prom alerts --history --since 2d --until 1d
+  dex prom alerts --history --alertmanager-url http://localhost:9093`,
 	Run: func(cmd *cobra.Command, args []string) {
 		urlFlag, _ := cmd.Flags().GetString("url")
 		output, _ := cmd.Flags().GetString("output")
+		history, _ := cmd.Flags().GetBool("history")
+		state, _ := cmd.Flags().GetString("state")
+		severities, _ := cmd.Flags().GetStringSlice("severity")
+
+		switch state {
+		case "all", "firing", "pending":
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid --state %q (use firing, pending or all)\n", state)
+			os.Exit(1)
+		}
+		if history && (cmd.Flags().Changed("state") || len(severities) > 0) {
+			fmt.Fprintf(os.Stderr, "--state and --severity filter active alerts and cannot be used with --history\n")
+			os.Exit(1)
+		}
 
 		promURL, err := getPrometheusURL(urlFlag)
 		if err != nil {
@@ -619,13 +1893,25 @@ Examples:
 		}
 
 		client := prometheus.NewClient(promURL)
+
+		if history {
+			runPromAlertHistory(cmd, client, output)
+			return
+		}
+
 		alerts, err := client.Alerts()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to get alerts: %v\n", err)
 			os.Exit(1)
 		}
+		total := len(alerts)
+		alerts = filterPromAlerts(alerts, state, severities)
+		sortPromAlerts(alerts)
 
 		if output == "json" {
+			if alerts == nil {
+				alerts = []prometheus.Alert{}
+			}
 			enc := json.NewEncoder(os.Stdout)
 			enc.SetIndent("", "  ")
 			enc.Encode(alerts)
@@ -633,13 +1919,21 @@ Examples:
 		}
 
 		if len(alerts) == 0 {
-			promSuccessColor.Println("No active alerts.")
+			if total > 0 {
+				promSuccessColor.Printf("No matching alerts (%d active).\n", total)
+			} else {
+				promSuccessColor.Println("No active alerts.")
+			}
 			return
 		}
 
 		line := strings.Repeat("─", 80)
 		fmt.Println()
-		promHeaderColor.Printf("  Alerts (%d)\n", len(alerts))
+		if len(alerts) < total {
+			promHeaderColor.Printf("  Alerts (%d of %d)\n", len(alerts), total)
+		} else {
+			promHeaderColor.Printf("  Alerts (%d)\n", len(alerts))
+		}
 		fmt.Println("  " + line)
 		fmt.Println()
 
@@ -690,6 +1984,432 @@ Examples:
 	},
 }
 
+// filterPromAlerts keeps alerts in state ("all" for any) whose severity label
+// matches one of severities case-insensitively (none = any severity)
+func filterPromAlerts(alerts []prometheus.Alert, state string, severities []string) []prometheus.Alert {
+	var out []prometheus.Alert
+	for _, a := range alerts {
+		if state != "all" && a.State != state {
+			continue
+		}
+		if len(severities) > 0 && !slices.ContainsFunc(severities, func(sev string) bool {
+			return strings.EqualFold(sev, a.Labels["severity"])
+		}) {
+			continue
+		}
+		out = append(out, a)
+	}
+	return out
+}
+
+// sortPromAlerts orders alerts firing first, then pending, then any other
+// state; within a state the most recently activated come first, ties broken
+// by alert name.
+func sortPromAlerts(alerts []prometheus.Alert) {
+	rank := func(state string) int {
+		switch state {
+		case "firing":
+			return 0
+		case "pending":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(alerts, func(i, j int) bool {
+		a, b := alerts[i], alerts[j]
+		if ra, rb := rank(a.State), rank(b.State); ra != rb {
+			return ra < rb
+		}
+		if !a.ActiveAt.Equal(b.ActiveAt) {
+			return a.ActiveAt.After(b.ActiveAt)
+		}
+		return a.Labels["alertname"] < b.Labels["alertname"]
+	})
+}
+
+// runPromAlertHistory renders the firing timeline of alerts over a time range
+func runPromAlertHistory(cmd *cobra.Command, client *prometheus.Client, output string) {
+	sinceStr, _ := cmd.Flags().GetString("since")
+	untilStr, _ := cmd.Flags().GetString("until")
+	amURL, _ := cmd.Flags().GetString("alertmanager-url")
+
+	start, err := parseTimeValue(sinceStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --since value: %v\n", err)
+		os.Exit(1)
+	}
+	end, err := parseTimeValue(untilStr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid --until value: %v\n", err)
+		os.Exit(1)
+	}
+	if !start.Before(end) {
+		fmt.Fprintf(os.Stderr, "Invalid time range: --since (%s) must be before --until (%s)\n",
+			start.Format("2006-01-02 15:04:05"), end.Format("2006-01-02 15:04:05"))
+		os.Exit(1)
+	}
+
+	step := autoStep(start, end)
+	series, err := client.QueryRange(`ALERTS{alertstate="firing"}`, start, end, step)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Query failed: %v\n", err)
+		os.Exit(1)
+	}
+	history := prometheus.BuildAlertHistory(series, step, end)
+
+	if amURL == "" {
+		if cfg, err := config.Load(); err == nil {
+			amURL = cfg.Prometheus.AlertmanagerURL
+		}
+	}
+	if amURL != "" {
+		amAlerts, err := prometheus.NewClient(amURL).AlertmanagerAlerts()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to query Alertmanager: %v\n", err)
+		} else {
+			history = prometheus.MergeAlertmanagerState(history, amAlerts, end)
+		}
+	}
+
+	if output == "json" {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(history)
+		return
+	}
+
+	if len(history) == 0 {
+		promSuccessColor.Printf("No alerts fired between %s and %s.\n",
+			start.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+		return
+	}
+
+	const barWidth = 60
+	line := strings.Repeat("─", 80)
+	fmt.Println()
+	promHeaderColor.Printf("  Alert History (%d)\n", len(history))
+	promDimColor.Printf("  %s → %s\n", start.Format("2006-01-02 15:04"), end.Format("2006-01-02 15:04"))
+	fmt.Println("  " + line)
+	fmt.Println()
+
+	span := end.Sub(start)
+	for _, h := range history {
+		if h.Active {
+			promErrorColor.Print("  🔥 ")
+		} else {
+			promDimColor.Print("  ✓ ")
+		}
+		name := h.AlertName
+		if name == "" {
+			name = "(unnamed)"
+		}
+		promHeaderColor.Println(name)
+
+		if labels := formatMetricLabels(h.Labels); labels != "{}" {
+			promLabelColor.Printf("    labels: %s\n", labels)
+		}
+		if summary, ok := h.Annotations["summary"]; ok {
+			promDimColor.Printf("    summary: %s\n", summary)
+		}
+
+		// Timeline bar across the whole window
+		bar := []rune(strings.Repeat("·", barWidth))
+		for _, iv := range h.Intervals {
+			from := int(float64(iv.Start.Sub(start)) / float64(span) * barWidth)
+			to := int(float64(iv.End.Sub(start)) / float64(span) * barWidth)
+			for i := max(from, 0); i <= min(to, barWidth-1); i++ {
+				bar[i] = '█'
+			}
+		}
+		fmt.Print("    ")
+		promErrorColor.Println(string(bar))
+
+		var total time.Duration
+		for _, iv := range h.Intervals {
+			total += iv.Duration()
+			promDimColor.Printf("    %s → ", iv.Start.Format("01-02 15:04"))
+			if iv.Ongoing {
+				promErrorColor.Print("now        ")
+			} else {
+				promDimColor.Printf("%s", iv.End.Format("01-02 15:04"))
+			}
+			promValueColor.Printf("  (%s)\n", iv.Duration().Truncate(time.Second))
+		}
+		promDimColor.Printf("    fired %d time(s), %s total\n", len(h.Intervals), total.Truncate(time.Second))
+		fmt.Println()
+	}
+}
+
+// ── prom drilldown ──────────────────────────────────────────────────────────
+
+// promDrilldownResult is the -o json shape of one alerting rule and its series
+type promDrilldownResult struct {
+	Alert  string                    `json:"alert"`
+	Group  string                    `json:"group"`
+	Expr   string                    `json:"expr"`
+	For    string                    `json:"for,omitempty"`
+	State  string                    `json:"state,omitempty"`
+	Series []prometheus.VectorSample `json:"series"`
+}
+
+var promDrilldownCmd = &cobra.Command{
+	Use:   "drilldown <alertname>",
+	Short: "Show the series behind an alert",
+	Long: `Look up an alerting rule by name and run its expression as an instant query.
+
+The result is the set of series that currently satisfy the alert condition,
+i.e. what makes the alert fire, with their labels and values. If the same alert
+name is defined in several rule groups, each rule is evaluated. Names match
+exactly, or case-insensitively when there is no exact match.
+
+Examples:
+  dex prom drilldown HighErrorRate
+  dex prom drilldown KubePodCrashLooping --time "2026-02-04 15:00"
+  dex prom drilldown HighErrorRate -o json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		timeStr, _ := cmd.Flags().GetString("time")
+		output, _ := cmd.Flags().GetString("output")
+		timeoutStr, _ := cmd.Flags().GetString("query-timeout")
+
+		promURL, err := getPrometheusURL(urlFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var evalTime time.Time
+		if timeStr != "" {
+			evalTime, err = parseTimeValueInLocation(timeStr, time.Local)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --time value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		queryTimeout, err := parsePromQueryTimeout(timeoutStr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		client := prometheus.NewClient(promURL)
+		client.SetQueryTimeout(queryTimeout)
+
+		groups, err := client.Rules("alert")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get rules: %v\n", err)
+			os.Exit(1)
+		}
+		rules := prometheus.FindAlertingRules(groups, args[0])
+		if len(rules) == 0 {
+			fmt.Fprintf(os.Stderr, "No alerting rule named %q\n", args[0])
+			promDimColor.Fprintln(os.Stderr, "  List active alerts with: dex prom alerts")
+			os.Exit(1)
+		}
+
+		var results []promDrilldownResult
+		for _, r := range rules {
+			samples, err := client.Query(r.Query, evalTime)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%s (group %s): ", r.Name, r.Group)
+				printPromQueryError(err, queryTimeout)
+				os.Exit(1)
+			}
+			sort.Slice(samples, func(i, j int) bool {
+				return formatMetricLabels(samples[i].Metric) < formatMetricLabels(samples[j].Metric)
+			})
+			res := promDrilldownResult{Alert: r.Name, Group: r.Group, Expr: r.Query, State: r.State, Series: samples}
+			if r.Duration > 0 {
+				res.For = (time.Duration(r.Duration * float64(time.Second))).String()
+			}
+			if res.Series == nil {
+				res.Series = []prometheus.VectorSample{}
+			}
+			results = append(results, res)
+		}
+
+		if output == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(results)
+			return
+		}
+
+		line := strings.Repeat("─", 80)
+		for _, res := range results {
+			fmt.Println()
+			switch res.State {
+			case "firing":
+				promErrorColor.Print("  🔥 ")
+			case "pending":
+				promWarnColor.Print("  ⏳ ")
+			default:
+				fmt.Print("  ")
+			}
+			promHeaderColor.Print(res.Alert)
+			details := "group: " + res.Group
+			if res.For != "" {
+				details += ", for: " + res.For
+			}
+			if res.State != "" {
+				details += ", state: " + res.State
+			}
+			promDimColor.Printf("  (%s)\n", details)
+			promDimColor.Printf("  expr: %s\n", strings.Join(strings.Fields(res.Expr), " "))
+			fmt.Println("  " + line)
+
+			if len(res.Series) == 0 {
+				promSuccessColor.Println("  No series match the expression (condition not met).")
+				continue
+			}
+			for _, s := range res.Series {
+				name := s.Metric["__name__"]
+				if name != "" {
+					promHeaderColor.Print("  " + name)
+				} else {
+					fmt.Print("  ")
+				}
+				promLabelColor.Print(formatMetricLabels(s.Metric))
+				if len(s.Value) == 2 && s.Value[1] != nil {
+					promValueColor.Printf("  %s", formatSampleValue(s.Value[1]))
+				}
+				fmt.Println()
+			}
+			fmt.Println()
+			promDimColor.Printf("  (%d series)\n", len(res.Series))
+		}
+		fmt.Println()
+	},
+}
+
+// ── prom rules ──────────────────────────────────────────────────────────────
+
+var promRulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "List recording and alerting rules",
+	Long: `List the rule groups loaded by Prometheus with each rule's expression,
+"for" duration, health and last evaluation.
+
+Health is shown as ✓ (ok), ✗ (err, with the evaluation error) or ? (not yet
+evaluated). Alerting rules also show their current state (firing, pending,
+inactive). Use --type to list only alerting or recording rules.
+
+Examples:
+  dex prom rules
+  dex prom rules --type alerting
+  dex prom rules --type recording -o json`,
+	Run: func(cmd *cobra.Command, args []string) {
+		urlFlag, _ := cmd.Flags().GetString("url")
+		output, _ := cmd.Flags().GetString("output")
+		ruleType, _ := cmd.Flags().GetString("type")
+
+		var apiType string
+		switch ruleType {
+		case "":
+		case "alerting":
+			apiType = "alert"
+		case "recording":
+			apiType = "record"
+		default:
+			fmt.Fprintf(os.Stderr, "Invalid --type %q (use alerting or recording)\n", ruleType)
+			os.Exit(1)
+		}
+
+		promURL, err := getPrometheusURL(urlFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		client := prometheus.NewClient(promURL)
+		groups, err := client.Rules(apiType)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to get rules: %v\n", err)
+			os.Exit(1)
+		}
+		groups = prometheus.FilterRuleGroups(groups, ruleType)
+
+		if output == "json" {
+			if groups == nil {
+				groups = []prometheus.RuleGroup{}
+			}
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(groups)
+			return
+		}
+
+		total := 0
+		for _, g := range groups {
+			total += len(g.Rules)
+		}
+		if total == 0 {
+			promDimColor.Println("No rules loaded.")
+			return
+		}
+
+		line := strings.Repeat("─", 80)
+		fmt.Println()
+		promHeaderColor.Printf("  Rules (%d in %d groups)\n", total, len(groups))
+		fmt.Println("  " + line)
+
+		for _, g := range groups {
+			fmt.Println()
+			promHeaderColor.Printf("  %s", g.Name)
+			details := g.File
+			if g.Interval > 0 {
+				if details != "" {
+					details += ", "
+				}
+				details += "every " + (time.Duration(g.Interval * float64(time.Second))).String()
+			}
+			if details != "" {
+				promDimColor.Printf("  (%s)", details)
+			}
+			fmt.Println()
+
+			for _, r := range g.Rules {
+				switch r.Health {
+				case "ok":
+					promSuccessColor.Print("    ✓ ")
+				case "err":
+					promErrorColor.Print("    ✗ ")
+				default:
+					promDimColor.Print("    ? ")
+				}
+				promValueColor.Print(r.Name)
+				promDimColor.Printf("  %s", r.Type)
+				if r.Duration > 0 {
+					promDimColor.Printf("  for %s", time.Duration(r.Duration*float64(time.Second)))
+				}
+				switch r.State {
+				case "firing":
+					promErrorColor.Print("  firing")
+				case "pending":
+					promWarnColor.Print("  pending")
+				case "":
+				default:
+					promDimColor.Printf("  %s", r.State)
+				}
+				fmt.Println()
+
+				promLabelColor.Printf("      %s\n", strings.Join(strings.Fields(r.Query), " "))
+				if r.LastError != "" {
+					promErrorColor.Printf("      error: %s\n", r.LastError)
+				}
+				if !r.LastEvaluation.IsZero() {
+					ago := time.Since(r.LastEvaluation).Truncate(time.Second)
+					promDimColor.Printf("      last evaluation: %s ago (took %s)\n", ago,
+						time.Duration(r.EvaluationTime*float64(time.Second)).Round(time.Microsecond))
+				}
+			}
+		}
+		fmt.Println()
+	},
+}
+
 // ── prom test ───────────────────────────────────────────────────────────────
 
 var promTestCmd = &cobra.Command{
@@ -732,11 +2452,31 @@ var promDiscoverCmd = &cobra.Command{
 Searches for Prometheus server pods in common namespaces (monitoring, prometheus,
 observability, kube-system), gets their Pod IP, and probes connectivity.
 
+The working URL is cached in ~/.dex/prometheus/discovered.json for 10 minutes
+and reused by all prom commands when no URL is configured, as long as it still
+responds and the kube context has not changed. Within that window this command shows the cached URL; use --refresh
+to scan again and overwrite the cache.
+
 Examples:
   dex prom discover
-  dex prom discover -n monitoring`,
+  dex prom discover -n monitoring
+  dex prom discover --refresh`,
 	Run: func(cmd *cobra.Command, args []string) {
 		namespace, _ := cmd.Flags().GetString("namespace")
+		refresh, _ := cmd.Flags().GetBool("refresh")
+
+		kubeContext, _ := k8s.CurrentContext()
+		cache, _ := prometheus.DefaultDiscoveryCache(kubeContext)
+		if cache != nil && !refresh {
+			if ep, ok := cache.Load(); ok && (namespace == "" || namespace == ep.Namespace) && probePrometheusURL(ep.URL) == nil {
+				promDimColor.Printf("Using cached discovery from %s ago (%s/%s); --refresh to scan again\n\n",
+					time.Since(ep.DiscoveredAt).Truncate(time.Second), ep.Namespace, ep.Pod)
+				promHeaderColor.Println("Prometheus URL:")
+				fmt.Printf("  %s\n\n", ep.URL)
+				promDimColor.Printf("To use: export PROMETHEUS_URL=%s\n", ep.URL)
+				return
+			}
+		}
 
 		if _, err := k8s.NewClient(""); err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to connect to Kubernetes: %v\n", err)
@@ -876,6 +2616,10 @@ Examples:
 			os.Exit(1)
 		}
 
+		if cache != nil {
+			cache.Save(prometheus.DiscoveredEndpoint{URL: working[0].url, Namespace: working[0].namespace, Pod: working[0].name})
+		}
+
 		promHeaderColor.Println("Prometheus URL:")
 		fmt.Printf("  %s\n\n", working[0].url)
 
@@ -892,15 +2636,26 @@ func init() {
 	// Register subcommands
 	promCmd.AddCommand(promQueryCmd)
 	promCmd.AddCommand(promQueryRangeCmd)
+	promCmd.AddCommand(promTallyCmd)
+	promCmd.AddCommand(promCompareInstancesCmd)
+	promCmd.AddCommand(promTopCmd)
 	promCmd.AddCommand(promLabelsCmd)
+	promCmd.AddCommand(promSeriesCmd)
 	promCmd.AddCommand(promTargetsCmd)
 	promCmd.AddCommand(promAlertsCmd)
+	promCmd.AddCommand(promDrilldownCmd)
+	promCmd.AddCommand(promRulesCmd)
 	promCmd.AddCommand(promTestCmd)
 	promCmd.AddCommand(promDiscoverCmd)
 
 	// Query command flags
 	promQueryCmd.Flags().String("time", "", "Evaluation time (timestamp, default: now)")
-	promQueryCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promQueryCmd.Flags().StringP("output", "o", "table", "Output format: table, json, csv")
+	promQueryCmd.Flags().String("query-timeout", "", "Server-side query evaluation timeout (e.g. 10s, 1m)")
+	promQueryCmd.Flags().Bool("raw-url", false, "Print the fully encoded request URL and exit without executing")
+	promQueryCmd.Flags().BoolP("debug", "d", false, "Print the request URL to stderr before executing")
+	promQueryCmd.Flags().Bool("humanize", false, "Format values in a readable unit guessed from the metric name")
+	promQueryCmd.Flags().String("unit", "", "Unit to format values in: bytes, bytes/s, seconds, percent, none (implies --humanize)")
 
 	// Query-range command flags
 	promQueryRangeCmd.Flags().StringP("since", "s", "1h", "Start of time range (duration or timestamp)")
@@ -908,17 +2663,72 @@ func init() {
 	promQueryRangeCmd.Flags().String("step", "", "Query step (e.g. 15s, 1m; default: auto ~250 points)")
 	promQueryRangeCmd.Flags().Bool("utc", false, "Interpret naive timestamps as UTC instead of local timezone")
 	promQueryRangeCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promQueryRangeCmd.Flags().String("query-timeout", "", "Server-side query evaluation timeout (e.g. 10s, 1m)")
+	promQueryRangeCmd.Flags().Bool("raw-url", false, "Print the fully encoded request URL and exit without executing")
+	promQueryRangeCmd.Flags().BoolP("debug", "d", false, "Print the request URL to stderr before executing")
+	promQueryRangeCmd.Flags().Bool("aggregate", false, "Print a min/max/avg/last/p95 summary per series instead of every sample")
+	promQueryRangeCmd.Flags().Bool("graph", false, "Draw one sparkline per series instead of every sample")
+	promQueryRangeCmd.Flags().String("at", "", "Also print the instant values at this time within the range (timestamp, duration, or \"end\")")
+	promQueryRangeCmd.Flags().Bool("humanize", false, "Format values in a readable unit guessed from the metric name")
+	promQueryRangeCmd.Flags().String("unit", "", "Unit to format values in: bytes, bytes/s, seconds, percent, none (implies --humanize)")
+
+	// Tally command flags
+	promTallyCmd.Flags().String("by", "", "Label to count series by (required)")
+	promTallyCmd.MarkFlagRequired("by")
+	promTallyCmd.Flags().Int("top", 0, "Show only the N largest values (0 = all)")
+	promTallyCmd.Flags().String("time", "", "Evaluation time (timestamp, default: now)")
+	promTallyCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promTallyCmd.Flags().String("query-timeout", "", "Server-side query evaluation timeout (e.g. 10s, 1m)")
+
+	// Compare-instances command flags
+	promCompareInstancesCmd.Flags().String("by", "instance", "Label to compare across")
+	promCompareInstancesCmd.Flags().String("agg", "avg", "Aggregation per label value when it has several series: avg, sum, min, max")
+	promCompareInstancesCmd.Flags().String("time", "", "Evaluation time (timestamp, default: now)")
+	promCompareInstancesCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promCompareInstancesCmd.Flags().String("query-timeout", "", "Server-side query evaluation timeout (e.g. 10s, 1m)")
+
+	// Top command flags
+	promTopCmd.Flags().IntP("limit", "n", 10, "Number of series to show (0 = all)")
+	promTopCmd.Flags().Bool("bottom", false, "Rank smallest values first")
+	promTopCmd.Flags().String("time", "", "Evaluation time (timestamp, default: now)")
+	promTopCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promTopCmd.Flags().String("query-timeout", "", "Server-side query evaluation timeout (e.g. 10s, 1m)")
 
 	// Labels command flags
-	promLabelsCmd.Flags().StringSliceP("match", "m", nil, "Series selector(s) to scope labels (repeatable)")
+	promLabelsCmd.Flags().StringArrayP("match", "m", nil, "Series selector(s) to scope labels (repeatable)")
+
+	// Series command flags
+	promSeriesCmd.Flags().StringArrayP("match", "m", nil, "Series selector (repeatable, required)")
+	promSeriesCmd.Flags().StringP("since", "s", "", "Only series with samples since (duration or timestamp, default: whole retention)")
+	promSeriesCmd.Flags().IntP("limit", "l", 100, "Maximum number of series to print (0 = all)")
+	promSeriesCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
 
 	// Targets command flags
 	promTargetsCmd.Flags().String("state", "active", "Target state filter: active, dropped, any")
 	promTargetsCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promTargetsCmd.Flags().Bool("unhealthy", false, "Only show targets that are not up; exit 1 if there are any")
+	promTargetsCmd.Flags().Bool("down", false, "Only show targets that are not up")
+	promTargetsCmd.Flags().String("job", "", "Only show targets with this job label (exact match)")
 
 	// Alerts command flags
 	promAlertsCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promAlertsCmd.Flags().Bool("history", false, "Show firing timeline over a time range instead of active alerts")
+	promAlertsCmd.Flags().String("state", "all", "Only show alerts in this state: firing, pending, all")
+	promAlertsCmd.Flags().StringSlice("severity", nil, "Only show alerts with this severity label (repeatable, case-insensitive)")
+	promAlertsCmd.Flags().StringP("since", "s", "12h", "Start of history range (duration or timestamp, with --history)")
+	promAlertsCmd.Flags().StringP("until", "u", "", "End of history range (duration or timestamp, default: now)")
+	promAlertsCmd.Flags().String("alertmanager-url", "", "Alertmanager URL for current state (overrides ALERTMANAGER_URL config)")
+
+	// Drilldown command flags
+	promDrilldownCmd.Flags().String("time", "", "Evaluation time (timestamp, default: now)")
+	promDrilldownCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
+	promDrilldownCmd.Flags().String("query-timeout", "", "Server-side query evaluation timeout (e.g. 10s, 1m)")
+
+	// Rules command flags
+	promRulesCmd.Flags().String("type", "", "Only list rules of this type: alerting, recording")
+	promRulesCmd.Flags().StringP("output", "o", "table", "Output format: table, json")
 
 	// Discover command flags
 	promDiscoverCmd.Flags().StringP("namespace", "n", "", "Namespace to search (default: monitoring, prometheus, observability, ...)")
+	promDiscoverCmd.Flags().Bool("refresh", false, "Ignore the cached discovery, scan again and overwrite the cache")
 }
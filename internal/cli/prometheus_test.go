@@ -0,0 +1,410 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codewandler/dex/internal/prometheus"
+	"github.com/spf13/cobra"
+)
+
+func TestWritePromSamplesCSV(t *testing.T) {
+	samples := []prometheus.VectorSample{
+		{Metric: map[string]string{"__name__": "up", "job": "api", "instance": "10.0.0.1:9090"}, Value: [2]interface{}{1700000000.0, "1"}},
+		{Metric: map[string]string{"__name__": "up", "job": "db"}, Value: [2]interface{}{1700000000.0, "NaN"}},
+		{Metric: map[string]string{"job": "a,b", "zone": "eu"}, Value: [2]interface{}{1700000000.0, "+Inf"}},
+	}
+
+	var sb strings.Builder
+	if err := writePromSamplesCSV(&sb, samples); err != nil {
+		t.Fatal(err)
+	}
+	want := `value,__name__,instance,job,zone
+1,up,10.0.0.1:9090,api,
+NaN,up,,db,
++Inf,,,"a,b",eu
+`
+	if sb.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", sb.String(), want)
+	}
+}
+
+func TestTallyPromSamples(t *testing.T) {
+	samples := []prometheus.VectorSample{
+		{Metric: map[string]string{"node": "node-b"}, Value: [2]interface{}{1700000000.0, "3"}},
+		{Metric: map[string]string{"node": "node-a"}, Value: [2]interface{}{1700000000.0, "7"}},
+		{Metric: map[string]string{}, Value: [2]interface{}{1700000000.0, "3"}},
+	}
+
+	rows, total := tallyPromSamples(samples, "node")
+	if total != 13 {
+		t.Errorf("total = %d, want 13", total)
+	}
+	want := []promTallyRow{{"node-a", 7}, {"", 3}, {"node-b", 3}}
+	if len(rows) != len(want) {
+		t.Fatalf("got %+v, want %+v", rows, want)
+	}
+	for i := range want {
+		if rows[i] != want[i] {
+			t.Errorf("row %d: got %+v, want %+v", i, rows[i], want[i])
+		}
+	}
+}
+
+func TestComparePromSamples(t *testing.T) {
+	var samples []prometheus.VectorSample
+	for i, v := range []string{"10", "11", "9", "10", "10", "11", "9", "40", "NaN"} {
+		samples = append(samples, prometheus.VectorSample{
+			Metric: map[string]string{"instance": fmt.Sprintf("10.0.0.%d:9100", i+1)},
+			Value:  [2]interface{}{1700000000.0, v},
+		})
+	}
+
+	cmp := comparePromSamples(samples, "instance")
+	if len(cmp.Rows) != 8 {
+		t.Fatalf("got %d rows, want 8 (NaN skipped)", len(cmp.Rows))
+	}
+	if cmp.Mean != 13.75 {
+		t.Errorf("Mean = %v, want 13.75", cmp.Mean)
+	}
+	if cmp.Rows[0].Label != "10.0.0.8:9100" || !cmp.Rows[0].Outlier {
+		t.Errorf("first row = %+v, want the 40 outlier", cmp.Rows[0])
+	}
+	for _, r := range cmp.Rows[1:] {
+		if r.Outlier {
+			t.Errorf("%s flagged as outlier (z=%.2f)", r.Label, r.ZScore)
+		}
+	}
+
+	flat := comparePromSamples(samples[:1], "instance")
+	if flat.StdDev != 0 || flat.Rows[0].ZScore != 0 || flat.Rows[0].Outlier {
+		t.Errorf("single value: got %+v, want zero stddev and no outlier", flat)
+	}
+}
+
+func TestSummarizePromSeries(t *testing.T) {
+	s := prometheus.MatrixSeries{Metric: map[string]string{"job": "api"}}
+	for i, v := range []string{"4", "NaN", "2", "+Inf", "10", "8", "6"} {
+		s.Values = append(s.Values, [2]interface{}{float64(1700000000 + i*15), v})
+	}
+
+	sum := summarizePromSeries(s)
+	if sum.Samples != 5 {
+		t.Errorf("Samples = %d, want 5", sum.Samples)
+	}
+	if sum.Min != 2 || sum.Max != 10 || sum.Avg != 6 || sum.Last != 6 || sum.P95 != 10 {
+		t.Errorf("got %+v", sum)
+	}
+
+	empty := summarizePromSeries(prometheus.MatrixSeries{Values: [][2]interface{}{{1700000000.0, "NaN"}}})
+	if empty.Samples != 0 || empty.Max != 0 {
+		t.Errorf("all-NaN series: got %+v", empty)
+	}
+}
+
+func TestFormatSampleValueUnit(t *testing.T) {
+	tests := []struct {
+		value any
+		unit  string
+		want  string
+	}{
+		{"1073741824", "bytes", "1.0 GiB"},
+		{"0", "bytes", "0 B"},
+		{"1023", "bytes", "1023 B"},
+		{"1024", "bytes", "1.0 KiB"},
+		{"1048575", "bytes", "1.0 MiB"},
+		{"1048576", "bytes", "1.0 MiB"},
+		{"-2048", "bytes", "-2.0 KiB"},
+		{"1048576", "bytes/s", "1.0 MiB/s"},
+		{"512", "bytes/s", "512 B/s"},
+		{"-2048", "bytes/s", "-2.0 KiB/s"},
+		{"1.5e21", "bytes", "1301.0 EiB"},
+		{"0.0423", "seconds", "42.3ms"},
+		{"0", "seconds", "0s"},
+		{"0.000004", "seconds", "4µs"},
+		{"0.999", "seconds", "999ms"},
+		{"1", "seconds", "1s"},
+		{"59.5", "seconds", "59.5s"},
+		{"60", "seconds", "1m"},
+		{"65", "seconds", "1m5s"},
+		{"3600", "seconds", "1h"},
+		{"0.0423", "percent", "4.23%"},
+		{"1", "percent", "100%"},
+		{"0.123456", "percent", "12.35%"},
+		{"-0.5", "percent", "-50%"},
+		{"NaN", "bytes", "NaN"},
+		{"+Inf", "seconds", "+Inf"},
+		{"1073741824", "none", "1073741824"},
+		{"1073741824", "", "1073741824"},
+	}
+	for _, tt := range tests {
+		if got := formatSampleValueUnit(tt.value, tt.unit); got != tt.want {
+			t.Errorf("formatSampleValueUnit(%v, %q) = %q, want %q", tt.value, tt.unit, got, tt.want)
+		}
+	}
+}
+
+func TestGuessPromUnit(t *testing.T) {
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"node_memory_MemAvailable_bytes", "bytes"},
+		{"node_network_receive_bytes_total", "bytes"},
+		{"rate(node_network_receive_bytes_total[5m])", "bytes/s"},
+		{`sum by (device) (irate(node_network_receive_bytes_total{device!="lo"}[5m]))`, "bytes/s"},
+		{"increase(node_network_receive_bytes_total[1h])", "none"},
+		{"rate(process_cpu_seconds_total[5m])", "none"},
+		{"deriv(process_cpu_seconds_total[5m])", "none"},
+		{"rate(node_network_receive_bytes_total[5m]) + node_memory_MemAvailable_bytes", "none"},
+		{"histogram_quantile(0.99, sum by (le) (rate(http_request_duration_seconds_bucket[5m])))", "seconds"},
+		{"rate(http_request_duration_seconds_sum[5m]) / rate(http_request_duration_seconds_count[5m])", "seconds"},
+		{"rate(http_request_duration_seconds_count[5m])", "none"},
+		{"cache_hit_ratio", "percent"},
+		{`up{job="backup_bytes"}`, "none"},
+		{"process_resident_memory_bytes / process_cpu_seconds_total", "none"},
+		{"up", "none"},
+	}
+	for _, tt := range tests {
+		if got := guessPromUnit(tt.query); got != tt.want {
+			t.Errorf("guessPromUnit(%q) = %q, want %q", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestPromSparkRune(t *testing.T) {
+	tests := []struct {
+		v, lo, hi float64
+		want      rune
+	}{
+		{0, 0, 7, '▁'},
+		{7, 0, 7, '█'},
+		{3, 0, 7, '▄'},
+		{3.4, 0, 7, '▄'},
+		{3.6, 0, 7, '▅'},
+		{0.5, 0, 1, '▅'},
+		{-1, 0, 7, '▁'}, // out of range clamps
+		{9, 0, 7, '█'},
+		{5, 5, 5, '▄'}, // flat series: mid height
+		{0, 0, 0, '▄'},
+	}
+	for _, tt := range tests {
+		if got := promSparkRune(tt.v, tt.lo, tt.hi); got != tt.want {
+			t.Errorf("promSparkRune(%v, %v, %v) = %q, want %q", tt.v, tt.lo, tt.hi, got, tt.want)
+		}
+	}
+}
+
+func TestPromSparkline(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	step := 15 * time.Second
+	end := start.Add(5 * step)
+	values := func(vs ...string) prometheus.MatrixSeries {
+		var s prometheus.MatrixSeries
+		for i, v := range vs {
+			if v == "" {
+				continue // no sample at this step
+			}
+			s.Values = append(s.Values, [2]interface{}{float64(start.Unix()) + float64(i)*step.Seconds(), v})
+		}
+		return s
+	}
+
+	line, lo, hi, ok := promSparkline(values("0", "7", "", "NaN", "3", "7"), start, end, step)
+	if !ok || line != "▁█  ▄█" || lo != 0 || hi != 7 {
+		t.Errorf("got %q (min %v, max %v, ok %v), want \"▁█  ▄█\" 0..7", line, lo, hi, ok)
+	}
+
+	line, lo, hi, ok = promSparkline(values("2", "2", "2", "2", "2", "2"), start, end, step)
+	if !ok || line != "▄▄▄▄▄▄" || lo != 2 || hi != 2 {
+		t.Errorf("flat: got %q (min %v, max %v)", line, lo, hi)
+	}
+
+	if line, _, _, ok = promSparkline(values("NaN", "+Inf"), start, end, step); ok || line != "      " {
+		t.Errorf("no finite samples: got %q, ok %v", line, ok)
+	}
+
+	// Long ranges are averaged down to promSparklineWidth columns
+	long := start.Add(time.Duration(2*promSparklineWidth-1) * step)
+	line, _, _, _ = promSparkline(values("1"), start, long, step)
+	if n := len([]rune(line)); n != promSparklineWidth {
+		t.Errorf("long range: %d columns, want %d", n, promSparklineWidth)
+	}
+}
+
+func TestSortPromAlerts(t *testing.T) {
+	base := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	alert := func(name, state string, ago time.Duration) prometheus.Alert {
+		return prometheus.Alert{Labels: map[string]string{"alertname": name}, State: state, ActiveAt: base.Add(-ago)}
+	}
+	alerts := []prometheus.Alert{
+		alert("OldPending", "pending", 2*time.Hour),
+		alert("OldFiring", "firing", 3*time.Hour),
+		alert("Inactive", "inactive", 0),
+		alert("NewFiring", "firing", 5*time.Minute),
+		alert("NewPending", "pending", time.Minute),
+		alert("B", "firing", time.Hour),
+		alert("A", "firing", time.Hour),
+	}
+
+	sortPromAlerts(alerts)
+	var got []string
+	for _, a := range alerts {
+		got = append(got, a.Labels["alertname"])
+	}
+	want := "NewFiring A B OldFiring NewPending OldPending Inactive"
+	if strings.Join(got, " ") != want {
+		t.Errorf("order = %s, want %s", strings.Join(got, " "), want)
+	}
+}
+
+func TestFilterPromAlerts(t *testing.T) {
+	alerts := []prometheus.Alert{
+		{Labels: map[string]string{"alertname": "DiskFull", "severity": "critical"}, State: "firing"},
+		{Labels: map[string]string{"alertname": "HighLatency", "severity": "Warning"}, State: "firing"},
+		{Labels: map[string]string{"alertname": "Watchdog"}, State: "firing"},
+		{Labels: map[string]string{"alertname": "CertExpiry", "severity": "warning"}, State: "pending"},
+		{Labels: map[string]string{"alertname": "Info", "severity": "info"}, State: "pending"},
+	}
+	names := func(as []prometheus.Alert) string {
+		var out []string
+		for _, a := range as {
+			out = append(out, a.Labels["alertname"])
+		}
+		return strings.Join(out, " ")
+	}
+
+	tests := []struct {
+		state      string
+		severities []string
+		want       string
+	}{
+		{"all", nil, "DiskFull HighLatency Watchdog CertExpiry Info"},
+		{"firing", nil, "DiskFull HighLatency Watchdog"},
+		{"pending", nil, "CertExpiry Info"},
+		{"all", []string{"warning"}, "HighLatency CertExpiry"},
+		{"all", []string{"CRITICAL", "info"}, "DiskFull Info"},
+		{"firing", []string{"warning"}, "HighLatency"},
+		{"pending", []string{"critical"}, ""},
+	}
+	for _, tt := range tests {
+		if got := names(filterPromAlerts(alerts, tt.state, tt.severities)); got != tt.want {
+			t.Errorf("filterPromAlerts(%s, %v) = %q, want %q", tt.state, tt.severities, got, tt.want)
+		}
+	}
+}
+
+func TestFilterPromTargets(t *testing.T) {
+	target := func(job, instance, health string) prometheus.ActiveTarget {
+		return prometheus.ActiveTarget{Labels: map[string]string{"job": job, "instance": instance}, Health: health}
+	}
+	targets := []prometheus.ActiveTarget{
+		target("api", "api-1", "up"),
+		target("api", "api-2", "down"),
+		target("node", "node-1", "unknown"),
+		target("node", "node-2", "up"),
+		target("api-canary", "canary-1", "down"),
+		{DiscoveredLabels: map[string]string{"job": "api"}, Health: "unknown"}, // dropped
+	}
+	instances := func(ts []prometheus.ActiveTarget) string {
+		var out []string
+		for _, t := range ts {
+			out = append(out, t.Labels["instance"])
+		}
+		return strings.Join(out, " ")
+	}
+
+	tests := []struct {
+		name     string
+		job      string
+		downOnly bool
+		want     string
+	}{
+		{"no filter", "", false, "api-1 api-2 node-1 node-2 canary-1 "},
+		{"job is exact", "api", false, "api-1 api-2 "},
+		{"down", "", true, "api-2 node-1 canary-1 "},
+		{"job and down", "api", true, "api-2 "},
+		{"job and down, other job", "node", true, "node-1"},
+		{"unknown job", "web", false, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := instances(filterPromTargets(targets, tt.job, tt.downOnly)); got != tt.want {
+				t.Errorf("instances = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	// A healthy job leaves nothing with --down
+	healthy := filterPromTargets(filterPromTargets(targets[:1], "api", false), "", true)
+	if len(healthy) != 0 {
+		t.Errorf("healthy job with --down = %d targets, want 0", len(healthy))
+	}
+}
+
+func TestRankPromSamples(t *testing.T) {
+	sample := func(pod, value string) prometheus.VectorSample {
+		return prometheus.VectorSample{
+			Metric: map[string]string{"__name__": "mem", "pod": pod},
+			Value:  [2]interface{}{1700000000.0, value},
+		}
+	}
+	samples := []prometheus.VectorSample{
+		sample("a", "5"),
+		sample("b", "NaN"),
+		sample("c", "+Inf"),
+		sample("d", "-3"),
+		sample("e", "5"),
+		sample("f", "garbage"),
+		sample("g", "-Inf"),
+		sample("h", "1e3"),
+	}
+
+	order := func(rows []promTopRow) string {
+		var out []string
+		for _, r := range rows {
+			out = append(out, fmt.Sprintf("%s:%d", r.Metric["pod"], r.Rank))
+		}
+		return strings.Join(out, " ")
+	}
+
+	rows, unranked := rankPromSamples(samples, false)
+	if want := "c:1 h:2 a:3 e:4 d:5 g:6 b:0 f:0"; order(rows) != want {
+		t.Errorf("top order = %q, want %q", order(rows), want)
+	}
+	if unranked != 2 {
+		t.Errorf("unranked = %d, want 2", unranked)
+	}
+
+	rows, _ = rankPromSamples(samples, true)
+	if want := "g:1 d:2 a:3 e:4 h:5 c:6 b:0 f:0"; order(rows) != want {
+		t.Errorf("bottom order = %q, want %q", order(rows), want)
+	}
+	if rows[0].Value != "-Inf" || rows[6].Value != "NaN" {
+		t.Errorf("values not preserved: %q, %q", rows[0].Value, rows[6].Value)
+	}
+}
+
+func TestPromMatchFlagKeepsSelectors(t *testing.T) {
+	selector := `{job="api",instance=~"10\\.0\\..*"}`
+	for _, cmd := range []*cobra.Command{promLabelsCmd, promSeriesCmd} {
+		flag := cmd.Flags().Lookup("match")
+		t.Cleanup(func() {
+			_ = flag.Value.(interface{ Replace([]string) error }).Replace(nil)
+			flag.Changed = false
+		})
+
+		if err := cmd.Flags().Parse([]string{"--match", selector, "-m", "up"}); err != nil {
+			t.Fatalf("%s: %v", cmd.Name(), err)
+		}
+		got, err := cmd.Flags().GetStringArray("match")
+		if err != nil {
+			t.Fatalf("%s: %v", cmd.Name(), err)
+		}
+		if len(got) != 2 || got[0] != selector || got[1] != "up" {
+			t.Errorf("%s: --match = %q, want [%q \"up\"]", cmd.Name(), got, selector)
+		}
+	}
+}
@@ -2,11 +2,17 @@ package cli
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"time"
+	"unicode/utf8"
 
 	"github.com/codewandler/dex/internal/config"
 	"github.com/codewandler/dex/internal/jira"
@@ -124,8 +130,9 @@ Examples:
 }
 
 var slackInfoCmd = &cobra.Command{
-	Use:   "info",
-	Short: "Show authenticated identities",
+	Use:     "info",
+	Aliases: []string{"whoami"},
+	Short:   "Show authenticated identities",
 	Long: `Show who you are from bot and user perspectives.
 
 Displays the authenticated identities for both the bot token and user token.
@@ -140,9 +147,17 @@ User token (SLACK_USER_TOKEN):
 - Search API (search, mentions)
 - Actions that require user context
 
+Use --scopes to list the OAuth scopes each token carries and the scopes dex
+requests that are missing. A missing scope shows up as missing_scope errors,
+or as channel_not_found when a conversation type can't be read (e.g. group DMs
+without mpim:read). Re-run 'dex slack auth' after adding scopes to the app.
+
 Examples:
-  dex slack info`,
+  dex slack info
+  dex slack info --scopes`,
 	Run: func(cmd *cobra.Command, args []string) {
+		showScopes, _ := cmd.Flags().GetBool("scopes")
+
 		cfg, err := config.Load()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
@@ -174,6 +189,9 @@ Examples:
 		fmt.Printf("  Team ID:   %s\n", botResp.TeamID)
 		fmt.Println()
 		fmt.Println("  Used for: sending messages, reading channels, listing users")
+		if showScopes {
+			printSlackTokenScopes(cfg.Slack.BotToken, false)
+		}
 		fmt.Println()
 
 		// User identity
@@ -192,11 +210,40 @@ Examples:
 				fmt.Printf("  Team ID:   %s\n", userResp.TeamID)
 				fmt.Println()
 				fmt.Println("  Used for: search API, mentions search")
+				if showScopes {
+					printSlackTokenScopes(cfg.Slack.UserToken, true)
+				}
 			}
 		}
 	},
 }
 
+// printSlackTokenScopes prints the scopes granted to a token for 'slack info
+// --scopes' and the scopes dex requests for that identity which are missing
+func printSlackTokenScopes(token string, user bool) {
+	scopes, err := slack.GetTokenScopes(token)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "  Failed to read scopes: %v\n", err)
+		return
+	}
+
+	fmt.Println()
+	fmt.Printf("  Scopes (%d):\n", len(scopes))
+	for _, s := range scopes {
+		fmt.Printf("    %s\n", s)
+	}
+
+	missing := slack.MissingScopes(scopes, user)
+	if len(missing) == 0 {
+		fmt.Println("  Missing:   none")
+		return
+	}
+	fmt.Printf("  Missing (%d, requested by dex):\n", len(missing))
+	for _, s := range missing {
+		fmt.Printf("    %s\n", s)
+	}
+}
+
 var slackPresenceCmd = &cobra.Command{
 	Use:   "presence",
 	Short: "Show or set presence status",
@@ -314,11 +361,24 @@ Index is stored at ~/.dex/slack/index.json and enables:
 - Sending DMs via @username
 - Fast lookups for autocomplete
 
+--incremental refreshes the existing index instead of rebuilding it: the
+channel and user lists are fetched again, but channel members are only fetched
+for new channels or channels whose member count changed, and users keep their
+entry unless their profile was updated since the last run. Deleted channels
+and users are removed. It does not reset the 24h age of the last full index;
+without a full index it runs one.
+
 Examples:
-  dex slack index           # Index if cache is older than 24h
-  dex slack index --force   # Force re-index regardless of cache age`,
+  dex slack index                 # Index if the last full index is older than 24h
+  dex slack index --force         # Force a full re-index regardless of cache age
+  dex slack index --incremental   # Quick refresh of what changed since the last run`,
 	Run: func(cmd *cobra.Command, args []string) {
 		force, _ := cmd.Flags().GetBool("force")
+		incremental, _ := cmd.Flags().GetBool("incremental")
+		if force && incremental {
+			fmt.Fprintln(os.Stderr, "--force and --incremental cannot be combined")
+			os.Exit(1)
+		}
 
 		cfg, err := config.Load()
 		if err != nil {
@@ -330,16 +390,23 @@ Examples:
 			os.Exit(1)
 		}
 
-		// Check if index is fresh (< 24h old)
-		if !force {
-			idx, err := slack.LoadIndex()
-			if err == nil && !idx.LastFullIndexAt.IsZero() {
-				age := time.Since(idx.LastFullIndexAt)
-				if age < 24*time.Hour {
-					fmt.Printf("Index is fresh (%s old, %d channels, %d users). Use --force to re-index.\n",
-						formatSlackIndexAge(age), len(idx.Channels), len(idx.Users))
-					return
+		prev, err := slack.LoadIndex()
+		if err != nil {
+			prev = nil
+		}
+
+		// Check if the last full index is fresh (< 24h old). Incremental
+		// refreshes don't count: they never pick up everything a full run does.
+		if !force && !incremental && prev != nil && !prev.LastFullIndexAt.IsZero() {
+			age := time.Since(prev.LastFullIndexAt)
+			if age < 24*time.Hour {
+				refreshed := ""
+				if prev.LastIncrementalAt.After(prev.LastFullIndexAt) {
+					refreshed = fmt.Sprintf(", refreshed %s ago", formatSlackIndexAge(time.Since(prev.LastIncrementalAt)))
 				}
+				fmt.Printf("Index is fresh (full index %s old%s, %d channels, %d users). Use --force to re-index or --incremental to refresh.\n",
+					formatSlackIndexAge(age), refreshed, len(prev.Channels), len(prev.Users))
+				return
 			}
 		}
 
@@ -349,8 +416,18 @@ Examples:
 			os.Exit(1)
 		}
 
+		indexFn := client.IndexAll
+		if incremental {
+			if prev == nil || prev.LastFullIndexAt.IsZero() {
+				fmt.Println("No full index yet, running a full index.")
+			}
+			indexFn = func(channelFn, userFn, groupFn, memberFn slack.ProgressFunc) (*slack.SlackIndex, error) {
+				return client.IndexIncremental(prev, channelFn, userFn, groupFn, memberFn)
+			}
+		}
+
 		fmt.Print("Indexing...")
-		idx, err := client.IndexAll(
+		idx, err := indexFn(
 			func(completed, total int) {
 				fmt.Printf("\rIndexing channels... %d/%d", completed, total)
 			},
@@ -374,6 +451,10 @@ Examples:
 			os.Exit(1)
 		}
 
+		if !idx.LastIncrementalAt.IsZero() {
+			fmt.Printf("\rRefreshed index: %d channels, %d users, %d groups for %s\n", len(idx.Channels), len(idx.Users), len(idx.UserGroups), idx.TeamName)
+			return
+		}
 		fmt.Printf("\rIndexed %d channels, %d users, %d groups for %s\n", len(idx.Channels), len(idx.Users), len(idx.UserGroups), idx.TeamName)
 	},
 }
@@ -386,7 +467,7 @@ func formatSlackIndexAge(d time.Duration) string {
 }
 
 var slackSendCmd = &cobra.Command{
-	Use:   "send <channel|@user> <message>",
+	Use:   "send <channel|@user> [message]",
 	Short: "Send a message to a channel or user",
 	Long: `Send a message to a Slack channel or user DM.
 
@@ -400,6 +481,11 @@ Use --thread/-t to reply to a specific thread.
 Use --as to choose the sender identity (bot or user).
 @mentions, @group mentions, and #channel mentions in the message body are auto-resolved.
 
+By default the message is sent as mrkdwn: *bold*, _italic_, ` + "`code`" + `, <url|label>
+links and mentions are rendered by Slack. Use --no-mrkdwn (or --mrkdwn=false)
+to post the text literally: &, < and > are escaped, formatting is disabled and
+mentions are not resolved. Use it for code snippets, URLs and logs.
+
 Examples:
   dex slack send dev-team "Hello from dex!"
   dex slack send dev-team "Hey @john.doe check this!"  # @mention in message
@@ -407,14 +493,82 @@ Examples:
   dex slack send dev-team "Check out #general for updates"  # #channel mention
   dex slack send dev-team "Follow up" -t 1770257991.873399  # Reply to thread
   dex slack send @john.doe "Hey, check this out!"      # DM (requires im:write)
-  dex slack send dev-team "Message as me" --as user       # Send as user (not bot)`,
-	Args:              cobra.ExactArgs(2),
+  dex slack send dev-team "Message as me" --as user       # Send as user (not bot)
+  dex slack send dev-team "if a < b && b > c" --no-mrkdwn  # Post literally
+  dex slack send dev-team "Fix is deployed" --attach-ticket DEV-123  # Append ticket line
+  dex slack send dev-team "Config in use:" --from-file app.yaml    # File as code block
+  dex slack send dev-team "Full log attached" --file build.log     # Upload as a file
+  dex slack send dev-team --file trace.txt -t 1770257991.873399    # Upload into a thread
+
+--file uploads a local file instead of posting text. The message (optional)
+becomes the file's initial comment, --thread posts it into a thread and --as
+user uploads it with the user token. The file's permalink is printed. Use it
+for logs too large for --from-file; the two cannot be combined.
+
+--from-file posts the contents of a file in a code block, with the message (if
+any) as preamble. It is meant for short logs and configs; content over Slack's
+message size limit is refused. It needs mrkdwn, so it cannot be combined with
+--no-mrkdwn.
+
+--attach-ticket (repeatable) looks up each Jira issue and appends a line with
+key, summary, status and link. If Jira is not configured or an issue cannot be
+fetched, only the key is appended and a warning is printed.`,
+	Args:              cobra.RangeArgs(1, 2),
 	ValidArgsFunction: completeSlackTargets,
 	Run: func(cmd *cobra.Command, args []string) {
 		targetArg := args[0]
-		message := args[1]
+		var message string
+		if len(args) > 1 {
+			message = args[1]
+		}
 		threadTS, _ := cmd.Flags().GetString("thread")
 		sendAs, _ := cmd.Flags().GetString("as")
+		mrkdwn, _ := cmd.Flags().GetBool("mrkdwn")
+		noMrkdwn, _ := cmd.Flags().GetBool("no-mrkdwn")
+		literal := noMrkdwn || !mrkdwn
+		tickets, _ := cmd.Flags().GetStringSlice("attach-ticket")
+		fromFile, _ := cmd.Flags().GetString("from-file")
+		uploadPath, _ := cmd.Flags().GetString("file")
+
+		var codeBlock string
+		if uploadPath != "" {
+			if fromFile != "" {
+				fmt.Fprintln(os.Stderr, "--file and --from-file cannot be combined")
+				os.Exit(1)
+			}
+			info, err := os.Stat(uploadPath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Cannot upload %s: %v\n", uploadPath, err)
+				os.Exit(1)
+			}
+			if info.IsDir() {
+				fmt.Fprintf(os.Stderr, "Cannot upload %s: is a directory\n", uploadPath)
+				os.Exit(1)
+			}
+		} else if fromFile != "" {
+			if literal {
+				fmt.Fprintln(os.Stderr, "--from-file posts a code block and needs mrkdwn; drop --no-mrkdwn")
+				os.Exit(1)
+			}
+			content, err := os.ReadFile(fromFile)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read file: %v\n", err)
+				os.Exit(1)
+			}
+			if strings.TrimSpace(string(content)) == "" {
+				fmt.Fprintf(os.Stderr, "File %s is empty\n", fromFile)
+				os.Exit(1)
+			}
+			codeBlock = slack.CodeBlock(string(content))
+			if n := utf8.RuneCountInString(codeBlock) + utf8.RuneCountInString(message); n > slack.MaxMessageLength {
+				fmt.Fprintf(os.Stderr, "Warning: %s is too long for a Slack message (%d characters, limit %d).\n", fromFile, n, slack.MaxMessageLength)
+				fmt.Fprintf(os.Stderr, "Upload it as a file instead: --file %s\n", fromFile)
+				os.Exit(1)
+			}
+		} else if message == "" {
+			fmt.Fprintln(os.Stderr, "A message is required (or use --from-file or --file)")
+			os.Exit(1)
+		}
 
 		cfg, err := config.Load()
 		if err != nil {
@@ -449,20 +603,84 @@ Examples:
 		} else {
 			// Resolve channel name to ID
 			channelID = slack.ResolveChannel(targetArg)
+
+			// Explain membership/archive problems before Slack answers with a
+			// bare channel_not_found
+			if problem := slackPostBlocker(cfg, client, channelID, sendAs); problem != "" {
+				fmt.Fprintf(os.Stderr, "Cannot send to %s: %s\n", targetArg, problem)
+				os.Exit(1)
+			}
 		}
 
-		// Resolve @mentions, @group mentions, and #channel mentions in message body
-		message = slack.ResolveMentions(message)
-		message = slack.ResolveGroupMentions(message)
-		message = slack.ResolveChannelMentions(message)
+		var ticketLines []string
+		if len(tickets) > 0 {
+			ticketLines = slackTicketLines(tickets, literal)
+		}
+
+		if uploadPath != "" {
+			if threadTS != "" {
+				threadTS = normalizeTimestamp(threadTS)
+			}
+			// The message and ticket lines become the file's initial comment
+			if literal {
+				message = slack.EscapeText(message)
+			} else {
+				message = slack.ResolveMentions(message)
+				message = slack.ResolveGroupMentions(message)
+				message = slack.ResolveChannelMentions(message)
+			}
+			var parts []string
+			if message != "" {
+				parts = append(parts, message)
+			}
+			comment := strings.Join(append(parts, ticketLines...), "\n")
+
+			summary, err := client.UploadFile(slack.UploadFileParams{
+				FilePath:        uploadPath,
+				Comment:         comment,
+				ChannelID:       channelID,
+				ThreadTimestamp: threadTS,
+			})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to upload file: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("File uploaded (id: %s, name: %s)\n", summary.ID, summary.Title)
+			if fi, err := client.GetFileInfo(summary.ID); err == nil && fi.Permalink != "" {
+				fmt.Println(fi.Permalink)
+			}
+			return
+		}
 
 		var ts string
-		if threadTS != "" {
-			// Reply to thread
-			ts, err = client.ReplyToThread(channelID, threadTS, message)
+		if literal {
+			// Escaped, unformatted text; mentions stay as typed
+			message = strings.Join(append([]string{message}, ticketLines...), "\n")
+			ts, err = client.PostLiteralMessage(channelID, threadTS, message)
 		} else {
-			// New message
-			ts, err = client.PostMessage(channelID, message)
+			// Resolve @mentions, @group mentions, and #channel mentions in message body
+			message = slack.ResolveMentions(message)
+			message = slack.ResolveGroupMentions(message)
+			message = slack.ResolveChannelMentions(message)
+			// The code block and ticket lines are added after resolution so
+			// their text is not rewritten
+			var parts []string
+			if message != "" {
+				parts = append(parts, message)
+			}
+			if codeBlock != "" {
+				parts = append(parts, codeBlock)
+			}
+			message = strings.Join(append(parts, ticketLines...), "\n")
+
+			if threadTS != "" {
+				// Reply to thread
+				ts, err = client.ReplyToThread(channelID, threadTS, message)
+			} else {
+				// New message
+				ts, err = client.PostMessage(channelID, message)
+			}
 		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Failed to send message: %v\n", err)
@@ -473,6 +691,86 @@ Examples:
 	},
 }
 
+// slackPostBlocker checks with conversations.info whether the sending identity
+// can post to channelID and returns why not (see slack.PostBlocker). If the
+// sender can't see the conversation, the user token's view is used to tell
+// why. Errors other than channel_not_found leave the decision to Slack.
+func slackPostBlocker(cfg *config.Config, client *slack.Client, channelID, sendAs string) string {
+	if channelID == "" {
+		return ""
+	}
+	ch, err := client.GetChannelInfo(channelID)
+	if err == nil {
+		return slack.PostBlocker(ch, nil, sendAs == "user", cfg.Slack.UserToken != "")
+	}
+	if sendAs == "user" || cfg.Slack.UserToken == "" || !strings.Contains(err.Error(), "channel_not_found") {
+		return ""
+	}
+	userClient, err := slack.NewClient(cfg.Slack.UserToken)
+	if err != nil {
+		return ""
+	}
+	userView, err := userClient.GetChannelInfo(channelID)
+	if err != nil {
+		return ""
+	}
+	return slack.PostBlocker(nil, userView, false, true)
+}
+
+// slackTicketLines looks up Jira issues for --attach-ticket and formats one line
+// per key: key (linked), summary and status. Without a usable Jira setup, or when
+// an issue cannot be fetched, the line is just the key and a warning is printed.
+func slackTicketLines(keys []string, literal bool) []string {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	jiraClient, err := jira.NewClient()
+	if err == nil && !jiraClient.HasToken() {
+		err = errors.New("not authenticated, run 'dex jira auth'")
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: Jira unavailable (%v); attaching ticket keys only\n", err)
+		jiraClient = nil
+	}
+
+	lines := make([]string, 0, len(keys))
+	for _, raw := range keys {
+		key, err := jira.NormalizeIssueKey(raw)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; skipping\n", err)
+			continue
+		}
+		if jiraClient == nil {
+			lines = append(lines, key)
+			continue
+		}
+
+		issue, err := jiraClient.GetIssue(ctx, key)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not fetch %s: %v\n", key, err)
+			lines = append(lines, key)
+			continue
+		}
+		issueURL, _ := jiraClient.IssueURL(ctx, key)
+		summary := issue.Fields.Summary
+		status := issue.Fields.Status.Name
+
+		switch {
+		case literal:
+			line := fmt.Sprintf("%s: %s (%s)", key, summary, status)
+			if issueURL != "" {
+				line += " " + issueURL
+			}
+			lines = append(lines, line)
+		case issueURL != "":
+			lines = append(lines, fmt.Sprintf("<%s|%s> %s (%s)", issueURL, key, slack.EscapeText(summary), status))
+		default:
+			lines = append(lines, fmt.Sprintf("%s %s (%s)", key, slack.EscapeText(summary), status))
+		}
+	}
+	return lines
+}
+
 var slackEditCmd = &cobra.Command{
 	Use:   "edit <channel> <timestamp> <message>",
 	Short: "Edit a message",
@@ -685,6 +983,8 @@ The emoji is the reaction name without colons (e.g. thumbsup, white_check_mark,
 Timestamps can be in Slack URL format (p1769777574026209) or API format (1769777574.026209).
 
 Use --as to choose the identity that adds the reaction (bot or user).
+Reacting to a mention (e.g. with eyes or white_check_mark) marks it as acked
+in "dex slack mentions". Use "dex slack unreact" to take a reaction back.
 
 Examples:
   dex slack react dev-team 1770257991.873399 thumbsup
@@ -704,7 +1004,7 @@ Examples:
 	Run: func(cmd *cobra.Command, args []string) {
 		targetArg := args[0]
 		timestamp := normalizeTimestamp(args[1])
-		emoji := strings.Trim(args[2], ":")
+		emoji := slack.NormalizeEmoji(args[2])
 		reactAs, _ := cmd.Flags().GetString("as")
 
 		cfg, err := config.Load()
@@ -726,6 +1026,10 @@ Examples:
 		channelID := slack.ResolveChannel(targetArg)
 
 		if err := client.AddReaction(channelID, timestamp, emoji); err != nil {
+			if errors.Is(err, slack.ErrAlreadyReacted) {
+				fmt.Fprintf(os.Stderr, "Already reacted with :%s: (as %s)\n", emoji, reactAs)
+				os.Exit(1)
+			}
 			fmt.Fprintf(os.Stderr, "Failed to add reaction: %v\n", err)
 			os.Exit(1)
 		}
@@ -734,160 +1038,541 @@ Examples:
 	},
 }
 
-var slackUnreadsCmd = &cobra.Command{
-	Use:   "unreads",
-	Short: "Show unread messages across channels",
-	Long: `Show all channels that have unread messages, grouped with their messages.
-
-Defaults to the last 14 days (--since 14d) to avoid scanning all history.
-Use --since to widen or narrow the window.
-
-Requires user token with channels:read and groups:read scopes.
-Re-run 'dex slack auth' if you haven't authenticated since these scopes were added.
+var slackUnreactCmd = &cobra.Command{
+	Use:   "unreact <channel> <timestamp> <emoji>",
+	Short: "Remove a reaction from a message",
+	Long: `Remove an emoji reaction from a Slack message.
 
-Use -o json/yaml for structured output. Use -o compact for a one-line-per-channel summary.
+Only the reaction of the identity chosen with --as (bot or user) is removed.
+Channel, timestamp and emoji are given as for "dex slack react".
 
 Examples:
-  dex slack unreads                      # Unreads from last 14 days (default)
-  dex slack unreads --since 1d           # Only last 24 hours
-  dex slack unreads --since 7d           # Last week
-  dex slack unreads --channel dev-team   # Only a specific channel
-  dex slack unreads -o compact           # Summary view
-  dex slack unreads -o json              # Machine-readable output`,
-	RunE: func(cmd *cobra.Command, args []string) error {
-		channelFilter, _ := cmd.Flags().GetString("channel")
-		limit, _ := cmd.Flags().GetInt("limit")
-		sinceStr, _ := cmd.Flags().GetString("since")
-		debug, _ := cmd.Flags().GetBool("debug")
+  dex slack unreact dev-team 1770257991.873399 thumbsup
+  dex slack unreact dev-team p1770257991873399 :eyes: --as user`,
+	Args: cobra.ExactArgs(3),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		switch len(args) {
+		case 0:
+			return completeSlackTargets(cmd, args, toComplete)
+		case 2:
+			return completeSlackEmojiNames(toComplete), cobra.ShellCompDirectiveNoFileComp
+		default:
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		targetArg := args[0]
+		timestamp := normalizeTimestamp(args[1])
+		emoji := slack.NormalizeEmoji(args[2])
+		reactAs, _ := cmd.Flags().GetString("as")
 
 		cfg, err := config.Load()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
 		}
 		if err := cfg.RequireSlack(); err != nil {
-			return err
-		}
-		if cfg.Slack.UserToken == "" {
-			return fmt.Errorf("user token required for unreads (set SLACK_USER_TOKEN and re-run 'dex slack auth')")
-		}
-
-		client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, cfg.Slack.UserToken)
-		if err != nil {
-			return fmt.Errorf("failed to create Slack client: %w", err)
-		}
-
-		idx, _ := slack.LoadIndex()
-
-		// Parse --since (default: 14 days)
-		var sinceUnix int64
-		duration := parseSlackDuration(sinceStr)
-		if duration <= 0 {
-			duration = 14 * 24 * time.Hour // default: 14 days
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
 		}
-		sinceUnix = time.Now().Add(-duration).Unix()
 
-		// Fetch channels with unreads — probes each channel concurrently with progress
-		unreads, err := client.ListUnreadChannels(sinceUnix, limit, debug, channelFilter, func(done, total int, name string) {
-			found := ""
-			if name != "" {
-				found = fmt.Sprintf(" [unread: #%s]", name)
-			}
-			fmt.Fprintf(os.Stderr, "\rScanning channels... %d/%d%s", done, total, found)
-		})
+		client, err := slackClientFor(cfg, reactAs)
 		if err != nil {
-			return fmt.Errorf("failed to list unread channels: %w", err)
-		}
-		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 60)) // clear progress line
-
-		if len(unreads) == 0 {
-			fmt.Println("No unread messages.")
-			return nil
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
 		}
 
-		// ListUnreadChannels already fetched and pre-sorted the messages — use them
-		// directly without any further API calls.
-		result := &slack.UnreadResult{}
-		for _, ch := range unreads {
-			msgs := ch.Messages
+		channelID := slack.ResolveChannel(targetArg)
 
-			// Resolve usernames
-			var unreadMsgs []slack.UnreadMessage
-			for _, m := range msgs {
-				username := ""
-				if idx != nil {
-					if u := idx.FindUser(m.User); u != nil {
-						username = u.Username
-					}
-				}
-				unreadMsgs = append(unreadMsgs, slack.UnreadMessage{
-					ChannelID:   ch.ID,
-					ChannelName: ch.Name,
-					UserID:      m.User,
-					Username:    username,
-					Timestamp:   m.Timestamp,
-					Text:        slack.ExtractMessageText(m),
-					Attachments: slack.ConvertAttachments(m.Attachments),
-					ThreadTS:    m.ThreadTimestamp,
-				})
+		if err := client.RemoveReaction(channelID, timestamp, emoji); err != nil {
+			if errors.Is(err, slack.ErrNoReaction) {
+				fmt.Fprintf(os.Stderr, "No :%s: reaction (as %s) on that message\n", emoji, reactAs)
+				os.Exit(1)
 			}
-
-			result.Channels = append(result.Channels, slack.UnreadChannelMessages{
-				Channel:  ch,
-				Messages: unreadMsgs,
-			})
-			result.Total += len(unreadMsgs)
+			fmt.Fprintf(os.Stderr, "Failed to remove reaction: %v\n", err)
+			os.Exit(1)
 		}
 
-		Render(result)
-		return nil
+		fmt.Printf("Reaction :%s: removed\n", emoji)
 	},
 }
 
-var slackMarkReadCmd = &cobra.Command{
-	Use:   "mark-read <channel> <timestamp>",
-	Short: "Mark a channel as read up to a specific message",
-	Long: `Mark a Slack channel as read up to the given message timestamp.
-
-This moves your read cursor in Slack — the same effect as opening the channel
-in the web app and reading down to that message. All clients (web, mobile, desktop)
-will reflect the updated read state.
-
-The timestamp must identify a specific message. Use 'dex slack unreads' to see
-message timestamps, or use the special value 'latest' to mark the entire channel
-as read up to the most recent message.
+var slackPollCmd = &cobra.Command{
+	Use:   "poll <channel> <question>",
+	Short: "Post a reaction-based poll",
+	Long: `Post a poll: the question followed by numbered options, with the number
+reactions (:one:, :two:, ...) pre-seeded so people vote by clicking them.
 
-Requires user token with channels:write / groups:write scope.
+Pass 2 to 10 options with --option. @mentions and #channel mentions in the
+question and options are auto-resolved. The message timestamp is printed so
+the votes can be read later with "dex slack thread".
 
 Examples:
-  dex slack mark-read dev-team 1770257991.873399    # Mark up to a specific message
-  dex slack mark-read dev-team latest               # Mark the whole channel as read
-  dex slack mark-read C0123456789 1770257991.873399 # Using channel ID`,
+  dex slack poll dev-team "Retro day?" --option Monday --option Thursday
+  dex slack poll dev-team "Deploy window" -O "10:00" -O "14:00" -O "16:00"
+  dex slack poll dev-team "Which one?" -O A -O B -t 1770257991.873399  # In a thread
+  dex slack poll dev-team "Lunch?" -O Pizza -O Sushi --as user`,
 	Args:              cobra.ExactArgs(2),
 	ValidArgsFunction: completeSlackTargets,
-	RunE: func(cmd *cobra.Command, args []string) error {
+	Run: func(cmd *cobra.Command, args []string) {
 		targetArg := args[0]
-		tsArg := args[1]
+		question := args[1]
+		options, _ := cmd.Flags().GetStringArray("option")
+		threadTS, _ := cmd.Flags().GetString("thread")
+		pollAs, _ := cmd.Flags().GetString("as")
+
+		text, err := slack.FormatPoll(question, options)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
 
 		cfg, err := config.Load()
 		if err != nil {
-			return fmt.Errorf("configuration error: %w", err)
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
 		}
 		if err := cfg.RequireSlack(); err != nil {
-			return err
-		}
-		if cfg.Slack.UserToken == "" {
-			return fmt.Errorf("user token required for mark-read (set SLACK_USER_TOKEN)")
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
 		}
 
-		client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, cfg.Slack.UserToken)
+		client, err := slackClientFor(cfg, pollAs)
 		if err != nil {
-			return fmt.Errorf("failed to create Slack client: %w", err)
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
 		}
 
 		channelID := slack.ResolveChannel(targetArg)
-		channelName := targetArg
 
-		// Resolve channel name from index
+		text = slack.ResolveMentions(text)
+		text = slack.ResolveGroupMentions(text)
+		text = slack.ResolveChannelMentions(text)
+
+		var ts string
+		if threadTS != "" {
+			ts, err = client.ReplyToThread(channelID, threadTS, text)
+		} else {
+			ts, err = client.PostMessage(channelID, text)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to post poll: %v\n", err)
+			os.Exit(1)
+		}
+
+		for _, emoji := range slack.PollEmoji[:len(options)] {
+			if err := client.AddReaction(channelID, ts, emoji); err != nil {
+				fmt.Fprintf(os.Stderr, "Poll posted (ts: %s), but seeding :%s: failed: %v\n", ts, emoji, err)
+				os.Exit(1)
+			}
+		}
+
+		fmt.Printf("Poll posted (ts: %s)\n", ts)
+	},
+}
+
+var slackScheduleCmd = &cobra.Command{
+	Use:   "schedule <channel|@user> <message> --at <time>",
+	Short: "Schedule a message to be posted later",
+	Long: `Schedule a message to be posted to a channel or DM at a future time.
+
+Slack posts the message itself, so nothing has to keep running. --at takes a
+duration from now (30m, 2h, 1d) or a timestamp in local time
+("2026-02-04 09:00", "2026-02-04T09:00:00+01:00"). Slack accepts post times up
+to 120 days ahead.
+
+@mentions, @group mentions and #channel mentions are resolved as with send.
+Scheduled messages belong to the identity that created them (--as); list or
+cancel them with "dex slack scheduled".
+
+Examples:
+  dex slack schedule dev-team "Standup in 5 minutes" --at 25m
+  dex slack schedule dev-team "@john.doe release freeze starts now" --at "2026-02-04 09:00"
+  dex slack schedule dev-team "Reminder: retro notes" --at 1d -t 1770257991.873399
+  dex slack schedule @john.doe "Ping about the review" --at 2h --as user`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSlackTargets,
+	Run: func(cmd *cobra.Command, args []string) {
+		targetArg := args[0]
+		message := args[1]
+		atStr, _ := cmd.Flags().GetString("at")
+		threadTS, _ := cmd.Flags().GetString("thread")
+		scheduleAs, _ := cmd.Flags().GetString("as")
+
+		postAt, err := parseSlackScheduleTime(atStr, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid --at value: %v\n", err)
+			os.Exit(1)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := slackClientFor(cfg, scheduleAs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var channelID string
+		if strings.HasPrefix(targetArg, "@") {
+			username := strings.TrimPrefix(targetArg, "@")
+			userID := slack.ResolveUser(username)
+			dmChannelID, err := client.OpenConversation(userID)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to open DM with user: %v\n", err)
+				os.Exit(1)
+			}
+			channelID = dmChannelID
+		} else {
+			channelID = slack.ResolveChannel(targetArg)
+		}
+
+		if threadTS != "" {
+			threadTS = normalizeTimestamp(threadTS)
+		}
+
+		message = slack.ResolveMentions(message)
+		message = slack.ResolveGroupMentions(message)
+		message = slack.ResolveChannelMentions(message)
+
+		id, err := client.ScheduleMessage(channelID, threadTS, message, postAt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to schedule message: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Message scheduled (id: %s, posts at %s)\n", id, postAt.Format("2006-01-02 15:04:05 MST"))
+	},
+}
+
+// parseSlackScheduleTime parses a --at value for slack schedule. Durations
+// count forward from now ("30m" is half an hour from now); timestamps are
+// parsed like --since values, naive ones in local time. The result must lie
+// in the future and within Slack's scheduling window.
+func parseSlackScheduleTime(s string, now time.Time) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, fmt.Errorf("a post time is required (e.g. --at 30m or --at \"2026-02-04 09:00\")")
+	}
+
+	var t time.Time
+	if d, err := parseLokiDuration(strings.TrimPrefix(s, "+")); err == nil {
+		t = now.Add(d)
+	} else {
+		t, err = parseTimeValueRelative(s, time.Local, now)
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	if !t.After(now) {
+		return time.Time{}, fmt.Errorf("%s is in the past", t.Format("2006-01-02 15:04:05 MST"))
+	}
+	if t.Sub(now) > slack.MaxScheduleAhead {
+		return time.Time{}, fmt.Errorf("%s is more than 120 days ahead", t.Format("2006-01-02 15:04:05 MST"))
+	}
+	return t, nil
+}
+
+var slackScheduledCmd = &cobra.Command{
+	Use:   "scheduled",
+	Short: "List or cancel scheduled messages",
+	Long:  `Commands for messages scheduled with "dex slack schedule".`,
+}
+
+var slackScheduledListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List pending scheduled messages",
+	Long: `List messages scheduled by the chosen identity (--as) that have not been
+posted yet, soonest first.
+
+Examples:
+  dex slack scheduled list
+  dex slack scheduled list -C dev-team
+  dex slack scheduled list --as user -o json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		channelArg, _ := cmd.Flags().GetString("channel")
+		listAs, _ := cmd.Flags().GetString("as")
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := slackClientFor(cfg, listAs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var channelID string
+		if channelArg != "" {
+			channelID = slack.ResolveChannel(channelArg)
+		}
+
+		msgs, err := client.ListScheduledMessages(channelID)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		if outputFormat == "json" {
+			enc := json.NewEncoder(os.Stdout)
+			enc.SetIndent("", "  ")
+			enc.Encode(msgs)
+			return
+		}
+
+		if len(msgs) == 0 {
+			fmt.Println("No scheduled messages.")
+			return
+		}
+
+		idx, _ := slack.LoadIndex()
+		for _, m := range msgs {
+			channel := m.Channel
+			if idx != nil {
+				if ch := idx.FindChannel(m.Channel); ch != nil {
+					channel = "#" + ch.Name
+				}
+			}
+			postAt := time.Unix(int64(m.PostAt), 0)
+			fmt.Printf("%s  %s  %-20s %s\n", m.ID, postAt.Format("2006-01-02 15:04"), channel, truncateText(m.Text, 60))
+		}
+		fmt.Printf("\n%d scheduled messages\n", len(msgs))
+	},
+}
+
+var slackScheduledCancelCmd = &cobra.Command{
+	Use:   "cancel <id>",
+	Short: "Cancel a scheduled message",
+	Long: `Cancel a pending scheduled message by the ID printed by "dex slack schedule"
+or "dex slack scheduled list". Use the same --as identity that scheduled it.
+The channel is looked up from the pending messages unless given with -C.
+
+Examples:
+  dex slack scheduled cancel Q0123ABCDEF
+  dex slack scheduled cancel Q0123ABCDEF -C dev-team --as user`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		id := args[0]
+		channelArg, _ := cmd.Flags().GetString("channel")
+		cancelAs, _ := cmd.Flags().GetString("as")
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := slackClientFor(cfg, cancelAs)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		var channelID string
+		if channelArg != "" {
+			channelID = slack.ResolveChannel(channelArg)
+		} else {
+			msgs, err := client.ListScheduledMessages("")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "%v\n", err)
+				os.Exit(1)
+			}
+			for _, m := range msgs {
+				if m.ID == id {
+					channelID = m.Channel
+					break
+				}
+			}
+			if channelID == "" {
+				fmt.Fprintf(os.Stderr, "No pending scheduled message %s (as %s)\n", id, cancelAs)
+				os.Exit(1)
+			}
+		}
+
+		if err := client.CancelScheduledMessage(channelID, id); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Scheduled message %s cancelled\n", id)
+	},
+}
+
+var slackUnreadsCmd = &cobra.Command{
+	Use:   "unreads",
+	Short: "Show unread messages across channels",
+	Long: `Show all channels that have unread messages, grouped with their messages.
+
+Defaults to the last 14 days (--since 14d) to avoid scanning all history.
+Use --since to widen or narrow the window.
+
+Requires user token with channels:read and groups:read scopes.
+Re-run 'dex slack auth' if you haven't authenticated since these scopes were added.
+
+Use -o json/yaml for structured output. Use -o compact for a one-line-per-channel summary.
+
+Examples:
+  dex slack unreads                      # Unreads from last 14 days (default)
+  dex slack unreads --since 1d           # Only last 24 hours
+  dex slack unreads --since 7d           # Last week
+  dex slack unreads --channel dev-team   # Only a specific channel
+  dex slack unreads -o compact           # Summary view
+  dex slack unreads -o json              # Machine-readable output`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		channelFilter, _ := cmd.Flags().GetString("channel")
+		limit, _ := cmd.Flags().GetInt("limit")
+		sinceStr, _ := cmd.Flags().GetString("since")
+		debug, _ := cmd.Flags().GetBool("debug")
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			return err
+		}
+		if cfg.Slack.UserToken == "" {
+			return fmt.Errorf("user token required for unreads (set SLACK_USER_TOKEN and re-run 'dex slack auth')")
+		}
+
+		client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, cfg.Slack.UserToken)
+		if err != nil {
+			return fmt.Errorf("failed to create Slack client: %w", err)
+		}
+
+		idx, _ := slack.LoadIndex()
+
+		// Parse --since (default: 14 days)
+		var sinceUnix int64
+		duration := parseSlackDuration(sinceStr)
+		if duration <= 0 {
+			duration = 14 * 24 * time.Hour // default: 14 days
+		}
+		sinceUnix = time.Now().Add(-duration).Unix()
+
+		// Fetch channels with unreads — probes each channel concurrently with progress
+		unreads, err := client.ListUnreadChannels(sinceUnix, limit, debug, channelFilter, func(done, total int, name string) {
+			found := ""
+			if name != "" {
+				found = fmt.Sprintf(" [unread: #%s]", name)
+			}
+			fmt.Fprintf(os.Stderr, "\rScanning channels... %d/%d%s", done, total, found)
+		})
+		if err != nil {
+			return fmt.Errorf("failed to list unread channels: %w", err)
+		}
+		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", 60)) // clear progress line
+
+		if len(unreads) == 0 {
+			fmt.Println("No unread messages.")
+			return nil
+		}
+
+		// ListUnreadChannels already fetched and pre-sorted the messages — use them
+		// directly without any further API calls.
+		result := &slack.UnreadResult{}
+		for _, ch := range unreads {
+			msgs := ch.Messages
+
+			// Resolve usernames
+			var unreadMsgs []slack.UnreadMessage
+			for _, m := range msgs {
+				username := ""
+				if idx != nil {
+					if u := idx.FindUser(m.User); u != nil {
+						username = u.Username
+					}
+				}
+				unreadMsgs = append(unreadMsgs, slack.UnreadMessage{
+					ChannelID:   ch.ID,
+					ChannelName: ch.Name,
+					UserID:      m.User,
+					Username:    username,
+					Timestamp:   m.Timestamp,
+					Text:        slack.ExtractMessageText(m),
+					Attachments: slack.ConvertAttachments(m.Attachments),
+					ThreadTS:    m.ThreadTimestamp,
+				})
+			}
+
+			result.Channels = append(result.Channels, slack.UnreadChannelMessages{
+				Channel:  ch,
+				Messages: unreadMsgs,
+			})
+			result.Total += len(unreadMsgs)
+		}
+
+		Render(result)
+		return nil
+	},
+}
+
+var slackMarkReadCmd = &cobra.Command{
+	Use:   "mark-read <channel> <timestamp>",
+	Short: "Mark a channel as read up to a specific message",
+	Long: `Mark a Slack channel as read up to the given message timestamp.
+
+This moves your read cursor in Slack — the same effect as opening the channel
+in the web app and reading down to that message. All clients (web, mobile, desktop)
+will reflect the updated read state.
+
+The timestamp must identify a specific message. Use 'dex slack unreads' to see
+message timestamps, or use the special value 'latest' to mark the entire channel
+as read up to the most recent message.
+
+Requires user token with channels:write / groups:write scope.
+
+Examples:
+  dex slack mark-read dev-team 1770257991.873399    # Mark up to a specific message
+  dex slack mark-read dev-team latest               # Mark the whole channel as read
+  dex slack mark-read C0123456789 1770257991.873399 # Using channel ID`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSlackTargets,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		targetArg := args[0]
+		tsArg := args[1]
+
+		cfg, err := config.Load()
+		if err != nil {
+			return fmt.Errorf("configuration error: %w", err)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			return err
+		}
+		if cfg.Slack.UserToken == "" {
+			return fmt.Errorf("user token required for mark-read (set SLACK_USER_TOKEN)")
+		}
+
+		client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, cfg.Slack.UserToken)
+		if err != nil {
+			return fmt.Errorf("failed to create Slack client: %w", err)
+		}
+
+		channelID := slack.ResolveChannel(targetArg)
+		channelName := targetArg
+
+		// Resolve channel name from index
 		idx, _ := slack.LoadIndex()
 		if idx != nil {
 			if ch := idx.FindChannel(channelID); ch != nil {
@@ -1281,14 +1966,34 @@ Examples:
   dex slack mentions --limit 50         # Show more results
   dex slack mentions --since 1h         # Mentions from last hour
   dex slack mentions --since 7d         # Mentions from last 7 days
-  dex slack mentions --compact          # Compact table view`,
+  dex slack mentions --compact          # Compact table view
+  dex slack mentions --group-by channel # Sections per channel with status counts
+  dex slack mentions --group-by status  # Pending, Acked, Replied sections
+  dex slack mentions --channel dev-team --unhandled --since 3d  # One busy channel
+  dex slack mentions -C dev-team -C C0123456789  # Several channels (name or ID)`,
 	Run: func(cmd *cobra.Command, args []string) {
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		if groupBy != "" && groupBy != "channel" && groupBy != "status" {
+			fmt.Fprintf(os.Stderr, "Invalid --group-by %q (use channel or status)\n", groupBy)
+			os.Exit(1)
+		}
 		userArg, _ := cmd.Flags().GetString("user")
 		botFlag, _ := cmd.Flags().GetBool("bot")
 		limit, _ := cmd.Flags().GetInt("limit")
 		compact, _ := cmd.Flags().GetBool("compact")
 		sinceStr, _ := cmd.Flags().GetString("since")
 		unhandled, _ := cmd.Flags().GetBool("unhandled")
+		channelArgs, _ := cmd.Flags().GetStringSlice("channel")
+
+		var onlyChannels []string
+		for _, arg := range channelArgs {
+			id := slack.ResolveChannel(strings.TrimPrefix(arg, "#"))
+			if id == "" {
+				fmt.Fprintf(os.Stderr, "Unknown channel %q (run 'dex slack index' or pass the channel ID)\n", arg)
+				os.Exit(1)
+			}
+			onlyChannels = append(onlyChannels, id)
+		}
 
 		cfg, err := config.Load()
 		if err != nil {
@@ -1384,7 +2089,7 @@ Examples:
 		// Use search API if user token available, otherwise fall back to channel scanning
 		if client.HasUserToken() {
 			fmt.Printf("Searching all channels for mentions of %s%s...\n", targetDesc, sinceDesc)
-			mentions, total, err = client.SearchMentions(userID, limit, sinceUnix)
+			mentions, total, err = client.SearchMentions(userID, limit, sinceUnix, onlyChannels...)
 		} else {
 			// Fall back to scanning channels bot is a member of
 			if idx == nil || len(idx.Channels) == 0 {
@@ -1394,12 +2099,16 @@ Examples:
 
 			var channelIDs []string
 			for _, ch := range idx.Channels {
-				if ch.IsMember {
+				if ch.IsMember && (len(onlyChannels) == 0 || slices.Contains(onlyChannels, ch.ID)) {
 					channelIDs = append(channelIDs, ch.ID)
 				}
 			}
 
 			if len(channelIDs) == 0 {
+				if len(onlyChannels) > 0 {
+					fmt.Println("Bot is not a member of the requested channels.")
+					return
+				}
 				fmt.Println("Bot is not a member of any channels.")
 				return
 			}
@@ -1471,6 +2180,7 @@ Examples:
 			Total:     total,
 			Shown:     len(mentions),
 			Unhandled: unhandled,
+			GroupBy:   groupBy,
 		}
 		for _, m := range mentions {
 			channelName := m.ChannelName
@@ -1504,6 +2214,9 @@ Examples:
 				Status:      string(m.Status),
 			})
 		}
+		if groupBy != "" {
+			result.Groups = slack.GroupMentions(result.Mentions, groupBy)
+		}
 
 		mode := render.ModeNormal
 		if compact {
@@ -1513,6 +2226,43 @@ Examples:
 	},
 }
 
+// parseSlackThreadRef extracts the channel ID and parent timestamp from the
+// arguments of 'slack thread': a message URL, channel:ts, or channel and ts.
+// Channel names are resolved via the index. For a reply's "Copy link" URL
+// the thread_ts query parameter, i.e. the parent, wins over the path
+// timestamp. Empty results mean the input could not be parsed.
+func parseSlackThreadRef(args []string) (channelID, threadTS string) {
+	if len(args) == 2 {
+		return slack.ResolveChannel(args[0]), normalizeTimestamp(args[1])
+	}
+
+	input := strings.TrimSpace(args[0])
+	if strings.HasPrefix(input, "http") {
+		// https://acme.slack.com/archives/C0123456789/p1769777574026209[?thread_ts=...&cid=...]
+		u, err := url.Parse(input)
+		if err != nil {
+			return "", ""
+		}
+		parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+		for i, part := range parts {
+			if part == "archives" && i+2 < len(parts) {
+				channelID = parts[i+1]
+				threadTS = normalizeTimestamp(parts[i+2])
+				break
+			}
+		}
+		if parent := u.Query().Get("thread_ts"); parent != "" && channelID != "" {
+			threadTS = normalizeTimestamp(parent)
+		}
+		return channelID, threadTS
+	}
+
+	if name, ts, ok := strings.Cut(input, ":"); ok {
+		return slack.ResolveChannel(name), normalizeTimestamp(ts)
+	}
+	return "", ""
+}
+
 // resolveUserMentions converts <@USER_ID> to @username for readability
 func resolveUserMentions(text string, idx *slack.SlackIndex) string {
 	if idx == nil {
@@ -1643,7 +2393,15 @@ Examples:
   dex slack search "error" --since 1d        # Errors in last day
   dex slack search "from:@john.doe"       # Messages from user
   dex slack search "bug" --tickets           # Find tickets mentioned with "bug"
-  dex slack search "DEV-" --tickets          # Find all DEV tickets mentioned`,
+  dex slack search "DEV-" --tickets          # Find all DEV tickets mentioned
+  dex slack search "outage" --context 3      # Show 3 messages before/after each hit
+  dex slack search "deploy" --since 1d -o json   # Results as JSON for scripts
+  dex slack search "DEV-" --tickets -o json      # {"tickets": {"DEV-123": [permalinks]}}
+
+With -o json (or yaml) the results are printed as structured data after
+--limit and --since are applied, with channel and user names resolved from the
+index. Combined with --tickets, the output maps each ticket key to the
+permalinks of the messages mentioning it instead.`,
 	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		query := args[0]
@@ -1651,6 +2409,7 @@ Examples:
 		sinceStr, _ := cmd.Flags().GetString("since")
 		extractTickets, _ := cmd.Flags().GetBool("tickets")
 		compact, _ := cmd.Flags().GetBool("compact")
+		contextN, _ := cmd.Flags().GetInt("context")
 
 		cfg, err := config.Load()
 		if err != nil {
@@ -1703,17 +2462,17 @@ Examples:
 			}
 		}
 
+		structured := outputFormat == "json" || outputFormat == "yaml"
+
 		results, total, err := client.Search(query, limit, sinceUnix)
 		if err != nil {
+			if structured {
+				RenderError(fmt.Errorf("search failed: %w", err))
+			}
 			fmt.Fprintf(os.Stderr, "Search failed: %v\n", err)
 			os.Exit(1)
 		}
 
-		if len(results) == 0 {
-			fmt.Println("No results found.")
-			return
-		}
-
 		// Collect all tickets if extraction is enabled
 		allTickets := make(map[string][]string) // ticket -> permalinks where mentioned
 		if extractTickets {
@@ -1725,11 +2484,28 @@ Examples:
 			}
 		}
 
+		if extractTickets && structured {
+			Render(&slack.SearchTicketsOutput{
+				Query:   query,
+				Tickets: allTickets,
+				Total:   total,
+				Shown:   len(results),
+			})
+			return
+		}
+
 		// Build result struct
 		result := slack.SearchResultOutput{
-			Query: query,
-			Total: total,
-			Shown: len(results),
+			Query:   query,
+			Results: []slack.SearchItem{},
+			Total:   total,
+			Shown:   len(results),
+		}
+
+		// Surrounding messages (--context), sharing fetched history windows per channel
+		var contextCache *slack.MessageWindowCache
+		if contextN > 0 {
+			contextCache = client.NewMessageWindowCache(slackSearchContextMaxFetches)
 		}
 
 		idx2 := idx // use already loaded index
@@ -1762,6 +2538,23 @@ Examples:
 				Files:       r.Files,
 				Permalink:   r.Permalink,
 			})
+
+			if contextCache == nil {
+				continue
+			}
+			before, after, err := contextCache.Around(r.ChannelID, r.Timestamp, contextN)
+			if errors.Is(err, slack.ErrContextBudget) {
+				result.Note = fmt.Sprintf("Context shown for the first %d results (fetch limit reached)", len(result.Results)-1)
+				contextCache = nil
+				continue
+			}
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: no context for message in #%s: %v\n", channelName, err)
+				continue
+			}
+			item := &result.Results[len(result.Results)-1]
+			item.Before = resolveContextMessages(before, idx2)
+			item.After = resolveContextMessages(after, idx2)
 		}
 
 		if extractTickets && len(allTickets) > 0 {
@@ -1787,6 +2580,23 @@ Examples:
 	},
 }
 
+// slackSearchContextMaxFetches bounds the history calls made by 'slack search --context'
+const slackSearchContextMaxFetches = 40
+
+// resolveContextMessages fills in usernames and resolves mentions for search context messages
+func resolveContextMessages(msgs []slack.ContextMessage, idx *slack.SlackIndex) []slack.ContextMessage {
+	for i := range msgs {
+		if msgs[i].Username == "" {
+			msgs[i].Username = msgs[i].UserID
+			if u := idx.FindUser(msgs[i].UserID); u != nil {
+				msgs[i].Username = u.Username
+			}
+		}
+		msgs[i].Text = resolveUserMentions(msgs[i].Text, idx)
+	}
+	return msgs
+}
+
 var slackThreadCmd = &cobra.Command{
 	Use:   "thread <url | channel:ts | channel ts>",
 	Short: "Show a Slack thread",
@@ -1794,6 +2604,11 @@ var slackThreadCmd = &cobra.Command{
 
 Accepts a Slack URL, channel:timestamp, or channel and timestamp as separate arguments.
 Timestamps can be in Slack URL format (p1769777574026209) or API format (1769777574.026209).
+Channels can be given by name (requires index) or ID. A "Copy link" URL of a
+reply carries the parent in its thread_ts parameter; the whole thread is shown.
+
+The channel is shown as #name, looked up in the index or, failing that, via
+the API. Use --url to print each message's permalink below it.
 
 Use --compact for a condensed one-line-per-message view.
 Use --debug to show identity and mention-classification details.
@@ -1804,40 +2619,16 @@ Examples:
   dex slack thread C0123456789 1769777574.026209
   dex slack thread C0123456789 p1769777574026209
   dex slack thread C0123456789:1769777574.026209 --compact
-  dex slack thread C0123456789:1769777574.026209 -o json`,
+  dex slack thread C0123456789:1769777574.026209 -o json
+  dex slack thread "https://acme.slack.com/archives/C0123456789/p1769777600123456?thread_ts=1769777574.026209&cid=C0123456789"
+  dex slack thread dev-team 1769777574.026209 --url`,
 	Args: cobra.RangeArgs(1, 2),
 	Run: func(cmd *cobra.Command, args []string) {
 		compact, _ := cmd.Flags().GetBool("compact")
 		debug, _ := cmd.Flags().GetBool("debug")
+		withURLs, _ := cmd.Flags().GetBool("url")
 
-		// Parse input - URL, channel:timestamp, or channel timestamp (two args)
-		var channelID, threadTS string
-
-		if len(args) == 2 {
-			channelID = args[0]
-			threadTS = normalizeTimestamp(args[1])
-		} else {
-			input := args[0]
-			if strings.HasPrefix(input, "http") {
-				// Parse URL: https://acme.slack.com/archives/C0123456789/p1769777574026209
-				parts := strings.Split(input, "/")
-				for i, part := range parts {
-					if part == "archives" && i+2 < len(parts) {
-						channelID = parts[i+1]
-						tsRaw := parts[i+2]
-						if idx := strings.Index(tsRaw, "?"); idx != -1 {
-							tsRaw = tsRaw[:idx]
-						}
-						threadTS = normalizeTimestamp(tsRaw)
-						break
-					}
-				}
-			} else if strings.Contains(input, ":") {
-				parts := strings.SplitN(input, ":", 2)
-				channelID = slack.ResolveChannel(parts[0])
-				threadTS = normalizeTimestamp(parts[1])
-			}
-		}
+		channelID, threadTS := parseSlackThreadRef(args)
 
 		if channelID == "" || threadTS == "" {
 			fmt.Fprintf(os.Stderr, "Could not parse input. Use URL, channel:timestamp, or channel timestamp format.\n")
@@ -1878,10 +2669,12 @@ Examples:
 			}
 		}
 
-		// Resolve channel name from index
+		// Resolve channel name from index, falling back to conversations.info
 		channelName := ""
 		if ch := idx.FindChannel(channelID); ch != nil {
 			channelName = ch.Name
+		} else if info, err := client.GetChannelInfo(channelID); err == nil {
+			channelName = info.Name
 		}
 
 		// Fetch thread
@@ -1899,6 +2692,7 @@ Examples:
 			ChannelID:   channelID,
 			ChannelName: channelName,
 			ThreadTS:    threadTS,
+			ThreadTime:  parseSlackTimestamp(threadTS),
 			Status:      string(status),
 		}
 		if debug {
@@ -1957,6 +2751,14 @@ Examples:
 
 			tm.Files = slack.ConvertFiles(msg.Files)
 
+			if withURLs {
+				link, err := client.GetPermalink(channelID, msg.Timestamp)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: no permalink for message %s: %v\n", msg.Timestamp, err)
+				}
+				tm.Permalink = link
+			}
+
 			result.Messages = append(result.Messages, tm)
 		}
 
@@ -2085,6 +2887,252 @@ Examples:
 	},
 }
 
+var slackSetTopicCmd = &cobra.Command{
+	Use:   "set-topic <channel> <topic>",
+	Short: "Set a channel's topic",
+	Long: `Set the topic of a Slack channel. Pass "" to clear it.
+
+The channel can be a name (requires index) or ID.
+Use --as to choose the identity (bot or user). The bot must be a member of the
+channel (see 'dex slack channel join'); with --as user your own membership counts.
+
+Requires the channels:write.topic scope (groups:write.topic for private
+channels). Re-run 'dex slack auth' if you get a missing_scope error.
+
+Examples:
+  dex slack set-topic dev-team "On call: @jane.doe (until Friday)"
+  dex slack set-topic C01234567 "Release freeze until 2026-03-01" --as user
+  dex slack set-topic dev-team ""`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSlackChannelNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		setSlackChannelInfo(cmd, args[0], args[1], "topic")
+	},
+}
+
+var slackSetPurposeCmd = &cobra.Command{
+	Use:   "set-purpose <channel> <purpose>",
+	Short: "Set a channel's purpose (description)",
+	Long: `Set the purpose (description) of a Slack channel. Pass "" to clear it.
+
+The channel can be a name (requires index) or ID.
+Use --as to choose the identity (bot or user). The bot must be a member of the
+channel (see 'dex slack channel join'); with --as user your own membership counts.
+
+Requires the channels:write.topic scope (groups:write.topic for private
+channels). Re-run 'dex slack auth' if you get a missing_scope error.
+
+Examples:
+  dex slack set-purpose dev-team "Backend team: deploys, incidents and reviews"
+  dex slack set-purpose C01234567 "Alerts from production" --as user`,
+	Args:              cobra.ExactArgs(2),
+	ValidArgsFunction: completeSlackChannelNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		setSlackChannelInfo(cmd, args[0], args[1], "purpose")
+	},
+}
+
+// setSlackChannelInfo sets the topic or purpose of a channel and prints the stored value.
+func setSlackChannelInfo(cmd *cobra.Command, channelArg, text, field string) {
+	setAs, _ := cmd.Flags().GetString("as")
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
+	}
+	if err := cfg.RequireSlack(); err != nil {
+		fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+		os.Exit(1)
+	}
+
+	client, err := slackClientFor(cfg, setAs)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		os.Exit(1)
+	}
+
+	channelID := slack.ResolveChannel(channelArg)
+	if channelID == "" {
+		channelID = channelArg
+	}
+
+	text = slack.ResolveMentions(text)
+	text = slack.ResolveChannelMentions(text)
+
+	var value string
+	if field == "topic" {
+		value, err = client.SetChannelTopic(channelID, text)
+	} else {
+		value, err = client.SetChannelPurpose(channelID, text)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%v\n", err)
+		if strings.Contains(err.Error(), "not_in_channel") {
+			fmt.Fprintf(os.Stderr, "Join the channel first (dex slack channel join %s) or use --as user\n", channelArg)
+		}
+		os.Exit(1)
+	}
+
+	name := strings.TrimPrefix(channelArg, "#")
+	if value == "" {
+		fmt.Printf("Cleared the %s of #%s\n", field, name)
+		return
+	}
+	fmt.Printf("Set the %s of #%s to: %s\n", field, name, value)
+}
+
+var slackResolveCmd = &cobra.Command{
+	Use:   "resolve <name-or-id>",
+	Short: "Show how dex resolves a channel, @user or ID",
+	Long: `Show step by step how dex resolves a send target: which index entry matched,
+the resulting ID, what kind of conversation it is (DM, MPDM, private or public
+channel, via conversations.info) and whether the bot and user identities can
+see it and are members.
+
+Use it when a command fails with channel_not_found or not_in_channel.
+Nothing is posted and no conversation is opened.
+
+Examples:
+  dex slack resolve dev-team
+  dex slack resolve @john.doe
+  dex slack resolve C0123456789
+  dex slack resolve G0123456789      # e.g. a group DM only the user token can see`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSlackTargets,
+	Run: func(cmd *cobra.Command, args []string) {
+		target := args[0]
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		idx, err := slack.LoadIndex()
+		if err != nil {
+			idx = slack.NewSlackIndex("", "")
+		}
+
+		fmt.Printf("Input:       %s\n", target)
+
+		// Users: "@name" (as send does) or a raw user ID
+		if strings.HasPrefix(target, "@") || (strings.HasPrefix(target, "U") && len(target) > 8 && strings.ToUpper(target) == target) {
+			name := strings.TrimPrefix(target, "@")
+			fmt.Println("Kind:        user (send opens a DM with conversations.open)")
+			if u := idx.FindUser(name); u != nil {
+				fmt.Printf("Index:       user %s (@%s, %s)", u.ID, u.Username, u.RealName)
+				if u.IsDeleted {
+					fmt.Print(" [deleted]")
+				}
+				if u.IsBot {
+					fmt.Print(" [bot]")
+				}
+				fmt.Println()
+				fmt.Printf("User ID:     %s\n", u.ID)
+			} else {
+				fmt.Printf("Index:       no match among %d indexed users, used as-is\n", len(idx.Users))
+				fmt.Printf("User ID:     %s\n", name)
+				fmt.Println()
+				fmt.Println("Hint: run 'dex slack index' or pass the user ID (U...)")
+			}
+			return
+		}
+
+		channelID := slack.ResolveChannel(target)
+		switch {
+		case slack.IsConversationID(target):
+			fmt.Println("Kind:        raw conversation ID, used as-is")
+			if ch := idx.FindChannel(target); ch != nil {
+				fmt.Printf("Index:       #%s\n", ch.Name)
+			} else {
+				fmt.Println("Index:       not indexed (normal for DMs and group DMs)")
+			}
+		case channelID != "":
+			ch := idx.FindChannel(target)
+			fmt.Println("Kind:        channel name")
+			visibility := "public"
+			if ch.IsPrivate {
+				visibility = "private"
+			}
+			fmt.Printf("Index:       #%s -> %s (%s", ch.Name, ch.ID, visibility)
+			if ch.IsArchived {
+				fmt.Print(", archived")
+			}
+			fmt.Printf(", %d members, indexed %s)\n", ch.NumMembers, ch.IndexedAt.Local().Format("2006-01-02 15:04"))
+		default:
+			fmt.Println("Kind:        channel name")
+			fmt.Printf("Index:       no match among %d indexed channels\n", len(idx.Channels))
+			fmt.Println()
+			if strings.HasPrefix(target, "#") {
+				fmt.Printf("Hint: channel names are matched without '#', try 'dex slack resolve %s'\n", strings.TrimPrefix(target, "#"))
+			} else {
+				fmt.Println("Hint: run 'dex slack index' (new or renamed channel) or pass the channel ID")
+			}
+			os.Exit(1)
+		}
+		fmt.Printf("Channel ID:  %s\n", channelID)
+
+		fmt.Println()
+		fmt.Println("conversations.info")
+		fmt.Println("────────────────────────────────────────────────────────────────")
+
+		// visible/member per identity, for the hints below
+		visible := map[string]bool{}
+		member := map[string]bool{}
+
+		identities := []struct{ name, token string }{{"bot", cfg.Slack.BotToken}, {"user", cfg.Slack.UserToken}}
+		for _, id := range identities {
+			if id.token == "" {
+				fmt.Printf("  %-5s not configured\n", id.name)
+				continue
+			}
+			client, err := slack.NewClient(id.token)
+			if err != nil {
+				fmt.Printf("  %-5s ✗ %v\n", id.name, err)
+				continue
+			}
+			ch, err := client.GetChannelInfo(channelID)
+			if err != nil {
+				fmt.Printf("  %-5s ✗ %v\n", id.name, err)
+				continue
+			}
+
+			name := "#" + ch.Name
+			if ch.IsIM {
+				name = "DM with " + ch.User
+				if u := idx.FindUser(ch.User); u != nil {
+					name = "DM with @" + u.Username
+				}
+			}
+			visible[id.name] = true
+			member[id.name] = ch.IsMember || ch.IsIM || ch.IsMpIM
+			membership := "not a member"
+			if member[id.name] {
+				membership = "member"
+			}
+			fmt.Printf("  %-5s ✓ %s · %s · %s", id.name, name, slack.ConversationKind(ch), membership)
+			if ch.IsArchived {
+				fmt.Print(" · archived")
+			}
+			fmt.Println()
+		}
+
+		switch {
+		case !visible["bot"] && visible["user"]:
+			fmt.Println()
+			fmt.Println("Hint: only the user token can see this conversation; use --as user")
+		case visible["bot"] && !member["bot"]:
+			fmt.Println()
+			fmt.Printf("Hint: the bot is not a member; run 'dex slack channel join %s' or use --as user\n", target)
+		}
+	},
+}
+
 // completeSlackEmojiNames provides shell completion for emoji names (custom + built-in)
 func completeSlackEmojiNames(toComplete string) []string {
 	toLower := strings.ToLower(toComplete)
@@ -2286,6 +3334,306 @@ Examples:
 	},
 }
 
+var slackExportChannelCmd = &cobra.Command{
+	Use:   "export-channel <channel>",
+	Short: "Export a channel's full history to JSON",
+	Long: `Export the complete message history of a channel to a JSON file for archival.
+
+Paginates conversations.history until the beginning of the channel (or --since),
+resolving user names from the local index. With --include-threads every thread's
+replies are fetched as well and nested under their parent message.
+
+This makes one API call per 200 messages plus one per thread, so large channels
+can take a while: rate-limited requests are retried automatically after the delay
+Slack asks for. Use 'dex slack thread' or 'dex slack search' for quick lookups.
+
+--since and --until accept durations (7d, 12h) or timestamps (2006-01-02 15:04).
+
+Examples:
+  dex slack export-channel dev-team
+  dex slack export-channel dev-team --include-threads
+  dex slack export-channel dev-team --since 30d -f dev-team.json
+  dex slack export-channel C0123456789 --since "2026-01-01" --until "2026-02-01"`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeSlackChannelNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		channelArg := args[0]
+		sinceStr, _ := cmd.Flags().GetString("since")
+		untilStr, _ := cmd.Flags().GetString("until")
+		includeThreads, _ := cmd.Flags().GetBool("include-threads")
+		outPath, _ := cmd.Flags().GetString("file")
+
+		var since, until time.Time
+		var err error
+		if sinceStr != "" {
+			if since, err = parseTimeValue(sinceStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --since value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if untilStr != "" {
+			if until, err = parseTimeValue(untilStr); err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid --until value: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if !since.IsZero() && !until.IsZero() && !since.Before(until) {
+			fmt.Fprintf(os.Stderr, "Invalid time range: --since must be before --until\n")
+			os.Exit(1)
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			fmt.Fprintf(os.Stderr, "Configuration error: %v\n", err)
+			os.Exit(1)
+		}
+
+		client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, cfg.Slack.UserToken)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create Slack client: %v\n", err)
+			os.Exit(1)
+		}
+
+		channelID := slack.ResolveChannel(channelArg)
+		if channelID == "" {
+			fmt.Fprintf(os.Stderr, "Channel %q not found. Run 'dex slack index' or pass a channel ID.\n", channelArg)
+			os.Exit(1)
+		}
+
+		idx, err := slack.LoadIndex()
+		if err != nil {
+			idx = slack.NewSlackIndex("", "")
+		}
+		channelName := channelArg
+		if ch := idx.FindChannel(channelID); ch != nil {
+			channelName = ch.Name
+		}
+
+		if outPath == "" {
+			outPath = fmt.Sprintf("%s-export-%s.json", channelName, time.Now().Format("20060102-150405"))
+		}
+
+		fmt.Fprintf(os.Stderr, "Exporting #%s (this may take a while on large channels; rate limits are retried automatically)\n", channelName)
+		exp, err := client.ExportChannel(channelID, since, until, includeThreads, func(messages, replies int) {
+			if includeThreads {
+				fmt.Fprintf(os.Stderr, "\rFetched %d messages, %d thread replies...", messages, replies)
+			} else {
+				fmt.Fprintf(os.Stderr, "\rFetched %d messages...", messages)
+			}
+		})
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Export failed: %v\n", err)
+			os.Exit(1)
+		}
+		exp.ChannelName = channelName
+
+		// Resolve user names from the index
+		var resolve func(msgs []slack.ExportMessage)
+		resolve = func(msgs []slack.ExportMessage) {
+			for i := range msgs {
+				if msgs[i].Username == "" {
+					if u := idx.FindUser(msgs[i].UserID); u != nil {
+						msgs[i].Username = u.Username
+					}
+				}
+				msgs[i].Text = resolveUserMentions(msgs[i].Text, idx)
+				resolve(msgs[i].Replies)
+			}
+		}
+		resolve(exp.Messages)
+
+		data, err := json.MarshalIndent(exp, "", "  ")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to encode export: %v\n", err)
+			os.Exit(1)
+		}
+		if err := os.WriteFile(outPath, data, 0600); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to write %s: %v\n", outPath, err)
+			os.Exit(1)
+		}
+
+		if includeThreads {
+			fmt.Printf("Exported %d messages and %d thread replies from #%s to %s\n", exp.MessageCount, exp.ReplyCount, channelName, outPath)
+		} else {
+			fmt.Printf("Exported %d messages from #%s to %s\n", exp.MessageCount, channelName, outPath)
+		}
+	},
+}
+
+var slackMeCmd = &cobra.Command{
+	Use:   "me",
+	Short: "Personal dashboard: identity, presence, status, mentions, reminders",
+	Long: `Show a compact "start of day" panel for the authenticated user.
+
+Combines:
+  - Identity (user token)
+  - Presence and custom status
+  - Mentions since midnight, and how many are still unhandled
+  - Open reminders
+
+Requires SLACK_USER_TOKEN. Sections are fetched concurrently; a section that
+fails (e.g. a missing scope) is reported without hiding the others. Mention
+classifications are cached like 'dex slack mentions'.
+
+Examples:
+  dex slack me
+  dex slack me -o json
+  dex slack me -o compact`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfg, err := config.Load()
+		if err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+		if err := cfg.RequireSlack(); err != nil {
+			RenderError(fmt.Errorf("configuration error: %w", err))
+		}
+
+		client, err := slack.NewClientWithUserToken(cfg.Slack.BotToken, cfg.Slack.UserToken)
+		if err != nil {
+			RenderError(fmt.Errorf("failed to create Slack client: %w", err))
+		}
+		if !client.HasUserToken() {
+			RenderError(fmt.Errorf("user token required; configure SLACK_USER_TOKEN or run 'dex slack auth'"))
+		}
+
+		userResp, err := client.TestUserAuth()
+		if err != nil {
+			RenderError(fmt.Errorf("failed to get user identity: %w", err))
+		}
+
+		summary := &slack.MeSummary{
+			UserID:    userResp.UserID,
+			Username:  userResp.User,
+			Team:      userResp.Team,
+			Reminders: []slack.Reminder{},
+		}
+
+		var mu sync.Mutex
+		fail := func(section string, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			if summary.Errors == nil {
+				summary.Errors = make(map[string]string)
+			}
+			summary.Errors[section] = err.Error()
+		}
+
+		var wg sync.WaitGroup
+		wg.Add(4)
+		go func() {
+			defer wg.Done()
+			presence, err := client.GetUserPresence(userResp.UserID)
+			if err != nil {
+				fail("presence", err)
+				return
+			}
+			summary.Presence = presence.Presence
+		}()
+		go func() {
+			defer wg.Done()
+			status, err := client.GetUserStatus(userResp.UserID)
+			if err != nil {
+				fail("status", err)
+				return
+			}
+			summary.Status = status
+		}()
+		go func() {
+			defer wg.Done()
+			reminders, err := client.ListOpenReminders()
+			if err != nil {
+				fail("reminders", err)
+				return
+			}
+			if reminders != nil {
+				summary.Reminders = reminders
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			total, unhandled, err := countTodaysMentions(client, userResp.UserID)
+			if err != nil {
+				fail("mentions", err)
+				return
+			}
+			summary.MentionsToday = total
+			summary.UnhandledMentions = unhandled
+		}()
+		wg.Wait()
+
+		Render(summary)
+	},
+}
+
+// slackMeMaxConcurrentClassify bounds the thread lookups made while
+// classifying mentions for 'dex slack me'.
+const slackMeMaxConcurrentClassify = 4
+
+// countTodaysMentions returns how many mentions of userID were posted since
+// midnight and how many of those are still pending (no reply or reaction).
+func countTodaysMentions(client *slack.Client, userID string) (int, int, error) {
+	now := time.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	mentions, _, err := client.SearchMentions(userID, 100, midnight.Unix())
+	if err != nil {
+		return 0, 0, err
+	}
+
+	myUserIDs := []string{userID}
+	var myBotIDs []string
+	if botUserID, _ := client.GetBotUserID(); botUserID != "" && botUserID != userID {
+		myUserIDs = append(myUserIDs, botUserID)
+	}
+	if botID, _ := client.GetBotID(); botID != "" {
+		myBotIDs = append(myBotIDs, botID)
+	}
+
+	statusCache, _ := slack.LoadMentionStatusCache()
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, slackMeMaxConcurrentClassify)
+	unhandled := 0
+
+	for _, m := range mentions {
+		classifyTS := m.Timestamp
+		if m.ThreadTS != "" {
+			classifyTS = m.ThreadTS
+		}
+
+		mu.Lock()
+		cached := statusCache.Get(m.ChannelID, classifyTS)
+		mu.Unlock()
+		if cached != "" {
+			continue // only Replied/Acked are cached
+		}
+
+		wg.Add(1)
+		go func(channelID, ts string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			status := client.ClassifyMentionStatus(channelID, ts, myUserIDs, myBotIDs)
+			mu.Lock()
+			defer mu.Unlock()
+			statusCache.Set(channelID, ts, status)
+			if status == slack.MentionStatusPending {
+				unhandled++
+			}
+		}(m.ChannelID, classifyTS)
+	}
+	wg.Wait()
+	_ = slack.SaveMentionStatusCache(statusCache)
+
+	return len(mentions), unhandled, nil
+}
+
 // normalizeTimestamp converts Slack URL timestamp format (p1769777574026209) to API format (1769777574.026209)
 func normalizeTimestamp(ts string) string {
 	// Remove 'p' prefix if present (URL format)
@@ -2303,6 +3651,7 @@ func init() {
 	slackCmd.AddCommand(slackAuthCmd)
 	slackCmd.AddCommand(slackTestCmd)
 	slackCmd.AddCommand(slackInfoCmd)
+	slackCmd.AddCommand(slackMeCmd)
 	slackCmd.AddCommand(slackPresenceCmd)
 	slackCmd.AddCommand(slackIndexCmd)
 	slackCmd.AddCommand(slackSendCmd)
@@ -2310,10 +3659,19 @@ func init() {
 	slackCmd.AddCommand(slackDeleteCmd)
 	slackCmd.AddCommand(slackEmojiCmd)
 	slackCmd.AddCommand(slackReactCmd)
+	slackCmd.AddCommand(slackUnreactCmd)
+	slackCmd.AddCommand(slackScheduleCmd)
+	slackCmd.AddCommand(slackScheduledCmd)
+	slackScheduledCmd.AddCommand(slackScheduledListCmd)
+	slackScheduledCmd.AddCommand(slackScheduledCancelCmd)
+	slackCmd.AddCommand(slackPollCmd)
 	slackCmd.AddCommand(slackUnreadsCmd)
 	slackCmd.AddCommand(slackMarkReadCmd)
 	slackCmd.AddCommand(slackChannelsCmd)
 	slackCmd.AddCommand(slackChannelCmd)
+	slackCmd.AddCommand(slackSetTopicCmd)
+	slackCmd.AddCommand(slackSetPurposeCmd)
+	slackCmd.AddCommand(slackResolveCmd)
 	slackCmd.AddCommand(slackUsersCmd)
 	slackCmd.AddCommand(slackMentionsCmd)
 	slackCmd.AddCommand(slackSearchCmd)
@@ -2322,6 +3680,7 @@ func init() {
 	slackCmd.AddCommand(slackBookmarksCmd)
 	slackCmd.AddCommand(slackDownloadCmd)
 	slackCmd.AddCommand(slackFileCmd)
+	slackCmd.AddCommand(slackExportChannelCmd)
 	slackFileCmd.AddCommand(slackFileListCmd)
 	slackFileCmd.AddCommand(slackFileInfoCmd)
 	slackFileCmd.AddCommand(slackFileDownloadCmd)
@@ -2331,10 +3690,24 @@ func init() {
 	slackChannelCmd.AddCommand(slackChannelMembersCmd)
 	slackChannelCmd.AddCommand(slackChannelJoinCmd)
 
+	slackInfoCmd.Flags().Bool("scopes", false, "List OAuth scopes of each token and flag missing ones")
 	slackIndexCmd.Flags().BoolP("force", "f", false, "Force re-index even if cache is fresh")
+	slackIndexCmd.Flags().Bool("incremental", false, "Refresh only what changed since the last index")
 	slackSendCmd.Flags().StringP("thread", "t", "", "Thread timestamp to reply to")
+	slackSendCmd.Flags().Bool("mrkdwn", true, "Format the message as Slack mrkdwn and resolve mentions")
+	slackSendCmd.Flags().Bool("no-mrkdwn", false, "Post the text literally: escape &, <, > and disable formatting")
+	slackSendCmd.Flags().StringSlice("attach-ticket", nil, "Jira issue key to append with summary, status and link (repeatable)")
+	slackSendCmd.Flags().String("from-file", "", "Post the contents of a file as a code block (message becomes the preamble)")
+	slackSendCmd.Flags().String("file", "", "Upload a local file (message becomes its initial comment)")
+	slackScheduleCmd.Flags().String("at", "", "When to post: duration from now (30m, 2h, 1d) or timestamp (2026-02-04 09:00)")
+	slackScheduleCmd.Flags().StringP("thread", "t", "", "Thread timestamp to post the message in")
+	_ = slackScheduleCmd.MarkFlagRequired("at")
+	slackScheduledListCmd.Flags().StringP("channel", "C", "", "Only messages scheduled in this channel")
+	slackScheduledCancelCmd.Flags().StringP("channel", "C", "", "Channel of the message (looked up if omitted)")
+	slackPollCmd.Flags().StringArrayP("option", "O", nil, "Poll option (repeatable, 2-10)")
+	slackPollCmd.Flags().StringP("thread", "t", "", "Thread timestamp to post the poll in")
 	// --as flag: unified identity selector for all write operations
-	for _, cmd := range []*cobra.Command{slackSendCmd, slackEditCmd, slackDeleteCmd, slackReactCmd, slackUploadCmd} {
+	for _, cmd := range []*cobra.Command{slackSendCmd, slackEditCmd, slackDeleteCmd, slackReactCmd, slackUnreactCmd, slackScheduleCmd, slackScheduledListCmd, slackScheduledCancelCmd, slackPollCmd, slackSetTopicCmd, slackSetPurposeCmd, slackUploadCmd} {
 		cmd.Flags().String("as", "bot", "Act as 'bot' (default) or 'user' (requires SLACK_USER_TOKEN)")
 	}
 	slackEmojiCmd.Flags().StringP("filter", "f", "", "Filter emoji by name substring")
@@ -2363,15 +3736,22 @@ func init() {
 	slackMentionsCmd.Flags().BoolP("compact", "c", false, "Compact table view")
 	slackMentionsCmd.Flags().StringP("since", "s", "", "Time period to look back (e.g., 1h, 30m, 7d); defaults to today")
 	slackMentionsCmd.Flags().Bool("unhandled", false, "Only show pending mentions (no reaction or reply)")
+	slackMentionsCmd.Flags().String("group-by", "", "Group results into sections: channel or status")
+	slackMentionsCmd.Flags().StringSliceP("channel", "C", nil, "Only mentions in this channel (name or ID, repeatable)")
+	_ = slackMentionsCmd.RegisterFlagCompletionFunc("channel", func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		return completeSlackChannelNames(cmd, nil, toComplete)
+	})
 	_ = slackMentionsCmd.RegisterFlagCompletionFunc("user", completeSlackUsers)
 
 	slackSearchCmd.Flags().IntP("limit", "l", 50, "Maximum number of results")
 	slackSearchCmd.Flags().StringP("since", "s", "", "Time period to look back (e.g., 1h, 30m, 7d)")
 	slackSearchCmd.Flags().BoolP("tickets", "t", false, "Extract and display Jira ticket references")
 	slackSearchCmd.Flags().BoolP("compact", "c", false, "Compact output (less detail)")
+	slackSearchCmd.Flags().Int("context", 0, "Show N channel messages before and after each result")
 
 	slackThreadCmd.Flags().Bool("compact", false, "One-line-per-message condensed view")
 	slackThreadCmd.Flags().Bool("debug", false, "Show identity info and mention classification details")
+	slackThreadCmd.Flags().Bool("url", false, "Print each message's permalink")
 	slackBookmarksCmd.Flags().Bool("compact", false, "Compact view (one line per bookmark)")
 	initSlackFileFlags()
 
@@ -2379,4 +3759,10 @@ func init() {
 	slackUploadCmd.Flags().StringP("comment", "m", "", "Initial message text posted alongside the file")
 	slackUploadCmd.Flags().StringP("thread", "t", "", "Thread timestamp to upload into (reply to thread)")
 	slackUploadCmd.Flags().String("filename", "", "Override the display filename (defaults to the local file's base name)")
+
+	// export-channel flags
+	slackExportChannelCmd.Flags().StringP("since", "s", "", "Only export messages after this time (duration or timestamp)")
+	slackExportChannelCmd.Flags().StringP("until", "u", "", "Only export messages before this time (duration or timestamp)")
+	slackExportChannelCmd.Flags().Bool("include-threads", false, "Also fetch all thread replies (one extra API call per thread)")
+	slackExportChannelCmd.Flags().StringP("file", "f", "", "Output file (default: <channel>-export-<timestamp>.json)")
 }
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseSlackScheduleTime(t *testing.T) {
+	now := time.Date(2026, 2, 4, 9, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		in      string
+		want    time.Time
+		wantErr bool
+	}{
+		{in: "30m", want: now.Add(30 * time.Minute)},
+		{in: "+2h", want: now.Add(2 * time.Hour)},
+		{in: "1d", want: now.Add(24 * time.Hour)},
+		{in: "2026-02-04 17:30", want: time.Date(2026, 2, 4, 17, 30, 0, 0, time.Local)},
+		{in: "2026-02-05T08:00:00Z", want: time.Date(2026, 2, 5, 8, 0, 0, 0, time.UTC)},
+		{in: "", wantErr: true},
+		{in: "2026-02-04 08:00", wantErr: true}, // past
+		{in: "now", wantErr: true},
+		{in: "2026-07-01", wantErr: true}, // beyond 120 days
+		{in: "tomorrow", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := parseSlackScheduleTime(tt.in, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseSlackScheduleTime(%q) = %v, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || !got.Equal(tt.want) {
+			t.Errorf("parseSlackScheduleTime(%q) = %v, %v; want %v", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestParseSlackThreadRef(t *testing.T) {
+	tests := []struct {
+		name        string
+		args        []string
+		wantChannel string
+		wantTS      string
+	}{
+		{"url", []string{"https://acme.slack.com/archives/C0123456789/p1769777574026209"}, "C0123456789", "1769777574.026209"},
+		{"reply url with thread_ts", []string{"https://acme.slack.com/archives/C0123456789/p1769777600123456?thread_ts=1769777574.026209&cid=C0123456789"}, "C0123456789", "1769777574.026209"},
+		{"thread_ts not first param", []string{"https://acme.slack.com/archives/C0123456789/p1769777600123456?cid=C0123456789&thread_ts=1769777574.026209"}, "C0123456789", "1769777574.026209"},
+		{"url with other params", []string{"https://acme.slack.com/archives/G0123456789/p1769777574026209?cid=G0123456789"}, "G0123456789", "1769777574.026209"},
+		{"channel:ts", []string{"C0123456789:1769777574.026209"}, "C0123456789", "1769777574.026209"},
+		{"channel:p-ts", []string{"C0123456789:p1769777574026209"}, "C0123456789", "1769777574.026209"},
+		{"two args", []string{"C0123456789", "p1769777574026209"}, "C0123456789", "1769777574.026209"},
+		{"url without archives", []string{"https://acme.slack.com/client/T1/C0123456789"}, "", ""},
+		{"garbage", []string{"nothing-here"}, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ch, ts := parseSlackThreadRef(tt.args)
+			if ch != tt.wantChannel || ts != tt.wantTS {
+				t.Errorf("parseSlackThreadRef(%q) = %q, %q; want %q, %q", tt.args, ch, ts, tt.wantChannel, tt.wantTS)
+			}
+		})
+	}
+}
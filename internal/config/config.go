@@ -79,7 +79,8 @@ type LokiConfig struct {
 
 // PrometheusConfig holds Prometheus-specific configuration
 type PrometheusConfig struct {
-	URL string `json:"url,omitempty" envconfig:"PROMETHEUS_URL"`
+	URL             string `json:"url,omitempty" envconfig:"PROMETHEUS_URL"`
+	AlertmanagerURL string `json:"alertmanager_url,omitempty" envconfig:"ALERTMANAGER_URL"`
 }
 
 // HomerConfig holds Homer SIP tracing configuration
@@ -88,6 +89,13 @@ type HomerConfig struct {
 	Username  string                   `json:"username,omitempty" envconfig:"HOMER_USERNAME"`
 	Password  string                   `json:"password,omitempty" envconfig:"HOMER_PASSWORD"`
 	Endpoints map[string]HomerEndpoint `json:"endpoints,omitempty"`
+	Queries   map[string]string        `json:"queries,omitempty"` // saved search expressions by name
+
+	// InternalPatterns mark the platform side of a call for direction
+	// inference: number patterns ("%" wildcard, e.g. "4930555%") matched
+	// against caller/callee, and IPs or CIDRs (e.g. "10.20.0.0/16") matched
+	// against the first INVITE's source/destination.
+	InternalPatterns []string `json:"internal_patterns,omitempty"`
 }
 
 // HomerEndpoint holds credentials for a specific Homer endpoint
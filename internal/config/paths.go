@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codewandler/dex/internal/render"
+)
+
+// Location is a resolved path of a file or directory dex reads or writes
+type Location struct {
+	Name   string `json:"name"`
+	Path   string `json:"path"`
+	Exists bool   `json:"exists"`
+	Error  string `json:"error,omitempty"` // set when the path could not be resolved or checked
+}
+
+// Locations lists where dex keeps its config and local data
+type Locations struct {
+	Locations []Location `json:"locations"`
+}
+
+// Add resolves a location from a path lookup and records whether it exists
+func (l *Locations) Add(name string, path string, err error) {
+	loc := Location{Name: name, Path: path}
+	if err == nil {
+		_, err = os.Stat(path)
+		if err == nil {
+			loc.Exists = true
+		} else if os.IsNotExist(err) {
+			err = nil
+		}
+	}
+	if err != nil {
+		loc.Error = err.Error()
+	}
+	l.Locations = append(l.Locations, loc)
+}
+
+// RenderText implements render.Renderable on Locations.
+// ModeCompact: one "name path" line per location.
+// ModeNormal: an aligned table with an existence marker per location.
+func (l *Locations) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, loc := range l.Locations {
+			fmt.Fprintf(&sb, "%s %s\n", loc.Name, loc.Path)
+		}
+		return sb.String()
+	}
+
+	width := 0
+	for _, loc := range l.Locations {
+		width = max(width, len(loc.Name))
+	}
+
+	fmt.Fprintln(&sb)
+	for _, loc := range l.Locations {
+		status := "✓"
+		switch {
+		case loc.Error != "":
+			status = "✗"
+		case !loc.Exists:
+			status = "-"
+		}
+		fmt.Fprintf(&sb, "  %s %-*s  %s", status, width, loc.Name, loc.Path)
+		switch {
+		case loc.Error != "":
+			fmt.Fprintf(&sb, "  (%s)", loc.Error)
+		case !loc.Exists:
+			fmt.Fprint(&sb, "  (missing)")
+		}
+		fmt.Fprintln(&sb)
+	}
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocationsAdd(t *testing.T) {
+	dir := t.TempDir()
+	present := filepath.Join(dir, "config.json")
+	if err := os.WriteFile(present, []byte("{}"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	var l Locations
+	l.Add("config", present, nil)
+	l.Add("index", filepath.Join(dir, "missing", "index.json"), nil)
+	l.Add("broken", "", errors.New("no home"))
+
+	got := l.Locations
+	if !got[0].Exists || got[0].Error != "" {
+		t.Errorf("present file: got %+v", got[0])
+	}
+	if got[1].Exists || got[1].Error != "" {
+		t.Errorf("missing file: got %+v", got[1])
+	}
+	if got[2].Exists || got[2].Error != "no home" {
+		t.Errorf("lookup error: got %+v", got[2])
+	}
+}
@@ -0,0 +1,119 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// Parse decodes a config file strictly: unknown keys, wrongly typed values and
+// trailing data are rejected, so a malformed file is never half-imported.
+func Parse(data []byte) (*Config, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return nil, errors.New("empty config")
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, fmt.Errorf("invalid config: %w", err)
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("invalid config: unexpected data after the JSON object")
+	}
+	return &cfg, nil
+}
+
+// Redacted returns a copy of the config with all credentials removed: tokens,
+// passwords, OAuth client secrets and stored OAuth tokens.
+func (c *Config) Redacted() *Config {
+	r := *c
+
+	r.GitLab.Token = ""
+	r.Jira.ClientSecret = ""
+	r.Jira.Token = nil
+	r.Confluence.ClientSecret = ""
+	r.Confluence.Token = nil
+	r.Slack.ClientSecret = ""
+	r.Slack.Token = nil
+	r.Slack.BotToken = ""
+	r.Slack.AppToken = ""
+	r.Slack.UserToken = ""
+	r.Homer.Password = ""
+
+	if c.Homer.Endpoints != nil {
+		r.Homer.Endpoints = make(map[string]HomerEndpoint, len(c.Homer.Endpoints))
+		for name, ep := range c.Homer.Endpoints {
+			ep.Password = ""
+			r.Homer.Endpoints[name] = ep
+		}
+	}
+	if c.SQL.Datasources != nil {
+		r.SQL.Datasources = make(map[string]SQLDatasource, len(c.SQL.Datasources))
+		for name, ds := range c.SQL.Datasources {
+			ds.Password = ""
+			r.SQL.Datasources[name] = ds
+		}
+	}
+	return &r
+}
+
+// Merge returns base with the values set in overlay applied on top. Nested
+// objects (integrations, map entries) are merged key by key; empty strings and
+// nulls in overlay never clear a value in base, so a redacted export can be
+// merged without dropping existing secrets.
+func Merge(base, overlay *Config) (*Config, error) {
+	baseMap, err := toJSONMap(base)
+	if err != nil {
+		return nil, err
+	}
+	overlayMap, err := toJSONMap(overlay)
+	if err != nil {
+		return nil, err
+	}
+	mergeJSONMaps(baseMap, overlayMap)
+
+	data, err := json.Marshal(baseMap)
+	if err != nil {
+		return nil, err
+	}
+	var merged Config
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, err
+	}
+	return &merged, nil
+}
+
+func toJSONMap(cfg *Config) (map[string]any, error) {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	m := make(map[string]any)
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func mergeJSONMaps(dst, src map[string]any) {
+	for key, val := range src {
+		switch v := val.(type) {
+		case nil:
+			continue
+		case string:
+			if v == "" {
+				continue
+			}
+		case map[string]any:
+			if existing, ok := dst[key].(map[string]any); ok {
+				mergeJSONMaps(existing, v)
+				continue
+			}
+		}
+		dst[key] = val
+	}
+}
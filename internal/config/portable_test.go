@@ -0,0 +1,80 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseRejectsMalformed(t *testing.T) {
+	for name, data := range map[string]string{
+		"empty":         "  ",
+		"unknown key":   `{"gitlab": {"url": "https://gitlab.example.com", "tokn": "x"}}`,
+		"wrong type":    `{"activity_days": "14"}`,
+		"not an object": `["gitlab"]`,
+		"trailing data": `{"loki": {"url": "http://loki:3100"}} {}`,
+	} {
+		if _, err := Parse([]byte(data)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	cfg, err := Parse([]byte(`{"loki": {"url": "http://loki:3100"}}`))
+	if err != nil || cfg.Loki.URL != "http://loki:3100" {
+		t.Errorf("valid config: got %+v, %v", cfg, err)
+	}
+}
+
+func TestRedactedKeepsOriginal(t *testing.T) {
+	cfg := &Config{
+		GitLab: GitLabConfig{URL: "https://gitlab.example.com", Token: "glpat-secret"},
+		Homer:  HomerConfig{Endpoints: map[string]HomerEndpoint{"prod": {Username: "admin", Password: "pw"}}},
+		SQL:    SQLConfig{Datasources: map[string]SQLDatasource{"main": {Host: "db", Password: "pw"}}},
+	}
+
+	r := cfg.Redacted()
+	if r.GitLab.Token != "" || r.GitLab.URL == "" {
+		t.Errorf("gitlab: got %+v", r.GitLab)
+	}
+	if ep := r.Homer.Endpoints["prod"]; ep.Password != "" || ep.Username != "admin" {
+		t.Errorf("homer endpoint: got %+v", ep)
+	}
+	if ds := r.SQL.Datasources["main"]; ds.Password != "" || ds.Host != "db" {
+		t.Errorf("sql datasource: got %+v", ds)
+	}
+	if cfg.Homer.Endpoints["prod"].Password != "pw" || cfg.SQL.Datasources["main"].Password != "pw" {
+		t.Error("Redacted modified the original config")
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := &Config{
+		GitLab: GitLabConfig{URL: "https://old.example.com", Token: "glpat-keep"},
+		Homer:  HomerConfig{Queries: map[string]string{"a": "method = INVITE"}},
+		SQL:    SQLConfig{Datasources: map[string]SQLDatasource{"main": {Host: "db", Password: "pw"}}},
+	}
+	overlay, err := Parse([]byte(`{
+  "gitlab": {"url": "https://new.example.com"},
+  "homer": {"queries": {"b": "status = 486"}},
+  "sql": {"datasources": {"main": {"host": "db2", "username": "app", "password": "", "database": "x"}}}
+}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	merged, err := Merge(base, overlay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.GitLab.URL != "https://new.example.com" || merged.GitLab.Token != "glpat-keep" {
+		t.Errorf("gitlab: got %+v", merged.GitLab)
+	}
+	if len(merged.Homer.Queries) != 2 {
+		t.Errorf("homer queries: got %v, want a and b", merged.Homer.Queries)
+	}
+	if ds := merged.SQL.Datasources["main"]; ds.Host != "db2" || ds.Password != "pw" || ds.Username != "app" {
+		t.Errorf("sql datasource: got %+v", ds)
+	}
+	if !strings.HasPrefix(base.GitLab.URL, "https://old") {
+		t.Error("Merge modified the base config")
+	}
+}
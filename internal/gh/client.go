@@ -198,7 +198,7 @@ const issueListQuery = `query($owner: String!, $repo: String!, $first: Int!, $af
       totalCount
       pageInfo { endCursor hasNextPage }
       nodes {
-        number title state url createdAt
+        number title state url createdAt body
         author { login }
         labels(first: 20) { nodes { name } }
         assignees(first: 10) { nodes { login } }
@@ -346,6 +346,7 @@ func (c *Client) IssueList(opts IssueListOptions) (*IssueListResult, error) {
 						State     string `json:"state"`
 						URL       string `json:"url"`
 						CreatedAt string `json:"createdAt"`
+						Body      string `json:"body"`
 						Author    struct {
 							Login string `json:"login"`
 						} `json:"author"`
@@ -407,6 +408,7 @@ func (c *Client) IssueList(opts IssueListOptions) (*IssueListResult, error) {
 			Assignees: assignees,
 			CreatedAt: node.CreatedAt,
 			URL:       node.URL,
+			Body:      node.Body,
 		})
 	}
 	if result.Issues == nil {
@@ -438,6 +440,15 @@ func (c *Client) resolveRepo(repoFlag string) (owner, repo string, err error) {
 	return parts[0], parts[1], nil
 }
 
+// RepoName returns "owner/repo" from the --repo flag or the current git remote.
+func (c *Client) RepoName(repoFlag string) (string, error) {
+	owner, repo, err := c.resolveRepo(repoFlag)
+	if err != nil {
+		return "", err
+	}
+	return owner + "/" + repo, nil
+}
+
 // IssueView retrieves a single issue by number
 func (c *Client) IssueView(number int, repo string) (*Issue, error) {
 	args := []string{"issue", "view", fmt.Sprintf("%d", number), "--json", "number,title,state,author,labels,assignees,createdAt,url,body"}
@@ -499,6 +510,65 @@ func (c *Client) IssueView(number int, repo string) (*Issue, error) {
 	}, nil
 }
 
+// IssueComment is a single comment in an issue's discussion
+type IssueComment struct {
+	Author    string `json:"author"`
+	CreatedAt string `json:"createdAt"`
+	Body      string `json:"body"`
+	URL       string `json:"url"`
+}
+
+// IssueComments retrieves all comments on an issue, oldest first
+func (c *Client) IssueComments(number int, repo string) (*IssueCommentsResult, error) {
+	args := []string{"issue", "view", fmt.Sprintf("%d", number), "--json", "number,title,comments"}
+
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+
+	cmd := exec.Command("gh", args...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh issue view failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh issue view failed: %w", err)
+	}
+
+	var raw struct {
+		Number   int    `json:"number"`
+		Title    string `json:"title"`
+		Comments []struct {
+			Author struct {
+				Login string `json:"login"`
+			} `json:"author"`
+			CreatedAt string `json:"createdAt"`
+			Body      string `json:"body"`
+			URL       string `json:"url"`
+		} `json:"comments"`
+	}
+
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse issue comments: %w", err)
+	}
+
+	result := &IssueCommentsResult{
+		Number:   raw.Number,
+		Title:    raw.Title,
+		Comments: make([]IssueComment, len(raw.Comments)),
+	}
+	for i, cm := range raw.Comments {
+		result.Comments[i] = IssueComment{
+			Author:    cm.Author.Login,
+			CreatedAt: cm.CreatedAt,
+			Body:      cm.Body,
+			URL:       cm.URL,
+		}
+	}
+
+	return result, nil
+}
+
 // IssueCreateOptions contains options for creating an issue
 type IssueCreateOptions struct {
 	Title    string
@@ -756,46 +826,17 @@ type ReleaseCreateOptions struct {
 	Prerelease    bool
 	Latest        *bool // nil = auto, true = mark as latest, false = don't mark
 	Target        string
+	Assets        []string // local files to attach, optionally "path#display label"
 	Repo          string
 }
 
 // ReleaseCreate creates a new release
 func (c *Client) ReleaseCreate(opts ReleaseCreateOptions) (*Release, error) {
-	args := []string{"release", "create", opts.Tag}
-
-	if opts.Title != "" {
-		args = append(args, "--title", opts.Title)
-	}
-	if opts.Notes != "" {
-		args = append(args, "--notes", opts.Notes)
-	}
-	if opts.NotesFile != "" {
-		args = append(args, "--notes-file", opts.NotesFile)
-	}
-	if opts.GenerateNotes {
-		args = append(args, "--generate-notes")
-	}
-	if opts.Draft {
-		args = append(args, "--draft")
-	}
-	if opts.Prerelease {
-		args = append(args, "--prerelease")
-	}
-	if opts.Latest != nil {
-		if *opts.Latest {
-			args = append(args, "--latest")
-		} else {
-			args = append(args, "--latest=false")
-		}
-	}
-	if opts.Target != "" {
-		args = append(args, "--target", opts.Target)
-	}
-	if opts.Repo != "" {
-		args = append(args, "--repo", opts.Repo)
+	if err := checkAssetFiles(opts.Assets); err != nil {
+		return nil, err
 	}
 
-	cmd := exec.Command("gh", args...)
+	cmd := exec.Command("gh", releaseCreateArgs(opts)...)
 	output, err := cmd.CombinedOutput()
 	if err != nil {
 		return nil, fmt.Errorf("gh release create failed: %s", string(output))
@@ -938,6 +979,7 @@ type LabelCreateOptions struct {
 	Description string
 	Color       string // hex color without # prefix
 	Repo        string
+	Force       bool // update the label if it already exists
 }
 
 // LabelCreate creates a new label
@@ -953,6 +995,9 @@ func (c *Client) LabelCreate(opts LabelCreateOptions) (*Label, error) {
 	if opts.Repo != "" {
 		args = append(args, "--repo", opts.Repo)
 	}
+	if opts.Force {
+		args = append(args, "--force")
+	}
 
 	cmd := exec.Command("gh", args...)
 	output, err := cmd.CombinedOutput()
@@ -993,6 +1038,8 @@ func (c *Client) LabelDelete(opts LabelDeleteOptions) error {
 
 // SearchIssuesOptions contains options for searching issues globally
 type SearchIssuesOptions struct {
+	Query    string // GitHub search query, e.g. "label:bug no:assignee"
+	Repo     string // owner/repo; empty searches all repos
 	Assignee string
 	Author   string
 	State    string // open, closed
@@ -1015,6 +1062,15 @@ func (c *Client) SearchIssues(opts SearchIssuesOptions) ([]Issue, error) {
 	if opts.Limit > 0 {
 		args = append(args, "--limit", fmt.Sprintf("%d", opts.Limit))
 	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	if opts.Query != "" {
+		// One argument per term so gh does not quote qualifiers as a phrase;
+		// "--" keeps exclusions like -label:wontfix from being read as flags
+		args = append(args, "--")
+		args = append(args, strings.Fields(opts.Query)...)
+	}
 
 	cmd := exec.Command("gh", args...)
 	output, err := cmd.Output()
@@ -1159,11 +1215,13 @@ type PR struct {
 	Assignees []string `json:"assignees"`
 	URL       string   `json:"url"`
 	IsDraft   bool     `json:"isDraft"`
+	Labels    []string `json:"labels,omitempty"`
 }
 
 // PRListOptions contains options for listing pull requests
 type PRListOptions struct {
 	State    string // open, closed, merged, all
+	Labels   []string
 	Assignee string
 	Author   string
 	Limit    int
@@ -1172,25 +1230,7 @@ type PRListOptions struct {
 
 // PRList lists pull requests in a repository
 func (c *Client) PRList(opts PRListOptions) ([]PR, error) {
-	args := []string{"pr", "list", "--json", "number,title,state,author,assignees,url,isDraft"}
-
-	if opts.State != "" {
-		args = append(args, "--state", opts.State)
-	}
-	if opts.Assignee != "" {
-		args = append(args, "--assignee", opts.Assignee)
-	}
-	if opts.Author != "" {
-		args = append(args, "--author", opts.Author)
-	}
-	if opts.Limit > 0 {
-		args = append(args, "--limit", fmt.Sprintf("%d", opts.Limit))
-	}
-	if opts.Repo != "" {
-		args = append(args, "--repo", opts.Repo)
-	}
-
-	cmd := exec.Command("gh", args...)
+	cmd := exec.Command("gh", prListArgs(opts)...)
 	output, err := cmd.Output()
 	if err != nil {
 		if exitErr, ok := err.(*exec.ExitError); ok {
@@ -1211,6 +1251,9 @@ func (c *Client) PRList(opts PRListOptions) ([]PR, error) {
 		} `json:"assignees"`
 		URL     string `json:"url"`
 		IsDraft bool   `json:"isDraft"`
+		Labels  []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
 	}
 
 	if err := json.Unmarshal(output, &rawPRs); err != nil {
@@ -1223,6 +1266,10 @@ func (c *Client) PRList(opts PRListOptions) ([]PR, error) {
 		for j, a := range raw.Assignees {
 			assignees[j] = a.Login
 		}
+		var labels []string
+		for _, l := range raw.Labels {
+			labels = append(labels, l.Name)
+		}
 		prs = append(prs, PR{
 			Number:    raw.Number,
 			Title:     raw.Title,
@@ -1231,6 +1278,7 @@ func (c *Client) PRList(opts PRListOptions) ([]PR, error) {
 			Assignees: assignees,
 			URL:       raw.URL,
 			IsDraft:   raw.IsDraft,
+			Labels:    labels,
 		})
 	}
 
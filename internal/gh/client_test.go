@@ -0,0 +1,59 @@
+package gh
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestIssueJSON(t *testing.T) {
+	issue := Issue{
+		Number:    42,
+		Title:     "Crash on start",
+		State:     "OPEN",
+		Author:    "octocat",
+		Labels:    []string{"bug", "p1"},
+		Assignees: []string{"hubot"},
+		CreatedAt: "2024-03-01T12:00:00Z",
+		URL:       "https://github.com/owner/repo/issues/42",
+		Body:      "Steps to reproduce:\n1. start",
+	}
+
+	// --json on issue view prints IssueResult; the embedded Issue's fields
+	// must appear at the top level
+	data, err := json.Marshal(&IssueResult{Issue: &issue})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	for _, key := range []string{"number", "title", "state", "author", "labels", "assignees", "url", "body", "createdAt"} {
+		if _, ok := fields[key]; !ok {
+			t.Errorf("serialized issue lacks %q: %s", key, data)
+		}
+	}
+
+	var got Issue
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, issue) {
+		t.Errorf("round trip:\n got  %+v\n want %+v", got, issue)
+	}
+
+	// issue list prints IssueListResult
+	list := IssueListResult{Issues: []Issue{issue}, NextCursor: "Y3Vyc29y", HasMore: true, TotalCount: 7}
+	data, err = json.Marshal(&list)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var gotList IssueListResult
+	if err := json.Unmarshal(data, &gotList); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(gotList, list) {
+		t.Errorf("list round trip:\n got  %+v\n want %+v", gotList, list)
+	}
+}
@@ -0,0 +1,140 @@
+package gh
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// labelFile is the object form of a label sync file
+type labelFile struct {
+	Labels []Label `json:"labels"`
+}
+
+// LoadLabelFile reads a label set from a YAML or JSON file. The file is either
+// a list of labels or an object with a "labels" list; each label has name,
+// color (hex, "#" optional) and description.
+func LoadLabelFile(path string) ([]Label, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read label file: %w", err)
+	}
+
+	var labels []Label
+	if err := yaml.Unmarshal(data, &labels); err != nil {
+		var f labelFile
+		if err2 := yaml.Unmarshal(data, &f); err2 != nil {
+			return nil, fmt.Errorf("failed to parse label file: %w", err)
+		}
+		labels = f.Labels
+	}
+
+	seen := make(map[string]bool)
+	for i := range labels {
+		labels[i].Name = strings.TrimSpace(labels[i].Name)
+		labels[i].Color = normalizeLabelColor(labels[i].Color)
+		if labels[i].Name == "" {
+			return nil, fmt.Errorf("label #%d has no name", i+1)
+		}
+		key := strings.ToLower(labels[i].Name)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate label %q", labels[i].Name)
+		}
+		seen[key] = true
+	}
+	return labels, nil
+}
+
+// LabelSyncPlan lists what a label sync changes
+type LabelSyncPlan struct {
+	Create    []Label `json:"create,omitempty"`
+	Update    []Label `json:"update,omitempty"`
+	Unchanged []Label `json:"unchanged,omitempty"`
+	Delete    []Label `json:"delete,omitempty"` // only filled when pruning
+}
+
+// PlanLabelSync compares the desired labels with the existing ones. Names
+// match case-insensitively (as on GitHub). A label is updated when its color
+// or description differ; an empty color in the file keeps the existing color.
+// With prune, existing labels not in the file are scheduled for deletion.
+func PlanLabelSync(desired, existing []Label, prune bool) LabelSyncPlan {
+	existingByName := make(map[string]Label, len(existing))
+	for _, l := range existing {
+		existingByName[strings.ToLower(l.Name)] = l
+	}
+
+	var plan LabelSyncPlan
+	wanted := make(map[string]bool, len(desired))
+	for _, d := range desired {
+		key := strings.ToLower(d.Name)
+		wanted[key] = true
+		cur, ok := existingByName[key]
+		if !ok {
+			plan.Create = append(plan.Create, d)
+			continue
+		}
+		if d.Color == "" {
+			d.Color = normalizeLabelColor(cur.Color)
+		}
+		if d.Name != cur.Name || d.Color != normalizeLabelColor(cur.Color) || d.Description != cur.Description {
+			plan.Update = append(plan.Update, d)
+		} else {
+			plan.Unchanged = append(plan.Unchanged, d)
+		}
+	}
+
+	if prune {
+		for _, l := range existing {
+			if !wanted[strings.ToLower(l.Name)] {
+				plan.Delete = append(plan.Delete, l)
+			}
+		}
+		sort.Slice(plan.Delete, func(i, j int) bool { return plan.Delete[i].Name < plan.Delete[j].Name })
+	}
+	return plan
+}
+
+func normalizeLabelColor(c string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(c), "#"))
+}
+
+// IssueLabelChange is the label edit planned (or applied) for one issue
+type IssueLabelChange struct {
+	Number int      `json:"number"`
+	Title  string   `json:"title"`
+	URL    string   `json:"url"`
+	Add    []string `json:"add,omitempty"`
+	Remove []string `json:"remove,omitempty"`
+	Error  string   `json:"error,omitempty"`
+}
+
+// Unchanged reports whether the issue already has the wanted labels
+func (c IssueLabelChange) Unchanged() bool {
+	return len(c.Add) == 0 && len(c.Remove) == 0
+}
+
+// PlanIssueLabels works out which of the requested labels actually change the
+// issue: labels to add that it does not have yet and labels to remove that it
+// has. Names match case-insensitively.
+func PlanIssueLabels(issue Issue, add, remove []string) IssueLabelChange {
+	has := make(map[string]bool, len(issue.Labels))
+	for _, l := range issue.Labels {
+		has[strings.ToLower(l)] = true
+	}
+
+	change := IssueLabelChange{Number: issue.Number, Title: issue.Title, URL: issue.URL}
+	for _, l := range add {
+		if !has[strings.ToLower(l)] {
+			change.Add = append(change.Add, l)
+		}
+	}
+	for _, l := range remove {
+		if has[strings.ToLower(l)] {
+			change.Remove = append(change.Remove, l)
+		}
+	}
+	return change
+}
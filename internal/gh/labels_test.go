@@ -0,0 +1,97 @@
+package gh
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadLabelFile(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlList := filepath.Join(dir, "labels.yaml")
+	os.WriteFile(yamlList, []byte(`- name: bug
+  color: "#D73A4A"
+  description: Something isn't working
+- name: enhancement
+`), 0o644)
+
+	jsonObj := filepath.Join(dir, "labels.json")
+	os.WriteFile(jsonObj, []byte(`{"labels": [{"name": "bug", "color": "d73a4a", "description": "Something isn't working"}, {"name": "enhancement"}]}`), 0o644)
+
+	for _, path := range []string{yamlList, jsonObj} {
+		labels, err := LoadLabelFile(path)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", path, err)
+		}
+		if len(labels) != 2 {
+			t.Fatalf("%s: expected 2 labels, got %d", path, len(labels))
+		}
+		if labels[0].Name != "bug" || labels[0].Color != "d73a4a" || labels[0].Description != "Something isn't working" {
+			t.Errorf("%s: unexpected first label %+v", path, labels[0])
+		}
+	}
+
+	dup := filepath.Join(dir, "dup.yaml")
+	os.WriteFile(dup, []byte("- name: bug\n- name: Bug\n"), 0o644)
+	if _, err := LoadLabelFile(dup); err == nil {
+		t.Error("expected error for duplicate label names")
+	}
+}
+
+func TestPlanLabelSync(t *testing.T) {
+	existing := []Label{
+		{Name: "bug", Color: "D73A4A", Description: "Something isn't working"},
+		{Name: "Enhancement", Color: "a2eeef", Description: ""},
+		{Name: "docs", Color: "0075ca", Description: "Old"},
+		{Name: "wontfix", Color: "ffffff"},
+	}
+	desired := []Label{
+		{Name: "bug", Color: "d73a4a", Description: "Something isn't working"}, // unchanged (color case)
+		{Name: "enhancement", Color: "a2eeef"},                                 // update: name case
+		{Name: "docs", Description: "Documentation"},                           // update: description, keeps color
+		{Name: "triage", Color: "ededed"},                                      // create
+	}
+
+	plan := PlanLabelSync(desired, existing, false)
+	if len(plan.Create) != 1 || plan.Create[0].Name != "triage" {
+		t.Errorf("create: %+v", plan.Create)
+	}
+	if len(plan.Update) != 2 || plan.Update[0].Name != "enhancement" || plan.Update[1].Name != "docs" {
+		t.Errorf("update: %+v", plan.Update)
+	}
+	if plan.Update[1].Color != "0075ca" {
+		t.Errorf("expected empty color to keep existing, got %q", plan.Update[1].Color)
+	}
+	if len(plan.Unchanged) != 1 || plan.Unchanged[0].Name != "bug" {
+		t.Errorf("unchanged: %+v", plan.Unchanged)
+	}
+	if len(plan.Delete) != 0 {
+		t.Errorf("expected no deletions without prune, got %+v", plan.Delete)
+	}
+
+	plan = PlanLabelSync(desired, existing, true)
+	if len(plan.Delete) != 1 || plan.Delete[0].Name != "wontfix" {
+		t.Errorf("prune: %+v", plan.Delete)
+	}
+}
+
+func TestPlanIssueLabels(t *testing.T) {
+	issue := Issue{Number: 7, Title: "Crash on start", Labels: []string{"Bug", "needs-triage"}}
+
+	c := PlanIssueLabels(issue, []string{"bug", "p1"}, []string{"needs-triage", "wontfix"})
+	if len(c.Add) != 1 || c.Add[0] != "p1" {
+		t.Errorf("Add = %v, want [p1]", c.Add)
+	}
+	if len(c.Remove) != 1 || c.Remove[0] != "needs-triage" {
+		t.Errorf("Remove = %v, want [needs-triage]", c.Remove)
+	}
+	if c.Unchanged() {
+		t.Error("expected a change")
+	}
+
+	c = PlanIssueLabels(issue, []string{"BUG"}, []string{"wontfix"})
+	if !c.Unchanged() {
+		t.Errorf("expected no change, got +%v -%v", c.Add, c.Remove)
+	}
+}
@@ -0,0 +1,257 @@
+package gh
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// PRDetail is a single pull request with its description and merge state
+type PRDetail struct {
+	PR
+	Body           string `json:"body"`
+	BaseRef        string `json:"baseRefName"`
+	HeadRef        string `json:"headRefName"`
+	CreatedAt      string `json:"createdAt"`
+	MergedAt       string `json:"mergedAt,omitempty"`
+	ReviewDecision string `json:"reviewDecision,omitempty"` // APPROVED, CHANGES_REQUESTED, REVIEW_REQUIRED
+	Mergeable      string `json:"mergeable,omitempty"`      // MERGEABLE, CONFLICTING, UNKNOWN
+	Additions      int    `json:"additions"`
+	Deletions      int    `json:"deletions"`
+	ChangedFiles   int    `json:"changedFiles"`
+}
+
+// PRCreateOptions contains options for creating a pull request
+type PRCreateOptions struct {
+	Title string
+	Body  string
+	Base  string // base branch (default: the repository's default branch)
+	Draft bool
+	Repo  string
+}
+
+// PRMergeOptions contains options for merging a pull request
+type PRMergeOptions struct {
+	Number       int
+	Method       string // merge, squash or rebase (default: merge)
+	DeleteBranch bool
+	Repo         string
+}
+
+const prListFields = "number,title,state,author,assignees,url,isDraft,labels"
+
+const prViewFields = "number,title,state,author,assignees,labels,url,isDraft,body,baseRefName,headRefName,createdAt,mergedAt,reviewDecision,mergeable,additions,deletions,changedFiles"
+
+func prListArgs(opts PRListOptions) []string {
+	args := []string{"pr", "list", "--json", prListFields}
+
+	if opts.State != "" {
+		args = append(args, "--state", opts.State)
+	}
+	for _, label := range opts.Labels {
+		args = append(args, "--label", label)
+	}
+	if opts.Assignee != "" {
+		args = append(args, "--assignee", opts.Assignee)
+	}
+	if opts.Author != "" {
+		args = append(args, "--author", opts.Author)
+	}
+	if opts.Limit > 0 {
+		args = append(args, "--limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return args
+}
+
+func prViewArgs(number int, repo string) []string {
+	args := []string{"pr", "view", fmt.Sprintf("%d", number), "--json", prViewFields}
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+	return args
+}
+
+// prCreateArgs always passes --body: without it gh prompts for one, which
+// fails when not attached to a terminal.
+func prCreateArgs(opts PRCreateOptions) []string {
+	args := []string{"pr", "create", "--title", opts.Title, "--body", opts.Body}
+
+	if opts.Base != "" {
+		args = append(args, "--base", opts.Base)
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return args
+}
+
+func prCheckoutArgs(number int, repo string) []string {
+	args := []string{"pr", "checkout", fmt.Sprintf("%d", number)}
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+	return args
+}
+
+// prMergeArgs always names the merge method, since gh asks for one
+// interactively otherwise.
+func prMergeArgs(opts PRMergeOptions) ([]string, error) {
+	method := opts.Method
+	if method == "" {
+		method = "merge"
+	}
+	switch method {
+	case "merge", "squash", "rebase":
+	default:
+		return nil, fmt.Errorf("invalid merge method %q (use merge, squash or rebase)", opts.Method)
+	}
+
+	args := []string{"pr", "merge", fmt.Sprintf("%d", opts.Number), "--" + method}
+	if opts.DeleteBranch {
+		args = append(args, "--delete-branch")
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return args, nil
+}
+
+// PRView retrieves a single pull request by number
+func (c *Client) PRView(number int, repo string) (*PRDetail, error) {
+	cmd := exec.Command("gh", prViewArgs(number, repo)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh pr view failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh pr view failed: %w", err)
+	}
+
+	var raw struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+		State  string `json:"state"`
+		Author struct {
+			Login string `json:"login"`
+		} `json:"author"`
+		Assignees []struct {
+			Login string `json:"login"`
+		} `json:"assignees"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		URL            string `json:"url"`
+		IsDraft        bool   `json:"isDraft"`
+		Body           string `json:"body"`
+		BaseRefName    string `json:"baseRefName"`
+		HeadRefName    string `json:"headRefName"`
+		CreatedAt      string `json:"createdAt"`
+		MergedAt       string `json:"mergedAt"`
+		ReviewDecision string `json:"reviewDecision"`
+		Mergeable      string `json:"mergeable"`
+		Additions      int    `json:"additions"`
+		Deletions      int    `json:"deletions"`
+		ChangedFiles   int    `json:"changedFiles"`
+	}
+
+	if err := json.Unmarshal(output, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse pull request: %w", err)
+	}
+
+	assignees := make([]string, len(raw.Assignees))
+	for i, a := range raw.Assignees {
+		assignees[i] = a.Login
+	}
+	var labels []string
+	for _, l := range raw.Labels {
+		labels = append(labels, l.Name)
+	}
+
+	return &PRDetail{
+		PR: PR{
+			Number:    raw.Number,
+			Title:     raw.Title,
+			State:     raw.State,
+			Author:    raw.Author.Login,
+			Assignees: assignees,
+			URL:       raw.URL,
+			IsDraft:   raw.IsDraft,
+			Labels:    labels,
+		},
+		Body:           raw.Body,
+		BaseRef:        raw.BaseRefName,
+		HeadRef:        raw.HeadRefName,
+		CreatedAt:      raw.CreatedAt,
+		MergedAt:       raw.MergedAt,
+		ReviewDecision: raw.ReviewDecision,
+		Mergeable:      raw.Mergeable,
+		Additions:      raw.Additions,
+		Deletions:      raw.Deletions,
+		ChangedFiles:   raw.ChangedFiles,
+	}, nil
+}
+
+// PRCreate opens a pull request from the current branch
+func (c *Client) PRCreate(opts PRCreateOptions) (*PR, error) {
+	cmd := exec.Command("gh", prCreateArgs(opts)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh pr create failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh pr create failed: %w", err)
+	}
+
+	// gh pr create prints the URL of the new pull request last
+	// (e.g., https://github.com/owner/repo/pull/123)
+	lines := strings.Split(strings.TrimSpace(string(output)), "\n")
+	url := strings.TrimSpace(lines[len(lines)-1])
+
+	var number int
+	parts := strings.Split(url, "/")
+	fmt.Sscanf(parts[len(parts)-1], "%d", &number)
+
+	return &PR{
+		Number:  number,
+		Title:   opts.Title,
+		State:   "OPEN",
+		URL:     url,
+		IsDraft: opts.Draft,
+	}, nil
+}
+
+// PRCheckout checks out a pull request's head branch in the current
+// repository, streaming gh's output
+func (c *Client) PRCheckout(number int, repo string) error {
+	cmd := exec.Command("gh", prCheckoutArgs(number, repo)...)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("gh pr checkout failed: %w", err)
+	}
+	return nil
+}
+
+// PRMerge merges a pull request
+func (c *Client) PRMerge(opts PRMergeOptions) error {
+	args, err := prMergeArgs(opts)
+	if err != nil {
+		return err
+	}
+
+	cmd := exec.Command("gh", args...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh pr merge failed: %s", string(output))
+	}
+	return nil
+}
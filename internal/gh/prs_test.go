@@ -0,0 +1,82 @@
+package gh
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPRArgs(t *testing.T) {
+	mergeArgs := func(opts PRMergeOptions) []string {
+		args, err := prMergeArgs(opts)
+		if err != nil {
+			t.Fatalf("prMergeArgs(%+v): %v", opts, err)
+		}
+		return args
+	}
+
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{
+			name: "list defaults",
+			got:  prListArgs(PRListOptions{}),
+			want: []string{"pr", "list", "--json", prListFields},
+		},
+		{
+			name: "list filters",
+			got:  prListArgs(PRListOptions{State: "merged", Labels: []string{"bug", "p1"}, Limit: 5, Repo: "owner/repo"}),
+			want: []string{"pr", "list", "--json", prListFields, "--state", "merged", "--label", "bug", "--label", "p1", "--limit", "5", "--repo", "owner/repo"},
+		},
+		{
+			name: "view",
+			got:  prViewArgs(42, ""),
+			want: []string{"pr", "view", "42", "--json", prViewFields},
+		},
+		{
+			name: "view with repo",
+			got:  prViewArgs(42, "owner/repo"),
+			want: []string{"pr", "view", "42", "--json", prViewFields, "--repo", "owner/repo"},
+		},
+		{
+			name: "create without body still passes --body",
+			got:  prCreateArgs(PRCreateOptions{Title: "Fix login"}),
+			want: []string{"pr", "create", "--title", "Fix login", "--body", ""},
+		},
+		{
+			name: "create draft against base",
+			got:  prCreateArgs(PRCreateOptions{Title: "WIP", Body: "Closes #1", Base: "release-1.2", Draft: true, Repo: "owner/repo"}),
+			want: []string{"pr", "create", "--title", "WIP", "--body", "Closes #1", "--base", "release-1.2", "--draft", "--repo", "owner/repo"},
+		},
+		{
+			name: "checkout",
+			got:  prCheckoutArgs(7, "owner/repo"),
+			want: []string{"pr", "checkout", "7", "--repo", "owner/repo"},
+		},
+		{
+			name: "merge defaults to merge commit",
+			got:  mergeArgs(PRMergeOptions{Number: 7}),
+			want: []string{"pr", "merge", "7", "--merge"},
+		},
+		{
+			name: "squash and delete branch",
+			got:  mergeArgs(PRMergeOptions{Number: 7, Method: "squash", DeleteBranch: true, Repo: "owner/repo"}),
+			want: []string{"pr", "merge", "7", "--squash", "--delete-branch", "--repo", "owner/repo"},
+		},
+		{
+			name: "rebase",
+			got:  mergeArgs(PRMergeOptions{Number: 7, Method: "rebase"}),
+			want: []string{"pr", "merge", "7", "--rebase"},
+		},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s:\n got  %q\n want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if _, err := prMergeArgs(PRMergeOptions{Number: 7, Method: "fast-forward"}); err == nil {
+		t.Error("expected error for unknown merge method")
+	}
+}
@@ -0,0 +1,143 @@
+package gh
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// ReleaseUploadOptions contains options for uploading release assets
+type ReleaseUploadOptions struct {
+	Tag     string
+	Files   []string // local files, optionally "path#display label"
+	Clobber bool     // overwrite assets with the same name
+	Repo    string
+}
+
+// ReleaseDownloadOptions contains options for downloading release assets
+type ReleaseDownloadOptions struct {
+	Tag      string
+	Patterns []string // glob patterns selecting assets (default: all)
+	Dir      string   // destination directory (default: current directory)
+	Clobber  bool     // overwrite existing files
+	Repo     string
+}
+
+func releaseCreateArgs(opts ReleaseCreateOptions) []string {
+	args := []string{"release", "create", opts.Tag}
+
+	if opts.Title != "" {
+		args = append(args, "--title", opts.Title)
+	}
+	if opts.Notes != "" {
+		args = append(args, "--notes", opts.Notes)
+	}
+	if opts.NotesFile != "" {
+		args = append(args, "--notes-file", opts.NotesFile)
+	}
+	if opts.GenerateNotes {
+		args = append(args, "--generate-notes")
+	}
+	if opts.Draft {
+		args = append(args, "--draft")
+	}
+	if opts.Prerelease {
+		args = append(args, "--prerelease")
+	}
+	if opts.Latest != nil {
+		if *opts.Latest {
+			args = append(args, "--latest")
+		} else {
+			args = append(args, "--latest=false")
+		}
+	}
+	if opts.Target != "" {
+		args = append(args, "--target", opts.Target)
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return append(args, opts.Assets...)
+}
+
+func releaseUploadArgs(opts ReleaseUploadOptions) []string {
+	args := []string{"release", "upload", opts.Tag}
+	args = append(args, opts.Files...)
+
+	if opts.Clobber {
+		args = append(args, "--clobber")
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return args
+}
+
+func releaseDownloadArgs(opts ReleaseDownloadOptions) []string {
+	args := []string{"release", "download", opts.Tag}
+
+	for _, p := range opts.Patterns {
+		args = append(args, "--pattern", p)
+	}
+	if opts.Dir != "" {
+		args = append(args, "--dir", opts.Dir)
+	}
+	if opts.Clobber {
+		args = append(args, "--clobber")
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return args
+}
+
+// checkAssetFiles verifies that every asset names an existing regular file,
+// so a typo fails before anything is created or uploaded. gh splits
+// "path#display label" at the first #, so a file whose own name contains a #
+// would be uploaded as a different file (or not found) and is rejected.
+func checkAssetFiles(files []string) error {
+	for _, f := range files {
+		path, _, labelled := strings.Cut(f, "#")
+		if labelled {
+			if _, err := os.Stat(f); err == nil {
+				return fmt.Errorf("asset %s: gh reads everything after the first # as a label; rename the file", f)
+			}
+		}
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("asset %s: %w", f, err)
+		}
+		if !info.Mode().IsRegular() {
+			return fmt.Errorf("asset %s is not a regular file", f)
+		}
+	}
+	return nil
+}
+
+// ReleaseUpload attaches local files to an existing release
+func (c *Client) ReleaseUpload(opts ReleaseUploadOptions) error {
+	if len(opts.Files) == 0 {
+		return fmt.Errorf("no files to upload")
+	}
+	if err := checkAssetFiles(opts.Files); err != nil {
+		return err
+	}
+
+	cmd := exec.Command("gh", releaseUploadArgs(opts)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh release upload failed: %s", string(output))
+	}
+	return nil
+}
+
+// ReleaseDownload downloads a release's assets
+func (c *Client) ReleaseDownload(opts ReleaseDownloadOptions) error {
+	cmd := exec.Command("gh", releaseDownloadArgs(opts)...)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("gh release download failed: %s", string(output))
+	}
+	return nil
+}
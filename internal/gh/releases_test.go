@@ -0,0 +1,86 @@
+package gh
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReleaseArgs(t *testing.T) {
+	latest := false
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{
+			name: "create with assets",
+			got: releaseCreateArgs(ReleaseCreateOptions{
+				Tag: "v1.0.0", GenerateNotes: true, Latest: &latest, Repo: "owner/repo",
+				Assets: []string{"dist/dex-linux-amd64", "dist/checksums.txt#Checksums"},
+			}),
+			want: []string{"release", "create", "v1.0.0", "--generate-notes", "--latest=false", "--repo", "owner/repo",
+				"dist/dex-linux-amd64", "dist/checksums.txt#Checksums"},
+		},
+		{
+			name: "create without assets",
+			got:  releaseCreateArgs(ReleaseCreateOptions{Tag: "v1.0.0", Notes: "First"}),
+			want: []string{"release", "create", "v1.0.0", "--notes", "First"},
+		},
+		{
+			name: "upload",
+			got:  releaseUploadArgs(ReleaseUploadOptions{Tag: "v1.0.0", Files: []string{"a.tar.gz", "b.zip"}}),
+			want: []string{"release", "upload", "v1.0.0", "a.tar.gz", "b.zip"},
+		},
+		{
+			name: "upload clobber",
+			got:  releaseUploadArgs(ReleaseUploadOptions{Tag: "v1.0.0", Files: []string{"a.tar.gz"}, Clobber: true, Repo: "owner/repo"}),
+			want: []string{"release", "upload", "v1.0.0", "a.tar.gz", "--clobber", "--repo", "owner/repo"},
+		},
+		{
+			name: "download all",
+			got:  releaseDownloadArgs(ReleaseDownloadOptions{Tag: "v1.0.0"}),
+			want: []string{"release", "download", "v1.0.0"},
+		},
+		{
+			name: "download patterns into dir",
+			got: releaseDownloadArgs(ReleaseDownloadOptions{
+				Tag: "v1.0.0", Patterns: []string{"*linux*", "checksums.txt"}, Dir: "/tmp/dex", Clobber: true, Repo: "owner/repo",
+			}),
+			want: []string{"release", "download", "v1.0.0", "--pattern", "*linux*", "--pattern", "checksums.txt",
+				"--dir", "/tmp/dex", "--clobber", "--repo", "owner/repo"},
+		},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s:\n got  %q\n want %q", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestCheckAssetFiles(t *testing.T) {
+	dir := t.TempDir()
+	bin := filepath.Join(dir, "dex-linux-amd64")
+	os.WriteFile(bin, []byte("bin"), 0o644)
+	hashed := filepath.Join(dir, "notes#1.txt")
+	os.WriteFile(hashed, []byte("notes"), 0o644)
+
+	if err := checkAssetFiles([]string{bin, bin + "#Linux binary", bin + "#Linux#amd64"}); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// gh would read notes#1.txt as "notes" labelled "1.txt"
+	if err := checkAssetFiles([]string{hashed}); err == nil || !strings.Contains(err.Error(), "first #") {
+		t.Errorf("expected error for a file named with #, got %v", err)
+	}
+	if err := checkAssetFiles([]string{hashed + "#Notes"}); err == nil {
+		t.Error("expected error for a labelled file named with #")
+	}
+	if err := checkAssetFiles([]string{bin, filepath.Join(dir, "missing")}); err == nil {
+		t.Error("expected error for missing file")
+	}
+	if err := checkAssetFiles([]string{dir}); err == nil {
+		t.Error("expected error for directory")
+	}
+}
@@ -3,7 +3,10 @@ package gh
 import (
 	"fmt"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/charmbracelet/glamour"
 	"github.com/codewandler/dex/internal/render"
 )
 
@@ -105,6 +108,74 @@ func (r *IssueResult) RenderText(mode render.Mode) string {
 	return b.String()
 }
 
+// ── IssueCommentsResult ──────────────────────────────────────────────────────
+
+// IssueCommentsResult holds the discussion on an issue for Renderable output.
+type IssueCommentsResult struct {
+	Number   int            `json:"number"`
+	Title    string         `json:"title"`
+	Comments []IssueComment `json:"comments"`
+}
+
+// RenderText implements render.Renderable on IssueCommentsResult.
+// ModeNormal: author and time per comment, body rendered as markdown.
+// ModeCompact: one line per comment with the first line of its body.
+func (r *IssueCommentsResult) RenderText(mode render.Mode) string {
+	if len(r.Comments) == 0 {
+		return fmt.Sprintf("No comments on #%d.\n", r.Number)
+	}
+
+	var b strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, c := range r.Comments {
+			line, _, _ := strings.Cut(strings.TrimSpace(c.Body), "\n")
+			if len(line) > 80 {
+				line = line[:77] + "..."
+			}
+			fmt.Fprintf(&b, "%s  @%-20s  %s\n", commentTime(c.CreatedAt), c.Author, line)
+		}
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "#%d %s - %d comments\n", r.Number, r.Title, len(r.Comments))
+	for _, c := range r.Comments {
+		fmt.Fprintf(&b, "\n@%s · %s\n", c.Author, commentTime(c.CreatedAt))
+		fmt.Fprintf(&b, "%s\n", renderMarkdown(c.Body))
+	}
+
+	return b.String()
+}
+
+// commentTime formats an RFC 3339 timestamp as local "2006-01-02 15:04".
+func commentTime(s string) string {
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+var (
+	mdRendererOnce sync.Once
+	mdRenderer     *glamour.TermRenderer
+)
+
+// renderMarkdown renders markdown for terminal display, falling back to the raw text.
+func renderMarkdown(text string) string {
+	mdRendererOnce.Do(func() {
+		mdRenderer, _ = glamour.NewTermRenderer(glamour.WithAutoStyle(), glamour.WithWordWrap(80))
+	})
+	if mdRenderer == nil {
+		return text
+	}
+	rendered, err := mdRenderer.Render(text)
+	if err != nil {
+		return text
+	}
+	return strings.TrimRight(rendered, "\n")
+}
+
 // ── ReleaseListResult ────────────────────────────────────────────────────────
 
 // ReleaseListResult wraps a slice of releases for Renderable output.
@@ -232,3 +303,336 @@ func (r *LabelListResult) RenderText(mode render.Mode) string {
 	return b.String()
 }
 
+
+// ── LabelSyncResult ──────────────────────────────────────────────────────────
+
+// LabelSyncResult reports the outcome of `dex gh label sync`.
+type LabelSyncResult struct {
+	Repo   string        `json:"repo,omitempty"`
+	DryRun bool          `json:"dry_run"`
+	Plan   LabelSyncPlan `json:"plan"`
+	Failed []string      `json:"failed,omitempty"` // "name: error" for labels that could not be applied
+}
+
+// RenderText implements render.Renderable on LabelSyncResult.
+// ModeNormal: one line per changed label followed by counts.
+// ModeCompact: counts only.
+func (r *LabelSyncResult) RenderText(mode render.Mode) string {
+	var b strings.Builder
+
+	if mode == render.ModeNormal {
+		for _, l := range r.Plan.Create {
+			fmt.Fprintf(&b, "+ %-30s #%s %s\n", l.Name, l.Color, l.Description)
+		}
+		for _, l := range r.Plan.Update {
+			fmt.Fprintf(&b, "~ %-30s #%s %s\n", l.Name, l.Color, l.Description)
+		}
+		for _, l := range r.Plan.Delete {
+			fmt.Fprintf(&b, "- %s\n", l.Name)
+		}
+		for _, f := range r.Failed {
+			fmt.Fprintf(&b, "! %s\n", f)
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	prefix := ""
+	if r.DryRun {
+		prefix = "Dry run: would have "
+	}
+	fmt.Fprintf(&b, "%screated %d, updated %d, unchanged %d", prefix, len(r.Plan.Create), len(r.Plan.Update), len(r.Plan.Unchanged))
+	if len(r.Plan.Delete) > 0 {
+		fmt.Fprintf(&b, ", deleted %d", len(r.Plan.Delete))
+	}
+	if len(r.Failed) > 0 {
+		fmt.Fprintf(&b, ", failed %d", len(r.Failed))
+	}
+	b.WriteString("\n")
+	return b.String()
+}
+
+// ── IssueBulkLabelResult ─────────────────────────────────────────────────────
+
+// IssueBulkLabelResult reports the outcome of `dex gh issue search-and-label`.
+type IssueBulkLabelResult struct {
+	Repo   string             `json:"repo,omitempty"`
+	Query  string             `json:"query"`
+	DryRun bool               `json:"dry_run"`
+	Issues []IssueLabelChange `json:"issues"`
+}
+
+// Failed returns the number of issues whose labels could not be changed
+func (r *IssueBulkLabelResult) Failed() int {
+	n := 0
+	for _, c := range r.Issues {
+		if c.Error != "" {
+			n++
+		}
+	}
+	return n
+}
+
+// RenderText implements render.Renderable on IssueBulkLabelResult.
+// ModeNormal: one line per matched issue followed by counts.
+// ModeCompact: counts only.
+func (r *IssueBulkLabelResult) RenderText(mode render.Mode) string {
+	var b strings.Builder
+
+	changed, unchanged := 0, 0
+	for _, c := range r.Issues {
+		switch {
+		case c.Error != "":
+		case c.Unchanged():
+			unchanged++
+		default:
+			changed++
+		}
+	}
+
+	if mode == render.ModeNormal {
+		for _, c := range r.Issues {
+			var labels []string
+			for _, l := range c.Add {
+				labels = append(labels, "+"+l)
+			}
+			for _, l := range c.Remove {
+				labels = append(labels, "-"+l)
+			}
+			switch {
+			case c.Error != "":
+				fmt.Fprintf(&b, "! #%-6d %s: %s\n", c.Number, c.Title, c.Error)
+			case c.Unchanged():
+				fmt.Fprintf(&b, "= #%-6d %s\n", c.Number, c.Title)
+			default:
+				fmt.Fprintf(&b, "~ #%-6d %s  [%s]\n", c.Number, c.Title, strings.Join(labels, " "))
+			}
+		}
+		if b.Len() > 0 {
+			b.WriteString("\n")
+		}
+	}
+
+	verb := "updated"
+	if r.DryRun {
+		verb = "would update"
+	}
+	fmt.Fprintf(&b, "%d issue(s) matched: %s %d, unchanged %d", len(r.Issues), verb, changed, unchanged)
+	if failed := r.Failed(); failed > 0 {
+		fmt.Fprintf(&b, ", failed %d", failed)
+	}
+	b.WriteString("\n")
+	if r.DryRun && changed > 0 {
+		b.WriteString("Dry run: re-run with --yes to apply\n")
+	}
+	return b.String()
+}
+
+// ── PRListResult ─────────────────────────────────────────────────────────────
+
+// PRListResult wraps a slice of pull requests for Renderable output.
+type PRListResult struct {
+	PRs []PR `json:"prs"`
+}
+
+// RenderText implements render.Renderable on PRListResult.
+// ModeNormal: number, state, author, title and labels per pull request.
+// ModeCompact: number and title only.
+func (r *PRListResult) RenderText(mode render.Mode) string {
+	if len(r.PRs) == 0 {
+		return "No pull requests found.\n"
+	}
+
+	var b strings.Builder
+	for _, pr := range r.PRs {
+		if mode == render.ModeCompact {
+			title := pr.Title
+			if len(title) > 60 {
+				title = title[:57] + "..."
+			}
+			fmt.Fprintf(&b, "#%-5d %s\n", pr.Number, title)
+			continue
+		}
+		state := strings.ToLower(pr.State)
+		if pr.IsDraft && state == "open" {
+			state = "draft"
+		}
+		labelStr := ""
+		if len(pr.Labels) > 0 {
+			labelStr = "  [" + strings.Join(pr.Labels, ", ") + "]"
+		}
+		fmt.Fprintf(&b, "#%-5d %-6s  @%-20s  %s%s\n", pr.Number, state, pr.Author, pr.Title, labelStr)
+	}
+
+	return b.String()
+}
+
+// ── PRResult ─────────────────────────────────────────────────────────────────
+
+// PRResult wraps a single PRDetail for Renderable output.
+type PRResult struct {
+	*PRDetail
+}
+
+// RenderText implements render.Renderable on PRResult.
+// ModeNormal: full multi-line detail view.
+// ModeCompact: single summary line.
+func (r *PRResult) RenderText(mode render.Mode) string {
+	if r.PRDetail == nil {
+		return "Pull request not found.\n"
+	}
+
+	state := strings.ToLower(r.State)
+	if r.IsDraft && state == "open" {
+		state = "draft"
+	}
+
+	if mode == render.ModeCompact {
+		return fmt.Sprintf("#%d [%s] %s (@%s, %s → %s)\n",
+			r.Number, state, r.Title, r.Author, r.HeadRef, r.BaseRef)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%d %s\n", r.Number, r.Title)
+
+	date := r.CreatedAt
+	if len(date) >= 10 {
+		date = date[:10]
+	}
+	fmt.Fprintf(&b, "State: %s | Author: @%s | Created: %s\n", state, r.Author, date)
+	fmt.Fprintf(&b, "Branch: %s → %s\n", r.HeadRef, r.BaseRef)
+	fmt.Fprintf(&b, "Changes: %d files, +%d -%d\n", r.ChangedFiles, r.Additions, r.Deletions)
+
+	var status []string
+	if r.ReviewDecision != "" {
+		status = append(status, "review: "+strings.ToLower(strings.ReplaceAll(r.ReviewDecision, "_", " ")))
+	}
+	if r.Mergeable != "" && state != "merged" && state != "closed" {
+		status = append(status, "mergeable: "+strings.ToLower(r.Mergeable))
+	}
+	if len(status) > 0 {
+		fmt.Fprintf(&b, "Status: %s\n", strings.Join(status, " | "))
+	}
+	if len(r.Labels) > 0 {
+		fmt.Fprintf(&b, "Labels: %s\n", strings.Join(r.Labels, ", "))
+	}
+	if len(r.Assignees) > 0 {
+		fmt.Fprintf(&b, "Assignees: %s\n", strings.Join(r.Assignees, ", "))
+	}
+	if r.URL != "" {
+		fmt.Fprintf(&b, "URL: %s\n", r.URL)
+	}
+	if r.Body != "" {
+		fmt.Fprintf(&b, "\n%s\n", r.Body)
+	}
+
+	return b.String()
+}
+
+// ── RunListResult ────────────────────────────────────────────────────────────
+
+// RunListResult wraps a slice of workflow runs for Renderable output.
+type RunListResult struct {
+	Runs []Run `json:"runs"`
+}
+
+// RenderText implements render.Renderable on RunListResult.
+// ModeNormal: id, state, workflow, branch, date and title per run.
+// ModeCompact: id, state and title only.
+func (r *RunListResult) RenderText(mode render.Mode) string {
+	if len(r.Runs) == 0 {
+		return "No workflow runs found.\n"
+	}
+
+	var b strings.Builder
+	for _, run := range r.Runs {
+		state := runState(run.Status, run.Conclusion)
+		if mode == render.ModeCompact {
+			fmt.Fprintf(&b, "%d  %-11s  %s\n", run.ID, state, run.DisplayTitle)
+			continue
+		}
+		date := run.CreatedAt
+		if len(date) >= 16 {
+			date = strings.Replace(date[:16], "T", " ", 1)
+		}
+		fmt.Fprintf(&b, "%-11d  %-11s  %-20s  %-20s  %s  %s\n",
+			run.ID, state, run.WorkflowName, run.HeadBranch, date, run.DisplayTitle)
+	}
+
+	return b.String()
+}
+
+// ── RunResult ────────────────────────────────────────────────────────────────
+
+// RunResult wraps a single RunDetail for Renderable output.
+type RunResult struct {
+	*RunDetail
+}
+
+// RenderText implements render.Renderable on RunResult.
+// ModeNormal: run header, then each job with its steps; steps are only
+// listed for jobs that did not succeed.
+// ModeCompact: single summary line.
+func (r *RunResult) RenderText(mode render.Mode) string {
+	if r.RunDetail == nil {
+		return "Workflow run not found.\n"
+	}
+
+	state := runState(r.Status, r.Conclusion)
+	if mode == render.ModeCompact {
+		return fmt.Sprintf("%d [%s] %s / %s (%s)\n", r.ID, state, r.WorkflowName, r.DisplayTitle, r.HeadBranch)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s #%d: %s\n", r.WorkflowName, r.Number, r.DisplayTitle)
+	sha := r.HeadSha
+	if len(sha) > 7 {
+		sha = sha[:7]
+	}
+	fmt.Fprintf(&b, "State: %s | Branch: %s (%s) | Event: %s\n", state, r.HeadBranch, sha, r.Event)
+	if r.URL != "" {
+		fmt.Fprintf(&b, "URL: %s\n", r.URL)
+	}
+
+	if len(r.Jobs) > 0 {
+		b.WriteString("\nJobs:\n")
+	}
+	for _, job := range r.Jobs {
+		fmt.Fprintf(&b, "  %s %-11s  %s\n", runMarker(job.Status, job.Conclusion), job.State(), job.Name)
+		if job.Status != "completed" || job.Conclusion == "success" || job.Conclusion == "skipped" {
+			continue
+		}
+		for _, step := range job.Steps {
+			if step.Conclusion == "success" || step.Conclusion == "skipped" {
+				continue
+			}
+			fmt.Fprintf(&b, "      %s %-11s  %d. %s\n", runMarker(step.Status, step.Conclusion),
+				runState(step.Status, step.Conclusion), step.Number, step.Name)
+		}
+	}
+
+	return b.String()
+}
+
+// runState is the conclusion of a completed run, job or step, else its status.
+func runState(status, conclusion string) string {
+	if status == "completed" && conclusion != "" {
+		return conclusion
+	}
+	return status
+}
+
+// runMarker is a one-character summary of a run, job or step state.
+func runMarker(status, conclusion string) string {
+	if status != "completed" {
+		return "*"
+	}
+	switch conclusion {
+	case "success":
+		return "✓"
+	case "skipped", "neutral":
+		return "-"
+	}
+	return "X"
+}
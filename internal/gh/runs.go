@@ -0,0 +1,185 @@
+package gh
+
+import (
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"time"
+)
+
+// Run is a GitHub Actions workflow run
+type Run struct {
+	ID           int64  `json:"databaseId"`
+	Number       int    `json:"number"`
+	WorkflowName string `json:"workflowName"`
+	DisplayTitle string `json:"displayTitle"`
+	Event        string `json:"event"`
+	HeadBranch   string `json:"headBranch"`
+	HeadSha      string `json:"headSha"`
+	Status       string `json:"status"`     // queued, in_progress, completed, ...
+	Conclusion   string `json:"conclusion"` // success, failure, cancelled, ... (empty until completed)
+	CreatedAt    string `json:"createdAt"`
+	UpdatedAt    string `json:"updatedAt"`
+	URL          string `json:"url"`
+}
+
+// Completed reports whether the run has finished
+func (r *Run) Completed() bool {
+	return r.Status == "completed"
+}
+
+// Succeeded reports whether the run finished without failing. Skipped and
+// neutral runs count as successful; failure, cancelled, timed_out and the
+// like do not.
+func (r *Run) Succeeded() bool {
+	switch r.Conclusion {
+	case "success", "skipped", "neutral":
+		return r.Completed()
+	}
+	return false
+}
+
+// RunStep is one step of a workflow job
+type RunStep struct {
+	Number     int    `json:"number"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`
+	Conclusion string `json:"conclusion"`
+}
+
+// RunJob is one job of a workflow run
+type RunJob struct {
+	ID          int64     `json:"databaseId"`
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Conclusion  string    `json:"conclusion"`
+	StartedAt   string    `json:"startedAt"`
+	CompletedAt string    `json:"completedAt"`
+	URL         string    `json:"url"`
+	Steps       []RunStep `json:"steps"`
+}
+
+// State is the job's conclusion once completed, else its status
+func (j *RunJob) State() string {
+	return runState(j.Status, j.Conclusion)
+}
+
+// RunDetail is a workflow run with its jobs and steps
+type RunDetail struct {
+	Run
+	Jobs []RunJob `json:"jobs"`
+}
+
+// RunListOptions contains options for listing workflow runs
+type RunListOptions struct {
+	Workflow string // workflow name or file name
+	Branch   string
+	Limit    int
+	Repo     string
+}
+
+const runListFields = "databaseId,number,workflowName,displayTitle,event,headBranch,headSha,status,conclusion,createdAt,updatedAt,url"
+
+const runViewFields = runListFields + ",jobs"
+
+func runListArgs(opts RunListOptions) []string {
+	args := []string{"run", "list", "--json", runListFields}
+
+	if opts.Workflow != "" {
+		args = append(args, "--workflow", opts.Workflow)
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.Limit > 0 {
+		args = append(args, "--limit", fmt.Sprintf("%d", opts.Limit))
+	}
+	if opts.Repo != "" {
+		args = append(args, "--repo", opts.Repo)
+	}
+	return args
+}
+
+func runViewArgs(id int64, repo string) []string {
+	args := []string{"run", "view", strconv.FormatInt(id, 10), "--json", runViewFields}
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+	return args
+}
+
+func runLogFailedArgs(id int64, repo string) []string {
+	args := []string{"run", "view", strconv.FormatInt(id, 10), "--log-failed"}
+	if repo != "" {
+		args = append(args, "--repo", repo)
+	}
+	return args
+}
+
+// RunList lists recent workflow runs, newest first
+func (c *Client) RunList(opts RunListOptions) ([]Run, error) {
+	cmd := exec.Command("gh", runListArgs(opts)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh run list failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh run list failed: %w", err)
+	}
+
+	var runs []Run
+	if err := json.Unmarshal(output, &runs); err != nil {
+		return nil, fmt.Errorf("failed to parse runs: %w", err)
+	}
+	return runs, nil
+}
+
+// RunView retrieves a workflow run with its jobs and steps
+func (c *Client) RunView(id int64, repo string) (*RunDetail, error) {
+	cmd := exec.Command("gh", runViewArgs(id, repo)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gh run view failed: %s", string(exitErr.Stderr))
+		}
+		return nil, fmt.Errorf("gh run view failed: %w", err)
+	}
+
+	var run RunDetail
+	if err := json.Unmarshal(output, &run); err != nil {
+		return nil, fmt.Errorf("failed to parse run: %w", err)
+	}
+	return &run, nil
+}
+
+// RunFailedLog returns the logs of the failed steps of a run
+func (c *Client) RunFailedLog(id int64, repo string) (string, error) {
+	cmd := exec.Command("gh", runLogFailedArgs(id, repo)...)
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return "", fmt.Errorf("gh run view --log-failed failed: %s", string(exitErr.Stderr))
+		}
+		return "", fmt.Errorf("gh run view --log-failed failed: %w", err)
+	}
+	return string(output), nil
+}
+
+// RunWatch polls a run every interval until it completes and returns its
+// final state. onUpdate, if set, is called with every polled state.
+func (c *Client) RunWatch(id int64, repo string, interval time.Duration, onUpdate func(*RunDetail)) (*RunDetail, error) {
+	for {
+		run, err := c.RunView(id, repo)
+		if err != nil {
+			return nil, err
+		}
+		if onUpdate != nil {
+			onUpdate(run)
+		}
+		if run.Completed() {
+			return run, nil
+		}
+		time.Sleep(interval)
+	}
+}
@@ -0,0 +1,66 @@
+package gh
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRunArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		got  []string
+		want []string
+	}{
+		{
+			name: "list defaults",
+			got:  runListArgs(RunListOptions{}),
+			want: []string{"run", "list", "--json", runListFields},
+		},
+		{
+			name: "list filters",
+			got:  runListArgs(RunListOptions{Workflow: "ci.yml", Branch: "main", Limit: 5, Repo: "owner/repo"}),
+			want: []string{"run", "list", "--json", runListFields, "--workflow", "ci.yml", "--branch", "main", "--limit", "5", "--repo", "owner/repo"},
+		},
+		{
+			name: "view",
+			got:  runViewArgs(9876543210, ""),
+			want: []string{"run", "view", "9876543210", "--json", runViewFields},
+		},
+		{
+			name: "failed logs",
+			got:  runLogFailedArgs(9876543210, "owner/repo"),
+			want: []string{"run", "view", "9876543210", "--log-failed", "--repo", "owner/repo"},
+		},
+	}
+	for _, tt := range tests {
+		if !reflect.DeepEqual(tt.got, tt.want) {
+			t.Errorf("%s:\n got  %q\n want %q", tt.name, tt.got, tt.want)
+		}
+	}
+
+	if !strings.HasSuffix(runViewFields, ",jobs") {
+		t.Errorf("run view does not request jobs: %s", runViewFields)
+	}
+}
+
+func TestRunSucceeded(t *testing.T) {
+	tests := []struct {
+		status, conclusion string
+		want               bool
+	}{
+		{"completed", "success", true},
+		{"completed", "skipped", true},
+		{"completed", "failure", false},
+		{"completed", "cancelled", false},
+		{"completed", "timed_out", false},
+		{"in_progress", "", false},
+		{"queued", "", false},
+	}
+	for _, tt := range tests {
+		r := Run{Status: tt.status, Conclusion: tt.conclusion}
+		if got := r.Succeeded(); got != tt.want {
+			t.Errorf("Run{%s, %s}.Succeeded() = %v, want %v", tt.status, tt.conclusion, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,228 @@
+package gitlab
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/codewandler/dex/internal/render"
+	"sigs.k8s.io/yaml"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// BatchComment is one inline comment in a comment batch file
+type BatchComment struct {
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Message string `json:"message"`
+}
+
+// BatchCommentOutcome is the result of validating and posting one batch comment
+type BatchCommentOutcome struct {
+	File     string `json:"file"`
+	Line     int    `json:"line"`
+	Message  string `json:"message"`
+	LineType string `json:"line_type,omitempty"` // add, del, ctx
+	OK       bool   `json:"ok"`
+	Posted   bool   `json:"posted"`
+	Error    string `json:"error,omitempty"`
+}
+
+// BatchCommentResult reports the outcome of posting a comment batch
+type BatchCommentResult struct {
+	Reference string                `json:"reference"`
+	DryRun    bool                  `json:"dry_run"`
+	Comments  []BatchCommentOutcome `json:"comments"`
+}
+
+// Failed returns the number of comments that failed validation or posting
+func (r *BatchCommentResult) Failed() int {
+	n := 0
+	for _, c := range r.Comments {
+		if !c.OK {
+			n++
+		}
+	}
+	return n
+}
+
+// batchCommentFile is the object form of a comment batch file
+type batchCommentFile struct {
+	Comments []BatchComment `json:"comments"`
+}
+
+// LoadBatchComments reads inline comments from a YAML or JSON file. The file is
+// either a list of {file, line, message} entries or an object with a
+// "comments" list.
+func LoadBatchComments(path string) ([]BatchComment, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read comment file: %w", err)
+	}
+	return ParseBatchComments(data)
+}
+
+// ParseBatchComments parses and checks the content of a comment batch file
+func ParseBatchComments(data []byte) ([]BatchComment, error) {
+	var comments []BatchComment
+	if err := yaml.Unmarshal(data, &comments); err != nil {
+		var f batchCommentFile
+		if err2 := yaml.Unmarshal(data, &f); err2 != nil {
+			return nil, fmt.Errorf("failed to parse comment file: %w", err)
+		}
+		comments = f.Comments
+	}
+
+	if len(comments) == 0 {
+		return nil, fmt.Errorf("comment file has no comments")
+	}
+	for i := range comments {
+		comments[i].File = strings.TrimSpace(comments[i].File)
+		comments[i].Message = strings.TrimSpace(comments[i].Message)
+		switch {
+		case comments[i].File == "":
+			return nil, fmt.Errorf("comment #%d has no file", i+1)
+		case comments[i].Line <= 0:
+			return nil, fmt.Errorf("comment #%d (%s) has no valid line", i+1, comments[i].File)
+		case comments[i].Message == "":
+			return nil, fmt.Errorf("comment #%d (%s:%d) has no message", i+1, comments[i].File, comments[i].Line)
+		}
+	}
+	return comments, nil
+}
+
+// PostBatchComments validates each comment against the merge request diff and
+// posts the valid ones as inline comments. The diff is fetched once for the
+// whole batch. With dryRun nothing is posted. A comment that fails validation
+// or posting does not stop the rest of the batch.
+func (c *Client) PostBatchComments(projectID any, mrIID int, comments []BatchComment, dryRun bool) (*BatchCommentResult, error) {
+	files, err := c.GetMergeRequestChanges(projectID, mrIID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request diff: %w", err)
+	}
+
+	diffs := make(map[string]*ParsedDiff)
+	for _, f := range files {
+		parsed := ParseUnifiedDiff(f.Diff)
+		parsed.OldPath = f.OldPath
+		parsed.NewPath = f.NewPath
+		diffs[f.NewPath] = parsed
+		if _, ok := diffs[f.OldPath]; !ok {
+			diffs[f.OldPath] = parsed
+		}
+	}
+
+	result := &BatchCommentResult{
+		Reference: fmt.Sprintf("%v!%d", projectID, mrIID),
+		DryRun:    dryRun,
+	}
+	for _, bc := range comments {
+		out := BatchCommentOutcome{File: bc.File, Line: bc.Line, Message: bc.Message}
+
+		line, err := checkBatchCommentLine(diffs, bc)
+		if err != nil {
+			out.Error = err.Error()
+			result.Comments = append(result.Comments, out)
+			continue
+		}
+		out.LineType = line.Type.String()
+
+		if !dryRun {
+			diff := diffs[bc.File]
+			opts := InlineCommentOptions{
+				Body:    bc.Message,
+				NewPath: diff.NewPath,
+				OldPath: diff.OldPath,
+				NewLine: bc.Line,
+				OldLine: line.OldLine,
+			}
+			if err := c.CreateMergeRequestInlineComment(projectID, mrIID, opts); err != nil {
+				out.Error = err.Error()
+				result.Comments = append(result.Comments, out)
+				continue
+			}
+			out.Posted = true
+		}
+		out.OK = true
+		result.Comments = append(result.Comments, out)
+	}
+	return result, nil
+}
+
+// checkBatchCommentLine applies the inline comment dry-run checks: the file
+// must be changed in the merge request and the line must appear in its diff.
+func checkBatchCommentLine(diffs map[string]*ParsedDiff, bc BatchComment) (*DiffLine, error) {
+	diff, ok := diffs[bc.File]
+	if !ok {
+		return nil, fmt.Errorf("file not found in diff")
+	}
+	line, found := diff.FindLineByNew(bc.Line)
+	if !found {
+		return nil, fmt.Errorf("line %d is not in the diff", bc.Line)
+	}
+	return line, nil
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+// RenderText implements render.Renderable on BatchCommentResult.
+// ModeCompact: one "ok/failed file:line" line per comment.
+// ModeNormal: one ✓/✗ line per comment with the message, then totals.
+func (r *BatchCommentResult) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, c := range r.Comments {
+			status := "posted"
+			switch {
+			case !c.OK:
+				status = "failed"
+			case r.DryRun:
+				status = "ok"
+			}
+			fmt.Fprintf(&sb, "%-6s %s:%d", status, c.File, c.Line)
+			if c.Error != "" {
+				fmt.Fprintf(&sb, "  %s", c.Error)
+			}
+			fmt.Fprintln(&sb)
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintln(&sb)
+	glProjectColor.Fprintf(&sb, "  %s", r.Reference)
+	if r.DryRun {
+		glDimColor.Fprint(&sb, "  (dry run)")
+	}
+	fmt.Fprint(&sb, "\n\n")
+
+	for _, c := range r.Comments {
+		msg, _, _ := strings.Cut(c.Message, "\n")
+		if len(msg) > 60 {
+			msg = msg[:57] + "..."
+		}
+		if c.OK {
+			fmt.Fprintf(&sb, "  %s %s:%d", glMRMergedColor.Sprint("✓"), c.File, c.Line)
+			glDimColor.Fprintf(&sb, " (%s)", c.LineType)
+			fmt.Fprintf(&sb, "  %s\n", msg)
+		} else {
+			fmt.Fprintf(&sb, "  %s %s:%d  %s\n", glMRClosedColor.Sprint("✗"), c.File, c.Line, glMRClosedColor.Sprint(c.Error))
+		}
+	}
+
+	fmt.Fprintln(&sb)
+	failed := r.Failed()
+	ok := len(r.Comments) - failed
+	switch {
+	case r.DryRun:
+		fmt.Fprintf(&sb, "  %d of %d comment(s) would be posted", ok, len(r.Comments))
+	default:
+		fmt.Fprintf(&sb, "  %d of %d comment(s) posted", ok, len(r.Comments))
+	}
+	if failed > 0 {
+		glMRClosedColor.Fprintf(&sb, ", %d failed", failed)
+	}
+	fmt.Fprint(&sb, "\n\n")
+	return sb.String()
+}
@@ -0,0 +1,77 @@
+package gitlab
+
+import (
+	"testing"
+)
+
+func TestParseBatchComments_YAMLList(t *testing.T) {
+	data := []byte(`
+- file: src/main.go
+  line: 42
+  message: Use a constant here
+- file: " src/util.go "
+  line: 7
+  message: |
+    Typo in the error message.
+`)
+	comments, err := ParseBatchComments(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 2 {
+		t.Fatalf("got %d comments, want 2", len(comments))
+	}
+	if comments[1].File != "src/util.go" || comments[1].Line != 7 || comments[1].Message != "Typo in the error message." {
+		t.Errorf("comments[1] = %+v", comments[1])
+	}
+}
+
+func TestParseBatchComments_JSONObject(t *testing.T) {
+	data := []byte(`{"comments": [{"file": "a.go", "line": 1, "message": "nit"}]}`)
+	comments, err := ParseBatchComments(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(comments) != 1 || comments[0].File != "a.go" {
+		t.Errorf("got %+v", comments)
+	}
+}
+
+func TestParseBatchComments_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"empty":      `[]`,
+		"no file":    `[{"line": 1, "message": "x"}]`,
+		"no line":    `[{"file": "a.go", "message": "x"}]`,
+		"no message": `[{"file": "a.go", "line": 1, "message": "  "}]`,
+		"garbage":    `: not yaml [`,
+	}
+	for name, data := range cases {
+		if _, err := ParseBatchComments([]byte(data)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestCheckBatchCommentLine(t *testing.T) {
+	diff := ParseUnifiedDiff(`@@ -10,3 +10,4 @@
+ context line
++added line
+ more context
+ last context`)
+	diffs := map[string]*ParsedDiff{"src/main.go": diff}
+
+	line, err := checkBatchCommentLine(diffs, BatchComment{File: "src/main.go", Line: 11})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if line.Type != LineAdded {
+		t.Errorf("line 11: got %s, want add", line.Type)
+	}
+
+	if _, err := checkBatchCommentLine(diffs, BatchComment{File: "src/main.go", Line: 99}); err == nil {
+		t.Error("line outside the diff: expected error")
+	}
+	if _, err := checkBatchCommentLine(diffs, BatchComment{File: "other.go", Line: 11}); err == nil {
+		t.Error("file not in diff: expected error")
+	}
+}
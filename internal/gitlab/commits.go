@@ -75,14 +75,26 @@ func (c *Client) ListProjectCommits(opts ListProjectCommitsOptions) ([]Commit, e
 }
 
 func (c *Client) GetCommits(projectID int, since time.Time) ([]Commit, error) {
+	return c.listCommitsSince(projectID, &gitlab.ListCommitsOptions{Since: gitlab.Ptr(since)})
+}
+
+// GetAuthorCommits returns the commits since a time whose author name or
+// email matches author (the commits API "author" filter), with line stats
+func (c *Client) GetAuthorCommits(projectID int, since time.Time, author string) ([]Commit, error) {
+	return c.listCommitsSince(projectID, &gitlab.ListCommitsOptions{
+		Since:     gitlab.Ptr(since),
+		Author:    gitlab.Ptr(author),
+		WithStats: gitlab.Ptr(true),
+	})
+}
+
+// listCommitsSince pages through all commits matching opts
+func (c *Client) listCommitsSince(projectID int, opts *gitlab.ListCommitsOptions) ([]Commit, error) {
 	var allCommits []Commit
 
-	opts := &gitlab.ListCommitsOptions{
-		ListOptions: gitlab.ListOptions{
-			PerPage: 100,
-			Page:    1,
-		},
-		Since: gitlab.Ptr(since),
+	opts.ListOptions = gitlab.ListOptions{
+		PerPage: 100,
+		Page:    1,
 	}
 
 	for {
@@ -103,6 +115,13 @@ func (c *Client) GetCommits(projectID int, since time.Time) ([]Commit, error) {
 			if c.CreatedAt != nil {
 				commit.CreatedAt = *c.CreatedAt
 			}
+			if c.Stats != nil {
+				commit.Stats = &CommitStats{
+					Additions: c.Stats.Additions,
+					Deletions: c.Stats.Deletions,
+					Total:     c.Stats.Total,
+				}
+			}
 			allCommits = append(allCommits, commit)
 		}
 
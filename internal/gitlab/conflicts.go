@@ -0,0 +1,429 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/codewandler/dex/internal/render"
+	"github.com/xanzy/go-gitlab"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// MRConflicts lists the files of a merge request that conflict with its target branch
+type MRConflicts struct {
+	Reference    string           `json:"reference"`
+	SourceBranch string           `json:"source_branch"`
+	TargetBranch string           `json:"target_branch"`
+	HasConflicts bool             `json:"has_conflicts"`
+	Files        []MRConflictFile `json:"files,omitempty"`
+}
+
+// MRConflictFile is a conflicting file and its sections. Sections are empty for
+// files GitLab can only resolve in its editor (e.g. binary or very large files).
+type MRConflictFile struct {
+	OldPath  string              `json:"old_path"`
+	NewPath  string              `json:"new_path"`
+	Sections []MRConflictSection `json:"sections,omitempty"`
+}
+
+// MRConflictSection is a run of lines that is either a conflict or context around one
+type MRConflictSection struct {
+	Conflict bool             `json:"conflict"`
+	Lines    []MRConflictLine `json:"lines"`
+}
+
+// MRConflictLine is a line in a conflict section. Type is "new" for the source
+// branch side, "old" for the target branch side and empty for context.
+type MRConflictLine struct {
+	Type    string `json:"type,omitempty"`
+	OldLine int    `json:"old_line,omitempty"`
+	NewLine int    `json:"new_line,omitempty"`
+	Text    string `json:"text"`
+}
+
+// Conflicts returns the number of conflict sections in the file
+func (f MRConflictFile) Conflicts() int {
+	n := 0
+	for _, s := range f.Sections {
+		if s.Conflict {
+			n++
+		}
+	}
+	return n
+}
+
+// conflictContextLines is the number of unchanged lines shown around a conflict
+const conflictContextLines = 3
+
+// maxConflictDiffCells bounds the line diff of a file (base lines × side lines).
+// Larger files are listed without inline sections.
+const maxConflictDiffCells = 4_000_000
+
+// GetMergeRequestConflicts fetches the conflicting files of a merge request.
+// GitLab's REST API only reports has_conflicts, so the files changed on both
+// sides since the merge base are compared and merged line by line the way
+// git does. Files that cannot be merged inline (deleted on one side, binary or
+// very large) are listed without sections. When the MR has no conflicts the
+// result has HasConflicts false and no files.
+func (c *Client) GetMergeRequestConflicts(projectID any, mrIID int) (*MRConflicts, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	mr, _, err := c.gl.MergeRequests.GetMergeRequest(pid, mrIID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	result := &MRConflicts{
+		Reference:    fmt.Sprintf("%s!%d", projectID, mrIID),
+		SourceBranch: mr.SourceBranch,
+		TargetBranch: mr.TargetBranch,
+		HasConflicts: mr.HasConflicts,
+	}
+	if !mr.HasConflicts {
+		return result, nil
+	}
+
+	// The source head is read by SHA so MRs from forks resolve in the target project
+	source, target := mr.SHA, mr.TargetBranch
+	base, _, err := c.gl.Repositories.MergeBase(pid, &gitlab.MergeBaseOptions{Ref: &[]string{source, target}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge base: %w", err)
+	}
+
+	sourcePaths, err := c.changedPaths(pid, base.ID, source)
+	if err != nil {
+		return nil, err
+	}
+	targetPaths, err := c.changedPaths(pid, base.ID, target)
+	if err != nil {
+		return nil, err
+	}
+
+	basePaths := make([]string, 0, len(sourcePaths))
+	for p := range sourcePaths {
+		if _, ok := targetPaths[p]; ok {
+			basePaths = append(basePaths, p)
+		}
+	}
+	sort.Strings(basePaths)
+
+	for _, p := range basePaths {
+		file := MRConflictFile{OldPath: targetPaths[p], NewPath: sourcePaths[p]}
+		// A file added on both sides has no base version and merges against empty
+		baseText, _, err := c.rawFile(pid, p, base.ID)
+		if err != nil {
+			return nil, err
+		}
+		sourceText, sourceOK, err := c.rawFile(pid, file.NewPath, source)
+		if err != nil {
+			return nil, err
+		}
+		targetText, targetOK, err := c.rawFile(pid, file.OldPath, target)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case !sourceOK && !targetOK:
+			// Deleted on both sides
+			continue
+		case !sourceOK || !targetOK, isBinary(baseText), isBinary(sourceText), isBinary(targetText):
+			result.Files = append(result.Files, file)
+			continue
+		}
+
+		sections, ok := mergeConflictSections(splitLines(baseText), splitLines(sourceText), splitLines(targetText))
+		if !ok {
+			result.Files = append(result.Files, file)
+			continue
+		}
+		if len(sections) > 0 {
+			file.Sections = sections
+			result.Files = append(result.Files, file)
+		}
+	}
+	return result, nil
+}
+
+// changedPaths returns the files changed between from and to, keyed by their
+// path at from and mapped to their path at to
+func (c *Client) changedPaths(pid int, from, to string) (map[string]string, error) {
+	cmp, _, err := c.gl.Repositories.Compare(pid, &gitlab.CompareOptions{From: &from, To: &to})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compare %s with the merge base: %w", to, err)
+	}
+	paths := make(map[string]string, len(cmp.Diffs))
+	for _, d := range cmp.Diffs {
+		paths[d.OldPath] = d.NewPath
+	}
+	return paths, nil
+}
+
+// rawFile returns the content of path at ref. A file missing at ref is
+// reported with ok false rather than an error.
+func (c *Client) rawFile(pid int, path, ref string) (string, bool, error) {
+	b, resp, err := c.gl.RepositoryFiles.GetRawFile(pid, path, &gitlab.GetRawFileOptions{Ref: &ref})
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("failed to get %s at %s: %w", path, ref, err)
+	}
+	return string(b), true, nil
+}
+
+func isBinary(s string) bool {
+	return strings.IndexByte(s, 0) >= 0
+}
+
+// lineHunk is a changed region: base[BaseStart:BaseEnd] became side[SideStart:SideEnd]
+type lineHunk struct {
+	BaseStart, BaseEnd int
+	SideStart, SideEnd int
+}
+
+// diffLines returns the changed regions between a and b from their longest
+// common subsequence. ok is false when the files are too large to diff.
+func diffLines(a, b []string) (hunks []lineHunk, ok bool) {
+	n, m := len(a), len(b)
+	if n*m > maxConflictDiffCells {
+		return nil, false
+	}
+	// lcs[i][j] is the LCS length of a[i:] and b[j:]
+	lcs := make([][]int, n+1)
+	for i := range lcs {
+		lcs[i] = make([]int, m+1)
+	}
+	for i := n - 1; i >= 0; i-- {
+		for j := m - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < n || j < m {
+		if i < n && j < m && a[i] == b[j] {
+			i++
+			j++
+			continue
+		}
+		h := lineHunk{BaseStart: i, SideStart: j}
+		for (i < n || j < m) && !(i < n && j < m && a[i] == b[j]) {
+			if j == m || (i < n && lcs[i+1][j] >= lcs[i][j+1]) {
+				i++
+			} else {
+				j++
+			}
+		}
+		h.BaseEnd, h.SideEnd = i, j
+		hunks = append(hunks, h)
+	}
+	return hunks, true
+}
+
+// mergeConflictSections merges source and target against base and returns the
+// conflicting regions with context from the source side. ok is false when the
+// files are too large to diff.
+func mergeConflictSections(base, source, target []string) ([]MRConflictSection, bool) {
+	sourceHunks, ok := diffLines(base, source)
+	if !ok {
+		return nil, false
+	}
+	targetHunks, ok := diffLines(base, target)
+	if !ok {
+		return nil, false
+	}
+
+	var sections []MRConflictSection
+	shown := 0 // source lines already shown
+	si, ti := 0, 0
+	for si < len(sourceHunks) && ti < len(targetHunks) {
+		s, t := sourceHunks[si], targetHunks[ti]
+		if !hunksOverlap(s, t) {
+			if s.BaseStart < t.BaseStart {
+				si++
+			} else {
+				ti++
+			}
+			continue
+		}
+
+		// Grow the region until no hunk on either side overlaps its edge
+		lo, hi := min(s.BaseStart, t.BaseStart), max(s.BaseEnd, t.BaseEnd)
+		sFirst, tFirst := si, ti
+		si, ti = si+1, ti+1
+		for {
+			region := lineHunk{BaseStart: lo, BaseEnd: hi}
+			if si < len(sourceHunks) && hunksOverlap(sourceHunks[si], region) {
+				hi = max(hi, sourceHunks[si].BaseEnd)
+				si++
+			} else if ti < len(targetHunks) && hunksOverlap(targetHunks[ti], region) {
+				hi = max(hi, targetHunks[ti].BaseEnd)
+				ti++
+			} else {
+				break
+			}
+		}
+
+		sStart, sEnd := sideRange(sourceHunks[sFirst:si], lo, hi)
+		tStart, tEnd := sideRange(targetHunks[tFirst:ti], lo, hi)
+		if slices.Equal(source[sStart:sEnd], target[tStart:tEnd]) {
+			// Both sides made the same change
+			continue
+		}
+
+		if from := max(shown, sStart-conflictContextLines); from < sStart {
+			sections = append(sections, contextSection(source, from, sStart))
+		}
+		conflict := MRConflictSection{Conflict: true}
+		for k := sStart; k < sEnd; k++ {
+			conflict.Lines = append(conflict.Lines, MRConflictLine{Type: "new", NewLine: k + 1, Text: source[k]})
+		}
+		for k := tStart; k < tEnd; k++ {
+			conflict.Lines = append(conflict.Lines, MRConflictLine{Type: "old", OldLine: k + 1, Text: target[k]})
+		}
+		sections = append(sections, conflict)
+
+		// Context after the conflict stops where the next change on either side starts
+		after := min(len(source), sEnd+conflictContextLines)
+		if si < len(sourceHunks) {
+			after = min(after, sEnd+sourceHunks[si].BaseStart-hi)
+		}
+		if ti < len(targetHunks) {
+			after = min(after, sEnd+targetHunks[ti].BaseStart-hi)
+		}
+		if sEnd < after {
+			sections = append(sections, contextSection(source, sEnd, after))
+		}
+		shown = max(sEnd, after)
+	}
+	return sections, true
+}
+
+// hunksOverlap reports whether two hunks touch the same or adjacent base
+// lines, which git also treats as a conflict
+func hunksOverlap(a, b lineHunk) bool {
+	return a.BaseStart <= b.BaseEnd && b.BaseStart <= a.BaseEnd
+}
+
+// sideRange maps the base region [lo, hi) to one side, given that side's hunks
+// inside the region
+func sideRange(hunks []lineHunk, lo, hi int) (int, int) {
+	first, last := hunks[0], hunks[len(hunks)-1]
+	return first.SideStart - (first.BaseStart - lo), last.SideEnd + (hi - last.BaseEnd)
+}
+
+func contextSection(lines []string, from, to int) MRConflictSection {
+	section := MRConflictSection{}
+	for k := from; k < to; k++ {
+		section.Lines = append(section.Lines, MRConflictLine{NewLine: k + 1, Text: lines[k]})
+	}
+	return section
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+// RenderText implements render.Renderable on MRConflicts.
+// ModeCompact: one line per conflicting file.
+// ModeNormal: each file with its conflict regions between conflict markers.
+func (r *MRConflicts) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		if !r.HasConflicts {
+			fmt.Fprintf(&sb, "%s  no conflicts\n", r.Reference)
+			return sb.String()
+		}
+		if len(r.Files) == 0 {
+			fmt.Fprintf(&sb, "%s  conflicts not found line by line; see the MR in GitLab\n", r.Reference)
+			return sb.String()
+		}
+		for _, f := range r.Files {
+			fmt.Fprintf(&sb, "%s  %d conflict(s)\n", conflictFilePath(f), f.Conflicts())
+		}
+		return sb.String()
+	}
+
+	line := strings.Repeat("═", 80)
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glHeaderColor.Fprintf(&sb, "  Conflicts - %s\n", r.Reference)
+	glHeaderColor.Fprintln(&sb, line)
+	glDimColor.Fprintf(&sb, "  %s → %s\n\n", r.SourceBranch, r.TargetBranch)
+
+	if !r.HasConflicts {
+		glMRMergedColor.Fprint(&sb, "  ✓ No conflicts.\n\n")
+		return sb.String()
+	}
+
+	if len(r.Files) == 0 {
+		glMRClosedColor.Fprint(&sb, "  ✗ GitLab reports conflicts, but none were found line by line; see the MR in GitLab.\n\n")
+		return sb.String()
+	}
+
+	glMRClosedColor.Fprintf(&sb, "  ✗ %d conflicting file(s)\n\n", len(r.Files))
+	for _, f := range r.Files {
+		glProjectColor.Fprintf(&sb, "  %s", conflictFilePath(f))
+		glDimColor.Fprintf(&sb, "  (%d conflict(s))\n", f.Conflicts())
+		if len(f.Sections) == 0 {
+			glDimColor.Fprint(&sb, "    Conflict cannot be shown inline; resolve it in an editor.\n\n")
+			continue
+		}
+		for _, s := range f.Sections {
+			if !s.Conflict {
+				for _, l := range s.Lines {
+					glDimColor.Fprintf(&sb, "    %5s  %s\n", conflictLineNumber(l), l.Text)
+				}
+				continue
+			}
+			glMRClosedColor.Fprintf(&sb, "    %5s  <<<<<<< %s\n", "", r.SourceBranch)
+			for _, l := range s.Lines {
+				if l.Type == "new" {
+					glMRMergedColor.Fprintf(&sb, "    %5s  %s\n", conflictLineNumber(l), l.Text)
+				}
+			}
+			glMRClosedColor.Fprintf(&sb, "    %5s  =======\n", "")
+			for _, l := range s.Lines {
+				if l.Type == "old" {
+					glMROpenColor.Fprintf(&sb, "    %5s  %s\n", conflictLineNumber(l), l.Text)
+				}
+			}
+			glMRClosedColor.Fprintf(&sb, "    %5s  >>>>>>> %s\n", "", r.TargetBranch)
+		}
+		fmt.Fprintln(&sb)
+	}
+
+	return sb.String()
+}
+
+func conflictFilePath(f MRConflictFile) string {
+	if f.OldPath != "" && f.OldPath != f.NewPath {
+		return f.OldPath + " → " + f.NewPath
+	}
+	return f.NewPath
+}
+
+// conflictLineNumber returns the line number on the side the line belongs to
+func conflictLineNumber(l MRConflictLine) string {
+	if l.Type == "old" && l.OldLine > 0 {
+		return fmt.Sprint(l.OldLine)
+	}
+	if l.NewLine > 0 {
+		return fmt.Sprint(l.NewLine)
+	}
+	if l.OldLine > 0 {
+		return fmt.Sprint(l.OldLine)
+	}
+	return ""
+}
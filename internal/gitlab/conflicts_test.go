@@ -0,0 +1,201 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// conflictsServer serves a merge request whose source (abc123) and target
+// (main) branches both changed app/config.go, notes.txt and same.txt since the
+// merge base. Requests to failPath get a 403.
+func conflictsServer(t *testing.T, hasConflicts bool, failPath string) (*httptest.Server, *[]string) {
+	t.Helper()
+	files := map[string]string{
+		"app/config.go@base1":  "package app\n\nfunc load() {\n\ttimeout := 10\n\tretries := 3\n}\n",
+		"app/config.go@abc123": "package app\n\nfunc load() {\n\ttimeout := 30\n\tretries := 3\n}\n",
+		"app/config.go@main":   "package app\n\nfunc load() {\n\ttimeout := 60\n\tretries := 3\n}\n",
+		"notes.txt@base1":      "todo\n",
+		"notes.txt@main":       "done\n",
+		"same.txt@base1":       "a\n",
+		"same.txt@abc123":      "b\n",
+		"same.txt@main":        "b\n",
+	}
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		req := r.Method + " " + r.URL.Path
+		switch {
+		case q.Has("ref"):
+			req += "?ref=" + q.Get("ref")
+		case q.Has("to"):
+			req += "?from=" + q.Get("from") + "&to=" + q.Get("to")
+		}
+		requests = append(requests, req)
+		if r.URL.Path == failPath {
+			http.Error(w, `{"message":"403 Forbidden"}`, http.StatusForbidden)
+			return
+		}
+
+		const repo = "/api/v4/projects/42/repository"
+		switch {
+		case r.URL.Path == "/api/v4/projects/42/merge_requests/7":
+			fmt.Fprintf(w, `{"iid":7,"source_branch":"feature","target_branch":"main","sha":"abc123","has_conflicts":%t}`, hasConflicts)
+		case r.URL.Path == repo+"/merge_base":
+			if refs := q["refs[]"]; !slices.Equal(refs, []string{"abc123", "main"}) {
+				t.Errorf("merge_base refs = %v, want [abc123 main]", refs)
+			}
+			fmt.Fprint(w, `{"id":"base1"}`)
+		case r.URL.Path == repo+"/compare" && q.Get("to") == "abc123":
+			fmt.Fprint(w, `{"diffs":[
+				{"old_path":"app/config.go","new_path":"app/config.go"},
+				{"old_path":"notes.txt","new_path":"notes.txt","deleted_file":true},
+				{"old_path":"same.txt","new_path":"same.txt"},
+				{"old_path":"README.md","new_path":"README.md"}]}`)
+		case r.URL.Path == repo+"/compare" && q.Get("to") == "main":
+			fmt.Fprint(w, `{"diffs":[
+				{"old_path":"app/config.go","new_path":"app/config.go"},
+				{"old_path":"notes.txt","new_path":"notes.txt"},
+				{"old_path":"same.txt","new_path":"same.txt"}]}`)
+		case strings.HasPrefix(r.URL.Path, repo+"/files/") && strings.HasSuffix(r.URL.Path, "/raw"):
+			path := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, repo+"/files/"), "/raw")
+			content, ok := files[path+"@"+q.Get("ref")]
+			if !ok {
+				http.Error(w, `{"message":"404 File Not Found"}`, http.StatusNotFound)
+				return
+			}
+			fmt.Fprint(w, content)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv, &requests
+}
+
+func TestGetMergeRequestConflicts(t *testing.T) {
+	srv, requests := conflictsServer(t, true, "")
+	client, err := NewClient(srv.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conflicts, err := client.GetMergeRequestConflicts(42, 7)
+	if err != nil {
+		t.Fatalf("GetMergeRequestConflicts: %v", err)
+	}
+
+	for _, want := range []string{
+		"GET /api/v4/projects/42/merge_requests/7",
+		"GET /api/v4/projects/42/repository/merge_base",
+		"GET /api/v4/projects/42/repository/compare?from=base1&to=abc123",
+		"GET /api/v4/projects/42/repository/compare?from=base1&to=main",
+		"GET /api/v4/projects/42/repository/files/app/config.go/raw?ref=abc123",
+		"GET /api/v4/projects/42/repository/files/app/config.go/raw?ref=main",
+	} {
+		if !slices.Contains(*requests, want) {
+			t.Errorf("missing request %q in %v", want, *requests)
+		}
+	}
+	for _, req := range *requests {
+		if strings.Contains(req, "README.md") || strings.Contains(req, "conflicts") {
+			t.Errorf("unexpected request %q", req)
+		}
+	}
+
+	if !conflicts.HasConflicts || conflicts.SourceBranch != "feature" || conflicts.TargetBranch != "main" {
+		t.Errorf("conflicts = %+v", conflicts)
+	}
+	if len(conflicts.Files) != 2 {
+		t.Fatalf("expected config.go and notes.txt, got %+v", conflicts.Files)
+	}
+
+	f := conflicts.Files[0]
+	if f.NewPath != "app/config.go" || f.Conflicts() != 1 || len(f.Sections) != 3 {
+		t.Fatalf("config.go: got %d conflicts in %d sections, want 1 in 3: %+v", f.Conflicts(), len(f.Sections), f)
+	}
+	before, conflict, after := f.Sections[0], f.Sections[1], f.Sections[2]
+	if len(before.Lines) != 3 || before.Lines[2].Text != "func load() {" || conflictLineNumber(before.Lines[2]) != "3" {
+		t.Errorf("context before: got %+v", before.Lines)
+	}
+	want := []MRConflictLine{
+		{Type: "new", NewLine: 4, Text: "\ttimeout := 30"},
+		{Type: "old", OldLine: 4, Text: "\ttimeout := 60"},
+	}
+	if !conflict.Conflict || !slices.Equal(conflict.Lines, want) {
+		t.Errorf("conflict: got %+v, want %+v", conflict.Lines, want)
+	}
+	if len(after.Lines) != 2 || after.Lines[0].Text != "\tretries := 3" {
+		t.Errorf("context after: got %+v", after.Lines)
+	}
+
+	if notes := conflicts.Files[1]; notes.NewPath != "notes.txt" || len(notes.Sections) != 0 {
+		t.Errorf("notes.txt (deleted on source): expected no inline sections, got %+v", notes)
+	}
+}
+
+func TestGetMergeRequestConflictsErrors(t *testing.T) {
+	// No conflicts: nothing beyond the MR is fetched
+	srv, requests := conflictsServer(t, false, "")
+	client, err := NewClient(srv.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conflicts, err := client.GetMergeRequestConflicts(42, 7)
+	if err != nil {
+		t.Fatalf("GetMergeRequestConflicts: %v", err)
+	}
+	if conflicts.HasConflicts || len(conflicts.Files) != 0 || len(*requests) != 1 {
+		t.Errorf("got %+v after requests %v, want no conflicts after one request", conflicts, *requests)
+	}
+
+	tests := []struct {
+		failPath string
+		wantErr  string
+	}{
+		{"/api/v4/projects/42/merge_requests/7", "failed to get merge request"},
+		{"/api/v4/projects/42/repository/merge_base", "failed to get merge base"},
+		{"/api/v4/projects/42/repository/compare", "failed to compare"},
+		{"/api/v4/projects/42/repository/files/app/config.go/raw", "failed to get app/config.go"},
+	}
+	for _, tt := range tests {
+		srv, _ := conflictsServer(t, true, tt.failPath)
+		client, err := NewClient(srv.URL, "token")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, err = client.GetMergeRequestConflicts(42, 7)
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), "403") {
+			t.Errorf("%s: err = %v, want %q with the 403", tt.failPath, err, tt.wantErr)
+		}
+	}
+}
+
+func TestMergeConflictSections(t *testing.T) {
+	lines := func(s string) []string { return strings.Split(s, " ") }
+	tests := []struct {
+		name                 string
+		base, source, target string
+		wantConflicts        int
+	}{
+		{"changes far apart merge cleanly", "a b c d e f", "A b c d e f", "a b c d e F", 0},
+		{"same change on both sides", "a b c", "a B c", "a B c", 0},
+		{"same line changed differently", "a b c", "a X c", "a Y c", 1},
+		{"adjacent lines conflict", "a b c d", "a B c d", "a b C d", 1},
+		{"insertions at the same place", "a b", "a x b", "a y b", 1},
+		{"two separate conflicts", "a b c d e f g h i", "A b c d e f g h I", "Z b c d e f g h Y", 2},
+	}
+	for _, tt := range tests {
+		sections, ok := mergeConflictSections(lines(tt.base), lines(tt.source), lines(tt.target))
+		if !ok {
+			t.Fatalf("%s: diff refused", tt.name)
+		}
+		got := MRConflictFile{Sections: sections}.Conflicts()
+		if got != tt.wantConflicts {
+			t.Errorf("%s: got %d conflicts, want %d: %+v", tt.name, got, tt.wantConflicts, sections)
+		}
+	}
+}
@@ -123,6 +123,19 @@ func ParseUnifiedDiff(diff string) *ParsedDiff {
 	return parsed
 }
 
+// Stats counts the added and deleted lines of the diff
+func (p *ParsedDiff) Stats() (added, deleted int) {
+	for _, l := range p.Lines {
+		switch l.Type {
+		case LineAdded:
+			added++
+		case LineDeleted:
+			deleted++
+		}
+	}
+	return added, deleted
+}
+
 // FindLineByNew finds a line by its new line number
 // Returns the DiffLine and whether it was found
 func (p *ParsedDiff) FindLineByNew(newLine int) (*DiffLine, bool) {
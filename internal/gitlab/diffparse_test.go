@@ -273,3 +273,42 @@ func TestParseUnifiedDiff_EmptyDiff(t *testing.T) {
 		t.Fatalf("expected 0 lines for empty diff, got %d", len(parsed.Lines))
 	}
 }
+
+func TestParsedDiff_Stats(t *testing.T) {
+	diff := `--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ package main
+-import "fmt"
++import (
++	"fmt"
++)
+ 
+@@ -20,4 +21,3 @@ func main() {
+ 	x := 1
+-	y := 2
+-	z := 3
++	y := x + 1
+ }
+\ No newline at end of file`
+
+	added, deleted := ParseUnifiedDiff(diff).Stats()
+	if added != 4 || deleted != 3 {
+		t.Errorf("Stats() = +%d/-%d, want +4/-3", added, deleted)
+	}
+
+	if added, deleted := ParseUnifiedDiff("").Stats(); added != 0 || deleted != 0 {
+		t.Errorf("empty diff Stats() = +%d/-%d, want +0/-0", added, deleted)
+	}
+
+	result := NewMRDiffStatResult("group/project!1", []MRFile{
+		{NewPath: "a.go", Additions: 4, Deletions: 3, Diff: diff},
+		{NewPath: "b.go", Additions: 1},
+	})
+	if result.Additions != 5 || result.Deletions != 3 {
+		t.Errorf("totals = +%d/-%d, want +5/-3", result.Additions, result.Deletions)
+	}
+	if result.Files[0].Diff != "" {
+		t.Error("diff contents kept in stat result")
+	}
+}
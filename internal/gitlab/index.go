@@ -15,15 +15,32 @@ import (
 
 const maxConcurrentFetches = 10
 
-func indexConfigDir() (string, error) {
+func indexDirPath() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	dir := filepath.Join(home, ".dex", "gitlab")
+	return filepath.Join(home, ".dex", "gitlab"), nil
+}
+
+func indexConfigDir() (string, error) {
+	dir, err := indexDirPath()
+	if err != nil {
+		return "", err
+	}
 	return dir, os.MkdirAll(dir, 0700)
 }
 
+// IndexPath returns the location of the GitLab index file without creating
+// its directory
+func IndexPath() (string, error) {
+	dir, err := indexDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "index.json"), nil
+}
+
 func indexFilePath() (string, error) {
 	dir, err := indexConfigDir()
 	if err != nil {
@@ -108,6 +125,7 @@ func (c *Client) fetchProjectMetadata(p *gitlab.Project) ProjectMetadata {
 		WebURL:         p.WebURL,
 		DefaultBranch:  p.DefaultBranch,
 		Visibility:     string(p.Visibility),
+		Archived:       p.Archived,
 		Topics:         p.Topics,
 		StarCount:      p.StarCount,
 		ForksCount:     p.ForksCount,
@@ -150,6 +168,21 @@ func (c *Client) fetchProjectMetadata(p *gitlab.Project) ProjectMetadata {
 	return pm
 }
 
+// SetIndexedProjectArchived records the archived state of a project in the
+// local index. Projects that are not indexed are left alone.
+func SetIndexedProjectArchived(id int, archived bool) error {
+	idx, err := LoadIndex()
+	if err != nil {
+		return err
+	}
+	i, ok := idx.ProjectsByID[id]
+	if !ok {
+		return nil
+	}
+	idx.Projects[i].Archived = archived
+	return SaveIndex(idx)
+}
+
 type ProgressFunc func(completed, total int)
 
 func (c *Client) IndexAllProjects(gitlabURL string, progressFn ProgressFunc) (*GitLabIndex, error) {
@@ -0,0 +1,50 @@
+package gitlab
+
+import (
+	"bytes"
+	"os"
+	"testing"
+)
+
+func TestSetIndexedProjectArchived(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	idx := searchTestIndex()
+	idx.Projects[0].Description = "Runbook for incident 4242"
+	if err := SaveIndex(idx); err != nil {
+		t.Fatal(err)
+	}
+	path, err := IndexPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// An ID that is not indexed leaves the index as it is, even when a
+	// project's description mentions it
+	if err := SetIndexedProjectArchived(42, true); err != nil {
+		t.Fatalf("SetIndexedProjectArchived(42): %v", err)
+	}
+	after, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(before, after) {
+		t.Errorf("unknown ID changed the index:\n%s", after)
+	}
+
+	if err := SetIndexedProjectArchived(2, true); err != nil {
+		t.Fatalf("SetIndexedProjectArchived(2): %v", err)
+	}
+	loaded, err := LoadIndex()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range loaded.Projects {
+		if want := p.ID == 2 || p.ID == 4; p.Archived != want {
+			t.Errorf("project %d archived = %v, want %v", p.ID, p.Archived, want)
+		}
+	}
+}
@@ -0,0 +1,639 @@
+package gitlab
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codewandler/dex/internal/render"
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// Issue represents a GitLab issue in list views
+type Issue struct {
+	IID         int       `json:"iid"`
+	Title       string    `json:"title"`
+	State       string    `json:"state"`
+	Author      string    `json:"author"`
+	Assignees   []string  `json:"assignees,omitempty"`
+	Labels      []string  `json:"labels,omitempty"`
+	Milestone   string    `json:"milestone,omitempty"`
+	ProjectPath string    `json:"project_path,omitempty"`
+	WebURL      string    `json:"web_url"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// IssueDetail is a single issue with its description and comments
+type IssueDetail struct {
+	Issue
+	Description string     `json:"description,omitempty"`
+	ClosedAt    *time.Time `json:"closed_at,omitempty"`
+	ClosedBy    string     `json:"closed_by,omitempty"`
+	DueDate     string     `json:"due_date,omitempty"`
+	Notes       []MRNote   `json:"notes,omitempty"`
+}
+
+// ListIssuesOptions configures the issue list query
+type ListIssuesOptions struct {
+	State    string   // opened, closed, all
+	Scope    string   // created_by_me, assigned_to_me, all
+	Labels   []string // only issues carrying all of these labels
+	Assignee string   // assignee username
+	Limit    int      // 0 = all
+	OrderBy  string   // created_at, updated_at
+	Sort     string   // asc, desc
+}
+
+// ListIssues fetches issues of a project, or of all visible projects when
+// projectID is empty, following pagination until Limit is reached
+func (c *Client) ListIssues(projectID string, opts ListIssuesOptions) ([]Issue, error) {
+	if opts.State == "" {
+		opts.State = "opened"
+	}
+	if opts.OrderBy == "" {
+		opts.OrderBy = "updated_at"
+	}
+	if opts.Sort == "" {
+		opts.Sort = "desc"
+	}
+	if projectID == "" {
+		return c.listAllIssues(opts)
+	}
+
+	perPage := 100
+	if opts.Limit > 0 {
+		perPage = min(opts.Limit, 100)
+	}
+
+	listOpts := &gogitlab.ListProjectIssuesOptions{
+		ListOptions: gogitlab.ListOptions{
+			PerPage: perPage,
+			Page:    1,
+		},
+		State:   gogitlab.Ptr(opts.State),
+		OrderBy: gogitlab.Ptr(opts.OrderBy),
+		Sort:    gogitlab.Ptr(opts.Sort),
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		listOpts.Labels = &labels
+	}
+	if opts.Assignee != "" {
+		listOpts.AssigneeUsername = gogitlab.Ptr(opts.Assignee)
+	}
+	if opts.Scope != "" {
+		listOpts.Scope = gogitlab.Ptr(opts.Scope)
+	}
+
+	var allIssues []Issue
+	for {
+		issues, resp, err := c.gl.Issues.ListProjectIssues(projectID, listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range issues {
+			allIssues = append(allIssues, convertIssue(i))
+			if opts.Limit > 0 && len(allIssues) >= opts.Limit {
+				return allIssues, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	return allIssues, nil
+}
+
+// listAllIssues is ListIssues across all projects visible to the user. Scope
+// defaults to all, as for merge requests.
+func (c *Client) listAllIssues(opts ListIssuesOptions) ([]Issue, error) {
+	if opts.Scope == "" {
+		opts.Scope = "all"
+	}
+
+	perPage := 100
+	if opts.Limit > 0 {
+		perPage = min(opts.Limit, 100)
+	}
+
+	listOpts := &gogitlab.ListIssuesOptions{
+		ListOptions: gogitlab.ListOptions{
+			PerPage: perPage,
+			Page:    1,
+		},
+		State:   gogitlab.Ptr(opts.State),
+		Scope:   gogitlab.Ptr(opts.Scope),
+		OrderBy: gogitlab.Ptr(opts.OrderBy),
+		Sort:    gogitlab.Ptr(opts.Sort),
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		listOpts.Labels = &labels
+	}
+	if opts.Assignee != "" {
+		listOpts.AssigneeUsername = gogitlab.Ptr(opts.Assignee)
+	}
+
+	var allIssues []Issue
+	for {
+		issues, resp, err := c.gl.Issues.ListIssues(listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range issues {
+			allIssues = append(allIssues, convertIssue(i))
+			if opts.Limit > 0 && len(allIssues) >= opts.Limit {
+				return allIssues, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	return allIssues, nil
+}
+
+// GetIssue fetches a single issue with its description and user comments
+func (c *Client) GetIssue(projectID any, issueIID int) (*IssueDetail, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	i, _, err := c.gl.Issues.GetIssue(pid, issueIID)
+	if err != nil {
+		return nil, err
+	}
+
+	issue := &IssueDetail{
+		Issue:       convertIssue(i),
+		Description: i.Description,
+		ClosedAt:    i.ClosedAt,
+	}
+	if i.ClosedBy != nil {
+		issue.ClosedBy = i.ClosedBy.Username
+	}
+	if i.DueDate != nil {
+		issue.DueDate = i.DueDate.String()
+	}
+
+	notes, err := c.getIssueNotes(pid, issueIID)
+	if err != nil {
+		return nil, err
+	}
+	issue.Notes = notes
+
+	return issue, nil
+}
+
+// getIssueNotes fetches the user comments on an issue, oldest first. System
+// notes (label changes, mentions) are dropped.
+func (c *Client) getIssueNotes(pid, issueIID int) ([]MRNote, error) {
+	opts := &gogitlab.ListIssueNotesOptions{
+		ListOptions: gogitlab.ListOptions{
+			PerPage: 100,
+			Page:    1,
+		},
+		Sort:    gogitlab.Ptr("asc"),
+		OrderBy: gogitlab.Ptr("created_at"),
+	}
+
+	var notes []MRNote
+	for {
+		apiNotes, resp, err := c.gl.Notes.ListIssueNotes(pid, issueIID, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, n := range apiNotes {
+			if n.System {
+				continue
+			}
+			note := MRNote{
+				ID:     n.ID,
+				Body:   n.Body,
+				Author: n.Author.Username,
+			}
+			if n.CreatedAt != nil {
+				note.CreatedAt = *n.CreatedAt
+			}
+			notes = append(notes, note)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return notes, nil
+}
+
+// CreateIssueOptions configures a new issue
+type CreateIssueOptions struct {
+	Title        string
+	Description  string
+	Labels       []string
+	Confidential bool
+}
+
+// CreateIssue opens a new issue in a project
+func (c *Client) CreateIssue(projectID any, opts CreateIssueOptions) (*Issue, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	createOpts := &gogitlab.CreateIssueOptions{
+		Title: gogitlab.Ptr(opts.Title),
+	}
+	if opts.Description != "" {
+		createOpts.Description = gogitlab.Ptr(opts.Description)
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		createOpts.Labels = &labels
+	}
+	if opts.Confidential {
+		createOpts.Confidential = gogitlab.Ptr(true)
+	}
+
+	i, _, err := c.gl.Issues.CreateIssue(pid, createOpts)
+	if err != nil {
+		return nil, err
+	}
+	issue := convertIssue(i)
+	return &issue, nil
+}
+
+// CreateIssueNote adds a comment to an issue
+func (c *Client) CreateIssueNote(projectID any, issueIID int, body string) error {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.gl.Notes.CreateIssueNote(pid, issueIID, &gogitlab.CreateIssueNoteOptions{
+		Body: gogitlab.Ptr(body),
+	})
+	return err
+}
+
+// CloseIssue closes an open issue
+func (c *Client) CloseIssue(projectID any, issueIID int) error {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = c.gl.Issues.UpdateIssue(pid, issueIID, &gogitlab.UpdateIssueOptions{
+		StateEvent: gogitlab.Ptr("close"),
+	})
+	return err
+}
+
+func convertIssue(i *gogitlab.Issue) Issue {
+	issue := Issue{
+		IID:    i.IID,
+		Title:  i.Title,
+		State:  i.State,
+		Labels: i.Labels,
+		WebURL: i.WebURL,
+	}
+	if i.Author != nil {
+		issue.Author = i.Author.Username
+	}
+	for _, a := range i.Assignees {
+		issue.Assignees = append(issue.Assignees, a.Username)
+	}
+	if i.Milestone != nil {
+		issue.Milestone = i.Milestone.Title
+	}
+	if i.References != nil {
+		if idx := strings.LastIndex(i.References.Full, "#"); idx > 0 {
+			issue.ProjectPath = i.References.Full[:idx]
+		}
+	}
+	if i.CreatedAt != nil {
+		issue.CreatedAt = *i.CreatedAt
+	}
+	if i.UpdatedAt != nil {
+		issue.UpdatedAt = *i.UpdatedAt
+	}
+	return issue
+}
+
+// ── Board ─────────────────────────────────────────────────────────────────────
+
+// IssueBoardNoneColumn is the column name for issues without a matching label
+const IssueBoardNoneColumn = "(none)"
+
+// IssueBoardColumn is one column of a label-grouped issue board
+type IssueBoardColumn struct {
+	Name   string  `json:"name"`
+	Label  string  `json:"label,omitempty"` // full label, empty for the (none) column
+	Issues []Issue `json:"issues"`
+}
+
+// IssueBoardResult is a label-grouped view of a project's issues
+type IssueBoardResult struct {
+	Project     string             `json:"project"`
+	LabelPrefix string             `json:"label_prefix"`
+	Total       int                `json:"total"`
+	Columns     []IssueBoardColumn `json:"columns"`
+}
+
+// BuildIssueBoard buckets issues by the first label starting with prefix.
+// Column order follows columns (label values without the prefix) when given,
+// otherwise the label values are sorted alphabetically. Issues without a
+// matching label, or whose value is not in columns, go to the (none) column,
+// which is placed first and omitted when empty.
+func BuildIssueBoard(project, prefix string, issues []Issue, columns []string) *IssueBoardResult {
+	buckets := make(map[string][]Issue)
+	for _, issue := range issues {
+		value := IssueBoardNoneColumn
+		for _, l := range issue.Labels {
+			if strings.HasPrefix(l, prefix) && len(l) > len(prefix) {
+				value = l[len(prefix):]
+				break
+			}
+		}
+		buckets[value] = append(buckets[value], issue)
+	}
+
+	order := columns
+	if len(order) == 0 {
+		for value := range buckets {
+			if value != IssueBoardNoneColumn {
+				order = append(order, value)
+			}
+		}
+		sort.Strings(order)
+	} else {
+		// Issues in columns that were not requested fall back to (none)
+		wanted := make(map[string]bool, len(order))
+		for _, v := range order {
+			wanted[v] = true
+		}
+		for value, list := range buckets {
+			if value != IssueBoardNoneColumn && !wanted[value] {
+				buckets[IssueBoardNoneColumn] = append(buckets[IssueBoardNoneColumn], list...)
+				delete(buckets, value)
+			}
+		}
+	}
+
+	result := &IssueBoardResult{
+		Project:     project,
+		LabelPrefix: prefix,
+		Total:       len(issues),
+	}
+	if none := buckets[IssueBoardNoneColumn]; len(none) > 0 {
+		result.Columns = append(result.Columns, IssueBoardColumn{Name: IssueBoardNoneColumn, Issues: none})
+	}
+	for _, value := range order {
+		result.Columns = append(result.Columns, IssueBoardColumn{
+			Name:   value,
+			Label:  prefix + value,
+			Issues: buckets[value],
+		})
+	}
+	return result
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+const issueBoardColWidth = 32
+
+// RenderText implements render.Renderable on IssueBoardResult.
+// ModeCompact: one line per issue grouped under column headings.
+// ModeNormal: side-by-side columns.
+func (r *IssueBoardResult) RenderText(mode render.Mode) string {
+	if r.Total == 0 {
+		return glDimColor.Sprint("No issues found.\n")
+	}
+
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, col := range r.Columns {
+			glSectionColor.Fprintf(&sb, "%s (%d)\n", col.Name, len(col.Issues))
+			for _, issue := range col.Issues {
+				fmt.Fprintf(&sb, "  #%-5d %s\n", issue.IID, glTruncate(issue.Title, 70))
+			}
+		}
+		return sb.String()
+	}
+
+	width := len(r.Columns)*(issueBoardColWidth+3) - 3
+	line := strings.Repeat("═", max(width, 60))
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glHeaderColor.Fprintf(&sb, "  %s — %s* (%d issues)\n", r.Project, r.LabelPrefix, r.Total)
+	glHeaderColor.Fprintln(&sb, line)
+	fmt.Fprintln(&sb)
+
+	// Column headers
+	for i, col := range r.Columns {
+		if i > 0 {
+			glDimColor.Fprint(&sb, " │ ")
+		}
+		header := glTruncate(fmt.Sprintf("%s (%d)", col.Name, len(col.Issues)), issueBoardColWidth)
+		glSectionColor.Fprintf(&sb, "%-*s", issueBoardColWidth, header)
+	}
+	fmt.Fprintln(&sb)
+	for i := range r.Columns {
+		if i > 0 {
+			glDimColor.Fprint(&sb, "─┼─")
+		}
+		glDimColor.Fprint(&sb, strings.Repeat("─", issueBoardColWidth))
+	}
+	fmt.Fprintln(&sb)
+
+	// Each issue takes two rows: "#iid title" and a dim assignee line
+	rows := 0
+	for _, col := range r.Columns {
+		rows = max(rows, len(col.Issues))
+	}
+	for row := 0; row < rows; row++ {
+		for i, col := range r.Columns {
+			if i > 0 {
+				glDimColor.Fprint(&sb, " │ ")
+			}
+			if row >= len(col.Issues) {
+				fmt.Fprint(&sb, strings.Repeat(" ", issueBoardColWidth))
+				continue
+			}
+			issue := col.Issues[row]
+			ref := fmt.Sprintf("#%d", issue.IID)
+			title := glTruncate(issue.Title, issueBoardColWidth-len(ref)-1)
+			glProjectColor.Fprint(&sb, glHyperlink(issue.WebURL, ref))
+			fmt.Fprintf(&sb, " %-*s", issueBoardColWidth-len(ref)-1, title)
+		}
+		fmt.Fprintln(&sb)
+		for i, col := range r.Columns {
+			if i > 0 {
+				glDimColor.Fprint(&sb, " │ ")
+			}
+			if row >= len(col.Issues) {
+				fmt.Fprint(&sb, strings.Repeat(" ", issueBoardColWidth))
+				continue
+			}
+			issue := col.Issues[row]
+			who := "unassigned"
+			if len(issue.Assignees) > 0 {
+				who = "@" + strings.Join(issue.Assignees, ", @")
+			}
+			glDimColor.Fprintf(&sb, "%-*s", issueBoardColWidth, glTruncate("  "+who, issueBoardColWidth))
+		}
+		fmt.Fprintln(&sb)
+	}
+	fmt.Fprintln(&sb)
+
+	return sb.String()
+}
+
+// IssueListResult holds a list of issues for display
+type IssueListResult struct {
+	Issues []Issue `json:"issues"`
+	Total  int     `json:"total"`
+}
+
+// RenderText implements render.Renderable on IssueListResult
+func (r *IssueListResult) RenderText(mode render.Mode) string {
+	if len(r.Issues) == 0 {
+		return glDimColor.Sprint("No issues found.\n")
+	}
+
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, issue := range r.Issues {
+			fmt.Fprintf(&sb, "%s  %-30s  %s\n",
+				glFormatMRState(issue.State),
+				glTruncate(fmt.Sprintf("%s#%d", issue.ProjectPath, issue.IID), 30),
+				glTruncate(issue.Title, 60),
+			)
+		}
+		return sb.String()
+	}
+
+	line := strings.Repeat("═", 90)
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glHeaderColor.Fprintf(&sb, "  Issues (%d)\n", len(r.Issues))
+	glHeaderColor.Fprintln(&sb, line)
+	fmt.Fprintln(&sb)
+
+	for _, issue := range r.Issues {
+		glProjectColor.Fprintf(&sb, "  %s ", glFormatMRState(issue.State))
+		fmt.Fprintf(&sb, "%s\n", glTruncate(issue.Title, 70))
+
+		ref := fmt.Sprintf("%s#%d", issue.ProjectPath, issue.IID)
+		fmt.Fprintf(&sb, "    %s", glHyperlink(issue.WebURL, ref))
+		glDimColor.Fprintf(&sb, "  by %s  %s\n", issue.Author, glTimeAgo(issue.UpdatedAt))
+
+		if len(issue.Assignees) > 0 || len(issue.Labels) > 0 {
+			fmt.Fprint(&sb, "    ")
+			if len(issue.Assignees) > 0 {
+				glDimColor.Fprintf(&sb, "@%s  ", strings.Join(issue.Assignees, ", @"))
+			}
+			if len(issue.Labels) > 0 {
+				glLabelColor.Fprint(&sb, strings.Join(issue.Labels, ", "))
+			}
+			fmt.Fprintln(&sb)
+		}
+		fmt.Fprintln(&sb)
+	}
+
+	return sb.String()
+}
+
+// IssueDetailResult holds full issue information for display
+type IssueDetailResult struct {
+	IssueDetail
+}
+
+// RenderText implements render.Renderable on IssueDetailResult.
+// ModeCompact omits the description and comments.
+func (r *IssueDetailResult) RenderText(mode render.Mode) string {
+	issue := &r.IssueDetail
+	var sb strings.Builder
+
+	line := strings.Repeat("═", 70)
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glProjectColor.Fprintf(&sb, "  %s %s\n", glFormatMRState(issue.State), issue.Title)
+	glHeaderColor.Fprintln(&sb, line)
+	fmt.Fprintln(&sb)
+
+	glPrintField(&sb, "Reference", fmt.Sprintf("%s#%d", issue.ProjectPath, issue.IID))
+	glPrintField(&sb, "URL", issue.WebURL)
+	glPrintField(&sb, "Author", issue.Author)
+	glPrintField(&sb, "Created", glFormatTimestamp(issue.CreatedAt))
+	glPrintField(&sb, "Updated", glFormatTimestamp(issue.UpdatedAt))
+	if issue.ClosedAt != nil {
+		closed := glFormatTimestamp(*issue.ClosedAt)
+		if issue.ClosedBy != "" {
+			closed += " by " + issue.ClosedBy
+		}
+		glPrintField(&sb, "Closed", closed)
+	}
+	if len(issue.Assignees) > 0 {
+		glPrintField(&sb, "Assignees", strings.Join(issue.Assignees, ", "))
+	}
+	if len(issue.Labels) > 0 {
+		glPrintField(&sb, "Labels", strings.Join(issue.Labels, ", "))
+	}
+	if issue.Milestone != "" {
+		glPrintField(&sb, "Milestone", issue.Milestone)
+	}
+	if issue.DueDate != "" {
+		glPrintField(&sb, "Due", issue.DueDate)
+	}
+
+	if mode == render.ModeCompact {
+		if len(issue.Notes) > 0 {
+			glPrintField(&sb, "Comments", fmt.Sprintf("%d", len(issue.Notes)))
+		}
+		fmt.Fprintln(&sb)
+		return sb.String()
+	}
+
+	if issue.Description != "" {
+		fmt.Fprintln(&sb)
+		glSectionColor.Fprint(&sb, "  Description:\n")
+		fmt.Fprintln(&sb)
+		for _, l := range strings.Split(strings.TrimSpace(issue.Description), "\n") {
+			fmt.Fprintf(&sb, "    %s\n", l)
+		}
+	}
+
+	if len(issue.Notes) > 0 {
+		fmt.Fprintln(&sb)
+		glSectionColor.Fprintf(&sb, "  Comments (%d):\n", len(issue.Notes))
+		for _, n := range issue.Notes {
+			fmt.Fprintln(&sb)
+			glLabelColor.Fprintf(&sb, "    %s ", n.Author)
+			glDimColor.Fprintf(&sb, "(%s):\n", glTimeAgo(n.CreatedAt))
+			for _, l := range strings.Split(strings.TrimSpace(n.Body), "\n") {
+				fmt.Fprintf(&sb, "    %s\n", l)
+			}
+		}
+	}
+
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
@@ -0,0 +1,175 @@
+package gitlab
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codewandler/dex/internal/render"
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// ProjectMember is a user with access to a project, directly or via a parent group
+type ProjectMember struct {
+	Username    string     `json:"username"`
+	Name        string     `json:"name"`
+	State       string     `json:"state"`
+	AccessLevel int        `json:"access_level"`
+	Role        string     `json:"role"` // Guest, Reporter, Developer, Maintainer, Owner
+	ExpiresAt   *time.Time `json:"expires_at,omitempty"`
+	WebURL      string     `json:"web_url"`
+}
+
+// accessLevels maps GitLab role names to access level values, lowest first
+var accessLevels = []struct {
+	name  string
+	level gogitlab.AccessLevelValue
+}{
+	{"minimal", gogitlab.MinimalAccessPermissions},
+	{"guest", gogitlab.GuestPermissions},
+	{"reporter", gogitlab.ReporterPermissions},
+	{"developer", gogitlab.DeveloperPermissions},
+	{"maintainer", gogitlab.MaintainerPermissions},
+	{"owner", gogitlab.OwnerPermissions},
+	{"admin", gogitlab.AdminPermissions},
+}
+
+// ParseAccessLevel converts a role name (guest, reporter, developer,
+// maintainer, owner; case-insensitive) to its GitLab access level
+func ParseAccessLevel(name string) (int, error) {
+	for _, l := range accessLevels {
+		if strings.EqualFold(name, l.name) {
+			return int(l.level), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown access level %q (use guest, reporter, developer, maintainer or owner)", name)
+}
+
+// AccessLevelName returns the role name of an access level, e.g. "Maintainer"
+func AccessLevelName(level int) string {
+	name := ""
+	for _, l := range accessLevels {
+		if level >= int(l.level) {
+			name = l.name
+		}
+	}
+	if name == "" {
+		return "None"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+// ListProjectMembers lists everyone with access to a project, including members
+// inherited from parent groups, with at least minLevel access (0 = all). Members
+// are sorted by access level, highest first, then by username.
+func (c *Client) ListProjectMembers(projectID any, minLevel int) ([]ProjectMember, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gogitlab.ListProjectMembersOptions{
+		ListOptions: gogitlab.ListOptions{PerPage: 100, Page: 1},
+	}
+
+	var members []ProjectMember
+	for {
+		page, resp, err := c.gl.ProjectMembers.ListAllProjectMembers(pid, opts)
+		if err != nil {
+			return nil, err
+		}
+		for _, m := range page {
+			if int(m.AccessLevel) < minLevel {
+				continue
+			}
+			member := ProjectMember{
+				Username:    m.Username,
+				Name:        m.Name,
+				State:       m.State,
+				AccessLevel: int(m.AccessLevel),
+				Role:        AccessLevelName(int(m.AccessLevel)),
+				WebURL:      m.WebURL,
+			}
+			if m.ExpiresAt != nil {
+				exp := time.Time(*m.ExpiresAt)
+				member.ExpiresAt = &exp
+			}
+			members = append(members, member)
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	SortProjectMembers(members)
+	return members, nil
+}
+
+// SortProjectMembers orders members by access level (highest first), then username
+func SortProjectMembers(members []ProjectMember) {
+	sort.SliceStable(members, func(i, j int) bool {
+		if members[i].AccessLevel != members[j].AccessLevel {
+			return members[i].AccessLevel > members[j].AccessLevel
+		}
+		return members[i].Username < members[j].Username
+	})
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+// ProjectMembersResult holds a project's members for display.
+type ProjectMembersResult struct {
+	Project  string          `json:"project"`
+	MinLevel string          `json:"min_level,omitempty"`
+	Members  []ProjectMember `json:"members"`
+}
+
+// RenderText implements render.Renderable on ProjectMembersResult.
+// ModeCompact: one line per member.
+// ModeNormal: members grouped under their role.
+func (r *ProjectMembersResult) RenderText(mode render.Mode) string {
+	if len(r.Members) == 0 {
+		return glDimColor.Sprint("No members found.\n")
+	}
+
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, m := range r.Members {
+			fmt.Fprintf(&sb, "%-11s @%s\n", m.Role, m.Username)
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintln(&sb)
+	glSectionColor.Fprintf(&sb, "  Members - %s (%d", r.Project, len(r.Members))
+	if r.MinLevel != "" {
+		glSectionColor.Fprintf(&sb, ", %s and above", r.MinLevel)
+	}
+	glSectionColor.Fprint(&sb, "):\n")
+
+	role := ""
+	for _, m := range r.Members {
+		if m.Role != role {
+			role = m.Role
+			fmt.Fprintln(&sb)
+			glHeaderColor.Fprintf(&sb, "  %s\n", role)
+		}
+		glProjectColor.Fprintf(&sb, "    @%-24s ", m.Username)
+		fmt.Fprint(&sb, m.Name)
+		if m.State != "" && m.State != "active" {
+			glMRClosedColor.Fprintf(&sb, " [%s]", m.State)
+		}
+		if m.ExpiresAt != nil {
+			glDimColor.Fprintf(&sb, " (expires %s)", m.ExpiresAt.Format("2006-01-02"))
+		}
+		fmt.Fprintln(&sb)
+	}
+
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
@@ -0,0 +1,38 @@
+package gitlab
+
+import "testing"
+
+func TestParseAccessLevel(t *testing.T) {
+	for name, want := range map[string]int{"guest": 10, "Reporter": 20, "developer": 30, "MAINTAINER": 40, "owner": 50} {
+		got, err := ParseAccessLevel(name)
+		if err != nil || got != want {
+			t.Errorf("ParseAccessLevel(%q) = %d, %v; want %d", name, got, err, want)
+		}
+		if AccessLevelName(want) == "" {
+			t.Errorf("AccessLevelName(%d) is empty", want)
+		}
+	}
+	if _, err := ParseAccessLevel("master"); err == nil {
+		t.Error("expected an error for an unknown level")
+	}
+	if got := AccessLevelName(40); got != "Maintainer" {
+		t.Errorf("AccessLevelName(40) = %q, want Maintainer", got)
+	}
+}
+
+func TestSortProjectMembers(t *testing.T) {
+	members := []ProjectMember{
+		{Username: "zed", AccessLevel: 30},
+		{Username: "bob", AccessLevel: 50},
+		{Username: "amy", AccessLevel: 30},
+		{Username: "carl", AccessLevel: 40},
+	}
+	SortProjectMembers(members)
+
+	want := []string{"bob", "carl", "amy", "zed"}
+	for i, u := range want {
+		if members[i].Username != u {
+			t.Fatalf("position %d: got %s, want order %v", i, members[i].Username, want)
+		}
+	}
+}
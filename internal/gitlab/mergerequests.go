@@ -13,14 +13,29 @@ type ListMergeRequestsOptions struct {
 	State         string // opened, closed, merged, all
 	Scope         string // created_by_me, assigned_to_me, all
 	Limit         int
-	OrderBy       string // created_at, updated_at
-	Sort          string // asc, desc
-	ProjectID     string // optional - filter to specific project
-	IncludeWIP    bool   // include WIP/draft MRs (excluded by default)
-	ConflictsOnly bool   // only show MRs with conflicts
+	OrderBy       string   // created_at, updated_at
+	Sort          string   // asc, desc
+	ProjectID     string   // optional - filter to specific project
+	IncludeWIP    bool     // include WIP/draft MRs (excluded by default)
+	ConflictsOnly bool     // only show MRs with conflicts
+	Labels        []string // only MRs carrying all of these labels
+	Author        string   // author username
+	TargetBranch  string   // target branch name
 }
 
 func (c *Client) GetMergeRequests(projectID int, since time.Time) ([]MergeRequest, error) {
+	return c.listMergeRequestsSince(projectID, since, "")
+}
+
+// GetAuthorMergeRequests returns the merge requests authored by username that
+// were updated since a time
+func (c *Client) GetAuthorMergeRequests(projectID int, since time.Time, username string) ([]MergeRequest, error) {
+	return c.listMergeRequestsSince(projectID, since, username)
+}
+
+// listMergeRequestsSince pages through the MRs updated since a time, optionally
+// limited to one author
+func (c *Client) listMergeRequestsSince(projectID int, since time.Time, author string) ([]MergeRequest, error) {
 	var allMRs []MergeRequest
 
 	opts := &gogitlab.ListProjectMergeRequestsOptions{
@@ -31,6 +46,9 @@ func (c *Client) GetMergeRequests(projectID int, since time.Time) ([]MergeReques
 		UpdatedAfter: gogitlab.Ptr(since),
 		Scope:        gogitlab.Ptr("all"),
 	}
+	if author != "" {
+		opts.AuthorUsername = gogitlab.Ptr(author)
+	}
 
 	for {
 		mrs, resp, err := c.gl.MergeRequests.ListProjectMergeRequests(projectID, opts)
@@ -40,10 +58,11 @@ func (c *Client) GetMergeRequests(projectID int, since time.Time) ([]MergeReques
 
 		for _, m := range mrs {
 			mr := MergeRequest{
-				IID:    m.IID,
-				Title:  m.Title,
-				State:  m.State,
-				WebURL: m.WebURL,
+				IID:      m.IID,
+				Title:    m.Title,
+				State:    m.State,
+				WebURL:   m.WebURL,
+				MergedAt: m.MergedAt,
 			}
 			if m.Author != nil {
 				mr.Author = m.Author.Username
@@ -87,21 +106,7 @@ func (c *Client) ListMergeRequests(opts ListMergeRequestsOptions) ([]MergeReques
 		opts.Sort = "desc"
 	}
 
-	listOpts := &gogitlab.ListMergeRequestsOptions{
-		ListOptions: gogitlab.ListOptions{
-			PerPage: min(opts.Limit, 100),
-			Page:    1,
-		},
-		State:   gogitlab.Ptr(opts.State),
-		Scope:   gogitlab.Ptr(opts.Scope),
-		OrderBy: gogitlab.Ptr(opts.OrderBy),
-		Sort:    gogitlab.Ptr(opts.Sort),
-	}
-
-	// Exclude WIP/drafts by default
-	if !opts.IncludeWIP {
-		listOpts.WIP = gogitlab.Ptr("no")
-	}
+	listOpts := buildListMergeRequestsOptions(opts)
 
 	for {
 		mrs, resp, err := c.gl.MergeRequests.ListMergeRequests(listOpts)
@@ -158,6 +163,39 @@ func (c *Client) ListMergeRequests(opts ListMergeRequestsOptions) ([]MergeReques
 	return allMRs, nil
 }
 
+// buildListMergeRequestsOptions maps opts (with defaults applied) to the API
+// query. Labels are ANDed by GitLab. ConflictsOnly has no API filter and is
+// applied to the results instead.
+func buildListMergeRequestsOptions(opts ListMergeRequestsOptions) *gogitlab.ListMergeRequestsOptions {
+	listOpts := &gogitlab.ListMergeRequestsOptions{
+		ListOptions: gogitlab.ListOptions{
+			PerPage: min(opts.Limit, 100),
+			Page:    1,
+		},
+		State:   gogitlab.Ptr(opts.State),
+		Scope:   gogitlab.Ptr(opts.Scope),
+		OrderBy: gogitlab.Ptr(opts.OrderBy),
+		Sort:    gogitlab.Ptr(opts.Sort),
+	}
+
+	// Exclude WIP/drafts by default
+	if !opts.IncludeWIP {
+		listOpts.WIP = gogitlab.Ptr("no")
+	}
+	if len(opts.Labels) > 0 {
+		labels := gogitlab.LabelOptions(opts.Labels)
+		listOpts.Labels = &labels
+	}
+	if opts.Author != "" {
+		listOpts.AuthorUsername = gogitlab.Ptr(opts.Author)
+	}
+	if opts.TargetBranch != "" {
+		listOpts.TargetBranch = gogitlab.Ptr(opts.TargetBranch)
+	}
+
+	return listOpts
+}
+
 // GetMergeRequest fetches a single merge request with full details
 func (c *Client) GetMergeRequest(projectID interface{}, mrIID int) (*MergeRequestDetail, error) {
 	pid, err := c.resolveProjectID(projectID)
@@ -222,15 +260,11 @@ func (c *Client) GetMergeRequest(projectID interface{}, mrIID int) (*MergeReques
 	}
 
 	// Approval state
-	if approvals, _, err := c.gl.MergeRequestApprovals.GetConfiguration(pid, mrIID); err == nil && approvals != nil {
+	if approvals, err := c.GetMergeRequestApprovals(pid, mrIID); err == nil {
 		mr.Approved = approvals.Approved
 		mr.ApprovalsRequired = approvals.ApprovalsRequired
 		mr.ApprovalsLeft = approvals.ApprovalsLeft
-		for _, approvedBy := range approvals.ApprovedBy {
-			if approvedBy != nil && approvedBy.User != nil {
-				mr.ApprovedBy = append(mr.ApprovedBy, approvedBy.User.Username)
-			}
-		}
+		mr.ApprovedBy = approvals.ApprovedBy
 	}
 
 	// Changes stats - ChangesCount is a string in the API
@@ -303,6 +337,7 @@ func (c *Client) GetMergeRequestChanges(projectID any, mrIID int, includeDiff bo
 				IsDeleted: diff.DeletedFile,
 				IsRenamed: diff.RenamedFile,
 			}
+			f.Additions, f.Deletions = ParseUnifiedDiff(diff.Diff).Stats()
 			if includeDiff {
 				f.Diff = diff.Diff
 			}
@@ -629,6 +664,87 @@ func (c *Client) ApproveMergeRequest(projectID any, mrIID int) error {
 	return err
 }
 
+// UnapproveMergeRequest revokes the current user's approval of a merge request
+func (c *Client) UnapproveMergeRequest(projectID any, mrIID int) error {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.gl.MergeRequestApprovals.UnapproveMergeRequest(pid, mrIID)
+	return err
+}
+
+// MRApprovals is the overall approval state of a merge request
+type MRApprovals struct {
+	Approved          bool     `json:"approved"`
+	ApprovalsRequired int      `json:"approvals_required"`
+	ApprovalsLeft     int      `json:"approvals_left"`
+	ApprovedBy        []string `json:"approved_by,omitempty"`
+}
+
+// GetMergeRequestApprovals returns whether a merge request is approved, how
+// many approvals it needs and who has approved it
+func (c *Client) GetMergeRequestApprovals(projectID any, mrIID int) (*MRApprovals, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	approvals, _, err := c.gl.MergeRequestApprovals.GetConfiguration(pid, mrIID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &MRApprovals{
+		Approved:          approvals.Approved,
+		ApprovalsRequired: approvals.ApprovalsRequired,
+		ApprovalsLeft:     approvals.ApprovalsLeft,
+	}
+	for _, approvedBy := range approvals.ApprovedBy {
+		if approvedBy != nil && approvedBy.User != nil {
+			result.ApprovedBy = append(result.ApprovedBy, approvedBy.User.Username)
+		}
+	}
+	return result, nil
+}
+
+// GetMergeRequestApprovalRules returns the approval rules of a merge request
+// together with who has approved under each rule
+func (c *Client) GetMergeRequestApprovalRules(projectID any, mrIID int) ([]MRApprovalRule, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	state, _, err := c.gl.MergeRequestApprovals.GetApprovalState(pid, mrIID)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []MRApprovalRule
+	for _, r := range state.Rules {
+		rule := MRApprovalRule{
+			Name:              r.Name,
+			RuleType:          r.RuleType,
+			Section:           r.Section,
+			ApprovalsRequired: r.ApprovalsRequired,
+			Approved:          r.Approved,
+		}
+		for _, u := range r.EligibleApprovers {
+			rule.EligibleApprovers = append(rule.EligibleApprovers, u.Username)
+		}
+		for _, g := range r.Groups {
+			rule.Groups = append(rule.Groups, g.FullPath)
+		}
+		for _, u := range r.ApprovedBy {
+			rule.ApprovedBy = append(rule.ApprovedBy, u.Username)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
 // MergeMergeRequestOptions contains options for merging a merge request
 type MergeMergeRequestOptions struct {
 	Squash                    bool
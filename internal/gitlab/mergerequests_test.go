@@ -0,0 +1,98 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/codewandler/dex/internal/render"
+)
+
+func TestBuildListMergeRequestsOptions(t *testing.T) {
+	got := buildListMergeRequestsOptions(ListMergeRequestsOptions{
+		State:         "opened",
+		Scope:         "all",
+		Limit:         250,
+		OrderBy:       "updated_at",
+		Sort:          "desc",
+		Labels:        []string{"bug", "backend"},
+		Author:        "john.doe",
+		TargetBranch:  "release-1.2",
+		ConflictsOnly: true,
+	})
+
+	if got.Labels == nil || !slices.Equal([]string(*got.Labels), []string{"bug", "backend"}) {
+		t.Errorf("Labels = %v, want [bug backend]", got.Labels)
+	}
+	if got.AuthorUsername == nil || *got.AuthorUsername != "john.doe" {
+		t.Errorf("AuthorUsername = %v, want john.doe", got.AuthorUsername)
+	}
+	if got.TargetBranch == nil || *got.TargetBranch != "release-1.2" {
+		t.Errorf("TargetBranch = %v, want release-1.2", got.TargetBranch)
+	}
+	if got.WIP == nil || *got.WIP != "no" {
+		t.Errorf("WIP = %v, want no (drafts excluded by default)", got.WIP)
+	}
+	if got.PerPage != 100 {
+		t.Errorf("PerPage = %d, want 100", got.PerPage)
+	}
+
+	// Unset filters are not sent
+	got = buildListMergeRequestsOptions(ListMergeRequestsOptions{State: "opened", Scope: "all", Limit: 20, IncludeWIP: true})
+	if got.Labels != nil || got.AuthorUsername != nil || got.TargetBranch != nil || got.WIP != nil {
+		t.Errorf("unset filters sent: labels=%v author=%v target=%v wip=%v", got.Labels, got.AuthorUsername, got.TargetBranch, got.WIP)
+	}
+	if got.PerPage != 20 {
+		t.Errorf("PerPage = %d, want 20", got.PerPage)
+	}
+}
+
+func TestMergeRequestApprovals(t *testing.T) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v4/projects/42/merge_requests/7/unapprove":
+			w.WriteHeader(http.StatusCreated)
+		case "GET /api/v4/projects/42/merge_requests/7/approvals":
+			fmt.Fprint(w, `{"approved":false,"approvals_required":2,"approvals_left":1,"approved_by":[{"user":{"username":"alice"}}]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client, err := NewClient(srv.URL, "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := client.UnapproveMergeRequest(42, 7); err != nil {
+		t.Fatalf("UnapproveMergeRequest: %v", err)
+	}
+	if len(requests) != 1 || requests[0] != "POST /api/v4/projects/42/merge_requests/7/unapprove" {
+		t.Errorf("requests = %v, want POST .../merge_requests/7/unapprove", requests)
+	}
+
+	approvals, err := client.GetMergeRequestApprovals(42, 7)
+	if err != nil {
+		t.Fatalf("GetMergeRequestApprovals: %v", err)
+	}
+	if approvals.Approved || approvals.ApprovalsRequired != 2 || approvals.ApprovalsLeft != 1 || !slices.Equal(approvals.ApprovedBy, []string{"alice"}) {
+		t.Errorf("approvals = %+v", approvals)
+	}
+
+	out := (&MRDetailResult{MergeRequestDetail{
+		Title:             "Add feature",
+		State:             "opened",
+		ApprovalsRequired: approvals.ApprovalsRequired,
+		ApprovalsLeft:     approvals.ApprovalsLeft,
+		ApprovedBy:        approvals.ApprovedBy,
+	}}).RenderText(render.ModeCompact)
+	if !strings.Contains(out, "no (1/2 approvals left), approved by alice") {
+		t.Errorf("approval state not rendered:\n%s", out)
+	}
+}
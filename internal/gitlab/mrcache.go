@@ -0,0 +1,114 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// MRCache stores fully fetched merge requests (commits, files with diffs and
+// discussions) on disk so repeated views of the same MR don't re-fetch them.
+// An entry stays valid until the live MR's UpdatedAt moves past the cached one.
+type MRCache struct {
+	Dir string
+}
+
+// DefaultMRCache returns the cache at ~/.dex/gitlab/mr-cache
+func DefaultMRCache() (*MRCache, error) {
+	dir, err := indexDirPath()
+	if err != nil {
+		return nil, err
+	}
+	return &MRCache{Dir: filepath.Join(dir, "mr-cache")}, nil
+}
+
+// path returns <dir>/<project>/<iid>.json. Project path segments are kept as
+// directories; ".." segments are neutralized so a reference can't escape Dir.
+func (c *MRCache) path(projectID string, mrIID int) string {
+	var segments []string
+	for _, s := range strings.Split(projectID, "/") {
+		switch s {
+		case "", ".":
+			continue
+		case "..":
+			s = "_"
+		}
+		segments = append(segments, s)
+	}
+	return filepath.Join(c.Dir, filepath.Join(segments...), strconv.Itoa(mrIID)+".json")
+}
+
+// Load returns the cached merge request, if any
+func (c *MRCache) Load(projectID string, mrIID int) (*MergeRequestDetail, bool) {
+	data, err := os.ReadFile(c.path(projectID, mrIID))
+	if err != nil {
+		return nil, false
+	}
+	var mr MergeRequestDetail
+	if err := json.Unmarshal(data, &mr); err != nil || mr.IID != mrIID {
+		return nil, false
+	}
+	return &mr, true
+}
+
+// Save stores mr, readable only by the user
+func (c *MRCache) Save(projectID string, mr *MergeRequestDetail) error {
+	path := c.path(projectID, mr.IID)
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	data, err := json.Marshal(mr)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0600)
+}
+
+// Delete drops the cached merge request
+func (c *MRCache) Delete(projectID string, mrIID int) error {
+	if err := os.Remove(c.path(projectID, mrIID)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Resolve returns a merge request with its commits, files and discussions.
+// head fetches just the MR itself; if its UpdatedAt is not newer than the
+// cached entry's, the cached details are attached to the fresh head.
+// Otherwise fill fetches the details and the result is saved; if fill fails,
+// the partially filled MR is returned but not saved, so a transient API error
+// isn't cached until the next update. If head fails, a cached entry is
+// returned as is, so reviews keep working offline; callers that need to tell
+// this apart from a fresh cache hit should record head's error.
+// fromCache reports whether cached details were used. A nil cache always
+// calls head and fill.
+func (c *MRCache) Resolve(projectID string, mrIID int, head func() (*MergeRequestDetail, error), fill func(*MergeRequestDetail) error) (mr *MergeRequestDetail, fromCache bool, err error) {
+	var cached *MergeRequestDetail
+	if c != nil {
+		cached, _ = c.Load(projectID, mrIID)
+	}
+
+	mr, err = head()
+	if err != nil {
+		if cached != nil {
+			return cached, true, nil
+		}
+		return nil, false, err
+	}
+
+	if cached != nil && !mr.UpdatedAt.After(cached.UpdatedAt) {
+		mr.Commits = cached.Commits
+		mr.Files = cached.Files
+		mr.Notes = cached.Notes
+		mr.Discussions = cached.Discussions
+		return mr, true, nil
+	}
+
+	if err := fill(mr); err == nil && c != nil {
+		c.Save(projectID, mr)
+	}
+	return mr, false, nil
+}
@@ -0,0 +1,112 @@
+package gitlab
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMRCacheResolve(t *testing.T) {
+	cache := &MRCache{Dir: t.TempDir()}
+	t0 := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	updated := t0
+	var headErr error
+	head := func() (*MergeRequestDetail, error) {
+		if headErr != nil {
+			return nil, headErr
+		}
+		return &MergeRequestDetail{IID: 7, Title: "Add feature", UpdatedAt: updated}, nil
+	}
+	fills := 0
+	var fillErr error
+	fill := func(mr *MergeRequestDetail) error {
+		fills++
+		mr.Commits = []MRCommit{{ShortID: "abc123", Title: "commit " + strings.Repeat("x", fills)}}
+		if fillErr != nil {
+			return fillErr
+		}
+		mr.Files = []MRFile{{NewPath: "main.go", Diff: "@@ -1 +1 @@\n-a\n+b"}}
+		return nil
+	}
+
+	// Empty cache: details fetched and saved
+	mr, cached, err := cache.Resolve("group/project", 7, head, fill)
+	if err != nil || cached || fills != 1 || len(mr.Commits) != 1 {
+		t.Fatalf("first resolve: mr=%+v cached=%v err=%v fills=%d", mr, cached, err, fills)
+	}
+
+	// Unchanged UpdatedAt: cached details attached to the fresh head
+	mr, cached, err = cache.Resolve("group/project", 7, head, fill)
+	if err != nil || !cached || fills != 1 {
+		t.Fatalf("cached resolve: cached=%v err=%v fills=%d", cached, err, fills)
+	}
+	if len(mr.Files) != 1 || mr.Files[0].Diff == "" || mr.Commits[0].Title != "commit x" {
+		t.Errorf("cached details not restored: %+v", mr)
+	}
+
+	// Newer UpdatedAt: invalidated and re-fetched
+	updated = t0.Add(time.Minute)
+	mr, cached, err = cache.Resolve("group/project", 7, head, fill)
+	if err != nil || cached || fills != 2 || mr.Commits[0].Title != "commit xx" {
+		t.Fatalf("updated MR: mr=%+v cached=%v err=%v fills=%d", mr, cached, err, fills)
+	}
+	if saved, ok := cache.Load("group/project", 7); !ok || !saved.UpdatedAt.Equal(updated) {
+		t.Errorf("cache after refetch = %+v, want updated_at %v", saved, updated)
+	}
+
+	// Failed fill: partial MR returned, but the cache keeps the previous copy
+	updated = t0.Add(2 * time.Minute)
+	fillErr = errors.New("discussions: 502 Bad Gateway")
+	mr, cached, err = cache.Resolve("group/project", 7, head, fill)
+	if err != nil || cached || fills != 3 || mr.Commits[0].Title != "commit xxx" {
+		t.Fatalf("failed fill: mr=%+v cached=%v err=%v fills=%d", mr, cached, err, fills)
+	}
+	if saved, ok := cache.Load("group/project", 7); !ok || !saved.UpdatedAt.Equal(t0.Add(time.Minute)) {
+		t.Errorf("partial MR was cached: %+v", saved)
+	}
+
+	// Fill recovers: the next run re-fetches and saves
+	fillErr = nil
+	mr, cached, err = cache.Resolve("group/project", 7, head, fill)
+	if err != nil || cached || fills != 4 || len(mr.Files) != 1 {
+		t.Fatalf("recovered fill: mr=%+v cached=%v err=%v fills=%d", mr, cached, err, fills)
+	}
+	if saved, ok := cache.Load("group/project", 7); !ok || !saved.UpdatedAt.Equal(updated) {
+		t.Errorf("cache after recovered fill = %+v, want updated_at %v", saved, updated)
+	}
+
+	// Head unreachable: cached copy served
+	headErr = errors.New("connection refused")
+	mr, cached, err = cache.Resolve("group/project", 7, head, fill)
+	if err != nil || !cached || mr.Title != "Add feature" || fills != 4 {
+		t.Errorf("offline resolve: mr=%+v cached=%v err=%v fills=%d", mr, cached, err, fills)
+	}
+
+	// Head unreachable and nothing cached: error
+	if err := cache.Delete("group/project", 7); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err = cache.Resolve("group/project", 7, head, fill); err == nil {
+		t.Error("expected head error without cache")
+	}
+
+	// Nil cache always fetches
+	headErr = nil
+	var none *MRCache
+	if _, cached, _ = none.Resolve("group/project", 7, head, fill); cached || fills != 5 {
+		t.Errorf("nil cache: cached=%v fills=%d, want fetch", cached, fills)
+	}
+}
+
+func TestMRCachePath(t *testing.T) {
+	cache := &MRCache{Dir: "/cache"}
+	if got, want := cache.path("group/sub/project", 12), filepath.Join("/cache", "group", "sub", "project", "12.json"); got != want {
+		t.Errorf("path = %q, want %q", got, want)
+	}
+	if got := cache.path("../../etc", 1); !strings.HasPrefix(got, filepath.Clean("/cache")+string(filepath.Separator)) {
+		t.Errorf("path %q escapes the cache dir", got)
+	}
+}
@@ -1,6 +1,8 @@
 package gitlab
 
 import (
+	"fmt"
+	"path"
 	"time"
 
 	"github.com/xanzy/go-gitlab"
@@ -90,3 +92,72 @@ func (c *Client) GetActiveProjects(since time.Time) ([]*gitlab.Project, error) {
 
 	return allProjects, nil
 }
+
+// ArchiveProject archives a project by ID or path, making it read-only
+func (c *Client) ArchiveProject(ref string) (*gitlab.Project, error) {
+	pid, err := c.resolveProjectID(ref)
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := c.gl.Projects.ArchiveProject(pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to archive project %s: %w", ref, err)
+	}
+	return project, nil
+}
+
+// UnarchiveProject unarchives a project by ID or path
+func (c *Client) UnarchiveProject(ref string) (*gitlab.Project, error) {
+	pid, err := c.resolveProjectID(ref)
+	if err != nil {
+		return nil, err
+	}
+	project, _, err := c.gl.Projects.UnarchiveProject(pid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unarchive project %s: %w", ref, err)
+	}
+	return project, nil
+}
+
+// FilterProjectsByPath keeps the projects whose PathWithNamespace matches the
+// path.Match pattern, so "*" stays within one path segment ("group/*" does
+// not include "group/sub/api"). An empty pattern keeps all projects.
+func FilterProjectsByPath(projects []*gitlab.Project, pattern string) ([]*gitlab.Project, error) {
+	if pattern == "" {
+		return projects, nil
+	}
+	if _, err := path.Match(pattern, ""); err != nil {
+		return nil, fmt.Errorf("invalid project glob %q: %w", pattern, err)
+	}
+	var matched []*gitlab.Project
+	for _, p := range projects {
+		if ok, _ := path.Match(pattern, p.PathWithNamespace); ok {
+			matched = append(matched, p)
+		}
+	}
+	return matched, nil
+}
+
+// GetProjects fetches the given projects by ID or path (resolved via the index
+// or API), in the order given. Duplicates are returned once.
+func (c *Client) GetProjects(refs []string) ([]*gitlab.Project, error) {
+	var projects []*gitlab.Project
+	seen := make(map[int]bool)
+	for _, ref := range refs {
+		pid, err := c.resolveProjectID(ref)
+		if err != nil {
+			return nil, err
+		}
+		if seen[pid] {
+			continue
+		}
+		seen[pid] = true
+
+		project, _, err := c.gl.Projects.GetProject(pid, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get project %s: %w", ref, err)
+		}
+		projects = append(projects, project)
+	}
+	return projects, nil
+}
@@ -0,0 +1,46 @@
+package gitlab
+
+import (
+	"reflect"
+	"testing"
+
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+func TestFilterProjectsByPath(t *testing.T) {
+	var projects []*gogitlab.Project
+	for _, p := range []string{"platform/api", "platform/web", "platform/sub/worker", "tools/cli", "Platform/Docs"} {
+		projects = append(projects, &gogitlab.Project{PathWithNamespace: p})
+	}
+
+	tests := []struct {
+		pattern string
+		want    []string
+	}{
+		{"", []string{"platform/api", "platform/web", "platform/sub/worker", "tools/cli", "Platform/Docs"}},
+		{"platform/*", []string{"platform/api", "platform/web"}},
+		{"platform/*/*", []string{"platform/sub/worker"}},
+		{"*/cli", []string{"tools/cli"}},
+		{"platform/[aw]*", []string{"platform/api", "platform/web"}},
+		{"tools/cli", []string{"tools/cli"}},
+		{"nothing/*", nil},
+	}
+	for _, tt := range tests {
+		got, err := FilterProjectsByPath(projects, tt.pattern)
+		if err != nil {
+			t.Errorf("FilterProjectsByPath(%q): %v", tt.pattern, err)
+			continue
+		}
+		var paths []string
+		for _, p := range got {
+			paths = append(paths, p.PathWithNamespace)
+		}
+		if !reflect.DeepEqual(paths, tt.want) {
+			t.Errorf("FilterProjectsByPath(%q) = %v, want %v", tt.pattern, paths, tt.want)
+		}
+	}
+
+	if _, err := FilterProjectsByPath(projects, "platform/[a"); err == nil {
+		t.Error("expected error for malformed pattern")
+	}
+}
@@ -0,0 +1,215 @@
+package gitlab
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/codewandler/dex/internal/render"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// MRCheck is the outcome of one pre-merge check
+type MRCheck struct {
+	Name   string `json:"name"` // state, draft, conflicts, pipeline, discussions, approvals
+	OK     bool   `json:"ok"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// MRReadinessResult reports whether a merge request is ready to merge
+type MRReadinessResult struct {
+	Reference string    `json:"reference"`
+	Title     string    `json:"title"`
+	WebURL    string    `json:"web_url"`
+	Ready     bool      `json:"ready"`
+	Checks    []MRCheck `json:"checks"`
+}
+
+// mrGateState is everything the readiness checks look at
+type mrGateState struct {
+	State             string
+	Draft             bool
+	HasConflicts      bool
+	PipelineStatus    string // head pipeline status, "" when there is none
+	Unresolved        int    // unresolved resolvable threads
+	ApprovalsRequired int
+	ApprovalsLeft     int
+	ApprovalsErr      error
+}
+
+// CheckMergeReadiness runs the pre-merge checks on a merge request: it must be
+// open, not a draft, free of conflicts, with a successful head pipeline, no
+// unresolved threads and no approvals left.
+func (c *Client) CheckMergeReadiness(projectID any, mrIID int) (*MRReadinessResult, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	m, _, err := c.gl.MergeRequests.GetMergeRequest(pid, mrIID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	st := mrGateState{
+		State:        m.State,
+		Draft:        m.Draft || m.WorkInProgress,
+		HasConflicts: m.HasConflicts,
+	}
+	if m.HeadPipeline != nil {
+		st.PipelineStatus = m.HeadPipeline.Status
+	}
+
+	discussions, err := c.GetMergeRequestDiscussions(pid, mrIID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get discussions: %w", err)
+	}
+	st.Unresolved = countUnresolvedThreads(discussions)
+
+	if approvals, _, err := c.gl.MergeRequestApprovals.GetConfiguration(pid, mrIID); err != nil {
+		st.ApprovalsErr = err
+	} else if approvals != nil {
+		st.ApprovalsRequired = approvals.ApprovalsRequired
+		st.ApprovalsLeft = approvals.ApprovalsLeft
+	}
+
+	ref := fmt.Sprintf("%v!%d", projectID, mrIID)
+	if m.References != nil && m.References.Full != "" {
+		ref = m.References.Full
+	}
+
+	checks := evaluateMRGate(st)
+	r := &MRReadinessResult{Reference: ref, Title: m.Title, WebURL: m.WebURL, Ready: true, Checks: checks}
+	for _, ch := range checks {
+		if !ch.OK {
+			r.Ready = false
+		}
+	}
+	return r, nil
+}
+
+// countUnresolvedThreads counts threads with at least one unresolved resolvable note
+func countUnresolvedThreads(discussions []MRDiscussion) int {
+	n := 0
+	for _, d := range discussions {
+		for _, note := range d.Notes {
+			if note.Resolvable && !note.Resolved {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// evaluateMRGate turns the gathered state into the list of checks
+func evaluateMRGate(st mrGateState) []MRCheck {
+	var checks []MRCheck
+
+	state := MRCheck{Name: "state", OK: st.State == "opened"}
+	if !state.OK {
+		state.Detail = "merge request is " + st.State
+	}
+	checks = append(checks, state)
+
+	draft := MRCheck{Name: "draft", OK: !st.Draft}
+	if st.Draft {
+		draft.Detail = "marked as draft"
+	}
+	checks = append(checks, draft)
+
+	conflicts := MRCheck{Name: "conflicts", OK: !st.HasConflicts}
+	if st.HasConflicts {
+		conflicts.Detail = "conflicts with the target branch"
+	}
+	checks = append(checks, conflicts)
+
+	pipeline := MRCheck{Name: "pipeline"}
+	switch st.PipelineStatus {
+	case "success":
+		pipeline.OK = true
+	case "":
+		pipeline.OK = true
+		pipeline.Detail = "no pipeline"
+	case "skipped", "manual":
+		pipeline.OK = true
+		pipeline.Detail = "pipeline " + st.PipelineStatus
+	case "failed", "canceled":
+		pipeline.Detail = "pipeline " + st.PipelineStatus
+	default:
+		pipeline.Detail = "pipeline " + strings.ReplaceAll(st.PipelineStatus, "_", " ") + ", not finished"
+	}
+	checks = append(checks, pipeline)
+
+	discussions := MRCheck{Name: "discussions", OK: st.Unresolved == 0}
+	if st.Unresolved > 0 {
+		discussions.Detail = fmt.Sprintf("%d unresolved thread(s)", st.Unresolved)
+	}
+	checks = append(checks, discussions)
+
+	approvals := MRCheck{Name: "approvals", OK: st.ApprovalsErr == nil && st.ApprovalsLeft <= 0}
+	switch {
+	case st.ApprovalsErr != nil:
+		approvals.Detail = "could not fetch approvals: " + st.ApprovalsErr.Error()
+	case st.ApprovalsLeft > 0:
+		approvals.Detail = fmt.Sprintf("%d of %d approval(s) left", st.ApprovalsLeft, st.ApprovalsRequired)
+	}
+	checks = append(checks, approvals)
+
+	return checks
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+// RenderText implements render.Renderable on MRReadinessResult.
+// ModeCompact: a single READY / NOT READY line with the failing checks.
+// ModeNormal: one line per check.
+func (r *MRReadinessResult) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		if r.Ready {
+			fmt.Fprintf(&sb, "%s %s\n", glMRMergedColor.Sprint("READY"), r.Reference)
+			return sb.String()
+		}
+		var reasons []string
+		for _, ch := range r.Checks {
+			if !ch.OK {
+				reasons = append(reasons, ch.Detail)
+			}
+		}
+		fmt.Fprintf(&sb, "%s %s: %s\n", glMRClosedColor.Sprint("NOT READY"), r.Reference, strings.Join(reasons, "; "))
+		return sb.String()
+	}
+
+	fmt.Fprintln(&sb)
+	glProjectColor.Fprintf(&sb, "  %s", r.Reference)
+	fmt.Fprintf(&sb, "  %s\n\n", r.Title)
+
+	for _, ch := range r.Checks {
+		if ch.OK {
+			fmt.Fprintf(&sb, "  %s %-12s", glMRMergedColor.Sprint("✓"), ch.Name)
+			if ch.Detail != "" {
+				glDimColor.Fprintf(&sb, " %s", ch.Detail)
+			}
+		} else {
+			fmt.Fprintf(&sb, "  %s %-12s %s", glMRClosedColor.Sprint("✗"), ch.Name, ch.Detail)
+		}
+		fmt.Fprintln(&sb)
+	}
+
+	fmt.Fprintln(&sb)
+	if r.Ready {
+		glMRMergedColor.Fprint(&sb, "  Ready to merge\n")
+	} else {
+		failed := 0
+		for _, ch := range r.Checks {
+			if !ch.OK {
+				failed++
+			}
+		}
+		glMRClosedColor.Fprintf(&sb, "  Not ready: %d check(s) failed\n", failed)
+	}
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
@@ -0,0 +1,59 @@
+package gitlab
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestEvaluateMRGate(t *testing.T) {
+	failing := func(st mrGateState) []string {
+		var names []string
+		for _, ch := range evaluateMRGate(st) {
+			if !ch.OK {
+				names = append(names, ch.Name)
+			}
+		}
+		return names
+	}
+
+	if got := failing(mrGateState{State: "opened", PipelineStatus: "success", ApprovalsRequired: 1}); len(got) != 0 {
+		t.Errorf("ready MR: unexpected failures %v", got)
+	}
+	if got := failing(mrGateState{State: "opened"}); len(got) != 0 {
+		t.Errorf("MR without pipeline: unexpected failures %v", got)
+	}
+
+	got := failing(mrGateState{
+		State:          "opened",
+		Draft:          true,
+		HasConflicts:   true,
+		PipelineStatus: "running",
+		Unresolved:     2,
+		ApprovalsLeft:  1,
+	})
+	want := []string{"draft", "conflicts", "pipeline", "discussions", "approvals"}
+	if len(got) != len(want) {
+		t.Fatalf("got failures %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("failure %d: got %s, want %s", i, got[i], want[i])
+		}
+	}
+
+	if got := failing(mrGateState{State: "merged", PipelineStatus: "success", ApprovalsErr: errors.New("403")}); len(got) != 2 {
+		t.Errorf("merged MR with approvals error: got failures %v, want state and approvals", got)
+	}
+}
+
+func TestCountUnresolvedThreads(t *testing.T) {
+	discussions := []MRDiscussion{
+		{Notes: []MRNote{{Resolvable: true, Resolved: true}}},
+		{Notes: []MRNote{{Resolvable: true}, {Resolvable: true}}},
+		{Notes: []MRNote{{Resolvable: false}}},
+		{Notes: []MRNote{{Resolvable: true, Resolved: true}, {Resolvable: true}}},
+	}
+	if n := countUnresolvedThreads(discussions); n != 2 {
+		t.Errorf("countUnresolvedThreads = %d, want 2", n)
+	}
+}
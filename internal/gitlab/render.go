@@ -13,17 +13,19 @@ import (
 // ── Color palette (mirrors internal/output/terminal.go) ──────────────────────
 
 var (
-	glHeaderColor   = color.New(color.FgCyan, color.Bold)
-	glProjectColor  = color.New(color.FgYellow, color.Bold)
-	glSectionColor  = color.New(color.FgGreen)
-	glCommitColor   = color.New(color.FgWhite)
-	glMROpenColor   = color.New(color.FgBlue)
-	glMRMergedColor = color.New(color.FgGreen)
-	glMRClosedColor = color.New(color.FgRed)
-	glDimColor      = color.New(color.FgHiBlack)
-	glLabelColor    = color.New(color.FgCyan)
-	glValueColor    = color.New(color.FgWhite)
-	glLangColor     = color.New(color.FgYellow)
+	glHeaderColor    = color.New(color.FgCyan, color.Bold)
+	glProjectColor   = color.New(color.FgYellow, color.Bold)
+	glSectionColor   = color.New(color.FgGreen)
+	glCommitColor    = color.New(color.FgWhite)
+	glMROpenColor    = color.New(color.FgBlue)
+	glMRMergedColor  = color.New(color.FgGreen)
+	glMRClosedColor  = color.New(color.FgRed)
+	glDimColor       = color.New(color.FgHiBlack)
+	glLabelColor     = color.New(color.FgCyan)
+	glValueColor     = color.New(color.FgWhite)
+	glLangColor      = color.New(color.FgYellow)
+	glTagColor       = color.New(color.FgMagenta)
+	glHighlightColor = color.New(color.BgYellow, color.FgBlack)
 )
 
 // ── Helpers ───────────────────────────────────────────────────────────────────
@@ -252,7 +254,11 @@ func (r *MRDetailResult) RenderText(mode render.Mode) string {
 		}
 		glPrintField(&sb, "Approved", approvalText)
 	} else if mr.ApprovalsRequired > 0 {
-		glPrintField(&sb, "Approved", fmt.Sprintf("no (%d/%d approvals left)", mr.ApprovalsLeft, mr.ApprovalsRequired))
+		approvalText := fmt.Sprintf("no (%d/%d approvals left)", mr.ApprovalsLeft, mr.ApprovalsRequired)
+		if len(mr.ApprovedBy) > 0 {
+			approvalText += ", approved by " + strings.Join(mr.ApprovedBy, ", ")
+		}
+		glPrintField(&sb, "Approved", approvalText)
 	}
 	if len(mr.Labels) > 0 {
 		glPrintField(&sb, "Labels", strings.Join(mr.Labels, ", "))
@@ -342,6 +348,82 @@ func (r *MRDetailResult) RenderText(mode render.Mode) string {
 	return sb.String()
 }
 
+// ── MRDiffStatResult ──────────────────────────────────────────────────────────
+
+// MRDiffStatResult is the per-file added/deleted line summary of an MR diff
+type MRDiffStatResult struct {
+	Reference string   `json:"reference"`
+	Files     []MRFile `json:"files"`
+	Additions int      `json:"additions"`
+	Deletions int      `json:"deletions"`
+}
+
+// NewMRDiffStatResult totals the line counts of files, dropping diff contents
+func NewMRDiffStatResult(reference string, files []MRFile) *MRDiffStatResult {
+	r := &MRDiffStatResult{Reference: reference, Files: make([]MRFile, 0, len(files))}
+	for _, f := range files {
+		f.Diff = ""
+		r.Files = append(r.Files, f)
+		r.Additions += f.Additions
+		r.Deletions += f.Deletions
+	}
+	return r
+}
+
+const mrDiffStatBarWidth = 40
+
+// RenderText implements render.Renderable on MRDiffStatResult.
+// ModeNormal draws git-style +/- bars, ModeCompact prints counts only.
+func (r *MRDiffStatResult) RenderText(mode render.Mode) string {
+	if len(r.Files) == 0 {
+		return glDimColor.Sprint("No changed files.\n")
+	}
+
+	var sb strings.Builder
+
+	pathWidth, maxChanges := 0, 0
+	for _, f := range r.Files {
+		pathWidth = max(pathWidth, len(f.NewPath))
+		maxChanges = max(maxChanges, f.Additions+f.Deletions)
+	}
+	pathWidth = min(pathWidth, 60)
+
+	for _, f := range r.Files {
+		if mode == render.ModeCompact {
+			fmt.Fprintf(&sb, "%s %s %s\n",
+				glMRMergedColor.Sprintf("+%-5d", f.Additions),
+				glMRClosedColor.Sprintf("-%-5d", f.Deletions),
+				f.NewPath)
+			continue
+		}
+
+		// Scale bars down so the largest file fits the bar width
+		plus, minus := f.Additions, f.Deletions
+		if maxChanges > mrDiffStatBarWidth {
+			plus = f.Additions * mrDiffStatBarWidth / maxChanges
+			minus = f.Deletions * mrDiffStatBarWidth / maxChanges
+			if f.Additions > 0 && plus == 0 {
+				plus = 1
+			}
+			if f.Deletions > 0 && minus == 0 {
+				minus = 1
+			}
+		}
+		fmt.Fprintf(&sb, " %-*s | %5d %s%s\n",
+			pathWidth, glTruncate(f.NewPath, pathWidth),
+			f.Additions+f.Deletions,
+			glMRMergedColor.Sprint(strings.Repeat("+", plus)),
+			glMRClosedColor.Sprint(strings.Repeat("-", minus)))
+	}
+
+	noun := "files"
+	if len(r.Files) == 1 {
+		noun = "file"
+	}
+	fmt.Fprintf(&sb, " %d %s changed, %d insertions(+), %d deletions(-)\n", len(r.Files), noun, r.Additions, r.Deletions)
+	return sb.String()
+}
+
 func renderMRFile(sb *strings.Builder, f MRFile) {
 	prefix := " "
 	if f.IsNew {
@@ -418,6 +500,118 @@ func renderMRDiscussions(sb *strings.Builder, discussions []MRDiscussion) {
 	}
 }
 
+// ── MRApprovalsResult ────────────────────────────────────────────────────────
+
+// MRApprovalsResult holds the approval rules of a merge request for display.
+type MRApprovalsResult struct {
+	Reference     string           `json:"reference"`
+	Approved      bool             `json:"approved"`
+	ApprovalsLeft int              `json:"approvals_left"`
+	ApprovedBy    []string         `json:"approved_by,omitempty"`
+	Rules         []MRApprovalRule `json:"rules"`
+}
+
+// NewMRApprovalsResult derives the overall approval state from the rules:
+// the MR is approved once every rule is satisfied.
+func NewMRApprovalsResult(reference string, rules []MRApprovalRule) *MRApprovalsResult {
+	r := &MRApprovalsResult{Reference: reference, Approved: true, Rules: rules}
+	seen := make(map[string]bool)
+	for _, rule := range rules {
+		if !rule.Approved {
+			r.Approved = false
+			r.ApprovalsLeft += max(rule.ApprovalsRequired-len(rule.ApprovedBy), 1)
+		}
+		for _, u := range rule.ApprovedBy {
+			if !seen[u] {
+				seen[u] = true
+				r.ApprovedBy = append(r.ApprovedBy, u)
+			}
+		}
+	}
+	return r
+}
+
+func (r *MRApprovalsResult) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	overall := glMRClosedColor.Sprintf("✗ %d approval(s) left", r.ApprovalsLeft)
+	if r.Approved {
+		overall = glMRMergedColor.Sprint("✓ Approved")
+	}
+
+	if mode == render.ModeCompact {
+		fmt.Fprintf(&sb, "%s  %s\n", r.Reference, overall)
+		for _, rule := range r.Rules {
+			mark := glMRClosedColor.Sprint("✗")
+			if rule.Approved {
+				mark = glMRMergedColor.Sprint("✓")
+			}
+			fmt.Fprintf(&sb, "  %s %-30s %d/%d\n", mark, glTruncate(rule.Name, 30), len(rule.ApprovedBy), rule.ApprovalsRequired)
+		}
+		return sb.String()
+	}
+
+	line := strings.Repeat("═", 90)
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glHeaderColor.Fprintf(&sb, "  Approval Rules - %s\n", r.Reference)
+	glHeaderColor.Fprintln(&sb, line)
+	fmt.Fprintln(&sb)
+
+	fmt.Fprintf(&sb, "  %s\n", overall)
+	if len(r.ApprovedBy) > 0 {
+		glDimColor.Fprintf(&sb, "  Approved by: %s\n", strings.Join(r.ApprovedBy, ", "))
+	}
+	fmt.Fprintln(&sb)
+
+	if len(r.Rules) == 0 {
+		glDimColor.Fprint(&sb, "  No approval rules configured.\n\n")
+		return sb.String()
+	}
+
+	for _, rule := range r.Rules {
+		mark := glMRClosedColor.Sprint("✗")
+		if rule.Approved {
+			mark = glMRMergedColor.Sprint("✓")
+		}
+		glProjectColor.Fprintf(&sb, "  %s %s", mark, rule.Name)
+		glDimColor.Fprintf(&sb, "  (%d/%d", len(rule.ApprovedBy), rule.ApprovalsRequired)
+		if rule.RuleType != "" && rule.RuleType != "regular" {
+			glDimColor.Fprintf(&sb, ", %s", rule.RuleType)
+		}
+		if rule.Section != "" && rule.Section != "codeowners" {
+			glDimColor.Fprintf(&sb, ", section %s", rule.Section)
+		}
+		glDimColor.Fprintln(&sb, ")")
+
+		approved := make(map[string]bool, len(rule.ApprovedBy))
+		for _, u := range rule.ApprovedBy {
+			approved[u] = true
+		}
+		if len(rule.ApprovedBy) > 0 {
+			glPrintField(&sb, "Approved by", strings.Join(rule.ApprovedBy, ", "))
+		}
+		var pending []string
+		for _, u := range rule.EligibleApprovers {
+			if !approved[u] {
+				pending = append(pending, u)
+			}
+		}
+		switch {
+		case len(pending) > 0:
+			glPrintField(&sb, "Can approve", strings.Join(pending, ", "))
+		case len(rule.EligibleApprovers) == 0 && rule.RuleType == "any_approver":
+			glPrintField(&sb, "Can approve", "any eligible member")
+		}
+		if len(rule.Groups) > 0 {
+			glPrintField(&sb, "Groups", strings.Join(rule.Groups, ", "))
+		}
+		fmt.Fprintln(&sb)
+	}
+
+	return sb.String()
+}
+
 // ── PipelineListResult ────────────────────────────────────────────────────────
 
 // PipelineListResult holds a list of pipelines for display.
@@ -670,6 +864,78 @@ func (r *CommitListResult) RenderText(mode render.Mode) string {
 	return sb.String()
 }
 
+// ── TagListResult ─────────────────────────────────────────────────────────────
+
+// TagListResult holds a project's tags for display.
+type TagListResult struct {
+	Project string `json:"project"`
+	Tags    []Tag  `json:"tags"`
+	Total   int    `json:"total"`
+}
+
+func (r *TagListResult) RenderText(mode render.Mode) string {
+	if len(r.Tags) == 0 {
+		return glDimColor.Sprint("No tags found.\n")
+	}
+
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, t := range r.Tags {
+			glTagColor.Fprintf(&sb, "%-24s ", glTruncate(t.Name, 24))
+			glCommitColor.Fprintf(&sb, "%s ", t.ShortID)
+			glDimColor.Fprintf(&sb, "%s\n", glTimeAgo(t.CreatedAt))
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintln(&sb)
+	glSectionColor.Fprintf(&sb, "  Tags - %s (%d):\n", r.Project, len(r.Tags))
+	fmt.Fprintln(&sb)
+
+	for _, t := range r.Tags {
+		glTagColor.Fprintf(&sb, "  • %s ", t.Name)
+		glCommitColor.Fprintf(&sb, "%s ", t.ShortID)
+		fmt.Fprintf(&sb, "%s ", glTruncate(t.Title, 50))
+		glDimColor.Fprintf(&sb, "(%s)", glTimeAgo(t.CreatedAt))
+		if t.Protected {
+			glDimColor.Fprint(&sb, " [protected]")
+		}
+		fmt.Fprintln(&sb)
+		if t.Message != "" {
+			glDimColor.Fprintf(&sb, "      %s\n", glTruncate(strings.ReplaceAll(strings.TrimSpace(t.Message), "\n", " "), 70))
+		}
+	}
+
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
+
+// TagCreateResult is the outcome of creating a tag.
+type TagCreateResult struct {
+	Project string `json:"project"`
+	Tag     Tag    `json:"tag"`
+}
+
+func (r *TagCreateResult) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+	if mode == render.ModeCompact {
+		fmt.Fprintf(&sb, "%s %s\n", r.Tag.Name, r.Tag.CommitID)
+		return sb.String()
+	}
+	glMRMergedColor.Fprint(&sb, "✓ Created tag ")
+	glTagColor.Fprint(&sb, r.Tag.Name)
+	fmt.Fprintf(&sb, " in %s\n", r.Project)
+	glPrintField(&sb, "Commit", r.Tag.CommitID)
+	if r.Tag.Title != "" {
+		glPrintField(&sb, "Title", r.Tag.Title)
+	}
+	if r.Tag.Message != "" {
+		glPrintField(&sb, "Message", r.Tag.Message)
+	}
+	return sb.String()
+}
+
 // ── CommitDetailResult ────────────────────────────────────────────────────────
 
 // CommitDetailResult holds full commit information for display.
@@ -814,6 +1080,54 @@ func (r *ProjectListResult) RenderText(mode render.Mode) string {
 	return sb.String()
 }
 
+// ── ProjectSearchResult ───────────────────────────────────────────────────────
+
+// ProjectSearchResult holds ranked index matches for a search term.
+type ProjectSearchResult struct {
+	Term    string         `json:"term"`
+	Matches []ProjectMatch `json:"matches"`
+	Total   int            `json:"total"`
+}
+
+func (r *ProjectSearchResult) RenderText(mode render.Mode) string {
+	if len(r.Matches) == 0 {
+		return glDimColor.Sprintf("No projects match %q.\n", r.Term)
+	}
+
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, m := range r.Matches {
+			fmt.Fprintf(&sb, "%-50s  %s\n", glHyperlink(m.Project.WebURL, m.Project.PathWithNS), glDimColor.Sprint(strings.Join(m.Matched, ",")))
+		}
+		return sb.String()
+	}
+
+	line := strings.Repeat("═", 80)
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glHeaderColor.Fprintf(&sb, "  Projects matching %q (%d)\n", r.Term, r.Total)
+	glHeaderColor.Fprintln(&sb, line)
+	fmt.Fprintln(&sb)
+
+	for _, m := range r.Matches {
+		p := m.Project
+		glProjectColor.Fprint(&sb, "  "+glHyperlink(p.WebURL, p.PathWithNS))
+		if p.Archived {
+			glDimColor.Fprint(&sb, " [archived]")
+		}
+		glDimColor.Fprintf(&sb, "  (%s)\n", strings.Join(m.Matched, ", "))
+		if p.Description != "" {
+			fmt.Fprintf(&sb, "    %s\n", glTruncate(strings.ReplaceAll(strings.TrimSpace(p.Description), "\n", " "), 90))
+		}
+		if len(p.Topics) > 0 {
+			glLabelColor.Fprintf(&sb, "    topics: %s\n", strings.Join(p.Topics, ", "))
+		}
+	}
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
+
 // ── ProjectDetailResult ───────────────────────────────────────────────────────
 
 // ProjectDetailResult holds full project information for display.
@@ -840,6 +1154,9 @@ func (r *ProjectDetailResult) RenderText(mode render.Mode) string {
 	}
 	glPrintField(&sb, "Default Branch", p.DefaultBranch)
 	glPrintField(&sb, "Visibility", p.Visibility)
+	if p.Archived {
+		glPrintField(&sb, "Archived", "yes")
+	}
 	if len(p.Topics) > 0 {
 		glPrintField(&sb, "Topics", strings.Join(p.Topics, ", "))
 	}
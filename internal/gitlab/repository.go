@@ -128,6 +128,42 @@ func (c *Client) GetFileBlame(projectID any, path, ref string) (*FileBlameResult
 	return result, nil
 }
 
+// LineCount returns the number of blamed lines
+func (r *FileBlameResult) LineCount() int {
+	n := 0
+	for _, br := range r.Ranges {
+		n += len(br.Lines)
+	}
+	return n
+}
+
+// LineRange trims the blame to lines start..end (1-based, inclusive). An end of
+// 0 means up to the last line. Ranges outside the window are dropped and the
+// ones crossing its edges are cut.
+func (r *FileBlameResult) LineRange(start, end int) {
+	if start < 1 {
+		start = 1
+	}
+	var kept []BlameRange
+	line := 1
+	for _, br := range r.Ranges {
+		first, last := line, line+len(br.Lines)-1
+		line += len(br.Lines)
+		if last < start || (end > 0 && first > end) {
+			continue
+		}
+		lo := max(start, first) - first
+		hi := len(br.Lines)
+		if end > 0 {
+			hi = min(end, last) - first + 1
+		}
+		br.Lines = br.Lines[lo:hi]
+		kept = append(kept, br)
+	}
+	r.Ranges = kept
+	r.StartLine = start
+}
+
 // ListTree lists files and directories in a repository at a given path and ref.
 func (c *Client) ListTree(projectID any, path, ref string, recursive bool) (*TreeResult, error) {
 	pid, err := c.resolveProjectID(projectID)
@@ -74,7 +74,7 @@ func (r *FileBlameResult) RenderText(mode render.Mode) string {
 	}
 
 	var sb strings.Builder
-	lineNum := 1
+	lineNum := max(r.StartLine, 1)
 
 	for _, br := range r.Ranges {
 		commitInfo := fmt.Sprintf("%s (%s)", br.CommitShortID, br.AuthorName)
@@ -87,8 +87,13 @@ func (r *FileBlameResult) RenderText(mode render.Mode) string {
 			} else {
 				fmt.Fprintf(&sb, "%-30s ", strings.Repeat(" ", len(commitInfo)))
 			}
-			glDimColor.Fprintf(&sb, "%4d ", lineNum)
-			fmt.Fprintln(&sb, line)
+			if lineNum == r.Highlight {
+				glHighlightColor.Fprintf(&sb, "→%3d %s", lineNum, line)
+				fmt.Fprintln(&sb)
+			} else {
+				glDimColor.Fprintf(&sb, "%4d ", lineNum)
+				fmt.Fprintln(&sb, line)
+			}
 			lineNum++
 		}
 	}
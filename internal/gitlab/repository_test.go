@@ -0,0 +1,41 @@
+package gitlab
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFileBlameResultLineRange(t *testing.T) {
+	blame := func() *FileBlameResult {
+		return &FileBlameResult{Ranges: []BlameRange{
+			{CommitShortID: "aaa", Lines: []string{"l1", "l2", "l3"}},
+			{CommitShortID: "bbb", Lines: []string{"l4", "l5"}},
+			{CommitShortID: "ccc", Lines: []string{"l6", "l7", "l8"}},
+		}}
+	}
+
+	tests := []struct {
+		start, end int
+		want       map[string][]string
+	}{
+		{2, 4, map[string][]string{"aaa": {"l2", "l3"}, "bbb": {"l4"}}},
+		{4, 5, map[string][]string{"bbb": {"l4", "l5"}}},
+		{5, 0, map[string][]string{"bbb": {"l5"}, "ccc": {"l6", "l7", "l8"}}},
+		{7, 100, map[string][]string{"ccc": {"l7", "l8"}}},
+		{20, 30, map[string][]string{}},
+	}
+	for _, tt := range tests {
+		r := blame()
+		r.LineRange(tt.start, tt.end)
+		got := map[string][]string{}
+		for _, br := range r.Ranges {
+			got[br.CommitShortID] = br.Lines
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("LineRange(%d, %d) = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+		if r.StartLine != tt.start {
+			t.Errorf("LineRange(%d, %d): StartLine = %d", tt.start, tt.end, r.StartLine)
+		}
+	}
+}
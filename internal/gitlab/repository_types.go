@@ -44,9 +44,11 @@ type BlameRange struct {
 
 // FileBlameResult holds git blame output for a file.
 type FileBlameResult struct {
-	FilePath string       `json:"file_path"`
-	Ref      string       `json:"ref"`
-	Ranges   []BlameRange `json:"ranges"`
+	FilePath  string       `json:"file_path"`
+	Ref       string       `json:"ref"`
+	StartLine int          `json:"start_line,omitempty"`     // line number of the first line in Ranges (1 when unset)
+	Highlight int          `json:"highlight_line,omitempty"` // line to mark in text output, 0 for none
+	Ranges    []BlameRange `json:"ranges"`
 }
 
 // ── Tree types ────────────────────────────────────────────────────────────────
@@ -0,0 +1,306 @@
+package gitlab
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/codewandler/dex/internal/render"
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// reviewerHalfLife is the age at which a commit counts half as much as one made today
+const reviewerHalfLife = 90 * 24 * time.Hour
+
+// ReviewerCandidate is a suggested reviewer with the history backing the suggestion
+type ReviewerCandidate struct {
+	Name       string    `json:"name"`
+	Email      string    `json:"email"`
+	Username   string    `json:"username,omitempty"` // resolved GitLab username, if unambiguous
+	Commits    int       `json:"commits"`            // commits touching the changed files
+	Files      int       `json:"files"`              // number of changed files they committed to
+	LastCommit time.Time `json:"last_commit"`
+	Score      float64   `json:"score"` // recency-weighted commit count
+}
+
+// SuggestReviewersOptions configures reviewer suggestion
+type SuggestReviewersOptions struct {
+	Since          time.Time // only consider commits after this time
+	Limit          int       // max candidates returned (default 5)
+	MaxFiles       int       // max changed files to inspect (default 30)
+	CommitsPerFile int       // max history commits per file (default 20)
+}
+
+// ReviewerSuggestions is the ranked reviewer list for a merge request
+type ReviewerSuggestions struct {
+	Reference     string              `json:"reference"`
+	Author        string              `json:"author"`
+	TargetBranch  string              `json:"target_branch"`
+	FilesTotal    int                 `json:"files_total"`
+	FilesAnalyzed int                 `json:"files_analyzed"`
+	Candidates    []ReviewerCandidate `json:"candidates"`
+}
+
+// SuggestReviewers ranks recent committers to the files changed by an MR.
+// History is read from the MR's target branch; the MR author and existing
+// reviewers are excluded.
+func (c *Client) SuggestReviewers(projectID any, mrIID int, opts SuggestReviewersOptions) (*ReviewerSuggestions, error) {
+	if opts.Limit == 0 {
+		opts.Limit = 5
+	}
+	if opts.MaxFiles == 0 {
+		opts.MaxFiles = 30
+	}
+	if opts.CommitsPerFile == 0 {
+		opts.CommitsPerFile = 20
+	}
+
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	mr, _, err := c.gl.MergeRequests.GetMergeRequest(pid, mrIID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get merge request: %w", err)
+	}
+
+	files, err := c.GetMergeRequestChanges(pid, mrIID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get changes: %w", err)
+	}
+
+	// New files have no history; deleted and renamed ones do under the old path
+	var paths []string
+	for _, f := range files {
+		if f.IsNew {
+			continue
+		}
+		paths = append(paths, f.OldPath)
+	}
+	total := len(files)
+	if len(paths) > opts.MaxFiles {
+		paths = paths[:opts.MaxFiles]
+	}
+
+	// Fetch path history with bounded concurrency
+	commitsByPath := make(map[string][]Commit, len(paths))
+	var (
+		mu  sync.Mutex
+		wg  sync.WaitGroup
+		sem = make(chan struct{}, 5)
+	)
+	for _, p := range paths {
+		wg.Add(1)
+		go func(path string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			listOpts := &gogitlab.ListCommitsOptions{
+				ListOptions: gogitlab.ListOptions{PerPage: opts.CommitsPerFile, Page: 1},
+				RefName:     gogitlab.Ptr(mr.TargetBranch),
+				Path:        gogitlab.Ptr(path),
+			}
+			if !opts.Since.IsZero() {
+				listOpts.Since = gogitlab.Ptr(opts.Since)
+			}
+			commits, _, err := c.gl.Commits.ListCommits(pid, listOpts)
+			if err != nil {
+				return // skip files whose history can't be read
+			}
+
+			var converted []Commit
+			for _, cm := range commits {
+				commit := Commit{
+					ID:          cm.ID,
+					ShortID:     cm.ShortID,
+					Title:       cm.Title,
+					AuthorName:  cm.AuthorName,
+					AuthorEmail: cm.AuthorEmail,
+					WebURL:      cm.WebURL,
+				}
+				if cm.CreatedAt != nil {
+					commit.CreatedAt = *cm.CreatedAt
+				}
+				converted = append(converted, commit)
+			}
+			mu.Lock()
+			commitsByPath[path] = converted
+			mu.Unlock()
+		}(p)
+	}
+	wg.Wait()
+
+	exclude := make(map[string]bool)
+	excludeUsers := make(map[string]bool)
+	if mr.Author != nil {
+		exclude[strings.ToLower(mr.Author.Name)] = true
+		excludeUsers[mr.Author.Username] = true
+	}
+	for _, r := range mr.Reviewers {
+		exclude[strings.ToLower(r.Name)] = true
+		excludeUsers[r.Username] = true
+	}
+
+	// Rank without a limit first: resolving usernames can exclude more candidates
+	ranked := RankReviewers(commitsByPath, exclude, time.Now())
+
+	result := &ReviewerSuggestions{
+		Reference:     fmt.Sprintf("%s!%d", projectID, mrIID),
+		TargetBranch:  mr.TargetBranch,
+		FilesTotal:    total,
+		FilesAnalyzed: len(paths),
+	}
+	if mr.Author != nil {
+		result.Author = mr.Author.Username
+	}
+	for _, cand := range ranked {
+		if len(result.Candidates) >= opts.Limit {
+			break
+		}
+		cand.Username = c.lookupUsername(cand.Email, cand.Name)
+		if cand.Username != "" && excludeUsers[cand.Username] {
+			continue
+		}
+		result.Candidates = append(result.Candidates, cand)
+	}
+	return result, nil
+}
+
+// lookupUsername resolves a commit author to a GitLab username. It returns ""
+// unless exactly one user matches the email (or, failing that, the name).
+func (c *Client) lookupUsername(email, name string) string {
+	for _, q := range []string{email, name} {
+		if q == "" {
+			continue
+		}
+		users, _, err := c.gl.Users.ListUsers(&gogitlab.ListUsersOptions{
+			ListOptions: gogitlab.ListOptions{PerPage: 2},
+			Search:      gogitlab.Ptr(q),
+			Active:      gogitlab.Ptr(true),
+		})
+		if err == nil && len(users) == 1 {
+			return users[0].Username
+		}
+	}
+	return ""
+}
+
+// RankReviewers aggregates per-path commit history by author (keyed by email)
+// and ranks authors by a recency-weighted commit count: each commit counts
+// 0.5^(age/90d). Ties are broken by commit count, then name. Authors whose
+// lowercased name is in exclude are skipped.
+func RankReviewers(commitsByPath map[string][]Commit, exclude map[string]bool, now time.Time) []ReviewerCandidate {
+	byEmail := make(map[string]*ReviewerCandidate)
+	filesByEmail := make(map[string]map[string]bool)
+	seenCommit := make(map[string]map[string]bool)
+
+	for path, commits := range commitsByPath {
+		for _, cm := range commits {
+			if exclude[strings.ToLower(cm.AuthorName)] {
+				continue
+			}
+			key := strings.ToLower(cm.AuthorEmail)
+			if key == "" {
+				key = strings.ToLower(cm.AuthorName)
+			}
+			cand, ok := byEmail[key]
+			if !ok {
+				cand = &ReviewerCandidate{Name: cm.AuthorName, Email: cm.AuthorEmail}
+				byEmail[key] = cand
+				filesByEmail[key] = make(map[string]bool)
+				seenCommit[key] = make(map[string]bool)
+			}
+			filesByEmail[key][path] = true
+
+			// A commit touching several changed files counts once
+			if seenCommit[key][cm.ID] {
+				continue
+			}
+			seenCommit[key][cm.ID] = true
+			cand.Commits++
+			if cm.CreatedAt.After(cand.LastCommit) {
+				cand.LastCommit = cm.CreatedAt
+			}
+			age := max(now.Sub(cm.CreatedAt), 0)
+			cand.Score += math.Pow(0.5, float64(age)/float64(reviewerHalfLife))
+		}
+	}
+
+	ranked := make([]ReviewerCandidate, 0, len(byEmail))
+	for key, cand := range byEmail {
+		cand.Files = len(filesByEmail[key])
+		cand.Score = math.Round(cand.Score*100) / 100
+		ranked = append(ranked, *cand)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].Score != ranked[j].Score {
+			return ranked[i].Score > ranked[j].Score
+		}
+		if ranked[i].Commits != ranked[j].Commits {
+			return ranked[i].Commits > ranked[j].Commits
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+	return ranked
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+// RenderText implements render.Renderable on ReviewerSuggestions.
+// ModeCompact: one line per candidate.
+// ModeNormal: header plus a ranked table.
+func (r *ReviewerSuggestions) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for i, c := range r.Candidates {
+			fmt.Fprintf(&sb, "%d. %s  %d commits, %d files, last %s\n",
+				i+1, reviewerDisplayName(c), c.Commits, c.Files, c.LastCommit.Format("2006-01-02"))
+		}
+		if len(r.Candidates) == 0 {
+			glDimColor.Fprint(&sb, "No reviewer candidates found.\n")
+		}
+		return sb.String()
+	}
+
+	line := strings.Repeat("═", 80)
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintln(&sb, line)
+	glHeaderColor.Fprintf(&sb, "  Suggested Reviewers - %s\n", r.Reference)
+	glHeaderColor.Fprintln(&sb, line)
+	glDimColor.Fprintf(&sb, "  History of %d/%d changed files on %s", r.FilesAnalyzed, r.FilesTotal, r.TargetBranch)
+	if r.Author != "" {
+		glDimColor.Fprintf(&sb, ", excluding author @%s", r.Author)
+	}
+	fmt.Fprint(&sb, "\n\n")
+
+	if len(r.Candidates) == 0 {
+		glDimColor.Fprint(&sb, "  No reviewer candidates found.\n\n")
+		return sb.String()
+	}
+
+	glSectionColor.Fprintf(&sb, "  %-3s %-36s %7s %5s %6s  %s\n", "#", "REVIEWER", "COMMITS", "FILES", "SCORE", "LAST COMMIT")
+	for i, c := range r.Candidates {
+		fmt.Fprintf(&sb, "  %-3d ", i+1)
+		glProjectColor.Fprintf(&sb, "%-36s", glTruncate(reviewerDisplayName(c), 36))
+		fmt.Fprintf(&sb, " %7d %5d %6.2f  ", c.Commits, c.Files, c.Score)
+		glDimColor.Fprintln(&sb, c.LastCommit.Format("2006-01-02"))
+	}
+	fmt.Fprintln(&sb)
+
+	return sb.String()
+}
+
+func reviewerDisplayName(c ReviewerCandidate) string {
+	if c.Username != "" {
+		return fmt.Sprintf("@%s (%s)", c.Username, c.Name)
+	}
+	return fmt.Sprintf("%s <%s>", c.Name, c.Email)
+}
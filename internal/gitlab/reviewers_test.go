@@ -0,0 +1,61 @@
+package gitlab
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRankReviewers(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	commit := func(id, name, email string, age time.Duration) Commit {
+		return Commit{ID: id, AuthorName: name, AuthorEmail: email, CreatedAt: now.Add(-age)}
+	}
+	day := 24 * time.Hour
+
+	commitsByPath := map[string][]Commit{
+		"a.go": {
+			commit("1", "Alice", "alice@example.com", 1*day),
+			commit("2", "Bob", "bob@example.com", 300*day),
+			commit("3", "Bob", "bob@example.com", 310*day),
+			commit("4", "Author", "author@example.com", 0),
+		},
+		"b.go": {
+			commit("1", "Alice", "alice@example.com", 1*day), // same commit touching both files
+			commit("5", "Bob", "BOB@example.com", 320*day),
+			commit("6", "Carol", "carol@example.com", 90*day),
+		},
+	}
+
+	ranked := RankReviewers(commitsByPath, map[string]bool{"author": true}, now)
+	if len(ranked) != 3 {
+		t.Fatalf("expected 3 candidates, got %d: %+v", len(ranked), ranked)
+	}
+
+	// Alice: one recent commit beats Bob's three old ones
+	if ranked[0].Name != "Alice" || ranked[0].Commits != 1 || ranked[0].Files != 2 {
+		t.Errorf("rank 0: got %+v, want Alice with 1 commit over 2 files", ranked[0])
+	}
+	if ranked[1].Name != "Carol" || ranked[1].Score != 0.5 {
+		t.Errorf("rank 1: got %+v, want Carol with score 0.5", ranked[1])
+	}
+	if ranked[2].Name != "Bob" || ranked[2].Commits != 3 || ranked[2].Files != 2 {
+		t.Errorf("rank 2: got %+v, want Bob with 3 commits over 2 files", ranked[2])
+	}
+	if !ranked[2].LastCommit.Equal(now.Add(-300 * day)) {
+		t.Errorf("Bob last commit: got %v", ranked[2].LastCommit)
+	}
+}
+
+func TestRankReviewersTieBreak(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	commitsByPath := map[string][]Commit{
+		"x.go": {
+			{ID: "1", AuthorName: "Zed", AuthorEmail: "z@example.com", CreatedAt: now},
+			{ID: "2", AuthorName: "Amy", AuthorEmail: "a@example.com", CreatedAt: now},
+		},
+	}
+	ranked := RankReviewers(commitsByPath, nil, now)
+	if len(ranked) != 2 || ranked[0].Name != "Amy" || ranked[1].Name != "Zed" {
+		t.Errorf("expected equal scores to sort by name, got %+v", ranked)
+	}
+}
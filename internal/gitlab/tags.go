@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/xanzy/go-gitlab"
@@ -51,3 +52,98 @@ func (c *Client) GetTags(projectID int, since time.Time) ([]Tag, error) {
 
 	return allTags, nil
 }
+
+// ListProjectTagsOptions configures the project tag list query
+type ListProjectTagsOptions struct {
+	ProjectID string // project path or numeric ID (required)
+	Search    string // only tags whose name contains this (^foo / foo$ anchor it)
+	Limit     int    // max results (default 20)
+}
+
+// ListProjectTags lists a project's tags, most recently updated first
+func (c *Client) ListProjectTags(opts ListProjectTagsOptions) ([]Tag, error) {
+	pid, err := c.resolveProjectID(opts.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Limit == 0 {
+		opts.Limit = 20
+	}
+
+	listOpts := &gitlab.ListTagsOptions{
+		ListOptions: gitlab.ListOptions{
+			PerPage: min(opts.Limit, 100),
+			Page:    1,
+		},
+		OrderBy: gitlab.Ptr("updated"),
+		Sort:    gitlab.Ptr("desc"),
+	}
+	if opts.Search != "" {
+		listOpts.Search = gitlab.Ptr(opts.Search)
+	}
+
+	var allTags []Tag
+	for {
+		tags, resp, err := c.gl.Tags.ListTags(pid, listOpts)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tags {
+			allTags = append(allTags, convertTag(t))
+		}
+
+		if len(allTags) >= opts.Limit {
+			allTags = allTags[:opts.Limit]
+			break
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		listOpts.Page = resp.NextPage
+	}
+
+	return allTags, nil
+}
+
+// CreateTag creates a tag named name at ref (branch, tag or SHA). A non-empty
+// message creates an annotated tag.
+func (c *Client) CreateTag(projectID, name, ref, message string) (*Tag, error) {
+	pid, err := c.resolveProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &gitlab.CreateTagOptions{
+		TagName: gitlab.Ptr(name),
+		Ref:     gitlab.Ptr(ref),
+	}
+	if message != "" {
+		opts.Message = gitlab.Ptr(message)
+	}
+
+	t, _, err := c.gl.Tags.CreateTag(pid, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag: %w", err)
+	}
+	tag := convertTag(t)
+	return &tag, nil
+}
+
+func convertTag(t *gitlab.Tag) Tag {
+	tag := Tag{
+		Name:      t.Name,
+		Message:   t.Message,
+		Protected: t.Protected,
+	}
+	if t.Commit != nil {
+		tag.CommitID = t.Commit.ID
+		tag.ShortID = t.Commit.ShortID
+		tag.Title = t.Commit.Title
+		if t.Commit.CreatedAt != nil {
+			tag.CreatedAt = *t.Commit.CreatedAt
+		}
+	}
+	return tag
+}
@@ -1,6 +1,7 @@
 package gitlab
 
 import (
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -8,13 +9,14 @@ import (
 
 // Commit represents a git commit in list views
 type Commit struct {
-	ID          string    `json:"id"`
-	ShortID     string    `json:"short_id"`
-	Title       string    `json:"title"`
-	AuthorName  string    `json:"author_name"`
-	AuthorEmail string    `json:"author_email"`
-	CreatedAt   time.Time `json:"created_at"`
-	WebURL      string    `json:"web_url"`
+	ID          string       `json:"id"`
+	ShortID     string       `json:"short_id"`
+	Title       string       `json:"title"`
+	AuthorName  string       `json:"author_name"`
+	AuthorEmail string       `json:"author_email"`
+	CreatedAt   time.Time    `json:"created_at"`
+	WebURL      string       `json:"web_url"`
+	Stats       *CommitStats `json:"stats,omitempty"` // only when fetched with stats
 }
 
 // CommitDetail contains full commit information including the body/message
@@ -44,13 +46,14 @@ type CommitStats struct {
 
 // MergeRequest represents a merge request in activity/summary views
 type MergeRequest struct {
-	IID       int       `json:"iid"`
-	Title     string    `json:"title"`
-	State     string    `json:"state"`
-	Author    string    `json:"author"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
-	WebURL    string    `json:"web_url"`
+	IID       int        `json:"iid"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"`
+	Author    string     `json:"author"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	MergedAt  *time.Time `json:"merged_at,omitempty"`
+	WebURL    string     `json:"web_url"`
 }
 
 // MergeRequestDetail contains full MR information for detailed views
@@ -86,6 +89,18 @@ type MergeRequestDetail struct {
 	Discussions       []MRDiscussion      `json:"discussions,omitempty"`
 }
 
+// MRApprovalRule is a single approval rule on a merge request and its state
+type MRApprovalRule struct {
+	Name              string   `json:"name"`
+	RuleType          string   `json:"rule_type"` // regular, code_owner, report_approver, any_approver
+	Section           string   `json:"section,omitempty"`
+	ApprovalsRequired int      `json:"approvals_required"`
+	EligibleApprovers []string `json:"eligible_approvers,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+	ApprovedBy        []string `json:"approved_by,omitempty"`
+	Approved          bool     `json:"approved"`
+}
+
 // MergeRequestChanges contains diff statistics
 type MergeRequestChanges struct {
 	Additions int `json:"additions"`
@@ -154,6 +169,10 @@ type Tag struct {
 	Message   string    `json:"message,omitempty"`
 	CreatedAt time.Time `json:"created_at"`
 	WebURL    string    `json:"web_url"`
+	CommitID  string    `json:"commit_id,omitempty"`
+	ShortID   string    `json:"short_id,omitempty"`
+	Title     string    `json:"title,omitempty"` // commit title
+	Protected bool      `json:"protected,omitempty"`
 }
 
 // ProjectActivity groups all activity for a single project
@@ -210,6 +229,7 @@ type ProjectMetadata struct {
 	WebURL          string             `json:"web_url"`
 	DefaultBranch   string             `json:"default_branch"`
 	Visibility      string             `json:"visibility"`
+	Archived        bool               `json:"archived,omitempty"`
 	Topics          []string           `json:"topics,omitempty"`
 	StarCount       int                `json:"star_count"`
 	ForksCount      int                `json:"forks_count"`
@@ -307,6 +327,9 @@ func (idx *GitLabIndex) BuildLookupMaps() {
 	}
 }
 
+// FindProject looks up a project by ID, exact or case-insensitive path, or a
+// name only one project has. It never matches on topics or descriptions, so
+// it is safe for resolving the project an API call (or write) goes to.
 func (idx *GitLabIndex) FindProject(idOrPath string) *ProjectMetadata {
 	if idx.ProjectsByID == nil || idx.ProjectsByPath == nil {
 		idx.BuildLookupMaps()
@@ -324,9 +347,166 @@ func (idx *GitLabIndex) FindProject(idOrPath string) *ProjectMetadata {
 		return &idx.Projects[i]
 	}
 
+	// Fall back to a case-insensitive path, or a name only one project has
+	var byName *ProjectMetadata
+	nameMatches := 0
+	for i := range idx.Projects {
+		p := &idx.Projects[i]
+		if strings.EqualFold(p.PathWithNS, idOrPath) {
+			return p
+		}
+		if strings.EqualFold(p.Name, idOrPath) {
+			byName = p
+			nameMatches++
+		}
+	}
+	if nameMatches == 1 {
+		return byName
+	}
+
 	return nil
 }
 
+// FindProjectByKeyword is FindProject with a fuzzy fallback for interactive
+// use (completion and search): a term that matches the path, name, topics or
+// description of exactly one project resolves to it. Terms that look like a
+// path or an ID are only matched exactly, so "team/billing" never resolves to
+// team/billing-old and "42" never to a project mentioning 4242.
+func (idx *GitLabIndex) FindProjectByKeyword(term string) *ProjectMetadata {
+	if p := idx.FindProject(term); p != nil {
+		return p
+	}
+	if strings.Contains(term, "/") {
+		return nil
+	}
+	if _, err := strconv.Atoi(term); err == nil {
+		return nil
+	}
+
+	if matches := idx.SearchProjects(term); len(matches) == 1 {
+		if i, ok := idx.ProjectsByID[matches[0].Project.ID]; ok {
+			return &idx.Projects[i]
+		}
+	}
+	return nil
+}
+
+// ProjectMatch is a project found by SearchProjects
+type ProjectMatch struct {
+	Project ProjectMetadata `json:"project"`
+	Score   int             `json:"score"`
+	Matched []string        `json:"matched"` // fields that matched: path, name, topics, description
+}
+
+// Per-word scores for SearchProjects: path and name hits outrank topics,
+// which outrank the description
+const (
+	searchScorePathExact   = 100
+	searchScoreNameExact   = 90
+	searchScorePrefix      = 70
+	searchScoreContains    = 60
+	searchScoreTopicExact  = 40
+	searchScoreTopic       = 30
+	searchScoreDescription = 20
+)
+
+// SearchProjects finds projects matching every word of term in their path,
+// name, topics or description (case-insensitive). Each word scores its best
+// field hit and the sums rank the results; ties go to active projects over
+// archived ones, then to the most recently active.
+func (idx *GitLabIndex) SearchProjects(term string) []ProjectMatch {
+	words := strings.Fields(strings.ToLower(term))
+	if len(words) == 0 {
+		return nil
+	}
+
+	var matches []ProjectMatch
+	for _, p := range idx.Projects {
+		match := ProjectMatch{Project: p}
+		fields := make(map[string]bool)
+		for _, w := range words {
+			score := scoreProjectWord(p, w, fields)
+			if score == 0 {
+				match.Score = 0
+				break
+			}
+			match.Score += score
+		}
+		if match.Score == 0 {
+			continue
+		}
+		for _, f := range []string{"path", "name", "topics", "description"} {
+			if fields[f] {
+				match.Matched = append(match.Matched, f)
+			}
+		}
+		matches = append(matches, match)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		if a.Project.Archived != b.Project.Archived {
+			return !a.Project.Archived
+		}
+		if !a.Project.LastActivityAt.Equal(b.Project.LastActivityAt) {
+			return a.Project.LastActivityAt.After(b.Project.LastActivityAt)
+		}
+		return a.Project.PathWithNS < b.Project.PathWithNS
+	})
+	return matches
+}
+
+// scoreProjectWord returns the best score of the lowercase word w against p's
+// fields, recording every field that matched
+func scoreProjectWord(p ProjectMetadata, w string, fields map[string]bool) int {
+	best := 0
+	hit := func(field string, score int) {
+		fields[field] = true
+		best = max(best, score)
+	}
+
+	path := strings.ToLower(p.PathWithNS)
+	base := path[strings.LastIndex(path, "/")+1:]
+	switch {
+	case path == w:
+		hit("path", searchScorePathExact)
+	case base == w:
+		hit("path", searchScoreNameExact)
+	case strings.HasPrefix(base, w):
+		hit("path", searchScorePrefix)
+	case strings.Contains(path, w):
+		hit("path", searchScoreContains)
+	}
+
+	name := strings.ToLower(p.Name)
+	switch {
+	case name == w:
+		hit("name", searchScoreNameExact)
+	case strings.HasPrefix(name, w):
+		hit("name", searchScorePrefix)
+	case strings.Contains(name, w):
+		hit("name", searchScoreContains)
+	}
+
+	for _, t := range p.Topics {
+		t = strings.ToLower(t)
+		if t == w {
+			hit("topics", searchScoreTopicExact)
+		} else if strings.Contains(t, w) {
+			hit("topics", searchScoreTopic)
+		}
+	}
+
+	if strings.Contains(strings.ToLower(p.Description), w) {
+		hit("description", searchScoreDescription)
+	}
+
+	return best
+}
+
 func (idx *GitLabIndex) UpsertProject(p ProjectMetadata) {
 	if idx.ProjectsByID == nil || idx.ProjectsByPath == nil {
 		idx.BuildLookupMaps()
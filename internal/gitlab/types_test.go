@@ -0,0 +1,130 @@
+package gitlab
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func searchTestIndex() *GitLabIndex {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	idx := NewGitLabIndex("https://gitlab.example.com")
+	idx.Projects = []ProjectMetadata{
+		{ID: 1, Name: "Docs", PathWithNS: "team/docs", Description: "Billing and invoicing handbook", LastActivityAt: now},
+		{ID: 2, Name: "Billing Service", PathWithNS: "team/billing", Description: "Invoices", LastActivityAt: now.Add(-time.Hour)},
+		{ID: 3, Name: "Gateway", PathWithNS: "team/gateway", Topics: []string{"billing", "kafka"}, LastActivityAt: now},
+		{ID: 4, Name: "Old Billing", PathWithNS: "legacy/billing-old", Archived: true, LastActivityAt: now},
+		{ID: 5, Name: "Payments", PathWithNS: "team/payments", Description: "Payment gateway adapters", Topics: []string{"kafka-consumer"}, LastActivityAt: now},
+	}
+	idx.BuildLookupMaps()
+	return idx
+}
+
+func TestSearchProjectsRanking(t *testing.T) {
+	idx := searchTestIndex()
+
+	var got []string
+	for _, m := range idx.SearchProjects("billing") {
+		got = append(got, m.Project.PathWithNS)
+	}
+	// exact path segment > archived prefix hit > topic > description
+	want := []string{"team/billing", "legacy/billing-old", "team/gateway", "team/docs"}
+	if !slices.Equal(got, want) {
+		t.Errorf("SearchProjects(billing) = %v, want %v", got, want)
+	}
+}
+
+func TestSearchProjectsFields(t *testing.T) {
+	idx := searchTestIndex()
+
+	tests := []struct {
+		term    string
+		want    []string
+		matched []string // fields matched on the first hit
+	}{
+		{term: "KAFKA", want: []string{"team/gateway", "team/payments"}, matched: []string{"topics"}},
+		{term: "invoic", want: []string{"team/docs", "team/billing"}, matched: []string{"description"}},
+		{term: "payment gateway", want: []string{"team/payments"}, matched: []string{"path", "name", "description"}},
+		{term: "billing kafka", want: []string{"team/gateway"}, matched: []string{"topics"}},
+		{term: "nothing-here", want: nil},
+		{term: "  ", want: nil},
+	}
+	for _, tt := range tests {
+		matches := idx.SearchProjects(tt.term)
+		var got []string
+		for _, m := range matches {
+			got = append(got, m.Project.PathWithNS)
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("SearchProjects(%q) = %v, want %v", tt.term, got, tt.want)
+			continue
+		}
+		if len(matches) > 0 && !slices.Equal(matches[0].Matched, tt.matched) {
+			t.Errorf("SearchProjects(%q) matched = %v, want %v", tt.term, matches[0].Matched, tt.matched)
+		}
+	}
+}
+
+func TestFindProjectFallbacks(t *testing.T) {
+	idx := searchTestIndex()
+	idx.Projects = append(idx.Projects, ProjectMetadata{ID: 6, Name: "Docs", PathWithNS: "other/docs"})
+	idx.BuildLookupMaps()
+
+	for ref, wantID := range map[string]int{
+		"2":               2,
+		"team/billing":    2,
+		"Team/Billing":    2,
+		"billing service": 2,
+		"Gateway":         3,
+	} {
+		if p := idx.FindProject(ref); p == nil || p.ID != wantID {
+			t.Errorf("FindProject(%q) = %+v, want ID %d", ref, p, wantID)
+		}
+		if p := idx.FindProjectByKeyword(ref); p == nil || p.ID != wantID {
+			t.Errorf("FindProjectByKeyword(%q) = %+v, want ID %d", ref, p, wantID)
+		}
+	}
+	// Ambiguous names and terms matching several projects don't resolve
+	for _, ref := range []string{"docs", "invoic", "kafka", "nothing-here"} {
+		if p := idx.FindProjectByKeyword(ref); p != nil {
+			t.Errorf("FindProjectByKeyword(%q) = %s, want nil", ref, p.PathWithNS)
+		}
+	}
+	// Topic and description terms only resolve through FindProjectByKeyword
+	for ref, wantID := range map[string]int{
+		"handbook":       1, // description word only one project has
+		"kafka-consumer": 5, // topic only one project has
+		"adapters":       5,
+		"invoices":       2,
+	} {
+		if p := idx.FindProject(ref); p != nil {
+			t.Errorf("FindProject(%q) = %s, want nil", ref, p.PathWithNS)
+		}
+		if p := idx.FindProjectByKeyword(ref); p == nil || p.ID != wantID {
+			t.Errorf("FindProjectByKeyword(%q) = %+v, want ID %d", ref, p, wantID)
+		}
+	}
+}
+
+func TestFindProjectByKeywordExactRefs(t *testing.T) {
+	// Neither team/billing nor project 42 is indexed; refs that look like a
+	// path or an ID must not fall back to the one project they appear in
+	idx := NewGitLabIndex("https://gitlab.example.com")
+	idx.Projects = []ProjectMetadata{
+		{ID: 4, Name: "Old Billing", PathWithNS: "team/billing-old"},
+		{ID: 7, Name: "Runbooks", PathWithNS: "ops/runbooks", Description: "Postmortem for incident 4242"},
+	}
+	idx.BuildLookupMaps()
+
+	for _, ref := range []string{"team/billing", "42"} {
+		if p := idx.FindProject(ref); p != nil {
+			t.Errorf("FindProject(%q) = %s, want nil", ref, p.PathWithNS)
+		}
+		if p := idx.FindProjectByKeyword(ref); p != nil {
+			t.Errorf("FindProjectByKeyword(%q) = %s, want nil", ref, p.PathWithNS)
+		}
+	}
+	if p := idx.FindProjectByKeyword("postmortem"); p == nil || p.ID != 7 {
+		t.Errorf("FindProjectByKeyword(postmortem) = %+v, want ID 7", p)
+	}
+}
@@ -0,0 +1,164 @@
+package gitlab
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codewandler/dex/internal/render"
+	gogitlab "github.com/xanzy/go-gitlab"
+)
+
+// ── Data types ────────────────────────────────────────────────────────────────
+
+// ActivityAuthor identifies the user an activity fetch is narrowed to. MRs are
+// matched on Username, commits on Name (the git author name or email).
+type ActivityAuthor struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+}
+
+// GetActivityAuthor looks up a user by exact username
+func (c *Client) GetActivityAuthor(username string) (*ActivityAuthor, error) {
+	username = strings.TrimPrefix(username, "@")
+	users, _, err := c.gl.Users.ListUsers(&gogitlab.ListUsersOptions{
+		Username: gogitlab.Ptr(username),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %q not found", username)
+	}
+	return &ActivityAuthor{Username: users[0].Username, Name: users[0].Name}, nil
+}
+
+// UserProjectActivity is one user's contribution to a single project
+type UserProjectActivity struct {
+	ProjectPath string `json:"project_path"`
+	WebURL      string `json:"web_url"`
+	Commits     int    `json:"commits"`
+	MRsOpened   int    `json:"mrs_opened"`
+	MRsMerged   int    `json:"mrs_merged"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+}
+
+// ── render.Renderable implementation ─────────────────────────────────────────
+
+// UserActivityReport sums up a user's commits and MRs across projects.
+type UserActivityReport struct {
+	Username        string                `json:"username"`
+	Name            string                `json:"name"`
+	Since           time.Time             `json:"since"`
+	ProjectsScanned int                   `json:"projects_scanned"`
+	Commits         int                   `json:"commits"`
+	MRsOpened       int                   `json:"mrs_opened"`
+	MRsMerged       int                   `json:"mrs_merged"`
+	Additions       int                   `json:"additions"`
+	Deletions       int                   `json:"deletions"`
+	Projects        []UserProjectActivity `json:"projects"`
+}
+
+// NewUserActivityReport builds the report from author-filtered project
+// activity. An MR counts as opened if it was created since the start of the
+// window and as merged if it was merged since then. Projects are sorted by
+// commits plus MRs, most active first.
+func NewUserActivityReport(author ActivityAuthor, since time.Time, scanned int, activities []ProjectActivity) *UserActivityReport {
+	r := &UserActivityReport{
+		Username:        author.Username,
+		Name:            author.Name,
+		Since:           since,
+		ProjectsScanned: scanned,
+		Projects:        []UserProjectActivity{},
+	}
+
+	for _, a := range activities {
+		p := UserProjectActivity{ProjectPath: a.ProjectPath, WebURL: a.WebURL}
+		for _, c := range a.Commits {
+			p.Commits++
+			if c.Stats != nil {
+				p.Additions += c.Stats.Additions
+				p.Deletions += c.Stats.Deletions
+			}
+		}
+		for _, mr := range a.MergeRequests {
+			if !mr.CreatedAt.Before(since) {
+				p.MRsOpened++
+			}
+			if mr.MergedAt != nil && !mr.MergedAt.Before(since) {
+				p.MRsMerged++
+			}
+		}
+		if p.Commits == 0 && p.MRsOpened == 0 && p.MRsMerged == 0 {
+			continue
+		}
+
+		r.Commits += p.Commits
+		r.MRsOpened += p.MRsOpened
+		r.MRsMerged += p.MRsMerged
+		r.Additions += p.Additions
+		r.Deletions += p.Deletions
+		r.Projects = append(r.Projects, p)
+	}
+
+	sort.SliceStable(r.Projects, func(i, j int) bool {
+		a, b := r.Projects[i], r.Projects[j]
+		if wa, wb := a.Commits+a.MRsOpened+a.MRsMerged, b.Commits+b.MRsOpened+b.MRsMerged; wa != wb {
+			return wa > wb
+		}
+		return a.ProjectPath < b.ProjectPath
+	})
+
+	return r
+}
+
+// RenderText implements render.Renderable on UserActivityReport.
+// ModeCompact: one line per project.
+// ModeNormal: totals followed by a per-project table.
+func (r *UserActivityReport) RenderText(mode render.Mode) string {
+	var sb strings.Builder
+
+	if mode == render.ModeCompact {
+		for _, p := range r.Projects {
+			fmt.Fprintf(&sb, "%-40s %3d commits  %2d opened  %2d merged  +%d -%d\n",
+				p.ProjectPath, p.Commits, p.MRsOpened, p.MRsMerged, p.Additions, p.Deletions)
+		}
+		return sb.String()
+	}
+
+	fmt.Fprintln(&sb)
+	glHeaderColor.Fprintf(&sb, "  @%s", r.Username)
+	if r.Name != "" {
+		fmt.Fprintf(&sb, " (%s)", r.Name)
+	}
+	glDimColor.Fprintf(&sb, "  since %s\n", r.Since.Format("2006-01-02 15:04"))
+	fmt.Fprintln(&sb)
+
+	if len(r.Projects) == 0 {
+		glDimColor.Fprintf(&sb, "  No commits or merge requests in %d projects.\n\n", r.ProjectsScanned)
+		return sb.String()
+	}
+
+	glPrintField(&sb, "Projects", fmt.Sprintf("%d of %d touched", len(r.Projects), r.ProjectsScanned))
+	glPrintField(&sb, "Commits", fmt.Sprintf("%d (+%d -%d)", r.Commits, r.Additions, r.Deletions))
+	glPrintField(&sb, "MRs", fmt.Sprintf("%d opened, %d merged", r.MRsOpened, r.MRsMerged))
+	fmt.Fprintln(&sb)
+
+	width := len("PROJECT")
+	for _, p := range r.Projects {
+		width = max(width, len(p.ProjectPath))
+	}
+	glDimColor.Fprintf(&sb, "  %-*s  %7s  %6s  %6s  %s\n", width, "PROJECT", "COMMITS", "OPENED", "MERGED", "LINES")
+	for _, p := range r.Projects {
+		glProjectColor.Fprintf(&sb, "  %-*s", width, p.ProjectPath)
+		fmt.Fprintf(&sb, "  %7d  %6d  %6d  ", p.Commits, p.MRsOpened, p.MRsMerged)
+		glMRMergedColor.Fprintf(&sb, "+%d", p.Additions)
+		fmt.Fprint(&sb, " ")
+		glMRClosedColor.Fprintf(&sb, "-%d\n", p.Deletions)
+	}
+
+	fmt.Fprintln(&sb)
+	return sb.String()
+}
@@ -0,0 +1,53 @@
+package gitlab
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewUserActivityReport(t *testing.T) {
+	since := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	before := since.Add(-48 * time.Hour)
+	after := since.Add(48 * time.Hour)
+
+	activities := []ProjectActivity{
+		{
+			ProjectPath: "group/web",
+			Commits: []Commit{
+				{ShortID: "a1", Stats: &CommitStats{Additions: 10, Deletions: 2}},
+			},
+		},
+		{
+			ProjectPath: "group/api",
+			Commits: []Commit{
+				{ShortID: "b1", Stats: &CommitStats{Additions: 5, Deletions: 1}},
+				{ShortID: "b2", Stats: &CommitStats{Additions: 3}},
+			},
+			MergeRequests: []MergeRequest{
+				{IID: 1, CreatedAt: after, MergedAt: &after},  // opened and merged
+				{IID: 2, CreatedAt: before, MergedAt: &after}, // merged only
+			},
+		},
+		{
+			// Only an MR opened before the window and updated since
+			ProjectPath:   "group/idle",
+			MergeRequests: []MergeRequest{{IID: 3, CreatedAt: before}},
+		},
+	}
+
+	r := NewUserActivityReport(ActivityAuthor{Username: "jdoe"}, since, 5, activities)
+
+	if r.Commits != 3 || r.MRsOpened != 1 || r.MRsMerged != 2 || r.Additions != 18 || r.Deletions != 3 {
+		t.Errorf("totals = %d commits, %d opened, %d merged, +%d -%d; want 3, 1, 2, +18 -3",
+			r.Commits, r.MRsOpened, r.MRsMerged, r.Additions, r.Deletions)
+	}
+	if r.ProjectsScanned != 5 {
+		t.Errorf("ProjectsScanned = %d, want 5", r.ProjectsScanned)
+	}
+	if len(r.Projects) != 2 {
+		t.Fatalf("got %d projects, want 2 (idle project dropped)", len(r.Projects))
+	}
+	if r.Projects[0].ProjectPath != "group/api" || r.Projects[1].ProjectPath != "group/web" {
+		t.Errorf("project order = %s, %s; want group/api first", r.Projects[0].ProjectPath, r.Projects[1].ProjectPath)
+	}
+}
@@ -0,0 +1,185 @@
+package homer
+
+import (
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AliasSuggestion is a busy endpoint without an alias, with a proposed name.
+type AliasSuggestion struct {
+	IP        string `json:"ip"`
+	Port      int    `json:"port"`
+	Messages  int    `json:"messages"`
+	UserAgent string `json:"user_agent,omitempty"`
+	Name      string `json:"name"`
+	Source    string `json:"source"` // where the name came from: "rdns", "user-agent" or "ip"
+}
+
+// AliasPayload returns the suggestion in the shape of Homer's alias API
+// (/api/v3/alias), ready to be created as a host alias.
+func (s AliasSuggestion) AliasPayload() Alias {
+	mask := 32
+	if ip := net.ParseIP(s.IP); ip != nil && ip.To4() == nil {
+		mask = 128
+	}
+	return Alias{IP: s.IP, Port: float64(s.Port), Mask: float64(mask), Alias: s.Name, Status: true, CaptureID: "0"}
+}
+
+// SuggestAliases counts every src and dst IP:port in records, drops endpoints
+// already covered by an alias (matching IP or subnet, with port 0 matching any
+// port) and returns the rest busiest first. Names come from lookup (typically
+// reverse DNS; may be nil), then from the product in the endpoint's most common
+// User-Agent, and finally from the address itself. Names are made unique.
+func SuggestAliases(records []CallRecord, aliases []Alias, lookup func(ip string) string) []AliasSuggestion {
+	type endpoint struct {
+		ip   string
+		port int
+	}
+	counts := make(map[endpoint]int)
+	agents := make(map[endpoint]map[string]int)
+	aliased := make(map[endpoint]bool)
+
+	for _, r := range records {
+		src := endpoint{r.SourceIP, int(r.SourcePort)}
+		dst := endpoint{r.DestIP, int(r.DestPort)}
+		if src.ip != "" {
+			counts[src]++
+			if r.AliasSrc != "" && r.AliasSrc != r.SourceIP {
+				aliased[src] = true
+			}
+			if ua := strings.TrimSpace(r.UserAgent); ua != "" {
+				if agents[src] == nil {
+					agents[src] = make(map[string]int)
+				}
+				agents[src][ua]++
+			}
+		}
+		if dst.ip != "" {
+			counts[dst]++
+			if r.AliasDst != "" && r.AliasDst != r.DestIP {
+				aliased[dst] = true
+			}
+		}
+	}
+
+	var out []AliasSuggestion
+	for ep, n := range counts {
+		if aliased[ep] || aliasCovers(aliases, ep.ip, ep.port) {
+			continue
+		}
+		out = append(out, AliasSuggestion{IP: ep.ip, Port: ep.port, Messages: n, UserAgent: mostCommon(agents[ep])})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Messages != out[j].Messages {
+			return out[i].Messages > out[j].Messages
+		}
+		if out[i].IP != out[j].IP {
+			return out[i].IP < out[j].IP
+		}
+		return out[i].Port < out[j].Port
+	})
+
+	hostnames := make(map[string]string)
+	used := make(map[string]int)
+	for _, a := range aliases {
+		used[strings.ToLower(a.Alias)]++
+	}
+	for i := range out {
+		s := &out[i]
+		if lookup != nil {
+			host, ok := hostnames[s.IP]
+			if !ok {
+				host = strings.TrimSuffix(lookup(s.IP), ".")
+				hostnames[s.IP] = host
+			}
+			if host != "" {
+				s.Name, s.Source = host, "rdns"
+			}
+		}
+		if s.Name == "" {
+			if product := uaProduct(s.UserAgent); product != "" {
+				s.Name, s.Source = product, "user-agent"
+			}
+		}
+		if s.Name == "" {
+			s.Name, s.Source = "host-"+aliasSlug(s.IP), "ip"
+		}
+
+		base := s.Name
+		for used[strings.ToLower(s.Name)] > 0 {
+			used[strings.ToLower(base)]++
+			s.Name = base + "-" + strconv.Itoa(used[strings.ToLower(base)])
+		}
+		used[strings.ToLower(s.Name)]++
+	}
+	return out
+}
+
+// aliasCovers reports whether an alias already matches ip:port.
+func aliasCovers(aliases []Alias, ip string, port int) bool {
+	addr := net.ParseIP(ip)
+	for _, a := range aliases {
+		if a.Port != 0 && int(a.Port) != port {
+			continue
+		}
+		if a.IP == ip {
+			return true
+		}
+		if addr == nil {
+			continue
+		}
+		cidr := a.IP
+		if !strings.Contains(cidr, "/") && a.Mask > 0 {
+			cidr += "/" + strconv.Itoa(int(a.Mask))
+		}
+		if _, subnet, err := net.ParseCIDR(cidr); err == nil && subnet.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// mostCommon returns the most frequent key, preferring the lexically smallest on ties.
+func mostCommon(m map[string]int) string {
+	best, bestN := "", 0
+	for k, n := range m {
+		if n > bestN || (n == bestN && k < best) {
+			best, bestN = k, n
+		}
+	}
+	return best
+}
+
+// uaProduct turns a User-Agent into a short alias name: its product token,
+// e.g. "FPBX-16.0.40(18.13.0)" → "fpbx", "Asterisk PBX 18.2.0" → "asterisk".
+func uaProduct(ua string) string {
+	token := strings.FieldsFunc(ua, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '.')
+	})
+	for _, t := range token {
+		t = strings.Trim(t, "._")
+		if strings.Trim(t, "0123456789.") == "" {
+			continue // version number
+		}
+		return aliasSlug(t)
+	}
+	return ""
+}
+
+// aliasSlug lowercases s and replaces anything but letters and digits with dashes.
+func aliasSlug(s string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if r >= 'a' && r <= 'z' || r >= '0' && r <= '9' {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
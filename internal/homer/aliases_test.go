@@ -0,0 +1,54 @@
+package homer
+
+import "testing"
+
+func TestSuggestAliases(t *testing.T) {
+	records := []CallRecord{
+		{SourceIP: "10.0.0.5", SourcePort: 5060, DestIP: "10.0.0.9", DestPort: 5060, UserAgent: "FPBX-16.0.40(18.13.0)"},
+		{SourceIP: "10.0.0.5", SourcePort: 5060, DestIP: "10.0.0.9", DestPort: 5060, UserAgent: "FPBX-16.0.40(18.13.0)"},
+		{SourceIP: "10.0.0.9", SourcePort: 5060, DestIP: "10.0.0.5", DestPort: 5060},
+		{SourceIP: "10.0.0.6", SourcePort: 5060, DestIP: "192.168.1.20", DestPort: 5080, UserAgent: "FPBX-16.0.40(18.13.0)"},
+		{SourceIP: "172.16.0.1", SourcePort: 5060, AliasSrc: "edge-proxy", DestIP: "10.0.0.7", DestPort: 5060},
+	}
+	aliases := []Alias{
+		{IP: "192.168.1.0", Mask: 24, Alias: "office"},
+		{IP: "10.0.0.7", Port: 5062, Alias: "other-port"},
+	}
+
+	got := SuggestAliases(records, aliases, func(ip string) string {
+		if ip == "10.0.0.9" {
+			return "sbc1.example.net."
+		}
+		return ""
+	})
+
+	want := []AliasSuggestion{
+		{IP: "10.0.0.5", Port: 5060, Messages: 3, Name: "fpbx", Source: "user-agent"},
+		{IP: "10.0.0.9", Port: 5060, Messages: 3, Name: "sbc1.example.net", Source: "rdns"},
+		{IP: "10.0.0.6", Port: 5060, Messages: 1, Name: "fpbx-2", Source: "user-agent"},
+		{IP: "10.0.0.7", Port: 5060, Messages: 1, Name: "host-10-0-0-7", Source: "ip"},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %d suggestions, want %d: %+v", len(got), len(want), got)
+	}
+	for i, w := range want {
+		g := got[i]
+		if g.IP != w.IP || g.Port != w.Port || g.Messages != w.Messages || g.Name != w.Name || g.Source != w.Source {
+			t.Errorf("suggestion %d: got %+v, want %+v", i, g, w)
+		}
+	}
+}
+
+func TestUAProduct(t *testing.T) {
+	for ua, want := range map[string]string{
+		"Asterisk PBX 18.2.0":   "asterisk",
+		"Ooma/1.0":              "ooma",
+		"FPBX-16.0.40(18.13.0)": "fpbx",
+		"3CX Phone System 18.0": "3cx",
+		"":                      "",
+	} {
+		if got := uaProduct(ua); got != want {
+			t.Errorf("uaProduct(%q) = %q, want %q", ua, got, want)
+		}
+	}
+}
@@ -0,0 +1,338 @@
+package homer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+)
+
+// IPMapping pairs an original address with the placeholder that replaced it.
+type IPMapping struct {
+	Original    string `json:"original"`
+	Placeholder string `json:"placeholder"`
+}
+
+// placeholderV4Nets are the IPv4 documentation ranges (RFC 5737) placeholders are taken from, in order.
+var placeholderV4Nets = [][3]byte{{198, 51, 100}, {203, 0, 113}, {192, 0, 2}}
+
+// placeholderV6Prefix is the IPv6 documentation prefix (RFC 3849).
+var placeholderV6Prefix = net.ParseIP("2001:db8::")
+
+var (
+	ipv4Literal      = regexp.MustCompile(`\b(?:\d{1,3}\.){3}\d{1,3}\b`)
+	sipContentLength = regexp.MustCompile(`(?im)^((?:content-length|l)[ \t]*:[ \t]*)(\d+)`)
+)
+
+// ipAnonymizer hands out placeholders in first-seen order, so the same address
+// always gets the same placeholder within one export.
+type ipAnonymizer struct {
+	byIP    map[string]net.IP
+	v6      []IPMapping // IPv6 mappings, replaced textually in payloads
+	mapping []IPMapping
+	nextV4  int
+	nextV6  uint32
+}
+
+func (a *ipAnonymizer) placeholder(ip net.IP) (net.IP, error) {
+	key := ip.String()
+	if p, ok := a.byIP[key]; ok {
+		return p, nil
+	}
+
+	var p net.IP
+	if ip.To4() != nil {
+		if a.nextV4 >= 254*len(placeholderV4Nets) {
+			return nil, fmt.Errorf("more than %d distinct IPv4 addresses", 254*len(placeholderV4Nets))
+		}
+		n := placeholderV4Nets[a.nextV4/254]
+		p = net.IPv4(n[0], n[1], n[2], byte(a.nextV4%254+1)).To4()
+		a.nextV4++
+	} else {
+		a.nextV6++
+		p = make(net.IP, net.IPv6len)
+		copy(p, placeholderV6Prefix)
+		binary.BigEndian.PutUint32(p[12:], a.nextV6)
+		a.v6 = append(a.v6, IPMapping{Original: key, Placeholder: p.String()})
+	}
+
+	a.byIP[key] = p
+	a.mapping = append(a.mapping, IPMapping{Original: key, Placeholder: p.String()})
+	return p, nil
+}
+
+// AnonymizePCAP rewrites every IPv4/IPv6 address in a classic PCAP file to a
+// placeholder from the documentation ranges (198.51.100.x, then 203.0.113.x and
+// 192.0.2.x; 2001:db8::x for IPv6). Addresses are replaced in the IP headers
+// and, for UDP packets, inside the payload (SIP Via/Contact headers, SDP c=
+// lines), with the SIP Content-Length, lengths and checksums adjusted. The
+// returned mapping lists each original address in first-seen order.
+func AnonymizePCAP(data []byte) ([]byte, []IPMapping, error) {
+	if len(data) < 24 {
+		return nil, nil, errors.New("not a PCAP file: too short")
+	}
+
+	var bo binary.ByteOrder
+	switch binary.LittleEndian.Uint32(data[:4]) {
+	case 0xa1b2c3d4, 0xa1b23c4d:
+		bo = binary.LittleEndian
+	case 0xd4c3b2a1, 0x4d3cb2a1:
+		bo = binary.BigEndian
+	default:
+		return nil, nil, errors.New("not a PCAP file: unknown magic number")
+	}
+	linkType := bo.Uint32(data[20:24]) & 0x0fffffff
+
+	a := &ipAnonymizer{byIP: make(map[string]net.IP)}
+	var out bytes.Buffer
+	out.Write(data[:24])
+
+	for off := 24; off < len(data); {
+		if off+16 > len(data) {
+			return nil, nil, errors.New("truncated PCAP record header")
+		}
+		hdr := make([]byte, 16)
+		copy(hdr, data[off:off+16])
+		capLen := int(bo.Uint32(hdr[8:12]))
+		origLen := int(bo.Uint32(hdr[12:16]))
+		if off+16+capLen > len(data) {
+			return nil, nil, errors.New("truncated PCAP record")
+		}
+
+		pkt, err := a.frame(data[off+16:off+16+capLen], linkType)
+		if err != nil {
+			return nil, nil, err
+		}
+		delta := len(pkt) - capLen
+		bo.PutUint32(hdr[8:12], uint32(capLen+delta))
+		bo.PutUint32(hdr[12:16], uint32(origLen+delta))
+		out.Write(hdr)
+		out.Write(pkt)
+
+		off += 16 + capLen
+	}
+	return out.Bytes(), a.mapping, nil
+}
+
+// frame rewrites the IP packet inside a link-layer frame.
+func (a *ipAnonymizer) frame(pkt []byte, linkType uint32) ([]byte, error) {
+	ipOff := 0
+	switch linkType {
+	case 1: // Ethernet, possibly VLAN-tagged
+		if len(pkt) < 14 {
+			return pkt, nil
+		}
+		ipOff = 14
+		etherType := binary.BigEndian.Uint16(pkt[12:14])
+		for (etherType == 0x8100 || etherType == 0x88a8) && len(pkt) >= ipOff+4 {
+			etherType = binary.BigEndian.Uint16(pkt[ipOff+2 : ipOff+4])
+			ipOff += 4
+		}
+		if etherType != 0x0800 && etherType != 0x86dd {
+			return pkt, nil
+		}
+	case 113: // Linux cooked capture
+		ipOff = 16
+	case 276: // Linux cooked capture v2
+		ipOff = 20
+	case 0: // BSD loopback
+		ipOff = 4
+	case 12, 14, 101, 228, 229: // raw IP
+	default:
+		return nil, fmt.Errorf("unsupported PCAP link type %d", linkType)
+	}
+	if len(pkt) <= ipOff {
+		return pkt, nil
+	}
+
+	var (
+		ip  []byte
+		err error
+	)
+	switch pkt[ipOff] >> 4 {
+	case 4:
+		ip, err = a.ipv4(pkt[ipOff:])
+	case 6:
+		ip, err = a.ipv6(pkt[ipOff:])
+	default:
+		return pkt, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return append(append([]byte{}, pkt[:ipOff]...), ip...), nil
+}
+
+func (a *ipAnonymizer) ipv4(p []byte) ([]byte, error) {
+	ihl := int(p[0]&0x0f) * 4
+	if ihl < 20 || len(p) < ihl {
+		return p, nil
+	}
+	p = append([]byte{}, p...)
+
+	for _, field := range [][]byte{p[12:16], p[16:20]} {
+		ph, err := a.placeholder(net.IP(field))
+		if err != nil {
+			return nil, err
+		}
+		copy(field, ph.To4())
+	}
+
+	totalLen := int(binary.BigEndian.Uint16(p[2:4]))
+	fragmented := binary.BigEndian.Uint16(p[6:8])&0x3fff != 0
+	if !fragmented && totalLen >= ihl && totalLen <= len(p) {
+		pseudo := make([]byte, 12)
+		copy(pseudo[0:8], p[12:20])
+		pseudo[9] = p[9]
+		seg, err := a.transport(p[ihl:totalLen], p[9], pseudo, false)
+		if err != nil {
+			return nil, err
+		}
+		p = append(append(append([]byte{}, p[:ihl]...), seg...), p[totalLen:]...)
+		binary.BigEndian.PutUint16(p[2:4], uint16(ihl+len(seg)))
+	}
+
+	p[10], p[11] = 0, 0
+	binary.BigEndian.PutUint16(p[10:12], checksumFold(checksumAdd(0, p[:ihl])))
+	return p, nil
+}
+
+func (a *ipAnonymizer) ipv6(p []byte) ([]byte, error) {
+	if len(p) < 40 {
+		return p, nil
+	}
+	p = append([]byte{}, p...)
+
+	for _, field := range [][]byte{p[8:24], p[24:40]} {
+		ph, err := a.placeholder(net.IP(append([]byte{}, field...)))
+		if err != nil {
+			return nil, err
+		}
+		copy(field, ph.To16())
+	}
+
+	// Extension headers are left alone; only a directly following UDP/TCP header is handled
+	payloadLen := int(binary.BigEndian.Uint16(p[4:6]))
+	if 40+payloadLen <= len(p) {
+		pseudo := make([]byte, 40)
+		copy(pseudo[0:32], p[8:40])
+		pseudo[39] = p[6]
+		seg, err := a.transport(p[40:40+payloadLen], p[6], pseudo, true)
+		if err != nil {
+			return nil, err
+		}
+		p = append(append(append([]byte{}, p[:40]...), seg...), p[40+payloadLen:]...)
+		binary.BigEndian.PutUint16(p[4:6], uint16(len(seg)))
+	}
+	return p, nil
+}
+
+// transport rewrites a UDP payload and recomputes the UDP or TCP checksum for
+// the new addresses. pseudo is the pseudo-header without the length field.
+// TCP payloads are not rewritten: changing their length would break the
+// sequence numbers.
+func (a *ipAnonymizer) transport(seg []byte, proto byte, pseudo []byte, v6 bool) ([]byte, error) {
+	var csumOff int
+	switch proto {
+	case 17:
+		if len(seg) < 8 {
+			return seg, nil
+		}
+		payload, err := a.payload(seg[8:])
+		if err != nil {
+			return nil, err
+		}
+		hadChecksum := binary.BigEndian.Uint16(seg[6:8]) != 0
+		seg = append(append([]byte{}, seg[:8]...), payload...)
+		binary.BigEndian.PutUint16(seg[4:6], uint16(len(seg)))
+		if !hadChecksum && !v6 {
+			return seg, nil // IPv4 UDP checksum is optional
+		}
+		csumOff = 6
+	case 6:
+		if len(seg) < 20 {
+			return seg, nil
+		}
+		seg = append([]byte{}, seg...)
+		csumOff = 16
+	default:
+		return seg, nil
+	}
+
+	if v6 {
+		binary.BigEndian.PutUint32(pseudo[32:36], uint32(len(seg)))
+	} else {
+		binary.BigEndian.PutUint16(pseudo[10:12], uint16(len(seg)))
+	}
+	seg[csumOff], seg[csumOff+1] = 0, 0
+	sum := checksumFold(checksumAdd(checksumAdd(0, pseudo), seg))
+	if sum == 0 && proto == 17 {
+		sum = 0xffff
+	}
+	binary.BigEndian.PutUint16(seg[csumOff:csumOff+2], sum)
+	return seg, nil
+}
+
+// payload replaces address literals in a (SIP) payload and keeps its
+// Content-Length in line with the rewritten body.
+func (a *ipAnonymizer) payload(b []byte) ([]byte, error) {
+	var err error
+	out := ipv4Literal.ReplaceAllFunc(b, func(m []byte) []byte {
+		ip := net.ParseIP(string(m))
+		if ip == nil || err != nil {
+			return m
+		}
+		ph, e := a.placeholder(ip)
+		if e != nil {
+			err = e
+			return m
+		}
+		return []byte(ph.String())
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, m := range a.v6 {
+		out = bytes.ReplaceAll(out, []byte(m.Original), []byte(m.Placeholder))
+	}
+	if bytes.Equal(out, b) {
+		return b, nil
+	}
+
+	_, oldBody, ok := bytes.Cut(b, []byte("\r\n\r\n"))
+	if !ok {
+		return out, nil
+	}
+	newHead, newBody, _ := bytes.Cut(out, []byte("\r\n\r\n"))
+	if len(newBody) == len(oldBody) {
+		return out, nil
+	}
+	newHead = sipContentLength.ReplaceAllFunc(newHead, func(m []byte) []byte {
+		sub := sipContentLength.FindSubmatch(m)
+		if n, _ := strconv.Atoi(string(sub[2])); n != len(oldBody) {
+			return m
+		}
+		return append(append([]byte{}, sub[1]...), strconv.Itoa(len(newBody))...)
+	})
+	return append(append(newHead, "\r\n\r\n"...), newBody...), nil
+}
+
+func checksumAdd(sum uint32, b []byte) uint32 {
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	return sum
+}
+
+func checksumFold(sum uint32) uint16 {
+	for sum>>16 != 0 {
+		sum = sum&0xffff + sum>>16
+	}
+	return ^uint16(sum)
+}
@@ -0,0 +1,130 @@
+package homer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// buildTestPCAP builds a little-endian Ethernet PCAP with one IPv4/UDP packet per payload.
+func buildTestPCAP(t *testing.T, src, dst string, payloads ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	hdr := make([]byte, 24)
+	binary.LittleEndian.PutUint32(hdr[0:4], 0xa1b2c3d4)
+	binary.LittleEndian.PutUint16(hdr[4:6], 2)
+	binary.LittleEndian.PutUint16(hdr[6:8], 4)
+	binary.LittleEndian.PutUint32(hdr[16:20], 65535)
+	binary.LittleEndian.PutUint32(hdr[20:24], 1)
+	buf.Write(hdr)
+
+	for _, payload := range payloads {
+		udp := make([]byte, 8, 8+len(payload))
+		binary.BigEndian.PutUint16(udp[0:2], 5060)
+		binary.BigEndian.PutUint16(udp[2:4], 5060)
+		binary.BigEndian.PutUint16(udp[4:6], uint16(8+len(payload)))
+		udp = append(udp, payload...)
+
+		ip := make([]byte, 20)
+		ip[0] = 0x45
+		binary.BigEndian.PutUint16(ip[2:4], uint16(20+len(udp)))
+		ip[8] = 64
+		ip[9] = 17
+		copy(ip[12:16], net.ParseIP(src).To4())
+		copy(ip[16:20], net.ParseIP(dst).To4())
+
+		pseudo := make([]byte, 12)
+		copy(pseudo[0:8], ip[12:20])
+		pseudo[9] = 17
+		binary.BigEndian.PutUint16(pseudo[10:12], uint16(len(udp)))
+		binary.BigEndian.PutUint16(udp[6:8], checksumFold(checksumAdd(checksumAdd(0, pseudo), udp)))
+		binary.BigEndian.PutUint16(ip[10:12], checksumFold(checksumAdd(0, ip)))
+
+		frame := append(make([]byte, 12), 0x08, 0x00)
+		frame = append(append(frame, ip...), udp...)
+
+		rec := make([]byte, 16)
+		binary.LittleEndian.PutUint32(rec[8:12], uint32(len(frame)))
+		binary.LittleEndian.PutUint32(rec[12:16], uint32(len(frame)))
+		buf.Write(rec)
+		buf.Write(frame)
+	}
+	return buf.Bytes()
+}
+
+func TestAnonymizePCAP(t *testing.T) {
+	body := "v=0\r\nc=IN IP4 10.20.0.99\r\nm=audio 4000 RTP/AVP 0\r\n"
+	invite := "INVITE sip:100@10.1.0.2 SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP 10.1.0.1:5060\r\n" +
+		"Content-Length: " + strconv.Itoa(len(body)) + "\r\n\r\n" + body
+	ok := "SIP/2.0 200 OK\r\nVia: SIP/2.0/UDP 10.1.0.1:5060\r\nContent-Length: 0\r\n\r\n"
+
+	data := buildTestPCAP(t, "10.1.0.1", "10.1.0.2", invite, ok)
+	out, mapping, err := AnonymizePCAP(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []IPMapping{
+		{Original: "10.1.0.1", Placeholder: "198.51.100.1"},
+		{Original: "10.1.0.2", Placeholder: "198.51.100.2"},
+		{Original: "10.20.0.99", Placeholder: "198.51.100.3"},
+	}
+	if len(mapping) != len(want) {
+		t.Fatalf("mapping: got %+v, want %+v", mapping, want)
+	}
+	for i := range want {
+		if mapping[i] != want[i] {
+			t.Errorf("mapping[%d]: got %+v, want %+v", i, mapping[i], want[i])
+		}
+	}
+
+	for _, orig := range []string{"10.1.0.1", "10.1.0.2", "10.20.0.99"} {
+		if bytes.Contains(out, []byte(orig)) {
+			t.Errorf("output still contains %s", orig)
+		}
+	}
+
+	// First record: walk the rewritten packet and check lengths and checksums
+	capLen := int(binary.LittleEndian.Uint32(out[24+8 : 24+12]))
+	frame := out[24+16 : 24+16+capLen]
+	ip := frame[14:]
+	if got := net.IP(ip[12:16]).String(); got != "198.51.100.1" {
+		t.Errorf("src IP: got %s", got)
+	}
+	if got := net.IP(ip[16:20]).String(); got != "198.51.100.2" {
+		t.Errorf("dst IP: got %s", got)
+	}
+	if int(binary.BigEndian.Uint16(ip[2:4])) != len(ip) {
+		t.Errorf("IP total length %d, packet has %d bytes", binary.BigEndian.Uint16(ip[2:4]), len(ip))
+	}
+	if checksumFold(checksumAdd(0, ip[:20])) != 0 {
+		t.Error("IP header checksum is invalid")
+	}
+	udp := ip[20:]
+	pseudo := make([]byte, 12)
+	copy(pseudo[0:8], ip[12:20])
+	pseudo[9] = 17
+	binary.BigEndian.PutUint16(pseudo[10:12], uint16(len(udp)))
+	if checksumFold(checksumAdd(checksumAdd(0, pseudo), udp)) != 0 {
+		t.Error("UDP checksum is invalid")
+	}
+
+	sip := string(udp[8:])
+	newBody := "v=0\r\nc=IN IP4 198.51.100.3\r\nm=audio 4000 RTP/AVP 0\r\n"
+	if !strings.HasSuffix(sip, newBody) || !strings.Contains(sip, "Content-Length: "+strconv.Itoa(len(newBody))+"\r\n") {
+		t.Errorf("SIP payload not rewritten as expected:\n%s", sip)
+	}
+	if !strings.Contains(sip, "Via: SIP/2.0/UDP 198.51.100.1:5060") {
+		t.Errorf("Via not rewritten:\n%s", sip)
+	}
+}
+
+func TestAnonymizePCAPRejectsNonPCAP(t *testing.T) {
+	if _, _, err := AnonymizePCAP([]byte("this is not a capture file at all")); err == nil {
+		t.Error("expected an error for non-PCAP input")
+	}
+}
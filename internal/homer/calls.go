@@ -33,7 +33,7 @@ func GroupCalls(records []CallRecord, number string) []CallSummary {
 	var summaries []CallSummary
 	for callID, msgs := range groups {
 		// Sort messages by time
-		sort.Slice(msgs, func(i, j int) bool {
+		sort.SliceStable(msgs, func(i, j int) bool {
 			return msgs[i].Date < msgs[j].Date
 		})
 
@@ -78,9 +78,13 @@ func GroupCalls(records []CallRecord, number string) []CallSummary {
 		summaries = append(summaries, cs)
 	}
 
-	// Sort by start time descending (newest first)
+	// Sort by start time descending (newest first); Call-ID breaks ties so the
+	// order doesn't depend on map iteration
 	sort.Slice(summaries, func(i, j int) bool {
-		return summaries[i].StartTime.After(summaries[j].StartTime)
+		if !summaries[i].StartTime.Equal(summaries[j].StartTime) {
+			return summaries[i].StartTime.After(summaries[j].StartTime)
+		}
+		return summaries[i].CallID < summaries[j].CallID
 	})
 
 	return summaries
@@ -263,3 +267,76 @@ func deriveStatus(msgs []CallRecord) string {
 		return ""
 	}
 }
+
+// Active reports whether the call looks still in progress: it has an INVITE,
+// no BYE or CANCEL, and no final error response (3xx-6xx). This is a heuristic
+// over the fetched messages only; a BYE outside the search window is missed.
+func (cs CallSummary) Active() bool {
+	invite := false
+	for _, m := range cs.Messages {
+		switch m.Method {
+		case "INVITE":
+			invite = true
+		case "BYE", "CANCEL":
+			return false
+		}
+	}
+	if !invite {
+		return false
+	}
+	return cs.Status == "answered" || cs.Status == "ringing" || cs.Status == ""
+}
+
+// FilterActiveCalls keeps only the calls for which Active reports true.
+func FilterActiveCalls(calls []CallSummary) []CallSummary {
+	var out []CallSummary
+	for _, c := range calls {
+		if c.Active() {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// FilterCallsByDuration keeps the calls whose duration lies within [min, max];
+// a zero bound is not applied. With a minimum set, calls with at most one
+// message are dropped since they have no reliable end time; their number is
+// returned as noEnd.
+func FilterCallsByDuration(calls []CallSummary, min, max time.Duration) (out []CallSummary, noEnd int) {
+	for _, c := range calls {
+		if min > 0 {
+			if c.MsgCount <= 1 {
+				noEnd++
+				continue
+			}
+			if c.Duration < min {
+				continue
+			}
+		}
+		if max > 0 && c.Duration > max {
+			continue
+		}
+		out = append(out, c)
+	}
+	return out, noEnd
+}
+
+// DedupByCallID keeps one record per Call-ID: the earliest one, or the most
+// recent one if latest is set. Kept records stay in their original order.
+func DedupByCallID(records []SearchRecord, latest bool) []SearchRecord {
+	keep := make(map[string]int, len(records))
+	for i, r := range records {
+		j, seen := keep[r.CallID]
+		if !seen || (latest && r.Date.After(records[j].Date)) || (!latest && r.Date.Before(records[j].Date)) {
+			keep[r.CallID] = i
+		}
+	}
+
+	out := make([]SearchRecord, 0, len(keep))
+	for i, r := range records {
+		if keep[r.CallID] == i {
+			out = append(out, r)
+		}
+	}
+	return out
+}
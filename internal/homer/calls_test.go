@@ -0,0 +1,123 @@
+package homer
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGroupCallsStableOrder(t *testing.T) {
+	t0 := time.Date(2026, 2, 4, 17, 13, 0, 0, time.UTC).UnixMilli()
+	records := []CallRecord{
+		{CallID: "c", Date: t0, Method: "INVITE"},
+		{CallID: "a", Date: t0, Method: "INVITE"},
+		{CallID: "late", Date: t0 + 1000, Method: "INVITE"},
+		{CallID: "b", Date: t0, Method: "INVITE"},
+	}
+
+	want := []string{"late", "a", "b", "c"}
+	for run := 0; run < 20; run++ {
+		calls := GroupCalls(records, "")
+		if len(calls) != len(want) {
+			t.Fatalf("got %d calls, want %d", len(calls), len(want))
+		}
+		for i, c := range calls {
+			if c.CallID != want[i] {
+				t.Fatalf("run %d: calls[%d] = %q, want %q", run, i, c.CallID, want[i])
+			}
+		}
+	}
+}
+
+func TestDedupByCallID(t *testing.T) {
+	t0 := time.Date(2026, 2, 4, 17, 13, 0, 0, time.UTC)
+	records := []SearchRecord{
+		{CallID: "a", Date: t0.Add(2 * time.Second), Method: "BYE"},
+		{CallID: "b", Date: t0.Add(1 * time.Second), Method: "INVITE"},
+		{CallID: "a", Date: t0, Method: "INVITE"},
+		{CallID: "a", Date: t0.Add(1 * time.Second), Method: "200"},
+		{CallID: "b", Date: t0.Add(3 * time.Second), Method: "CANCEL"},
+	}
+
+	for _, tc := range []struct {
+		latest bool
+		want   []string
+	}{
+		{false, []string{"b/INVITE", "a/INVITE"}},
+		{true, []string{"a/BYE", "b/CANCEL"}},
+	} {
+		var got []string
+		for _, r := range DedupByCallID(records, tc.latest) {
+			got = append(got, r.CallID+"/"+r.Method)
+		}
+		if strings.Join(got, " ") != strings.Join(tc.want, " ") {
+			t.Errorf("latest=%v: got %v, want %v", tc.latest, got, tc.want)
+		}
+	}
+}
+
+func TestFilterActiveCalls(t *testing.T) {
+	t0 := time.Date(2026, 2, 4, 17, 13, 0, 0, time.UTC).UnixMilli()
+	records := []CallRecord{
+		// answered, never hung up -> active
+		{CallID: "up", Date: t0, Method: "INVITE"},
+		{CallID: "up", Date: t0 + 100, Method: "200"},
+		{CallID: "up", Date: t0 + 200, Method: "ACK"},
+		// answered and hung up
+		{CallID: "done", Date: t0, Method: "INVITE"},
+		{CallID: "done", Date: t0 + 100, Method: "200"},
+		{CallID: "done", Date: t0 + 5000, Method: "BYE"},
+		// still ringing -> active
+		{CallID: "ring", Date: t0, Method: "INVITE"},
+		{CallID: "ring", Date: t0 + 100, Method: "180"},
+		// rejected busy
+		{CallID: "busy", Date: t0, Method: "INVITE"},
+		{CallID: "busy", Date: t0 + 100, Method: "486"},
+		// cancelled by the caller
+		{CallID: "cancel", Date: t0, Method: "INVITE"},
+		{CallID: "cancel", Date: t0 + 100, Method: "CANCEL"},
+		// no INVITE in the window (only a BYE leg or an OPTIONS ping)
+		{CallID: "options", Date: t0, Method: "OPTIONS"},
+		{CallID: "options", Date: t0 + 10, Method: "200"},
+	}
+
+	active := FilterActiveCalls(GroupCalls(records, ""))
+	var got []string
+	for _, c := range active {
+		got = append(got, c.CallID)
+	}
+	if strings.Join(got, ",") != "ring,up" {
+		t.Errorf("active calls = %v, want [ring up]", got)
+	}
+}
+
+func TestFilterCallsByDuration(t *testing.T) {
+	calls := []CallSummary{
+		{CallID: "short", Duration: 2 * time.Second, MsgCount: 4},
+		{CallID: "long", Duration: 2 * time.Hour, MsgCount: 6},
+		{CallID: "normal", Duration: 3 * time.Minute, MsgCount: 7},
+		{CallID: "single", MsgCount: 1},
+	}
+	ids := func(cs []CallSummary) string {
+		var out []string
+		for _, c := range cs {
+			out = append(out, c.CallID)
+		}
+		return strings.Join(out, ",")
+	}
+
+	got, noEnd := FilterCallsByDuration(calls, 10*time.Second, 0)
+	if ids(got) != "long,normal" || noEnd != 1 {
+		t.Errorf("min 10s: got %s (noEnd %d), want long,normal (noEnd 1)", ids(got), noEnd)
+	}
+
+	got, noEnd = FilterCallsByDuration(calls, 0, 5*time.Second)
+	if ids(got) != "short,single" || noEnd != 0 {
+		t.Errorf("max 5s: got %s (noEnd %d), want short,single (noEnd 0)", ids(got), noEnd)
+	}
+
+	got, _ = FilterCallsByDuration(calls, time.Minute, time.Hour)
+	if ids(got) != "normal" {
+		t.Errorf("1m..1h: got %s, want normal", ids(got))
+	}
+}
@@ -17,6 +17,14 @@ type Client struct {
 	token      string
 	httpClient *http.Client
 	Debug      bool
+
+	// Sessions, when set, lets Authenticate reuse a cached token instead of
+	// logging in, and stores the token of every fresh login
+	Sessions *SessionCache
+
+	username  string
+	password  string
+	fromCache bool
 }
 
 // SearchParams holds search query parameters for Homer API calls
@@ -201,8 +209,28 @@ func NewClient(baseURL string) *Client {
 	}
 }
 
-// Authenticate logs in to Homer and stores the JWT token
+// Authenticate logs in to Homer and stores the JWT token. With a session
+// cache, a non-expired cached token is used instead and the credentials are
+// kept for a fresh login should Homer reject it.
 func (c *Client) Authenticate(username, password string) error {
+	c.username, c.password = username, password
+	if c.Sessions != nil {
+		if token, ok := c.Sessions.Get(c.baseURL, username); ok {
+			if c.Debug {
+				fmt.Fprintf(os.Stderr, "\n[DEBUG] reusing cached Homer session for %s\n", username)
+			}
+			c.token = token
+			c.fromCache = true
+			return nil
+		}
+	}
+	return c.login()
+}
+
+// login performs a fresh login with the stored credentials and refreshes
+// the session cache
+func (c *Client) login() error {
+	username, password := c.username, c.password
 	payload := map[string]string{
 		"username": username,
 		"password": password,
@@ -238,6 +266,12 @@ func (c *Client) Authenticate(username, password string) error {
 	}
 
 	c.token = authResp.Token
+	c.fromCache = false
+	if c.Sessions != nil {
+		if err := c.Sessions.Put(c.baseURL, username, c.token); err != nil && c.Debug {
+			fmt.Fprintf(os.Stderr, "\n[DEBUG] failed to cache Homer session: %v\n", err)
+		}
+	}
 	return nil
 }
 
@@ -349,9 +383,11 @@ func (c *Client) GetQoS(params SearchParams, searchData []CallRecord) (*QoSResul
 	return &result, nil
 }
 
-// ExportPCAP exports call messages as a PCAP file
-func (c *Client) ExportPCAP(params SearchParams) ([]byte, error) {
-	reqBody := c.buildSearchPayload(params)
+// ExportPCAP exports call messages as a PCAP file. Like GetTransaction it takes
+// search results (IDs + callIDs), so records merged from several Call-IDs end
+// up in a single capture.
+func (c *Client) ExportPCAP(params SearchParams, searchData []CallRecord) ([]byte, error) {
+	reqBody := buildTransactionPayload(params, searchData)
 
 	body, err := c.doAuthRequest("POST", "/api/v3/export/call/messages/pcap", reqBody)
 	if err != nil {
@@ -451,11 +487,13 @@ func (c *Client) buildSearchPayload(params SearchParams) map[string]any {
 	}
 }
 
-// doAuthRequest makes an authenticated HTTP request to the Homer API
+// doAuthRequest makes an authenticated HTTP request to the Homer API. If a
+// cached token is rejected with 401, it logs in again and retries once.
 func (c *Client) doAuthRequest(method, path string, payload any) ([]byte, error) {
-	var bodyReader io.Reader
+	var data []byte
 	if payload != nil {
-		data, err := json.Marshal(payload)
+		var err error
+		data, err = json.Marshal(payload)
 		if err != nil {
 			return nil, fmt.Errorf("failed to marshal request: %w", err)
 		}
@@ -464,14 +502,42 @@ func (c *Client) doAuthRequest(method, path string, payload any) ([]byte, error)
 			json.Indent(&pretty, data, "", "  ")
 			fmt.Fprintf(os.Stderr, "\n[DEBUG] %s %s%s\n%s\n\n", method, c.baseURL, path, pretty.String())
 		}
-		bodyReader = bytes.NewReader(data)
 	} else if c.Debug {
 		fmt.Fprintf(os.Stderr, "\n[DEBUG] %s %s%s\n\n", method, c.baseURL, path)
 	}
 
+	status, body, err := c.sendAuthRequest(method, path, data)
+	if err == nil && status == http.StatusUnauthorized && c.fromCache {
+		if c.Debug {
+			fmt.Fprintf(os.Stderr, "\n[DEBUG] cached Homer session rejected, logging in again\n")
+		}
+		if err := c.login(); err != nil {
+			return nil, err
+		}
+		status, body, err = c.sendAuthRequest(method, path, data)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if status != http.StatusOK && status != http.StatusCreated {
+		return nil, fmt.Errorf("homer returned status %d: %s", status, string(body))
+	}
+
+	return body, nil
+}
+
+// sendAuthRequest sends one request with the current token and returns the
+// status code and body
+func (c *Client) sendAuthRequest(method, path string, data []byte) (int, []byte, error) {
+	var bodyReader io.Reader
+	if data != nil {
+		bodyReader = bytes.NewReader(data)
+	}
+
 	req, err := http.NewRequest(method, c.baseURL+path, bodyReader)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return 0, nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", "application/json")
@@ -481,18 +547,14 @@ func (c *Client) doAuthRequest(method, path string, payload any) ([]byte, error)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("request failed: %w", err)
+		return 0, nil, fmt.Errorf("request failed: %w", err)
 	}
 	defer resp.Body.Close()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+		return 0, nil, fmt.Errorf("failed to read response: %w", err)
 	}
 
-	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
-		return nil, fmt.Errorf("homer returned status %d: %s", resp.StatusCode, string(body))
-	}
-
-	return body, nil
+	return resp.StatusCode, body, nil
 }
@@ -0,0 +1,125 @@
+package homer
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strings"
+)
+
+// Call directions inferred by InternalMatcher
+const (
+	DirectionInbound  = "inbound"
+	DirectionOutbound = "outbound"
+	DirectionInternal = "internal"
+)
+
+// InternalMatcher decides which side of a call belongs to the platform. It is
+// built from the homer.internal_patterns config: IPs and CIDRs match the
+// signalling addresses of the first INVITE, everything else is a number
+// pattern matched against caller and callee.
+type InternalMatcher struct {
+	numbers  []*regexp.Regexp
+	prefixes []netip.Prefix
+}
+
+// NewInternalMatcher parses internal patterns. A pattern is an IP address, a
+// CIDR, or a number pattern where "%" matches any run of characters; a leading
+// "+" is ignored on both the pattern and the number.
+func NewInternalMatcher(patterns []string) (*InternalMatcher, error) {
+	m := &InternalMatcher{}
+	for _, p := range patterns {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if prefix, err := netip.ParsePrefix(p); err == nil {
+			m.prefixes = append(m.prefixes, prefix.Masked())
+			continue
+		}
+		if addr, err := netip.ParseAddr(p); err == nil {
+			m.prefixes = append(m.prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+			continue
+		}
+		if strings.ContainsAny(p, "/:") {
+			return nil, fmt.Errorf("invalid internal pattern %q: not an IP, CIDR or number pattern", p)
+		}
+		parts := strings.Split(strings.TrimPrefix(p, "+"), "%")
+		for i := range parts {
+			parts[i] = regexp.QuoteMeta(parts[i])
+		}
+		m.numbers = append(m.numbers, regexp.MustCompile("^"+strings.Join(parts, ".*")+"$"))
+	}
+	return m, nil
+}
+
+// Empty reports whether no patterns are configured
+func (m *InternalMatcher) Empty() bool {
+	return len(m.numbers) == 0 && len(m.prefixes) == 0
+}
+
+// Direction infers the direction of a call: inbound when only the callee side
+// is internal, outbound when only the caller side is, internal when both are,
+// and "" when neither is. A side is internal if its number matches a number
+// pattern or, for the first INVITE, its source (caller) or destination
+// (callee) IP is in an internal range.
+func (m *InternalMatcher) Direction(cs CallSummary) string {
+	var srcIP, dstIP string
+	for _, msg := range cs.Messages {
+		if msg.Method == "INVITE" {
+			srcIP, dstIP = msg.SourceIP, msg.DestIP
+			break
+		}
+	}
+
+	callerInternal := m.matchNumber(cs.Caller) || m.matchIP(srcIP)
+	calleeInternal := m.matchNumber(cs.Callee) || m.matchIP(dstIP)
+	switch {
+	case callerInternal && calleeInternal:
+		return DirectionInternal
+	case callerInternal:
+		return DirectionOutbound
+	case calleeInternal:
+		return DirectionInbound
+	default:
+		return ""
+	}
+}
+
+func (m *InternalMatcher) matchNumber(number string) bool {
+	number = strings.TrimPrefix(number, "+")
+	if number == "" {
+		return false
+	}
+	for _, re := range m.numbers {
+		if re.MatchString(number) {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *InternalMatcher) matchIP(ip string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, p := range m.prefixes {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterCallsByDirection keeps the calls whose inferred direction is dir
+func (m *InternalMatcher) FilterCallsByDirection(calls []CallSummary, dir string) []CallSummary {
+	var out []CallSummary
+	for _, c := range calls {
+		if m.Direction(c) == dir {
+			out = append(out, c)
+		}
+	}
+	return out
+}
@@ -0,0 +1,59 @@
+package homer
+
+import "testing"
+
+func TestInternalMatcherDirection(t *testing.T) {
+	m, err := NewInternalMatcher([]string{"+4930555%", "10.20.0.0/16", "192.0.2.7"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	call := func(caller, callee, src, dst string) CallSummary {
+		return CallSummary{
+			Caller: caller,
+			Callee: callee,
+			Messages: []CallRecord{
+				{Method: "OPTIONS", SourceIP: "10.20.1.1", DestIP: "10.20.1.2"},
+				{Method: "INVITE", SourceIP: src, DestIP: dst},
+			},
+		}
+	}
+
+	tests := []struct {
+		name string
+		cs   CallSummary
+		want string
+	}{
+		{"customer calls platform number", call("4917012345", "+49305551000", "203.0.113.5", "198.51.100.1"), DirectionInbound},
+		{"platform calls customer", call("49305551000", "4917012345", "203.0.113.5", "198.51.100.1"), DirectionOutbound},
+		{"INVITE from internal SBC", call("anonymous", "4917012345", "10.20.3.4", "198.51.100.1"), DirectionOutbound},
+		{"INVITE to single internal IP", call("4917012345", "100", "203.0.113.5", "192.0.2.7"), DirectionInbound},
+		{"both sides internal", call("49305551000", "49305552000", "10.20.3.4", "10.20.3.5"), DirectionInternal},
+		{"neither side internal", call("4917012345", "4917099999", "203.0.113.5", "198.51.100.1"), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Direction(tt.cs); got != tt.want {
+				t.Errorf("Direction() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	calls := []CallSummary{tests[0].cs, tests[1].cs, tests[3].cs}
+	if got := m.FilterCallsByDirection(calls, DirectionInbound); len(got) != 2 {
+		t.Errorf("FilterCallsByDirection(inbound) kept %d calls, want 2", len(got))
+	}
+}
+
+func TestNewInternalMatcherInvalid(t *testing.T) {
+	if _, err := NewInternalMatcher([]string{"10.0.0.0/33"}); err == nil {
+		t.Error("expected error for invalid CIDR")
+	}
+	m, err := NewInternalMatcher([]string{" ", ""})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !m.Empty() {
+		t.Error("blank patterns should leave the matcher empty")
+	}
+}
@@ -0,0 +1,24 @@
+package homer
+
+import "time"
+
+// FlowArrow is one SIP message in the ladder of correlated call legs. Endpoint
+// indexes refer to CallFlow.Endpoints, left to right.
+type FlowArrow struct {
+	Timestamp        time.Time `json:"timestamp"`
+	OffsetMS         int64     `json:"offset_ms"` // since the start of the first leg
+	SrcEndpointIndex int       `json:"src_endpoint_index"`
+	DstEndpointIndex int       `json:"dst_endpoint_index"`
+	Method           string    `json:"method"` // request method or response code
+	LegNumber        int       `json:"leg_number"`
+	CallID           string    `json:"call_id"`
+	SDPMedia         string    `json:"sdp_media,omitempty"` // codec and port when the message carries SDP
+}
+
+// CallFlow is the structured form of a correlated call: its legs, the
+// endpoints of the ladder in display order, and the message arrows between them
+type CallFlow struct {
+	Legs      []CallSummary `json:"legs"`
+	Endpoints []string      `json:"endpoints"`
+	Arrows    []FlowArrow   `json:"arrows"`
+}
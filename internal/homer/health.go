@@ -0,0 +1,54 @@
+package homer
+
+import (
+	"fmt"
+	"time"
+)
+
+// RetentionProbeDays are the data ages, in days, checked by ProbeRetention
+var RetentionProbeDays = []int{0, 1, 3, 7, 10, 14, 30, 60, 90}
+
+// Retention is the outcome of a retention probe
+type Retention struct {
+	Days    int       `json:"days"`            // oldest probed age that still has data, -1 when no probe found any
+	Oldest  time.Time `json:"oldest,omitzero"` // oldest message seen by the probe
+	MaxDays int       `json:"max_probed_days"` // deepest age probed
+}
+
+// ProbeRetention estimates how far back Homer still holds SIP data. For each
+// age in RetentionProbeDays it searches the day before that age for a single
+// message; the deepest age with a hit is the effective retention.
+func (c *Client) ProbeRetention(now time.Time) (*Retention, error) {
+	return probeRetention(now, RetentionProbeDays, func(from, to time.Time) ([]CallRecord, error) {
+		result, err := c.SearchCalls(SearchParams{From: from, To: to, Limit: 1})
+		if err != nil {
+			return nil, err
+		}
+		return result.Data, nil
+	})
+}
+
+// probeRetention runs the retention probe against the given search function
+func probeRetention(now time.Time, days []int, search func(from, to time.Time) ([]CallRecord, error)) (*Retention, error) {
+	r := &Retention{Days: -1}
+	for _, d := range days {
+		to := now.Add(-time.Duration(d) * 24 * time.Hour)
+		from := to.Add(-24 * time.Hour)
+		records, err := search(from, to)
+		if err != nil {
+			return nil, fmt.Errorf("retention probe at %d days failed: %w", d, err)
+		}
+		r.MaxDays = d
+		if len(records) == 0 {
+			continue
+		}
+		r.Days = d
+		for _, rec := range records {
+			ts := time.UnixMilli(rec.Date)
+			if r.Oldest.IsZero() || ts.Before(r.Oldest) {
+				r.Oldest = ts
+			}
+		}
+	}
+	return r, nil
+}
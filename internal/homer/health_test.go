@@ -0,0 +1,59 @@
+package homer
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestProbeRetention(t *testing.T) {
+	now := time.Date(2026, 2, 20, 12, 0, 0, 0, time.UTC)
+	oldest := now.Add(-8 * 24 * time.Hour)
+
+	// Data exists for the last 8.x days only.
+	search := func(from, to time.Time) ([]CallRecord, error) {
+		if to.Before(oldest) {
+			return nil, nil
+		}
+		ts := from
+		if ts.Before(oldest) {
+			ts = oldest
+		}
+		return []CallRecord{{Date: ts.UnixMilli()}}, nil
+	}
+
+	r, err := probeRetention(now, []int{0, 1, 3, 7, 10, 14}, search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Days != 7 {
+		t.Errorf("Days = %d, want 7", r.Days)
+	}
+	if r.MaxDays != 14 {
+		t.Errorf("MaxDays = %d, want 14", r.MaxDays)
+	}
+	if !r.Oldest.Equal(oldest) {
+		t.Errorf("Oldest = %v, want %v", r.Oldest, oldest)
+	}
+}
+
+func TestProbeRetentionNoData(t *testing.T) {
+	r, err := probeRetention(time.Now(), []int{0, 1}, func(from, to time.Time) ([]CallRecord, error) {
+		return nil, nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.Days != -1 || !r.Oldest.IsZero() {
+		t.Errorf("got %+v, want no retention", r)
+	}
+}
+
+func TestProbeRetentionError(t *testing.T) {
+	_, err := probeRetention(time.Now(), []int{0, 1}, func(from, to time.Time) ([]CallRecord, error) {
+		return nil, errors.New("boom")
+	})
+	if err == nil {
+		t.Fatal("expected error")
+	}
+}
@@ -0,0 +1,76 @@
+package homer
+
+import (
+	"sort"
+	"strings"
+)
+
+// LegNode is a call leg in a leg tree, with the legs it spawned as children.
+type LegNode struct {
+	Leg      CallSummary `json:"leg"`
+	SrcIP    string      `json:"src_ip,omitempty"` // source of the leg's first INVITE
+	DstIP    string      `json:"dst_ip,omitempty"` // destination of the leg's first INVITE
+	Children []*LegNode  `json:"children,omitempty"`
+}
+
+// BuildLegTree arranges correlated legs into a tree by following the INVITE chain:
+// a leg is a child of the most recent earlier leg whose first INVITE was sent to
+// the host that originated this leg's first INVITE (the B2BUA/PBX that received
+// the parent INVITE placed the child call). The seed leg is always a root; legs
+// without a parent (or without an INVITE) become further roots after it.
+func BuildLegTree(legs []CallSummary, msgs []TransactionMessage, seedCallID string) []*LegNode {
+	// First INVITE per Call-ID
+	firstInvite := make(map[string]TransactionMessage)
+	for _, m := range msgs {
+		if !m.IsSIP() || !strings.HasPrefix(m.Raw, "INVITE ") {
+			continue
+		}
+		if prev, ok := firstInvite[m.CallID]; !ok || m.CreateDate < prev.CreateDate {
+			firstInvite[m.CallID] = m
+		}
+	}
+
+	nodes := make([]*LegNode, len(legs))
+	for i, l := range legs {
+		nodes[i] = &LegNode{Leg: l}
+		if inv, ok := firstInvite[l.CallID]; ok {
+			nodes[i].SrcIP = inv.SrcIP
+			nodes[i].DstIP = inv.DstIP
+		}
+	}
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return legStartMS(nodes[i], firstInvite) < legStartMS(nodes[j], firstInvite)
+	})
+
+	var roots []*LegNode
+	for i, n := range nodes {
+		var parent *LegNode
+		if n.Leg.CallID != seedCallID && n.SrcIP != "" {
+			for j := i - 1; j >= 0; j-- {
+				if nodes[j].DstIP == n.SrcIP {
+					parent = nodes[j]
+					break
+				}
+			}
+		}
+		if parent != nil {
+			parent.Children = append(parent.Children, n)
+			continue
+		}
+		if n.Leg.CallID == seedCallID {
+			roots = append([]*LegNode{n}, roots...)
+		} else {
+			roots = append(roots, n)
+		}
+	}
+	return roots
+}
+
+// legStartMS returns the time of the leg's first INVITE in epoch milliseconds,
+// falling back to the leg's start time.
+func legStartMS(n *LegNode, firstInvite map[string]TransactionMessage) int64 {
+	if inv, ok := firstInvite[n.Leg.CallID]; ok {
+		return inv.CreateDate
+	}
+	return n.Leg.StartTime.UnixMilli()
+}
@@ -0,0 +1,72 @@
+package homer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildLegTree(t *testing.T) {
+	t0 := time.Date(2026, 2, 4, 17, 13, 0, 0, time.UTC)
+	invite := func(callID, src, dst string, offset time.Duration) TransactionMessage {
+		return TransactionMessage{
+			CallID:     callID,
+			SrcIP:      src,
+			DstIP:      dst,
+			CreateDate: t0.Add(offset).UnixMilli(),
+			Raw:        "INVITE sip:123@" + dst + " SIP/2.0\r\n",
+		}
+	}
+
+	// carrier → pbx (seed), pbx forks to two phones, phone A then
+	// places an attended-transfer consult call back through the pbx.
+	legs := []CallSummary{
+		{CallID: "consult", StartTime: t0.Add(30 * time.Second)},
+		{CallID: "seed", StartTime: t0},
+		{CallID: "fork-a", StartTime: t0.Add(time.Second)},
+		{CallID: "fork-b", StartTime: t0.Add(time.Second)},
+		{CallID: "unrelated", StartTime: t0.Add(2 * time.Second)},
+	}
+	msgs := []TransactionMessage{
+		invite("seed", "1.1.1.1", "10.0.0.1", 0),
+		{CallID: "seed", SrcIP: "10.0.0.1", DstIP: "1.1.1.1", CreateDate: t0.Add(10 * time.Millisecond).UnixMilli(), Raw: "SIP/2.0 100 Trying\r\n"},
+		invite("fork-a", "10.0.0.1", "10.0.0.10", time.Second),
+		invite("fork-b", "10.0.0.1", "10.0.0.11", time.Second+5*time.Millisecond),
+		invite("consult", "10.0.0.10", "10.0.0.1", 30*time.Second),
+		invite("unrelated", "9.9.9.9", "8.8.8.8", 2*time.Second),
+	}
+
+	roots := BuildLegTree(legs, msgs, "seed")
+
+	if len(roots) != 2 {
+		t.Fatalf("got %d roots, want 2", len(roots))
+	}
+	if roots[0].Leg.CallID != "seed" {
+		t.Errorf("first root = %s, want seed", roots[0].Leg.CallID)
+	}
+	if roots[1].Leg.CallID != "unrelated" {
+		t.Errorf("second root = %s, want unrelated", roots[1].Leg.CallID)
+	}
+
+	seed := roots[0]
+	if len(seed.Children) != 2 {
+		t.Fatalf("seed has %d children, want 2", len(seed.Children))
+	}
+	if seed.Children[0].Leg.CallID != "fork-a" || seed.Children[1].Leg.CallID != "fork-b" {
+		t.Errorf("seed children = %s, %s; want fork-a, fork-b", seed.Children[0].Leg.CallID, seed.Children[1].Leg.CallID)
+	}
+	forkA := seed.Children[0]
+	if len(forkA.Children) != 1 || forkA.Children[0].Leg.CallID != "consult" {
+		t.Errorf("fork-a should have consult as only child, got %d children", len(forkA.Children))
+	}
+	if forkA.SrcIP != "10.0.0.1" || forkA.DstIP != "10.0.0.10" {
+		t.Errorf("fork-a route = %s → %s", forkA.SrcIP, forkA.DstIP)
+	}
+}
+
+func TestBuildLegTreeNoInvite(t *testing.T) {
+	legs := []CallSummary{{CallID: "a"}, {CallID: "b"}}
+	roots := BuildLegTree(legs, nil, "b")
+	if len(roots) != 2 || roots[0].Leg.CallID != "b" {
+		t.Fatalf("expected seed b first among 2 roots, got %+v", roots)
+	}
+}
@@ -230,9 +230,22 @@ func AggregateStreams(qos *QoSResult, clockRate int, defaultLatencyMS float64) [
 		})
 	}
 
-	// Sort by first report time
+	// Sort by first report time, then by stream addresses for a stable order
 	sort.Slice(result, func(i, j int) bool {
-		return result[i].FirstReport.Before(result[j].FirstReport)
+		a, b := result[i], result[j]
+		if !a.FirstReport.Equal(b.FirstReport) {
+			return a.FirstReport.Before(b.FirstReport)
+		}
+		if a.SrcIP != b.SrcIP {
+			return a.SrcIP < b.SrcIP
+		}
+		if a.SrcPort != b.SrcPort {
+			return a.SrcPort < b.SrcPort
+		}
+		if a.DstIP != b.DstIP {
+			return a.DstIP < b.DstIP
+		}
+		return a.DstPort < b.DstPort
 	})
 
 	return result
@@ -20,6 +20,12 @@ var queryFields = map[string]string{
 	"sid":        "sid",
 }
 
+// numericFields are the Homer columns that accept range operators and
+// status class wildcards (e.g. 4xx).
+var numericFields = map[string]bool{
+	"status": true,
+}
+
 // tokenType represents the type of a lexer token.
 type tokenType int
 
@@ -27,13 +33,17 @@ const (
 	tokIdent  tokenType = iota // identifier (field name)
 	tokString                  // single-quoted string
 	tokNumber                  // numeric literal
-	tokEq                     // =
-	tokNeq                    // !=
-	tokLParen                 // (
-	tokRParen                 // )
-	tokAnd                    // AND
-	tokOr                     // OR
-	tokEOF                    // end of input
+	tokEq                      // =
+	tokNeq                     // !=
+	tokGt                      // >
+	tokGte                     // >=
+	tokLt                      // <
+	tokLte                     // <=
+	tokLParen                  // (
+	tokRParen                  // )
+	tokAnd                     // AND
+	tokOr                      // OR
+	tokEOF                     // end of input
 )
 
 type token struct {
@@ -61,12 +71,24 @@ func tokenize(input string) ([]token, error) {
 		case input[i] == ')':
 			tokens = append(tokens, token{tokRParen, ")", i})
 			i++
-		case input[i] == '=' :
+		case input[i] == '=':
 			tokens = append(tokens, token{tokEq, "=", i})
 			i++
 		case input[i] == '!' && i+1 < len(input) && input[i+1] == '=':
 			tokens = append(tokens, token{tokNeq, "!=", i})
 			i += 2
+		case input[i] == '>' && i+1 < len(input) && input[i+1] == '=':
+			tokens = append(tokens, token{tokGte, ">=", i})
+			i += 2
+		case input[i] == '>':
+			tokens = append(tokens, token{tokGt, ">", i})
+			i++
+		case input[i] == '<' && i+1 < len(input) && input[i+1] == '=':
+			tokens = append(tokens, token{tokLte, "<=", i})
+			i += 2
+		case input[i] == '<':
+			tokens = append(tokens, token{tokLt, "<", i})
+			i++
 		case input[i] == '\'':
 			// Quoted string
 			start := i
@@ -86,6 +108,10 @@ func tokenize(input string) ([]token, error) {
 			for i < len(input) && input[i] >= '0' && input[i] <= '9' {
 				i++
 			}
+			// Status class wildcard such as 4xx
+			for i < len(input) && (input[i] == 'x' || input[i] == 'X') {
+				i++
+			}
 			tokens = append(tokens, token{tokNumber, input[start:i], start})
 		case isIdentStart(input[i]):
 			start := i
@@ -122,7 +148,7 @@ func isIdentChar(c byte) bool {
 type condition struct {
 	// leaf
 	field string // mapped Homer field name
-	op    string // "=" or "!="
+	op    string // "=", "!=", ">", ">=", "<" or "<="
 	value string // literal value (string or number)
 	isNum bool   // true if value is numeric
 
@@ -234,8 +260,16 @@ func (p *parser) parseCondition() (*condition, error) {
 
 	// Operator
 	opTok := p.peek()
-	if opTok.typ != tokEq && opTok.typ != tokNeq {
-		return nil, fmt.Errorf("expected operator (= or !=) at position %d, got %q", opTok.pos, opTok.val)
+	isRange := false
+	switch opTok.typ {
+	case tokEq, tokNeq:
+	case tokGt, tokGte, tokLt, tokLte:
+		isRange = true
+	default:
+		return nil, fmt.Errorf("expected operator (=, !=, >, >=, <, <=) at position %d, got %q", opTok.pos, opTok.val)
+	}
+	if isRange && !numericFields[mapped] {
+		return nil, fmt.Errorf("operator %s at position %d is only supported on numeric fields (status), not %s", opTok.val, opTok.pos, fieldTok.val)
 	}
 	p.advance()
 
@@ -244,8 +278,15 @@ func (p *parser) parseCondition() (*condition, error) {
 	if valTok.typ != tokString && valTok.typ != tokNumber {
 		return nil, fmt.Errorf("expected value (string or number) at position %d, got %q", valTok.pos, valTok.val)
 	}
+	if isRange && valTok.typ != tokNumber {
+		return nil, fmt.Errorf("operator %s at position %d needs a number, got '%s'", opTok.val, opTok.pos, valTok.val)
+	}
 	p.advance()
 
+	if valTok.typ == tokNumber && strings.ContainsAny(valTok.val, "xX") {
+		return statusClassCondition(mapped, fieldTok, opTok, valTok)
+	}
+
 	return &condition{
 		field: mapped,
 		op:    opTok.val,
@@ -254,6 +295,35 @@ func (p *parser) parseCondition() (*condition, error) {
 	}, nil
 }
 
+// statusClassCondition expands a status class wildcard such as 4xx into a
+// range: "= 4xx" becomes ">= 400 AND < 500", "!= 4xx" becomes "< 400 OR >= 500".
+func statusClassCondition(mapped string, fieldTok, opTok, valTok token) (*condition, error) {
+	v := strings.ToLower(valTok.val)
+	if len(v) != 3 || v[0] < '1' || v[0] > '6' || v[1:] != "xx" {
+		return nil, fmt.Errorf("invalid status class %q at position %d (expected 1xx-6xx)", valTok.val, valTok.pos)
+	}
+	if !numericFields[mapped] {
+		return nil, fmt.Errorf("status class %q at position %d is only supported on status, not %s", valTok.val, valTok.pos, fieldTok.val)
+	}
+	if opTok.typ != tokEq && opTok.typ != tokNeq {
+		return nil, fmt.Errorf("status class %q at position %d only works with = or !=", valTok.val, valTok.pos)
+	}
+
+	lo := int(v[0]-'0') * 100
+	low := fmt.Sprintf("%d", lo)
+	high := fmt.Sprintf("%d", lo+100)
+	if opTok.typ == tokEq {
+		return &condition{logic: "AND", children: []*condition{
+			{field: mapped, op: ">=", value: low, isNum: true},
+			{field: mapped, op: "<", value: high, isNum: true},
+		}}, nil
+	}
+	return &condition{logic: "OR", children: []*condition{
+		{field: mapped, op: "<", value: low, isNum: true},
+		{field: mapped, op: ">=", value: high, isNum: true},
+	}}, nil
+}
+
 // ParseQuery parses a user query string and returns the Homer smart input equivalent.
 // Field names are validated and mapped to Homer's internal column names.
 // Returns an error for unknown fields or invalid syntax.
@@ -295,6 +365,14 @@ func tokenName(t tokenType) string {
 		return "'='"
 	case tokNeq:
 		return "'!='"
+	case tokGt:
+		return "'>'"
+	case tokGte:
+		return "'>='"
+	case tokLt:
+		return "'<'"
+	case tokLte:
+		return "'<='"
 	case tokLParen:
 		return "'('"
 	case tokRParen:
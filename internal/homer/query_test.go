@@ -57,6 +57,71 @@ func TestParseQuery(t *testing.T) {
 			input: "status != 200",
 			want:  "status != 200",
 		},
+		{
+			name:  "greater or equal",
+			input: "status >= 400",
+			want:  "status >= 400",
+		},
+		{
+			name:  "less than",
+			input: "status < 300",
+			want:  "status < 300",
+		},
+		{
+			name:  "greater and less or equal",
+			input: "status > 199 AND status <= 299",
+			want:  "status > 199 AND status <= 299",
+		},
+		{
+			name:  "status class wildcard",
+			input: "status = 4xx",
+			want:  "status >= 400 AND status < 500",
+		},
+		{
+			name:  "status class wildcard uppercase",
+			input: "status = 5XX",
+			want:  "status >= 500 AND status < 600",
+		},
+		{
+			name:  "negated status class",
+			input: "status != 2xx",
+			want:  "status < 200 OR status >= 300",
+		},
+		{
+			name:  "status classes combined with OR",
+			input: "status = 4xx OR status = 5xx",
+			want:  "(status >= 400 AND status < 500) OR (status >= 500 AND status < 600)",
+		},
+		{
+			name:  "status class within AND",
+			input: "method = 'INVITE' AND status != 2xx",
+			want:  "method = 'INVITE' AND (status < 200 OR status >= 300)",
+		},
+		{
+			name:    "range operator on string field",
+			input:   "from_user >= '100'",
+			wantErr: "only supported on numeric fields",
+		},
+		{
+			name:    "range operator with string value",
+			input:   "status >= '400'",
+			wantErr: "needs a number",
+		},
+		{
+			name:    "status class on string field",
+			input:   "from_user = 4xx",
+			wantErr: "only supported on status",
+		},
+		{
+			name:    "status class with range operator",
+			input:   "status >= 4xx",
+			wantErr: "only works with = or !=",
+		},
+		{
+			name:    "invalid status class",
+			input:   "status = 45x",
+			wantErr: "invalid status class",
+		},
 		{
 			name:  "call_id alias to sid",
 			input: "call_id = 'abc123@host'",
@@ -0,0 +1,125 @@
+package homer
+
+import (
+	"encoding/json"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// DefaultSessionTTL is how long a cached login is reused. A token Homer
+// rejects earlier is replaced by a fresh login anyway.
+const DefaultSessionTTL = time.Hour
+
+// SessionCache stores Homer auth tokens on disk, keyed by normalized URL and
+// username, so consecutive commands don't log in again every time
+type SessionCache struct {
+	Path string
+	TTL  time.Duration
+}
+
+type cachedSession struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// DefaultSessionCache returns the cache at ~/.dex/homer/session.json
+func DefaultSessionCache() (*SessionCache, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &SessionCache{
+		Path: filepath.Join(home, ".dex", "homer", "session.json"),
+		TTL:  DefaultSessionTTL,
+	}, nil
+}
+
+// sessionKey identifies a login: scheme and host are lowercased and trailing
+// slashes dropped so equivalent URLs share a session
+func sessionKey(baseURL, username string) string {
+	u, err := url.Parse(strings.TrimSpace(baseURL))
+	if err != nil || u.Host == "" {
+		return strings.ToLower(strings.TrimRight(baseURL, "/")) + "|" + username
+	}
+	return strings.ToLower(u.Scheme) + "://" + strings.ToLower(u.Host) + strings.TrimRight(u.Path, "/") + "|" + username
+}
+
+// Get returns the cached token for baseURL and username if it has not expired
+func (s *SessionCache) Get(baseURL, username string) (string, bool) {
+	sessions, err := s.load()
+	if err != nil {
+		return "", false
+	}
+	sess, ok := sessions[sessionKey(baseURL, username)]
+	if !ok || sess.Token == "" || !time.Now().Before(sess.ExpiresAt) {
+		return "", false
+	}
+	return sess.Token, true
+}
+
+// Put stores token for baseURL and username, valid for the cache TTL
+func (s *SessionCache) Put(baseURL, username, token string) error {
+	sessions, _ := s.load()
+	if sessions == nil {
+		sessions = make(map[string]cachedSession)
+	}
+	ttl := s.TTL
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+	sessions[sessionKey(baseURL, username)] = cachedSession{Token: token, ExpiresAt: time.Now().Add(ttl)}
+	return s.save(sessions)
+}
+
+// Delete drops the cached token for baseURL and username
+func (s *SessionCache) Delete(baseURL, username string) error {
+	sessions, err := s.load()
+	if err != nil {
+		return err
+	}
+	delete(sessions, sessionKey(baseURL, username))
+	return s.save(sessions)
+}
+
+func (s *SessionCache) load() (map[string]cachedSession, error) {
+	data, err := os.ReadFile(s.Path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return make(map[string]cachedSession), nil
+		}
+		return nil, err
+	}
+
+	var sessions map[string]cachedSession
+	if err := json.Unmarshal(data, &sessions); err != nil {
+		// Corrupted cache, start fresh
+		return make(map[string]cachedSession), nil
+	}
+	if sessions == nil {
+		sessions = make(map[string]cachedSession)
+	}
+	return sessions, nil
+}
+
+// save writes sessions, dropping expired ones, readable only by the user
+func (s *SessionCache) save(sessions map[string]cachedSession) error {
+	now := time.Now()
+	for k, sess := range sessions {
+		if !now.Before(sess.ExpiresAt) {
+			delete(sessions, k)
+		}
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.Path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(sessions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(s.Path, data, 0600)
+}
@@ -0,0 +1,106 @@
+package homer
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeHomerAuth issues token-N on the N-th login and accepts only tokens
+// listed in valid
+func fakeHomerAuth(t *testing.T, logins *int, valid map[string]bool) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/auth":
+			*logins++
+			token := fmt.Sprintf("token-%d", *logins)
+			valid[token] = true
+			fmt.Fprintf(w, `{"token":%q}`, token)
+		case "/api/v3/alias":
+			if !valid[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, `{"data":[]}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestSessionCacheReusesToken(t *testing.T) {
+	logins := 0
+	srv := fakeHomerAuth(t, &logins, map[string]bool{})
+	cache := &SessionCache{Path: filepath.Join(t.TempDir(), "session.json"), TTL: time.Hour}
+
+	for i := 0; i < 3; i++ {
+		c := NewClient(srv.URL)
+		c.Sessions = cache
+		if err := c.Authenticate("admin", "secret"); err != nil {
+			t.Fatalf("Authenticate #%d: %v", i+1, err)
+		}
+		if _, err := c.ListAliases(); err != nil {
+			t.Fatalf("ListAliases #%d: %v", i+1, err)
+		}
+	}
+	if logins != 1 {
+		t.Errorf("logins = %d, want 1 (token reused within TTL)", logins)
+	}
+
+	// A trailing slash or different case in the URL is the same session.
+	if _, ok := cache.Get(srv.URL+"/", "admin"); !ok {
+		t.Error("cached session not found for URL with trailing slash")
+	}
+	if _, ok := cache.Get(srv.URL, "other"); ok {
+		t.Error("cached session found for a different username")
+	}
+}
+
+func TestSessionCacheExpired(t *testing.T) {
+	logins := 0
+	srv := fakeHomerAuth(t, &logins, map[string]bool{})
+	cache := &SessionCache{Path: filepath.Join(t.TempDir(), "session.json"), TTL: time.Nanosecond}
+
+	for i := 0; i < 2; i++ {
+		c := NewClient(srv.URL)
+		c.Sessions = cache
+		if err := c.Authenticate("admin", "secret"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if logins != 2 {
+		t.Errorf("logins = %d, want 2 (expired token not reused)", logins)
+	}
+}
+
+func TestSessionCacheRejectedToken(t *testing.T) {
+	logins := 0
+	valid := map[string]bool{}
+	srv := fakeHomerAuth(t, &logins, valid)
+	cache := &SessionCache{Path: filepath.Join(t.TempDir(), "session.json"), TTL: time.Hour}
+	if err := cache.Put(srv.URL, "admin", "revoked"); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewClient(srv.URL)
+	c.Sessions = cache
+	if err := c.Authenticate("admin", "secret"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListAliases(); err != nil {
+		t.Fatalf("ListAliases after 401: %v", err)
+	}
+	if logins != 1 {
+		t.Errorf("logins = %d, want 1 (re-login after 401)", logins)
+	}
+	if token, _ := cache.Get(srv.URL, "admin"); token != "token-1" {
+		t.Errorf("cached token = %q, want refreshed token-1", token)
+	}
+}
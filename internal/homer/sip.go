@@ -1,6 +1,7 @@
 package homer
 
 import (
+	"regexp"
 	"strings"
 )
 
@@ -21,6 +22,53 @@ func ExtractSIPHeader(raw string, header string) string {
 	return ""
 }
 
+// CorrelationKey derives the key that correlates call legs from a header
+// value. Without a regexp the whole value is the key. With one, the first
+// capture group is the key, or the full match if the regexp has no group;
+// "" is returned when the value does not match.
+func CorrelationKey(value string, re *regexp.Regexp) string {
+	if re == nil {
+		return value
+	}
+	m := re.FindStringSubmatch(value)
+	if m == nil {
+		return ""
+	}
+	if len(m) > 1 {
+		return m[1]
+	}
+	return m[0]
+}
+
+// KeepaliveMethods are SIP methods that carry keepalive, subscription or
+// presence traffic rather than call signaling.
+var KeepaliveMethods = map[string]bool{
+	"OPTIONS": true,
+	"NOTIFY":  true,
+	"PUBLISH": true,
+}
+
+// SIPTransactionMethod returns the method of the transaction a raw SIP message
+// belongs to: the request method for requests, or the method from the CSeq
+// header for responses ("SIP/2.0 200 OK" with "CSeq: 1 OPTIONS" → "OPTIONS").
+// Returns "" if it cannot be determined.
+func SIPTransactionMethod(raw string) string {
+	firstLine, _, _ := strings.Cut(raw, "\n")
+	firstLine = strings.TrimRight(firstLine, "\r")
+	if firstLine == "" {
+		return ""
+	}
+	if !strings.HasPrefix(firstLine, "SIP/") {
+		method, _, _ := strings.Cut(firstLine, " ")
+		return strings.ToUpper(method)
+	}
+	fields := strings.Fields(ExtractSIPHeader(raw, "CSeq"))
+	if len(fields) == 2 {
+		return strings.ToUpper(fields[1])
+	}
+	return ""
+}
+
 // ExtractSIPHeadersByPrefix returns all headers whose name starts with prefix (case-insensitive).
 // Returns map[canonicalHeaderName]value. Stops at empty line.
 func ExtractSIPHeadersByPrefix(raw string, prefix string) map[string]string {
@@ -43,6 +91,42 @@ func ExtractSIPHeadersByPrefix(raw string, prefix string) map[string]string {
 	return result
 }
 
+// FilterSIPHeaders reduces a raw SIP message to its request/status line and
+// the header lines whose name starts with one of prefixes (case-insensitive).
+// Matching headers keep their order, repeats and folded continuation lines;
+// the body after the empty line is dropped.
+func FilterSIPHeaders(raw string, prefixes []string) string {
+	names := make(map[string]bool)
+	for _, prefix := range prefixes {
+		for name := range ExtractSIPHeadersByPrefix(raw, prefix) {
+			names[name] = true
+		}
+	}
+
+	lines := strings.Split(raw, "\n")
+	kept := []string{strings.TrimRight(lines[0], "\r")}
+	keep := false
+	for _, line := range lines[1:] {
+		line = strings.TrimRight(line, "\r")
+		if line == "" {
+			break
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			// Folded continuation of the previous header
+			if keep {
+				kept = append(kept, line)
+			}
+			continue
+		}
+		name, _, ok := strings.Cut(line, ":")
+		keep = ok && names[name]
+		if keep {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, "\n")
+}
+
 // ExtractSIPAllHeaders returns all SIP headers as map[canonicalName]value.
 // Stops at empty line. Skips the request/status line (first line).
 func ExtractSIPAllHeaders(raw string) map[string]string {
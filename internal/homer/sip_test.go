@@ -1,6 +1,9 @@
 package homer
 
-import "testing"
+import (
+	"regexp"
+	"testing"
+)
 
 func TestExtractSDPMedia(t *testing.T) {
 	tests := []struct {
@@ -72,3 +75,81 @@ func TestExtractSDPMedia(t *testing.T) {
 		})
 	}
 }
+
+func TestSIPTransactionMethod(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"request", "INVITE sip:123@10.0.0.1 SIP/2.0\r\nCSeq: 1 INVITE\r\n", "INVITE"},
+		{"options request", "OPTIONS sip:10.0.0.1 SIP/2.0\r\nCSeq: 42 OPTIONS\r\n", "OPTIONS"},
+		{"response uses CSeq", "SIP/2.0 200 OK\r\nVia: SIP/2.0/UDP 10.0.0.2\r\nCSeq: 42 OPTIONS\r\n\r\n", "OPTIONS"},
+		{"response to NOTIFY", "SIP/2.0 200 OK\nCSeq: 7 notify\n", "NOTIFY"},
+		{"response without CSeq", "SIP/2.0 180 Ringing\r\n\r\n", ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SIPTransactionMethod(tt.raw); got != tt.want {
+				t.Errorf("SIPTransactionMethod() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilterSIPHeaders(t *testing.T) {
+	raw := "INVITE sip:4934155003500@10.0.0.2 SIP/2.0\r\n" +
+		"Via: SIP/2.0/UDP 10.0.0.1:5060\r\n" +
+		"From: <sip:4921514174858@10.0.0.1>;tag=a\r\n" +
+		"P-Asserted-Identity: <sip:+4921514174858@example.com>\r\n" +
+		"P-Charging-Vector: icid-value=1\r\n" +
+		"Reason: Q.850;cause=16\r\n" +
+		"Reason: SIP;cause=200\r\n" +
+		"X-Acme-Call-ID: abc\r\n" +
+		" continued\r\n" +
+		"Content-Type: application/sdp\r\n" +
+		"\r\n" +
+		"v=0\r\n" +
+		"Reason: not a header\r\n"
+
+	got := FilterSIPHeaders(raw, []string{"p-asserted", "REASON", "X-Acme"})
+	want := "INVITE sip:4934155003500@10.0.0.2 SIP/2.0\n" +
+		"P-Asserted-Identity: <sip:+4921514174858@example.com>\n" +
+		"Reason: Q.850;cause=16\n" +
+		"Reason: SIP;cause=200\n" +
+		"X-Acme-Call-ID: abc\n" +
+		" continued"
+	if got != want {
+		t.Errorf("FilterSIPHeaders() =\n%s\nwant:\n%s", got, want)
+	}
+
+	if got := FilterSIPHeaders("SIP/2.0 200 OK\r\nVia: x\r\n", []string{"Reason"}); got != "SIP/2.0 200 OK" {
+		t.Errorf("no match: got %q, want only the status line", got)
+	}
+}
+
+func TestCorrelationKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		pattern string
+		want    string
+	}{
+		{"exact", "id=abc123;node=7", "", "id=abc123;node=7"},
+		{"capture group", "id=abc123;node=7", `id=([^;]+)`, "abc123"},
+		{"full match", "id=abc123;node=7", `[0-9a-f]{6}`, "abc123"},
+		{"no match", "node=7", `id=([^;]+)`, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var re *regexp.Regexp
+			if tt.pattern != "" {
+				re = regexp.MustCompile(tt.pattern)
+			}
+			if got := CorrelationKey(tt.value, re); got != tt.want {
+				t.Errorf("CorrelationKey(%q, %q) = %q, want %q", tt.value, tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package homer
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// CallStatuses lists the statuses derived by GroupCalls, in display order
+var CallStatuses = []string{"answered", "busy", "no answer", "failed", "ringing", "cancelled"}
+
+// UserAgentCount is the number of calls placed by one User-Agent
+type UserAgentCount struct {
+	UserAgent string `json:"user_agent"`
+	Calls     int    `json:"calls"`
+}
+
+// CallStats aggregates a set of calls: volume, outcomes, answer-seizure ratio
+// (answered / total), duration percentiles and the busiest User-Agents.
+// Durations only cover calls with more than one message, since a single
+// message has no end time.
+type CallStats struct {
+	From                  time.Time        `json:"from"`
+	To                    time.Time        `json:"to"`
+	Total                 int              `json:"total"`
+	ByStatus              map[string]int   `json:"by_status"`
+	ASR                   float64          `json:"asr"`
+	DurationSamples       int              `json:"duration_samples"`
+	MedianDurationSeconds float64          `json:"median_duration_seconds"`
+	P95DurationSeconds    float64          `json:"p95_duration_seconds"`
+	TopUserAgents         []UserAgentCount `json:"top_user_agents"`
+}
+
+// ComputeCallStats builds CallStats for calls, keeping the topUA most common
+// User-Agents (0 = all). A call's User-Agent is the first one seen on its
+// INVITE, or on any message if no INVITE carries one; calls without one are
+// counted as "".
+func ComputeCallStats(calls []CallSummary, topUA int) CallStats {
+	st := CallStats{
+		Total:         len(calls),
+		ByStatus:      make(map[string]int),
+		TopUserAgents: []UserAgentCount{},
+	}
+
+	var durations []time.Duration
+	uaCalls := make(map[string]int)
+	for _, c := range calls {
+		st.ByStatus[c.Status]++
+		if c.MsgCount > 1 {
+			durations = append(durations, c.Duration)
+		}
+		uaCalls[callUserAgent(c)]++
+	}
+
+	if st.Total > 0 {
+		st.ASR = float64(st.ByStatus["answered"]) / float64(st.Total)
+	}
+	st.DurationSamples = len(durations)
+	st.MedianDurationSeconds = DurationPercentile(durations, 50).Seconds()
+	st.P95DurationSeconds = DurationPercentile(durations, 95).Seconds()
+
+	for ua, n := range uaCalls {
+		st.TopUserAgents = append(st.TopUserAgents, UserAgentCount{UserAgent: ua, Calls: n})
+	}
+	sort.Slice(st.TopUserAgents, func(i, j int) bool {
+		if st.TopUserAgents[i].Calls != st.TopUserAgents[j].Calls {
+			return st.TopUserAgents[i].Calls > st.TopUserAgents[j].Calls
+		}
+		return st.TopUserAgents[i].UserAgent < st.TopUserAgents[j].UserAgent
+	})
+	if topUA > 0 && len(st.TopUserAgents) > topUA {
+		st.TopUserAgents = st.TopUserAgents[:topUA]
+	}
+
+	return st
+}
+
+// DurationPercentile returns the p-th percentile (0-100) of durations using
+// the nearest-rank method, or 0 for an empty slice. The input is not modified.
+func DurationPercentile(durations []time.Duration, p float64) time.Duration {
+	if len(durations) == 0 {
+		return 0
+	}
+	sorted := make([]time.Duration, len(durations))
+	copy(sorted, durations)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	return sorted[min(max(rank-1, 0), len(sorted)-1)]
+}
+
+func callUserAgent(c CallSummary) string {
+	ua := ""
+	for _, m := range c.Messages {
+		if m.UserAgent == "" {
+			continue
+		}
+		if m.Method == "INVITE" {
+			return m.UserAgent
+		}
+		if ua == "" {
+			ua = m.UserAgent
+		}
+	}
+	return ua
+}
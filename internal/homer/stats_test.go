@@ -0,0 +1,67 @@
+package homer
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDurationPercentile(t *testing.T) {
+	secs := func(ns ...int) []time.Duration {
+		var out []time.Duration
+		for _, n := range ns {
+			out = append(out, time.Duration(n)*time.Second)
+		}
+		return out
+	}
+
+	tests := []struct {
+		name      string
+		durations []time.Duration
+		p         float64
+		want      time.Duration
+	}{
+		{"empty", nil, 50, 0},
+		{"single", secs(7), 95, 7 * time.Second},
+		{"median odd", secs(9, 1, 5), 50, 5 * time.Second},
+		{"median even", secs(4, 1, 3, 2), 50, 2 * time.Second},
+		{"p95 of 10", secs(1, 2, 3, 4, 5, 6, 7, 8, 9, 100), 95, 100 * time.Second},
+		{"p95 of 20", secs(1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19, 20), 95, 19 * time.Second},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DurationPercentile(tt.durations, tt.p); got != tt.want {
+				t.Errorf("DurationPercentile(%v, %v) = %v, want %v", tt.durations, tt.p, got, tt.want)
+			}
+		})
+	}
+
+	unsorted := secs(3, 1, 2)
+	DurationPercentile(unsorted, 50)
+	if unsorted[0] != 3*time.Second {
+		t.Error("DurationPercentile modified its input")
+	}
+}
+
+func TestComputeCallStats(t *testing.T) {
+	calls := []CallSummary{
+		{Status: "answered", MsgCount: 6, Duration: 60 * time.Second, Messages: []CallRecord{{Method: "INVITE", UserAgent: "PBX-A"}}},
+		{Status: "answered", MsgCount: 6, Duration: 120 * time.Second, Messages: []CallRecord{{Method: "INVITE", UserAgent: "PBX-A"}}},
+		{Status: "busy", MsgCount: 3, Duration: time.Second, Messages: []CallRecord{{Method: "100"}, {Method: "INVITE", UserAgent: "Phone-B"}}},
+		{Status: "ringing", MsgCount: 1, Messages: []CallRecord{{Method: "INVITE"}}},
+	}
+
+	st := ComputeCallStats(calls, 1)
+	if st.Total != 4 || st.ByStatus["answered"] != 2 || st.ByStatus["busy"] != 1 {
+		t.Errorf("counts = %d total, %v; want 4 total, 2 answered, 1 busy", st.Total, st.ByStatus)
+	}
+	if st.ASR != 0.5 {
+		t.Errorf("ASR = %v, want 0.5", st.ASR)
+	}
+	if st.DurationSamples != 3 || st.MedianDurationSeconds != 60 || st.P95DurationSeconds != 120 {
+		t.Errorf("durations = %d samples, median %v, p95 %v; want 3, 60, 120",
+			st.DurationSamples, st.MedianDurationSeconds, st.P95DurationSeconds)
+	}
+	if len(st.TopUserAgents) != 1 || st.TopUserAgents[0] != (UserAgentCount{"PBX-A", 2}) {
+		t.Errorf("TopUserAgents = %+v, want [{PBX-A 2}]", st.TopUserAgents)
+	}
+}
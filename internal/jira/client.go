@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 
@@ -304,9 +305,8 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int) (
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		var errResp map[string]any
-		json.NewDecoder(resp.Body).Decode(&errResp)
-		return nil, fmt.Errorf("search failed %d: %v", resp.StatusCode, errResp)
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("search failed: %w", parseAPIError(resp.StatusCode, body))
 	}
 
 	var result SearchResult
@@ -317,6 +317,69 @@ func (c *Client) SearchIssues(ctx context.Context, jql string, maxResults int) (
 	return &result, nil
 }
 
+// Search returns the issues matching jql, at most limit of them
+func (c *Client) Search(ctx context.Context, jql string, limit int) ([]Issue, error) {
+	result, err := c.SearchIssues(ctx, jql, limit)
+	if err != nil {
+		return nil, err
+	}
+	return result.Issues, nil
+}
+
+// mineClause restricts a query to the current user's open issues
+const mineClause = "assignee = currentUser() AND statusCategory != Done"
+
+// orderByPattern matches an ORDER BY keyword in any case, and quoted strings
+// so that an ORDER BY inside them can be skipped
+var orderByPattern = regexp.MustCompile(`(?i)"(?:[^"\\]|\\.)*"|'(?:[^'\\]|\\.)*'|\bORDER\s+BY\b`)
+
+// MineJQL narrows jql to the current user's open issues. The original
+// filter is parenthesized so its ORs can't escape the restriction; a
+// trailing ORDER BY is kept at the end. An empty jql yields the clause alone.
+func MineJQL(jql string) string {
+	filter, order := strings.TrimSpace(jql), ""
+	i := -1
+	for _, m := range orderByPattern.FindAllStringIndex(filter, -1) {
+		if c := filter[m[0]]; c != '"' && c != '\'' {
+			i = m[0]
+		}
+	}
+	if i >= 0 {
+		filter, order = strings.TrimSpace(filter[:i]), " "+strings.TrimSpace(filter[i:])
+	}
+	if filter == "" {
+		return mineClause + order
+	}
+	return mineClause + " AND (" + filter + ")" + order
+}
+
+// parseAPIError turns a failed response into an error carrying Jira's
+// errorMessages and field errors, falling back to the raw body
+func parseAPIError(status int, body []byte) error {
+	var errResp struct {
+		ErrorMessages []string          `json:"errorMessages"`
+		Errors        map[string]string `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &errResp); err == nil {
+		msgs := errResp.ErrorMessages
+		fields := make([]string, 0, len(errResp.Errors))
+		for field := range errResp.Errors {
+			fields = append(fields, field)
+		}
+		sort.Strings(fields)
+		for _, field := range fields {
+			msgs = append(msgs, field+": "+errResp.Errors[field])
+		}
+		if len(msgs) > 0 {
+			return fmt.Errorf("%s (status %d)", strings.Join(msgs, "; "), status)
+		}
+	}
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return fmt.Errorf("status %d: %s", status, text)
+	}
+	return fmt.Errorf("status %d", status)
+}
+
 // GetMyIssues fetches issues assigned to the current user
 func (c *Client) GetMyIssues(ctx context.Context, maxResults int) (*SearchResult, error) {
 	return c.SearchIssues(ctx, "assignee = currentUser() AND status != Done ORDER BY updated DESC", maxResults)
@@ -327,6 +390,70 @@ func (c *Client) GetRecentIssues(ctx context.Context, maxResults int) (*SearchRe
 	return c.SearchIssues(ctx, "updated >= -7d ORDER BY updated DESC", maxResults)
 }
 
+// Filter is a saved Jira filter
+type Filter struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	JQL         string `json:"jql"`
+	ViewURL     string `json:"viewUrl,omitempty"`
+	Favourite   bool   `json:"favourite"`
+	Owner       *struct {
+		DisplayName string `json:"displayName"`
+	} `json:"owner,omitempty"`
+}
+
+// ListMyFilters fetches the saved filters owned by the current user, including favourites
+func (c *Client) ListMyFilters(ctx context.Context) ([]Filter, error) {
+	query := url.Values{
+		"includeFavourites": {"true"},
+		"expand":            {"description,favourite,jql,owner,viewUrl"},
+	}
+
+	resp, err := c.doRequest(ctx, "GET", "/filter/my", query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to list filters: %w", parseAPIError(resp.StatusCode, body))
+	}
+
+	var filters []Filter
+	if err := json.NewDecoder(resp.Body).Decode(&filters); err != nil {
+		return nil, err
+	}
+
+	return filters, nil
+}
+
+// GetFilter fetches a saved filter by ID
+func (c *Client) GetFilter(ctx context.Context, filterID string) (*Filter, error) {
+	resp, err := c.doRequest(ctx, "GET", "/filter/"+url.PathEscape(filterID), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusBadRequest {
+		return nil, fmt.Errorf("filter %s not found", filterID)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("failed to get filter: %w", parseAPIError(resp.StatusCode, body))
+	}
+
+	var filter Filter
+	if err := json.NewDecoder(resp.Body).Decode(&filter); err != nil {
+		return nil, err
+	}
+
+	return &filter, nil
+}
+
 // ListProjects fetches all accessible Jira projects
 func (c *Client) ListProjects(ctx context.Context) ([]Project, error) {
 	resp, err := c.doRequest(ctx, "GET", "/project", nil)
@@ -411,6 +538,12 @@ func (c *Client) GetProjectKeys(ctx context.Context) ([]string, error) {
 	return keys, nil
 }
 
+// HasToken reports whether an OAuth token is stored, i.e. whether requests can
+// run without starting the interactive browser login
+func (c *Client) HasToken() bool {
+	return c.token != nil
+}
+
 // GetSiteURL returns the browsable Jira site URL (e.g., https://company.atlassian.net)
 func (c *Client) GetSiteURL() string {
 	if c.token != nil {
@@ -419,6 +552,37 @@ func (c *Client) GetSiteURL() string {
 	return ""
 }
 
+// issueKeyPattern matches a Jira issue key (e.g., DEV-123)
+var issueKeyPattern = regexp.MustCompile(`^[A-Z][A-Z0-9_]*-[1-9][0-9]*$`)
+
+// NormalizeIssueKey uppercases and validates an issue key
+func NormalizeIssueKey(key string) (string, error) {
+	k := strings.ToUpper(strings.TrimSpace(key))
+	if !issueKeyPattern.MatchString(k) {
+		return "", fmt.Errorf("invalid issue key %q (expected PROJECT-123)", key)
+	}
+	return k, nil
+}
+
+// IssueURL returns the browse URL of an issue (<site>/browse/<KEY>).
+// Tokens created before the site URL was stored are backfilled via EnsureAuth.
+func (c *Client) IssueURL(ctx context.Context, key string) (string, error) {
+	k, err := NormalizeIssueKey(key)
+	if err != nil {
+		return "", err
+	}
+	if c.GetSiteURL() == "" {
+		if err := c.EnsureAuth(ctx); err != nil {
+			return "", err
+		}
+	}
+	siteURL := strings.TrimSuffix(c.GetSiteURL(), "/")
+	if siteURL == "" {
+		return "", fmt.Errorf("jira site URL unknown, run 'dex jira auth'")
+	}
+	return fmt.Sprintf("%s/browse/%s", siteURL, k), nil
+}
+
 // markdownToADF converts markdown to ADF, linkifying any Jira issue keys.
 // Issue keys matching known project prefixes are converted to clickable links.
 func (c *Client) markdownToADF(ctx context.Context, markdown string) md2adf.Node {
@@ -802,6 +966,22 @@ func (c *Client) ListTransitions(ctx context.Context, issueKey string) ([]Transi
 	return result.Transitions, nil
 }
 
+// FindTransition returns the transition whose name (case-insensitive) or ID
+// matches transitionNameOrID
+func FindTransition(transitions []Transition, transitionNameOrID string) (*Transition, error) {
+	for i, t := range transitions {
+		if strings.EqualFold(t.Name, transitionNameOrID) || t.ID == transitionNameOrID {
+			return &transitions[i], nil
+		}
+	}
+
+	available := make([]string, len(transitions))
+	for i, t := range transitions {
+		available[i] = t.Name
+	}
+	return nil, fmt.Errorf("transition %q not found, available: %v", transitionNameOrID, available)
+}
+
 // TransitionIssue moves an issue to a new status
 func (c *Client) TransitionIssue(ctx context.Context, issueKey string, transitionNameOrID string) error {
 	// First, get available transitions
@@ -810,23 +990,16 @@ func (c *Client) TransitionIssue(ctx context.Context, issueKey string, transitio
 		return err
 	}
 
-	// Find matching transition (by name or ID)
-	var transitionID string
-	for _, t := range transitions {
-		if strings.EqualFold(t.Name, transitionNameOrID) || t.ID == transitionNameOrID {
-			transitionID = t.ID
-			break
-		}
+	t, err := FindTransition(transitions, transitionNameOrID)
+	if err != nil {
+		return err
 	}
 
-	if transitionID == "" {
-		available := make([]string, len(transitions))
-		for i, t := range transitions {
-			available[i] = t.Name
-		}
-		return fmt.Errorf("transition %q not found, available: %v", transitionNameOrID, available)
-	}
+	return c.ApplyTransition(ctx, issueKey, t.ID)
+}
 
+// ApplyTransition performs a transition by ID, as resolved by FindTransition
+func (c *Client) ApplyTransition(ctx context.Context, issueKey string, transitionID string) error {
 	body := map[string]interface{}{
 		"transition": map[string]string{
 			"id": transitionID,
@@ -976,7 +1149,7 @@ func FormatIssue(issue *Issue) string {
 		reporter = issue.Fields.Reporter.DisplayName
 	}
 
-	description := parseADF(issue.Fields.Description)
+	description := RenderADF(issue.Fields.Description)
 	if description == "" {
 		description = "(no description)"
 	}
@@ -1044,7 +1217,7 @@ func FormatIssue(issue *Issue) string {
 			if comment.Author != nil {
 				author = comment.Author.DisplayName
 			}
-			commentBody := parseADF(comment.Body)
+			commentBody := RenderADF(comment.Body)
 			result.WriteString(fmt.Sprintf("\n  ── %s (%s) ──\n", author, formatJiraTime(comment.Created)))
 			result.WriteString(indentText(commentBody, "  "))
 			result.WriteString("\n")
@@ -1081,8 +1254,11 @@ func parseJiraTime(timestamp string) (time.Time, error) {
 	return time.Time{}, fmt.Errorf("unable to parse time: %s", timestamp)
 }
 
-// parseADF converts Atlassian Document Format to plain text
-func parseADF(doc any) string {
+// RenderADF converts an Atlassian Document Format document (as decoded from
+// JSON) to plain text. Lists become "•" or numbered lines, code blocks are
+// fenced, mentions and links show their text or URL. A plain string is
+// returned as is (older API versions), anything else yields "".
+func RenderADF(doc any) string {
 	if doc == nil {
 		return ""
 	}
@@ -0,0 +1,148 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// adf decodes an ADF document the way it arrives in an API response
+func adf(t *testing.T, doc string) any {
+	t.Helper()
+	var v any
+	if err := json.Unmarshal([]byte(doc), &v); err != nil {
+		t.Fatal(err)
+	}
+	return v
+}
+
+func TestRenderADF(t *testing.T) {
+	tests := []struct {
+		name string
+		doc  string
+		want string
+	}{
+		{
+			name: "paragraphs",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"paragraph","content":[{"type":"text","text":"Hello "},{"type":"text","text":"world","marks":[{"type":"strong"}]}]},
+				{"type":"paragraph","content":[{"type":"text","text":"Second paragraph"}]}
+			]}`,
+			want: "Hello world\n\nSecond paragraph",
+		},
+		{
+			name: "bullet list",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"paragraph","content":[{"type":"text","text":"Steps:"}]},
+				{"type":"bulletList","content":[
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"restart the pod"}]}]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"check the logs"}]}]}
+				]}
+			]}`,
+			want: "Steps:\n\n• restart the pod\n• check the logs",
+		},
+		{
+			name: "ordered list",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"orderedList","content":[
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"first"}]}]},
+					{"type":"listItem","content":[{"type":"paragraph","content":[{"type":"text","text":"second"}]}]}
+				]}
+			]}`,
+			want: "1. first\n2. second",
+		},
+		{
+			name: "inline nodes",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"paragraph","content":[
+					{"type":"mention","attrs":{"id":"abc","text":"@Jane Doe"}},
+					{"type":"text","text":" see "},
+					{"type":"inlineCard","attrs":{"url":"https://example.atlassian.net/browse/DEV-1"}},
+					{"type":"hardBreak"},
+					{"type":"text","text":"thanks"}
+				]}
+			]}`,
+			want: "@Jane Doe see https://example.atlassian.net/browse/DEV-1\nthanks",
+		},
+		{
+			name: "code block",
+			doc: `{"type":"doc","version":1,"content":[
+				{"type":"codeBlock","attrs":{"language":"go"},"content":[{"type":"text","text":"fmt.Println(1)"}]}
+			]}`,
+			want: "```\nfmt.Println(1)\n```",
+		},
+	}
+	for _, tt := range tests {
+		if got := RenderADF(adf(t, tt.doc)); got != tt.want {
+			t.Errorf("%s:\n got  %q\n want %q", tt.name, got, tt.want)
+		}
+	}
+
+	if got := RenderADF("plain text body"); got != "plain text body" {
+		t.Errorf("string body = %q", got)
+	}
+	if got := RenderADF(nil); got != "" {
+		t.Errorf("nil body = %q", got)
+	}
+}
+
+func TestMineJQL(t *testing.T) {
+	tests := []struct {
+		jql  string
+		want string
+	}{
+		{"", "assignee = currentUser() AND statusCategory != Done"},
+		{"project = TEL", "assignee = currentUser() AND statusCategory != Done AND (project = TEL)"},
+		{"project = TEL OR project = DEV", "assignee = currentUser() AND statusCategory != Done AND (project = TEL OR project = DEV)"},
+		{"project = TEL ORDER BY updated DESC", "assignee = currentUser() AND statusCategory != Done AND (project = TEL) ORDER BY updated DESC"},
+		{"ORDER BY updated DESC", "assignee = currentUser() AND statusCategory != Done ORDER BY updated DESC"},
+		{"  priority = High order by created  ", "assignee = currentUser() AND statusCategory != Done AND (priority = High) order by created"},
+		{`summary ~ "sort ORDER BY date" ORDER BY rank`, `assignee = currentUser() AND statusCategory != Done AND (summary ~ "sort ORDER BY date") ORDER BY rank`},
+		{`summary ~ 'order by \'x\''`, `assignee = currentUser() AND statusCategory != Done AND (summary ~ 'order by \'x\'')`},
+		{"summary ~ \"ıı\" order by created", "assignee = currentUser() AND statusCategory != Done AND (summary ~ \"ıı\") order by created"},
+		{"reorder by = 1", "assignee = currentUser() AND statusCategory != Done AND (reorder by = 1)"},
+	}
+	for _, tt := range tests {
+		if got := MineJQL(tt.jql); got != tt.want {
+			t.Errorf("MineJQL(%q)\n got  %q\n want %q", tt.jql, got, tt.want)
+		}
+	}
+}
+
+func TestParseAPIError(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		want   string
+	}{
+		{
+			name:   "error messages",
+			status: 400,
+			body:   `{"errorMessages":["Error in the JQL Query: Expecting operator but got 'TEL'.","The value 'NOPE' does not exist for the field 'project'."],"errors":{}}`,
+			want:   "Error in the JQL Query: Expecting operator but got 'TEL'.; The value 'NOPE' does not exist for the field 'project'. (status 400)",
+		},
+		{
+			name:   "field errors sorted",
+			status: 400,
+			body:   `{"errorMessages":[],"errors":{"summary":"Summary is required.","project":"Project is required."}}`,
+			want:   "project: Project is required.; summary: Summary is required. (status 400)",
+		},
+		{
+			name:   "non-JSON body",
+			status: 502,
+			body:   "Bad Gateway\n",
+			want:   "status 502: Bad Gateway",
+		},
+		{
+			name:   "empty body",
+			status: 401,
+			body:   "",
+			want:   "status 401",
+		},
+	}
+	for _, tt := range tests {
+		if got := parseAPIError(tt.status, []byte(tt.body)).Error(); got != tt.want {
+			t.Errorf("%s:\n got  %q\n want %q", tt.name, got, tt.want)
+		}
+	}
+}
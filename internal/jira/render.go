@@ -303,6 +303,76 @@ func (tl *TransitionList) RenderText(mode render.Mode) string {
 	return b.String()
 }
 
+// Outcomes of a single issue in a bulk transition
+const (
+	BulkTransitionPlanned     = "planned" // dry run: transition available
+	BulkTransitionDone        = "transitioned"
+	BulkTransitionUnavailable = "unavailable" // no matching transition from the current status
+	BulkTransitionFailed      = "failed"
+)
+
+// BulkTransitionItem is the outcome of a bulk transition for one issue.
+type BulkTransitionItem struct {
+	Key        string `json:"key"`
+	Summary    string `json:"summary,omitempty"`
+	FromStatus string `json:"from_status,omitempty"`
+	ToStatus   string `json:"to_status,omitempty"`
+	Outcome    string `json:"outcome"`
+	Error      string `json:"error,omitempty"`
+}
+
+// BulkTransitionResult reports a bulk transition issue by issue.
+type BulkTransitionResult struct {
+	Transition string               `json:"transition"`
+	DryRun     bool                 `json:"dry_run"`
+	Issues     []BulkTransitionItem `json:"issues"`
+}
+
+// Count returns the number of issues with the given outcome
+func (r *BulkTransitionResult) Count(outcome string) int {
+	n := 0
+	for _, it := range r.Issues {
+		if it.Outcome == outcome {
+			n++
+		}
+	}
+	return n
+}
+
+// RenderText implements render.Renderable on BulkTransitionResult.
+func (r *BulkTransitionResult) RenderText(mode render.Mode) string {
+	var b strings.Builder
+	if len(r.Issues) == 0 {
+		return "No issues matched\n"
+	}
+	for _, it := range r.Issues {
+		switch it.Outcome {
+		case BulkTransitionPlanned, BulkTransitionDone:
+			mark := "✓"
+			if it.Outcome == BulkTransitionPlanned {
+				mark = "•"
+			}
+			fmt.Fprintf(&b, "%s %-12s %s → %s", mark, it.Key, it.FromStatus, it.ToStatus)
+		default:
+			fmt.Fprintf(&b, "✗ %-12s %s", it.Key, it.Error)
+		}
+		if mode != render.ModeCompact && it.Summary != "" {
+			fmt.Fprintf(&b, "  %s", truncateJira(it.Summary, 50))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	unavailable, failed := r.Count(BulkTransitionUnavailable), r.Count(BulkTransitionFailed)
+	if r.DryRun {
+		fmt.Fprintf(&b, "Dry run: %d of %d issues can be transitioned with %q, %d unavailable. Re-run with --yes to apply.\n",
+			r.Count(BulkTransitionPlanned), len(r.Issues), r.Transition, unavailable)
+	} else {
+		fmt.Fprintf(&b, "%d transitioned, %d unavailable, %d failed\n", r.Count(BulkTransitionDone), unavailable, failed)
+	}
+	return b.String()
+}
+
 // WorkflowStatuses is a slice of IssueTypeWithStatus with a RenderText implementation.
 // It implements json.Marshaler for a clean flat shape.
 type WorkflowStatuses struct {
@@ -429,3 +499,87 @@ func (m *MyIssueResult) RenderText(mode render.Mode) string {
 	}
 	return b.String()
 }
+
+// FilterList is a slice of saved Filters with a RenderText implementation.
+type FilterList struct {
+	Filters []Filter `json:"filters"`
+}
+
+// RenderText implements render.Renderable on FilterList.
+// ModeNormal: ID, name and owner per filter with the JQL underneath.
+// ModeCompact: one "id  name" line per filter.
+func (fl *FilterList) RenderText(mode render.Mode) string {
+	var b strings.Builder
+	if len(fl.Filters) == 0 {
+		return "No saved filters found.\n"
+	}
+	if mode == render.ModeCompact {
+		for _, f := range fl.Filters {
+			fmt.Fprintf(&b, "%-8s %s\n", f.ID, f.Name)
+		}
+		return b.String()
+	}
+	for _, f := range fl.Filters {
+		star := " "
+		if f.Favourite {
+			star = "★"
+		}
+		owner := ""
+		if f.Owner != nil && f.Owner.DisplayName != "" {
+			owner = " (" + f.Owner.DisplayName + ")"
+		}
+		fmt.Fprintf(&b, "%s %-8s %s%s\n", star, f.ID, f.Name, owner)
+		if f.JQL != "" {
+			fmt.Fprintf(&b, "           %s\n", f.JQL)
+		}
+	}
+	fmt.Fprintf(&b, "\n%d filters\n", len(fl.Filters))
+	return b.String()
+}
+
+// FilterRunResult wraps SearchResult with the saved filter it came from.
+// It implements json.Marshaler for a clean flat shape.
+type FilterRunResult struct {
+	Filter *Filter
+	*SearchResult
+}
+
+// MarshalJSON produces {filter:{id,name,jql}, total, issues:[...]}.
+func (r *FilterRunResult) MarshalJSON() ([]byte, error) {
+	type filterSummary struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+		JQL  string `json:"jql"`
+	}
+	type out struct {
+		Filter filterSummary `json:"filter"`
+		Total  int           `json:"total"`
+		Issues []Issue       `json:"issues"`
+	}
+	return json.Marshal(out{
+		Filter: filterSummary{ID: r.Filter.ID, Name: r.Filter.Name, JQL: r.Filter.JQL},
+		Total:  len(r.Issues),
+		Issues: r.Issues,
+	})
+}
+
+// RenderText implements render.Renderable on FilterRunResult.
+// ModeNormal prints the filter name and JQL followed by compact rows.
+// ModeCompact prints only the compact rows.
+func (r *FilterRunResult) RenderText(mode render.Mode) string {
+	var b strings.Builder
+	if mode == render.ModeNormal {
+		fmt.Fprintf(&b, "Filter %s: %s\n%s\n\n", r.Filter.ID, r.Filter.Name, r.Filter.JQL)
+	}
+	if len(r.Issues) == 0 {
+		b.WriteString("No issues found.\n")
+		return b.String()
+	}
+	if mode == render.ModeNormal {
+		fmt.Fprintf(&b, "Found %d issues:\n\n", len(r.Issues))
+	}
+	for i := range r.Issues {
+		b.WriteString(r.Issues[i].RenderText(render.ModeCompact))
+	}
+	return b.String()
+}
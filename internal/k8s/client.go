@@ -76,6 +76,18 @@ func ListContexts() ([]ContextInfo, error) {
 	return contextsFromConfig(&config), nil
 }
 
+// CurrentContext returns the name of the kubeconfig's current context
+func CurrentContext() (string, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	config, err := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{},
+	).RawConfig()
+	if err != nil {
+		return "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	return config.CurrentContext, nil
+}
+
 func contextsFromConfig(config *api.Config) []ContextInfo {
 	var contexts []ContextInfo
 	for name, ctx := range config.Contexts {
@@ -0,0 +1,193 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// AlertmanagerAlert represents an alert as returned by the Alertmanager v2 API
+type AlertmanagerAlert struct {
+	Labels      map[string]string `json:"labels"`
+	Annotations map[string]string `json:"annotations"`
+	StartsAt    time.Time         `json:"startsAt"`
+	EndsAt      time.Time         `json:"endsAt"`
+	UpdatedAt   time.Time         `json:"updatedAt"`
+	Fingerprint string            `json:"fingerprint"`
+	Status      struct {
+		State       string   `json:"state"`
+		SilencedBy  []string `json:"silencedBy"`
+		InhibitedBy []string `json:"inhibitedBy"`
+	} `json:"status"`
+}
+
+// AlertFiringInterval is a contiguous period during which an alert was firing
+type AlertFiringInterval struct {
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Ongoing bool      `json:"ongoing"`
+}
+
+// Duration returns how long the alert was firing during this interval
+func (i AlertFiringInterval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// AlertHistory is the reconstructed firing timeline of a single alert series
+type AlertHistory struct {
+	AlertName   string                `json:"alertname"`
+	Labels      map[string]string     `json:"labels"`
+	Annotations map[string]string     `json:"annotations,omitempty"`
+	Active      bool                  `json:"active"`
+	Intervals   []AlertFiringInterval `json:"intervals"`
+}
+
+// AlertmanagerAlerts returns the active, non-silenced, non-inhibited alerts from Alertmanager.
+// The client must point at the Alertmanager base URL, not at Prometheus.
+func (c *Client) AlertmanagerAlerts() ([]AlertmanagerAlert, error) {
+	params := url.Values{}
+	params.Set("silenced", "false")
+	params.Set("inhibited", "false")
+
+	resp, err := c.httpClient.Get(fmt.Sprintf("%s/api/v2/alerts?%s", c.baseURL, params.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("alertmanager returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var alerts []AlertmanagerAlert
+	if err := json.NewDecoder(resp.Body).Decode(&alerts); err != nil {
+		return nil, fmt.Errorf("failed to decode alertmanager response: %w", err)
+	}
+	return alerts, nil
+}
+
+// SampleTime converts the timestamp element of a Prometheus sample pair to a time.Time.
+func SampleTime(v interface{}) time.Time {
+	t, ok := v.(float64)
+	if !ok {
+		return time.Time{}
+	}
+	sec, frac := math.Modf(t)
+	return time.Unix(int64(sec), int64(frac*1e9))
+}
+
+// BuildAlertHistory reconstructs firing intervals from a range query over
+// ALERTS{alertstate="firing"}. Samples further apart than 1.5 steps start a new
+// interval. An interval whose last sample is within one step of end is ongoing.
+func BuildAlertHistory(series []MatrixSeries, step time.Duration, end time.Time) []AlertHistory {
+	maxGap := step + step/2
+
+	var history []AlertHistory
+	for _, s := range series {
+		labels := make(map[string]string, len(s.Metric))
+		for k, v := range s.Metric {
+			if k == "__name__" || k == "alertstate" {
+				continue
+			}
+			labels[k] = v
+		}
+
+		h := AlertHistory{
+			AlertName: s.Metric["alertname"],
+			Labels:    labels,
+		}
+
+		var cur *AlertFiringInterval
+		for _, v := range s.Values {
+			ts := SampleTime(v[0])
+			if ts.IsZero() {
+				continue
+			}
+			if cur != nil && ts.Sub(cur.End) <= maxGap {
+				cur.End = ts
+				continue
+			}
+			if cur != nil {
+				h.Intervals = append(h.Intervals, *cur)
+			}
+			cur = &AlertFiringInterval{Start: ts, End: ts}
+		}
+		if cur != nil {
+			cur.Ongoing = end.Sub(cur.End) <= step
+			h.Intervals = append(h.Intervals, *cur)
+		}
+		if len(h.Intervals) == 0 {
+			continue
+		}
+		h.Active = h.Intervals[len(h.Intervals)-1].Ongoing
+		history = append(history, h)
+	}
+
+	sortAlertHistory(history)
+	return history
+}
+
+// sortAlertHistory orders history by alert name, then by first firing
+func sortAlertHistory(history []AlertHistory) {
+	sort.SliceStable(history, func(i, j int) bool {
+		if history[i].AlertName != history[j].AlertName {
+			return history[i].AlertName < history[j].AlertName
+		}
+		return history[i].Intervals[0].Start.Before(history[j].Intervals[0].Start)
+	})
+}
+
+// MergeAlertmanagerState enriches the history with annotations and active state
+// from Alertmanager, matching alerts by their full label set. Active alerts
+// missing from the range data (e.g. firing for less than one step) are added
+// with a single ongoing interval from their StartsAt to end.
+func MergeAlertmanagerState(history []AlertHistory, amAlerts []AlertmanagerAlert, end time.Time) []AlertHistory {
+	added := false
+	for _, a := range amAlerts {
+		matched := false
+		for i := range history {
+			if !labelsEqual(history[i].Labels, a.Labels) {
+				continue
+			}
+			history[i].Annotations = a.Annotations
+			if a.Status.State == "active" {
+				history[i].Active = true
+			}
+			matched = true
+			break
+		}
+		if matched || a.Status.State != "active" || a.StartsAt.After(end) {
+			continue
+		}
+		history = append(history, AlertHistory{
+			AlertName:   a.Labels["alertname"],
+			Labels:      a.Labels,
+			Annotations: a.Annotations,
+			Active:      true,
+			Intervals:   []AlertFiringInterval{{Start: a.StartsAt, End: end, Ongoing: true}},
+		})
+		added = true
+	}
+	if added {
+		sortAlertHistory(history)
+	}
+	return history
+}
+
+func labelsEqual(a, b map[string]string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		if b[k] != v {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,158 @@
+package prometheus
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// firingSeries builds an ALERTS{alertstate="firing"} series with a sample
+// at each of the given offsets from base
+func firingSeries(labels map[string]string, base time.Time, offsets ...time.Duration) MatrixSeries {
+	metric := map[string]string{"__name__": "ALERTS", "alertstate": "firing"}
+	for k, v := range labels {
+		metric[k] = v
+	}
+	s := MatrixSeries{Metric: metric}
+	for _, off := range offsets {
+		s.Values = append(s.Values, [2]interface{}{float64(base.Add(off).Unix()), "1"})
+	}
+	return s
+}
+
+func TestBuildAlertHistory(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	step := time.Minute
+	end := base.Add(time.Hour)
+	m := time.Minute
+	disk := map[string]string{"alertname": "DiskFull", "instance": "db-1"}
+
+	type interval struct {
+		start, end time.Duration
+		ongoing    bool
+	}
+	tests := []struct {
+		name       string
+		offsets    []time.Duration
+		want       []interval
+		wantActive bool
+	}{
+		{
+			name:    "contiguous samples form one interval",
+			offsets: []time.Duration{0, m, 2 * m, 3 * m},
+			want:    []interval{{0, 3 * m, false}},
+		},
+		{
+			name:    "jitter within 1.5 steps stays contiguous",
+			offsets: []time.Duration{0, m, 2*m + 20*time.Second},
+			want:    []interval{{0, 2*m + 20*time.Second, false}},
+		},
+		{
+			name:    "gap splits intervals",
+			offsets: []time.Duration{0, m, 2 * m, 10 * m, 11 * m},
+			want:    []interval{{0, 2 * m, false}, {10 * m, 11 * m, false}},
+		},
+		{
+			name:       "last sample within one step of end is ongoing",
+			offsets:    []time.Duration{0, m, 50 * m, 59 * m, 60 * m},
+			want:       []interval{{0, m, false}, {50 * m, 50 * m, false}, {59 * m, 60 * m, true}},
+			wantActive: true,
+		},
+	}
+	for _, tt := range tests {
+		history := BuildAlertHistory([]MatrixSeries{firingSeries(disk, base, tt.offsets...)}, step, end)
+		if len(history) != 1 {
+			t.Fatalf("%s: got %d histories, want 1", tt.name, len(history))
+		}
+		h := history[0]
+		var got []interval
+		for _, i := range h.Intervals {
+			got = append(got, interval{i.Start.Sub(base), i.End.Sub(base), i.Ongoing})
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n got  %v\n want %v", tt.name, got, tt.want)
+		}
+		if h.Active != tt.wantActive {
+			t.Errorf("%s: active = %v, want %v", tt.name, h.Active, tt.wantActive)
+		}
+		if h.AlertName != "DiskFull" || !reflect.DeepEqual(h.Labels, disk) {
+			t.Errorf("%s: name %q labels %v, want DiskFull %v", tt.name, h.AlertName, h.Labels, disk)
+		}
+	}
+
+	if history := BuildAlertHistory([]MatrixSeries{{Metric: disk}}, step, end); len(history) != 0 {
+		t.Errorf("series without samples: got %+v, want none", history)
+	}
+}
+
+func TestMergeAlertmanagerState(t *testing.T) {
+	base := time.Unix(1700000000, 0)
+	end := base.Add(time.Hour)
+	disk := map[string]string{"alertname": "DiskFull", "instance": "db-1"}
+	cpu := map[string]string{"alertname": "CPUHigh", "instance": "web-1"}
+
+	amAlert := func(labels map[string]string, state string, startsAt time.Time) AlertmanagerAlert {
+		a := AlertmanagerAlert{
+			Labels:      labels,
+			Annotations: map[string]string{"summary": labels["alertname"] + " on " + labels["instance"]},
+			StartsAt:    startsAt,
+		}
+		a.Status.State = state
+		return a
+	}
+
+	tests := []struct {
+		name     string
+		amAlerts []AlertmanagerAlert
+		want     []AlertHistory
+	}{
+		{
+			name:     "matching alert adds annotations and active state",
+			amAlerts: []AlertmanagerAlert{amAlert(disk, "active", base)},
+			want: []AlertHistory{{
+				AlertName:   "DiskFull",
+				Labels:      disk,
+				Annotations: map[string]string{"summary": "DiskFull on db-1"},
+				Active:      true,
+				Intervals:   []AlertFiringInterval{{Start: base, End: base.Add(2 * time.Minute)}},
+			}},
+		},
+		{
+			name:     "firing in alertmanager but missing from range data",
+			amAlerts: []AlertmanagerAlert{amAlert(cpu, "active", end.Add(-20*time.Second))},
+			want: []AlertHistory{
+				{
+					AlertName:   "CPUHigh",
+					Labels:      cpu,
+					Annotations: map[string]string{"summary": "CPUHigh on web-1"},
+					Active:      true,
+					Intervals:   []AlertFiringInterval{{Start: end.Add(-20 * time.Second), End: end, Ongoing: true}},
+				},
+				{
+					AlertName: "DiskFull",
+					Labels:    disk,
+					Intervals: []AlertFiringInterval{{Start: base, End: base.Add(2 * time.Minute)}},
+				},
+			},
+		},
+		{
+			name: "suppressed and future alerts are not added",
+			amAlerts: []AlertmanagerAlert{
+				amAlert(cpu, "suppressed", base),
+				amAlert(map[string]string{"alertname": "Late", "instance": "x"}, "active", end.Add(time.Minute)),
+			},
+			want: []AlertHistory{{
+				AlertName: "DiskFull",
+				Labels:    disk,
+				Intervals: []AlertFiringInterval{{Start: base, End: base.Add(2 * time.Minute)}},
+			}},
+		},
+	}
+	for _, tt := range tests {
+		history := BuildAlertHistory([]MatrixSeries{firingSeries(disk, base, 0, time.Minute, 2*time.Minute)}, time.Minute, end)
+		got := MergeAlertmanagerState(history, tt.amAlerts, end)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s:\n got  %+v\n want %+v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,19 +1,54 @@
 package prometheus
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"strings"
 	"time"
 )
 
 // Client wraps the Prometheus HTTP API
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL      string
+	httpClient   *http.Client
+	queryTimeout time.Duration
+	Debug        bool // print each request URL to stderr
+}
+
+// queryTimeoutGrace is added to the client-side deadline so that Prometheus
+// has a chance to report its own timeout error before the request is aborted.
+const queryTimeoutGrace = 5 * time.Second
+
+// APIError is an error reported in the Prometheus API response envelope
+type APIError struct {
+	StatusCode int
+	Type       string
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("prometheus error (%s): %s", e.Type, e.Message)
+}
+
+// IsTimeout reports whether err is a query timeout, either reported by
+// Prometheus (errorType "timeout") or hit by the client-side deadline.
+func IsTimeout(err error) bool {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Type == "timeout"
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
 }
 
 // promResponse represents the standard Prometheus API envelope
@@ -85,9 +120,40 @@ func NewProbeClient(baseURL string) *Client {
 	}
 }
 
+// SetQueryTimeout bounds query evaluation. The timeout is sent to Prometheus
+// as the "timeout" parameter of query and query_range requests, and also used
+// as the client-side deadline (plus a short grace period). Zero disables it.
+func (c *Client) SetQueryTimeout(d time.Duration) {
+	c.queryTimeout = d
+	if d > 0 && c.httpClient.Timeout > 0 && c.httpClient.Timeout < d+queryTimeoutGrace {
+		c.httpClient.Timeout = d + queryTimeoutGrace
+	}
+}
+
+// setQueryTimeoutParam adds the server-side timeout parameter if one is configured.
+func (c *Client) setQueryTimeoutParam(params url.Values) {
+	if c.queryTimeout > 0 {
+		params.Set("timeout", fmt.Sprintf("%gs", c.queryTimeout.Seconds()))
+	}
+}
+
 // doGet performs a GET request and returns the parsed data field from the Prometheus response envelope.
 func (c *Client) doGet(endpoint string) (json.RawMessage, error) {
-	resp, err := c.httpClient.Get(endpoint)
+	ctx := context.Background()
+	if c.queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.queryTimeout+queryTimeoutGrace)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	if c.Debug {
+		fmt.Fprintf(os.Stderr, "[DEBUG] GET %s\n", endpoint)
+	}
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("request failed: %w", err)
 	}
@@ -95,6 +161,11 @@ func (c *Client) doGet(endpoint string) (json.RawMessage, error) {
 
 	if resp.StatusCode != http.StatusOK {
 		body, _ := io.ReadAll(resp.Body)
+		// Error responses (e.g. 422 bad_data, 503 timeout) still carry the envelope
+		var pr promResponse
+		if json.Unmarshal(body, &pr) == nil && pr.ErrorType != "" {
+			return nil, &APIError{StatusCode: resp.StatusCode, Type: pr.ErrorType, Message: pr.Error}
+		}
 		return nil, fmt.Errorf("prometheus returned status %d: %s", resp.StatusCode, string(body))
 	}
 
@@ -104,21 +175,26 @@ func (c *Client) doGet(endpoint string) (json.RawMessage, error) {
 	}
 
 	if pr.Status != "success" {
-		return nil, fmt.Errorf("prometheus error (%s): %s", pr.ErrorType, pr.Error)
+		return nil, &APIError{StatusCode: resp.StatusCode, Type: pr.ErrorType, Message: pr.Error}
 	}
 
 	return pr.Data, nil
 }
 
-// Query executes an instant PromQL query. evalTime may be zero (defaults to server now).
-func (c *Client) Query(query string, evalTime time.Time) ([]VectorSample, error) {
+// QueryURL returns the request URL Query would send, for reproducing it with curl.
+func (c *Client) QueryURL(query string, evalTime time.Time) string {
 	params := url.Values{}
 	params.Set("query", query)
 	if !evalTime.IsZero() {
 		params.Set("time", fmt.Sprintf("%d", evalTime.Unix()))
 	}
+	c.setQueryTimeoutParam(params)
+	return fmt.Sprintf("%s/api/v1/query?%s", c.baseURL, params.Encode())
+}
 
-	data, err := c.doGet(fmt.Sprintf("%s/api/v1/query?%s", c.baseURL, params.Encode()))
+// Query executes an instant PromQL query. evalTime may be zero (defaults to server now).
+func (c *Client) Query(query string, evalTime time.Time) ([]VectorSample, error) {
+	data, err := c.doGet(c.QueryURL(query, evalTime))
 	if err != nil {
 		return nil, err
 	}
@@ -140,15 +216,20 @@ func (c *Client) Query(query string, evalTime time.Time) ([]VectorSample, error)
 	return samples, nil
 }
 
-// QueryRange executes a range PromQL query.
-func (c *Client) QueryRange(query string, start, end time.Time, step time.Duration) ([]MatrixSeries, error) {
+// QueryRangeURL returns the request URL QueryRange would send, for reproducing it with curl.
+func (c *Client) QueryRangeURL(query string, start, end time.Time, step time.Duration) string {
 	params := url.Values{}
 	params.Set("query", query)
 	params.Set("start", fmt.Sprintf("%d", start.Unix()))
 	params.Set("end", fmt.Sprintf("%d", end.Unix()))
 	params.Set("step", fmt.Sprintf("%g", step.Seconds()))
+	c.setQueryTimeoutParam(params)
+	return fmt.Sprintf("%s/api/v1/query_range?%s", c.baseURL, params.Encode())
+}
 
-	data, err := c.doGet(fmt.Sprintf("%s/api/v1/query_range?%s", c.baseURL, params.Encode()))
+// QueryRange executes a range PromQL query.
+func (c *Client) QueryRange(query string, start, end time.Time, step time.Duration) ([]MatrixSeries, error) {
+	data, err := c.doGet(c.QueryRangeURL(query, start, end, step))
 	if err != nil {
 		return nil, err
 	}
@@ -218,6 +299,36 @@ func (c *Client) LabelValues(label string, match []string) ([]string, error) {
 	return values, nil
 }
 
+// SeriesURL returns the request URL Series would send. Each selector becomes
+// its own match[] parameter; a zero start or end is left to the server.
+func (c *Client) SeriesURL(matches []string, start, end time.Time) string {
+	params := url.Values{}
+	for _, m := range matches {
+		params.Add("match[]", m)
+	}
+	if !start.IsZero() {
+		params.Set("start", fmt.Sprintf("%d", start.Unix()))
+	}
+	if !end.IsZero() {
+		params.Set("end", fmt.Sprintf("%d", end.Unix()))
+	}
+	return fmt.Sprintf("%s/api/v1/series?%s", c.baseURL, params.Encode())
+}
+
+// Series returns the label sets of the series matching any of the selectors.
+func (c *Client) Series(matches []string, start, end time.Time) ([]map[string]string, error) {
+	data, err := c.doGet(c.SeriesURL(matches, start, end))
+	if err != nil {
+		return nil, err
+	}
+
+	var series []map[string]string
+	if err := json.Unmarshal(data, &series); err != nil {
+		return nil, fmt.Errorf("failed to parse series: %w", err)
+	}
+	return series, nil
+}
+
 // targetsData wraps the targets API response shape
 type targetsData struct {
 	ActiveTargets  []ActiveTarget `json:"activeTargets"`
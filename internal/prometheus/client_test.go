@@ -0,0 +1,50 @@
+package prometheus
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSeriesEncodesMatchers(t *testing.T) {
+	matches := []string{`up{job="api"}`, `http_requests_total{code=~"5.."}`}
+	start := time.Unix(1700000000, 0)
+	end := time.Unix(1700003600, 0)
+
+	var gotPath string
+	var gotMatch []string
+	var gotStart, gotEnd string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		q := r.URL.Query()
+		gotMatch = q["match[]"]
+		gotStart, gotEnd = q.Get("start"), q.Get("end")
+		w.Write([]byte(`{"status":"success","data":[{"__name__":"up","job":"api"}]}`))
+	}))
+	defer srv.Close()
+
+	series, err := NewClient(srv.URL).Series(matches, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotPath != "/api/v1/series" {
+		t.Errorf("path = %q, want /api/v1/series", gotPath)
+	}
+	if strings.Join(gotMatch, " | ") != strings.Join(matches, " | ") {
+		t.Errorf("match[] = %q, want %q", gotMatch, matches)
+	}
+	if gotStart != "1700000000" || gotEnd != "1700003600" {
+		t.Errorf("start, end = %q, %q; want 1700000000, 1700003600", gotStart, gotEnd)
+	}
+	if len(series) != 1 || series[0]["job"] != "api" {
+		t.Errorf("series = %v, want one series with job=api", series)
+	}
+
+	// Without a time range only the selectors are sent
+	url := NewClient("http://prom:9090").SeriesURL([]string{"up"}, time.Time{}, time.Time{})
+	if want := "http://prom:9090/api/v1/series?match%5B%5D=up"; url != want {
+		t.Errorf("SeriesURL = %q, want %q", url, want)
+	}
+}
@@ -0,0 +1,121 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultDiscoveryTTL is how long a discovered Prometheus URL is reused
+// before the cluster is scanned again
+const DefaultDiscoveryTTL = 10 * time.Minute
+
+// DiscoveredEndpoint is a Prometheus URL found by Kubernetes discovery, the
+// pod it belongs to and the kube context it was found in
+type DiscoveredEndpoint struct {
+	URL          string    `json:"url"`
+	Namespace    string    `json:"namespace"`
+	Pod          string    `json:"pod"`
+	KubeContext  string    `json:"kube_context"`
+	DiscoveredAt time.Time `json:"discovered_at"`
+}
+
+// DiscoveryCache remembers the last discovered endpoint on disk so commands
+// without a configured URL don't scan the cluster on every invocation.
+// Entries are only valid for the kube context they were discovered in: a
+// port-forward to the previous cluster may still respond after a context
+// switch.
+type DiscoveryCache struct {
+	Path        string
+	TTL         time.Duration
+	KubeContext string
+}
+
+// DefaultDiscoveryCache returns the cache at ~/.dex/prometheus/discovered.json
+// for the given kube context
+func DefaultDiscoveryCache(kubeContext string) (*DiscoveryCache, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+	return &DiscoveryCache{
+		Path:        filepath.Join(home, ".dex", "prometheus", "discovered.json"),
+		TTL:         DefaultDiscoveryTTL,
+		KubeContext: kubeContext,
+	}, nil
+}
+
+// Load returns the cached endpoint if there is one younger than the TTL
+// that was discovered in the cache's kube context
+func (c *DiscoveryCache) Load() (*DiscoveredEndpoint, bool) {
+	data, err := os.ReadFile(c.Path)
+	if err != nil {
+		return nil, false
+	}
+	var ep DiscoveredEndpoint
+	if err := json.Unmarshal(data, &ep); err != nil || ep.URL == "" {
+		return nil, false
+	}
+	if ep.KubeContext != c.KubeContext {
+		return nil, false
+	}
+	ttl := c.TTL
+	if ttl <= 0 {
+		ttl = DefaultDiscoveryTTL
+	}
+	if time.Since(ep.DiscoveredAt) >= ttl {
+		return nil, false
+	}
+	return &ep, true
+}
+
+// Save stores ep, stamping DiscoveredAt with the current time and
+// KubeContext with the cache's context if unset
+func (c *DiscoveryCache) Save(ep DiscoveredEndpoint) error {
+	if ep.DiscoveredAt.IsZero() {
+		ep.DiscoveredAt = time.Now()
+	}
+	if ep.KubeContext == "" {
+		ep.KubeContext = c.KubeContext
+	}
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(ep, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(c.Path, data, 0600)
+}
+
+// Clear removes the cached endpoint
+func (c *DiscoveryCache) Clear() error {
+	if err := os.Remove(c.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// Resolve returns the cached endpoint if it is fresh, from the current kube
+// context and probe accepts its URL. Otherwise the cache is cleared, discover runs, and its result is saved.
+// fromCache reports whether the cached endpoint was used. A nil cache always
+// runs discover.
+func (c *DiscoveryCache) Resolve(probe func(url string) error, discover func() (*DiscoveredEndpoint, error)) (ep *DiscoveredEndpoint, fromCache bool, err error) {
+	if c != nil {
+		if cached, ok := c.Load(); ok && probe(cached.URL) == nil {
+			return cached, true, nil
+		}
+		c.Clear()
+	}
+
+	ep, err = discover()
+	if err != nil {
+		return nil, false, err
+	}
+	if c != nil {
+		c.Save(*ep)
+	}
+	return ep, false, nil
+}
@@ -0,0 +1,85 @@
+package prometheus
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestDiscoveryCacheResolve(t *testing.T) {
+	cache := &DiscoveryCache{Path: filepath.Join(t.TempDir(), "discovered.json"), TTL: 10 * time.Minute, KubeContext: "staging"}
+
+	discoveries := 0
+	next := "http://10.0.0.1:9090"
+	discover := func() (*DiscoveredEndpoint, error) {
+		discoveries++
+		return &DiscoveredEndpoint{URL: next, Namespace: "monitoring", Pod: "prometheus-0"}, nil
+	}
+	reachable := map[string]bool{}
+	probe := func(url string) error {
+		if !reachable[url] {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	// Empty cache: discover and save
+	reachable["http://10.0.0.1:9090"] = true
+	ep, cached, err := cache.Resolve(probe, discover)
+	if err != nil || cached || ep.URL != "http://10.0.0.1:9090" || discoveries != 1 {
+		t.Fatalf("first resolve: ep=%+v cached=%v err=%v discoveries=%d", ep, cached, err, discoveries)
+	}
+
+	// Fresh and reachable: reused without discovery
+	ep, cached, err = cache.Resolve(probe, discover)
+	if err != nil || !cached || ep.Pod != "prometheus-0" || discoveries != 1 {
+		t.Fatalf("cached resolve: ep=%+v cached=%v err=%v discoveries=%d", ep, cached, err, discoveries)
+	}
+
+	// Unreachable: discarded, discovery re-runs and the new URL is cached
+	reachable["http://10.0.0.1:9090"] = false
+	next = "http://10.0.0.2:9090"
+	reachable[next] = true
+	ep, cached, err = cache.Resolve(probe, discover)
+	if err != nil || cached || ep.URL != next || discoveries != 2 {
+		t.Fatalf("unreachable cache: ep=%+v cached=%v err=%v discoveries=%d", ep, cached, err, discoveries)
+	}
+	if saved, ok := cache.Load(); !ok || saved.URL != next {
+		t.Errorf("cache after rediscovery = %+v, want %s", saved, next)
+	}
+
+	// Other kube context: discarded even though the old URL still responds
+	other := &DiscoveryCache{Path: cache.Path, TTL: cache.TTL, KubeContext: "production"}
+	if _, ok := other.Load(); ok {
+		t.Error("entry from kube context staging loaded for production")
+	}
+	next = "http://10.1.0.1:9090"
+	reachable[next] = true
+	ep, cached, err = other.Resolve(probe, discover)
+	if err != nil || cached || ep.URL != next || discoveries != 3 {
+		t.Fatalf("context switch: ep=%+v cached=%v err=%v discoveries=%d", ep, cached, err, discoveries)
+	}
+	if saved, ok := other.Load(); !ok || saved.KubeContext != "production" {
+		t.Errorf("cache after context switch = %+v, want kube_context production", saved)
+	}
+	next = "http://10.0.0.2:9090"
+
+	// Stale: discarded even though still reachable
+	if err := cache.Save(DiscoveredEndpoint{URL: next, DiscoveredAt: time.Now().Add(-11 * time.Minute)}); err != nil {
+		t.Fatal(err)
+	}
+	if _, cached, _ = cache.Resolve(probe, discover); cached || discoveries != 4 {
+		t.Errorf("stale cache: cached=%v discoveries=%d, want rediscovery", cached, discoveries)
+	}
+
+	// Failed discovery leaves no cache behind
+	reachable[next] = false
+	failing := func() (*DiscoveredEndpoint, error) { return nil, errors.New("no pods") }
+	if _, _, err = cache.Resolve(probe, failing); err == nil {
+		t.Error("expected discovery error")
+	}
+	if _, ok := cache.Load(); ok {
+		t.Error("unreachable endpoint still cached after failed discovery")
+	}
+}
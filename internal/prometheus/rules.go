@@ -0,0 +1,113 @@
+package prometheus
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Rule is an alerting or recording rule from /api/v1/rules
+type Rule struct {
+	Name           string            `json:"name"`
+	Query          string            `json:"query"`
+	Type           string            `json:"type"`               // alerting, recording
+	Duration       float64           `json:"duration,omitempty"` // "for" in seconds (alerting only)
+	Labels         map[string]string `json:"labels,omitempty"`
+	Annotations    map[string]string `json:"annotations,omitempty"`
+	Alerts         []Alert           `json:"alerts,omitempty"`
+	State          string            `json:"state,omitempty"` // firing, pending, inactive (alerting only)
+	Health         string            `json:"health"`
+	LastError      string            `json:"lastError,omitempty"`
+	LastEvaluation time.Time         `json:"lastEvaluation"`
+	EvaluationTime float64           `json:"evaluationTime"`
+}
+
+// RuleGroup is a named group of rules evaluated together
+type RuleGroup struct {
+	Name     string  `json:"name"`
+	File     string  `json:"file"`
+	Interval float64 `json:"interval"`
+	Rules    []Rule  `json:"rules"`
+}
+
+// rulesData wraps the rules API response shape
+type rulesData struct {
+	Groups []RuleGroup `json:"groups"`
+}
+
+// Rules returns the loaded rule groups. ruleType may be "alert", "record" or
+// empty for both.
+func (c *Client) Rules(ruleType string) ([]RuleGroup, error) {
+	endpoint := fmt.Sprintf("%s/api/v1/rules", c.baseURL)
+	if ruleType != "" {
+		endpoint += "?" + url.Values{"type": {ruleType}}.Encode()
+	}
+
+	data, err := c.doGet(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	var rd rulesData
+	if err := json.Unmarshal(data, &rd); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+
+	return rd.Groups, nil
+}
+
+// GroupedRule is a rule together with the group it belongs to
+type GroupedRule struct {
+	Group string `json:"group"`
+	Rule
+}
+
+// FindAlertingRules returns the alerting rules named name. Names are matched
+// exactly; if nothing matches, a case-insensitive match is tried. The same
+// alert name may be defined in several groups, so more than one rule can be
+// returned.
+func FindAlertingRules(groups []RuleGroup, name string) []GroupedRule {
+	var exact, folded []GroupedRule
+	for _, g := range groups {
+		for _, r := range g.Rules {
+			if r.Type != "alerting" {
+				continue
+			}
+			switch {
+			case r.Name == name:
+				exact = append(exact, GroupedRule{Group: g.Name, Rule: r})
+			case strings.EqualFold(r.Name, name):
+				folded = append(folded, GroupedRule{Group: g.Name, Rule: r})
+			}
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return folded
+}
+
+// FilterRuleGroups keeps only rules of ruleType ("alerting" or "recording")
+// and drops groups left empty. Older Prometheus versions ignore the type
+// parameter of /api/v1/rules, so the API result is filtered again here.
+func FilterRuleGroups(groups []RuleGroup, ruleType string) []RuleGroup {
+	if ruleType == "" {
+		return groups
+	}
+	var out []RuleGroup
+	for _, g := range groups {
+		var rules []Rule
+		for _, r := range g.Rules {
+			if r.Type == ruleType {
+				rules = append(rules, r)
+			}
+		}
+		if len(rules) > 0 {
+			g.Rules = rules
+			out = append(out, g)
+		}
+	}
+	return out
+}
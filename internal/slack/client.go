@@ -1,10 +1,13 @@
 package slack
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -81,6 +84,42 @@ func (c *Client) PostMessage(channelID, text string) (string, error) {
 	return timestamp, nil
 }
 
+// textEscaper escapes the characters Slack reserves for its control sequences
+var textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+// EscapeText escapes &, < and > so Slack shows them literally instead of
+// interpreting them as links, mentions or entities
+func EscapeText(text string) string {
+	return textEscaper.Replace(text)
+}
+
+// MaxMessageLength is the longest message text Slack accepts; longer text is
+// truncated by the API
+const MaxMessageLength = 40000
+
+// CodeBlock wraps text in a ``` code block, escaping it so it shows verbatim.
+// Trailing newlines are dropped so the block has no empty last line.
+func CodeBlock(text string) string {
+	return "```\n" + EscapeText(strings.TrimRight(text, "\r\n")) + "\n```"
+}
+
+// PostLiteralMessage sends text exactly as given: special characters are
+// escaped and mrkdwn formatting is disabled. threadTS is optional.
+func (c *Client) PostLiteralMessage(channelID, threadTS, text string) (string, error) {
+	opts := []slack.MsgOption{
+		slack.MsgOptionText(EscapeText(text), false),
+		slack.MsgOptionDisableMarkdown(),
+	}
+	if threadTS != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTS))
+	}
+	_, timestamp, err := c.api.PostMessage(channelID, opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to post message: %w", err)
+	}
+	return timestamp, nil
+}
+
 // PostMessageWithBlocks sends a message with Block Kit blocks
 func (c *Client) PostMessageWithBlocks(channelID, fallbackText string, blocks []slack.Block) (string, error) {
 	_, timestamp, err := c.api.PostMessage(
@@ -94,6 +133,57 @@ func (c *Client) PostMessageWithBlocks(channelID, fallbackText string, blocks []
 	return timestamp, nil
 }
 
+// MaxScheduleAhead is how far in the future chat.scheduleMessage accepts a post time
+const MaxScheduleAhead = 120 * 24 * time.Hour
+
+// ScheduleMessage schedules text to be posted to a channel (or thread, if
+// threadTS is set) at postAt. Returns the scheduled message ID.
+func (c *Client) ScheduleMessage(channelID, threadTS, text string, postAt time.Time) (string, error) {
+	opts := []slack.MsgOption{slack.MsgOptionText(text, false)}
+	if threadTS != "" {
+		opts = append(opts, slack.MsgOptionTS(threadTS))
+	}
+	_, id, err := c.api.ScheduleMessage(channelID, strconv.FormatInt(postAt.Unix(), 10), opts...)
+	if err != nil {
+		return "", fmt.Errorf("failed to schedule message: %w", err)
+	}
+	return id, nil
+}
+
+// ListScheduledMessages returns the pending scheduled messages of the token's
+// identity, optionally limited to one channel, soonest first
+func (c *Client) ListScheduledMessages(channelID string) ([]slack.ScheduledMessage, error) {
+	var all []slack.ScheduledMessage
+	params := &slack.GetScheduledMessagesParameters{Channel: channelID, Limit: 100}
+	for {
+		msgs, cursor, err := c.api.GetScheduledMessages(params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list scheduled messages: %w", err)
+		}
+		all = append(all, msgs...)
+		if cursor == "" {
+			break
+		}
+		params.Cursor = cursor
+	}
+	sort.Slice(all, func(i, j int) bool {
+		return all[i].PostAt < all[j].PostAt
+	})
+	return all, nil
+}
+
+// CancelScheduledMessage deletes a pending scheduled message
+func (c *Client) CancelScheduledMessage(channelID, id string) error {
+	_, err := c.api.DeleteScheduledMessage(&slack.DeleteScheduledMessageParameters{
+		Channel:            channelID,
+		ScheduledMessageID: id,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cancel scheduled message: %w", err)
+	}
+	return nil
+}
+
 // UpdateMessage edits an existing message
 func (c *Client) UpdateMessage(channelID, timestamp, text string) (string, error) {
 	_, ts, _, err := c.api.UpdateMessage(channelID, timestamp, slack.MsgOptionText(text, false))
@@ -122,6 +212,26 @@ func (c *Client) JoinChannel(channelID string) error {
 	return nil
 }
 
+// SetChannelTopic sets a channel's topic and returns the topic Slack stored.
+// The identity (bot or user) must be a member of the channel.
+func (c *Client) SetChannelTopic(channelID, topic string) (string, error) {
+	channel, err := c.api.SetTopicOfConversation(channelID, topic)
+	if err != nil {
+		return "", fmt.Errorf("failed to set topic: %w", err)
+	}
+	return channel.Topic.Value, nil
+}
+
+// SetChannelPurpose sets a channel's purpose (description) and returns the
+// purpose Slack stored. The identity (bot or user) must be a member of the channel.
+func (c *Client) SetChannelPurpose(channelID, purpose string) (string, error) {
+	channel, err := c.api.SetPurposeOfConversation(channelID, purpose)
+	if err != nil {
+		return "", fmt.Errorf("failed to set purpose: %w", err)
+	}
+	return channel.Purpose.Value, nil
+}
+
 // ReplyToThread sends a reply to a thread
 func (c *Client) ReplyToThread(channelID, threadTS, text string) (string, error) {
 	_, timestamp, err := c.api.PostMessage(
@@ -396,6 +506,65 @@ func (c *Client) GetChannelInfo(channelID string) (*slack.Channel, error) {
 	return channel, nil
 }
 
+// ConversationKind describes a conversation returned by conversations.info:
+// "DM", "group DM (MPDM)", "private channel" or "public channel".
+func ConversationKind(ch *slack.Channel) string {
+	switch {
+	case ch.IsIM:
+		return "DM"
+	case ch.IsMpIM:
+		return "group DM (MPDM)"
+	case ch.IsPrivate || ch.IsGroup:
+		return "private channel"
+	default:
+		return "public channel"
+	}
+}
+
+// PostBlocker explains why posting to a conversation will fail, so callers
+// can give a clear local error instead of Slack's bare channel_not_found or
+// not_in_channel. ch is the conversation as seen by the posting identity, or
+// nil if that identity can't see it; userView is the user token's view and is
+// only consulted when ch is nil. asUser tells which identity posts and
+// hasUserToken whether --as user is an alternative. Returns "" when posting
+// should work or nothing is known. The bot may post to public channels it
+// hasn't joined (chat:write.public), so those are not blocked.
+func PostBlocker(ch, userView *slack.Channel, asUser, hasUserToken bool) string {
+	if ch == nil {
+		if userView == nil || asUser {
+			return ""
+		}
+		if userView.IsMpIM {
+			return "it is a group DM (MPDM) the bot is not part of; bots can't post there. Use --as user"
+		}
+		return fmt.Sprintf("the bot can't see this %s (it is not a member). Invite the bot (/invite) or use --as user", ConversationKind(userView))
+	}
+
+	name := "this " + ConversationKind(ch)
+	if ch.Name != "" && !ch.IsIM && !ch.IsMpIM {
+		name = "#" + ch.Name
+	}
+
+	switch {
+	case ch.IsArchived:
+		return name + " is archived and can't receive messages"
+	case ch.IsIM || ch.IsMember:
+		return ""
+	case asUser:
+		return "you are not a member of " + name + "; join it first"
+	case ch.IsMpIM && !hasUserToken:
+		return "bots can't post in group DMs (MPDMs) they are not part of. Posting needs a user token: set SLACK_USER_TOKEN and use --as user"
+	case ch.IsMpIM:
+		return "bots can't post in group DMs (MPDMs) they are not part of. Use --as user"
+	case !ch.IsPrivate && !ch.IsGroup:
+		return ""
+	case hasUserToken:
+		return "the bot is not a member of " + name + ". Use --as user or invite the bot (/invite)"
+	default:
+		return "the bot is not a member of " + name + ". Invite the bot (/invite)"
+	}
+}
+
 // ListChannels lists all channels visible to the user (or bot as fallback).
 // Using the user token returns private channels the bot hasn't joined.
 func (c *Client) ListChannels() ([]slack.Channel, error) {
@@ -515,7 +684,8 @@ func (c *Client) SearchMessages(query string, count int) ([]slack.SearchMessage,
 
 // SearchMentions searches for mentions of a user using the search API
 // Returns mentions sorted by timestamp descending. Requires user token.
-func (c *Client) SearchMentions(userID string, limit int, since int64) ([]Mention, int, error) {
+// If channelIDs are given, only mentions in those channels are returned.
+func (c *Client) SearchMentions(userID string, limit int, since int64, channelIDs ...string) ([]Mention, int, error) {
 	if c.userAPI == nil {
 		return nil, 0, fmt.Errorf("user token required for search")
 	}
@@ -527,6 +697,11 @@ func (c *Client) SearchMentions(userID string, limit int, since int64) ([]Mentio
 		sinceTime := time.Unix(since, 0).AddDate(0, 0, -1)
 		query += fmt.Sprintf(" after:%s", sinceTime.Format("2006-01-02"))
 	}
+	query += SearchChannelFilter(channelIDs)
+	inChannel := make(map[string]bool, len(channelIDs))
+	for _, id := range channelIDs {
+		inChannel[id] = true
+	}
 
 	params := slack.SearchParameters{
 		Sort:          "timestamp",
@@ -549,6 +724,9 @@ func (c *Client) SearchMentions(userID string, limit int, since int64) ([]Mentio
 				continue
 			}
 		}
+		if len(inChannel) > 0 && !inChannel[msg.Channel.ID] {
+			continue
+		}
 		mentions = append(mentions, Mention{
 			ChannelID:   msg.Channel.ID,
 			ChannelName: msg.Channel.Name,
@@ -565,6 +743,17 @@ func (c *Client) SearchMentions(userID string, limit int, since int64) ([]Mentio
 	return mentions, len(mentions), nil
 }
 
+// SearchChannelFilter returns the search modifiers restricting a query to the
+// given channel IDs, e.g. " in:<#C01> in:<#C02>". Slack matches messages in
+// any of the listed channels. Empty for no channels.
+func SearchChannelFilter(channelIDs []string) string {
+	var b strings.Builder
+	for _, id := range channelIDs {
+		fmt.Fprintf(&b, " in:<#%s>", id)
+	}
+	return b.String()
+}
+
 // MentionStatus indicates whether a mention has been handled
 type MentionStatus string
 
@@ -684,17 +873,59 @@ func (c *Client) GetBotID() (string, error) {
 	return resp.BotID, nil
 }
 
+// GetPermalink returns the permalink of a message
+func (c *Client) GetPermalink(channelID, timestamp string) (string, error) {
+	link, err := c.preferredReadAPI().GetPermalink(&slack.PermalinkParameters{
+		Channel: channelID,
+		Ts:      timestamp,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to get permalink: %w", err)
+	}
+	return link, nil
+}
+
+// ErrAlreadyReacted is returned by AddReaction when the identity already
+// reacted to the message with that emoji
+var ErrAlreadyReacted = errors.New("already reacted with this emoji")
+
+// ErrNoReaction is returned by RemoveReaction when the identity has no such
+// reaction on the message
+var ErrNoReaction = errors.New("no such reaction to remove")
+
+// NormalizeEmoji turns user input like ":Thumbsup:" or " eyes " into the
+// reaction name the API expects. Skin tone suffixes are kept
+// (":+1::skin-tone-2:" becomes "+1::skin-tone-2").
+func NormalizeEmoji(emoji string) string {
+	return strings.ToLower(strings.Trim(strings.TrimSpace(emoji), ":"))
+}
+
 // AddReaction adds an emoji reaction to a message using c.api.
 // The caller selects the identity by constructing the client with the appropriate token
 // (bot or user) via slackClientFor in the CLI layer.
 func (c *Client) AddReaction(channelID, timestamp, emoji string) error {
 	item := slack.NewRefToMessage(channelID, timestamp)
 	if err := c.api.AddReaction(emoji, item); err != nil {
+		if err.Error() == "already_reacted" {
+			return ErrAlreadyReacted
+		}
 		return fmt.Errorf("failed to add reaction: %w", err)
 	}
 	return nil
 }
 
+// RemoveReaction removes the identity's emoji reaction from a message using c.api.
+func (c *Client) RemoveReaction(channelID, timestamp, emoji string) error {
+	item := slack.NewRefToMessage(channelID, timestamp)
+	if err := c.api.RemoveReaction(emoji, item); err != nil {
+		if err.Error() == "no_reaction" {
+			return ErrNoReaction
+		}
+		return fmt.Errorf("failed to remove reaction: %w", err)
+	}
+	return nil
+}
+
 // Bookmark represents a single bookmark in a Slack channel.
 type Bookmark struct {
 	ID        string `json:"id"`
@@ -0,0 +1,120 @@
+package slack
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+func TestEscapeText(t *testing.T) {
+	for in, want := range map[string]string{
+		"if a < b && b > c":         "if a &lt; b &amp;&amp; b &gt; c",
+		"<https://example.com|x>":   "&lt;https://example.com|x&gt;",
+		"already &amp; escaped":     "already &amp;amp; escaped",
+		"*bold* stays as is @alice": "*bold* stays as is @alice",
+	} {
+		if got := EscapeText(in); got != want {
+			t.Errorf("EscapeText(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestCodeBlock(t *testing.T) {
+	got := CodeBlock("level=error msg=\"a < b\"\nexit 1\n\n")
+	want := "```\nlevel=error msg=\"a &lt; b\"\nexit 1\n```"
+	if got != want {
+		t.Errorf("CodeBlock() = %q, want %q", got, want)
+	}
+}
+
+func TestConversationKind(t *testing.T) {
+	tests := []struct {
+		ch   slack.Channel
+		want string
+	}{
+		{slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{IsIM: true}}}, "DM"},
+		{slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{IsMpIM: true, IsPrivate: true}}}, "group DM (MPDM)"},
+		{slack.Channel{GroupConversation: slack.GroupConversation{Conversation: slack.Conversation{IsPrivate: true}}}, "private channel"},
+		{slack.Channel{}, "public channel"},
+	}
+	for _, tt := range tests {
+		if got := ConversationKind(&tt.ch); got != tt.want {
+			t.Errorf("ConversationKind(%+v) = %q, want %q", tt.ch.Conversation, got, tt.want)
+		}
+	}
+}
+
+func TestPostBlocker(t *testing.T) {
+	conv := func(c slack.Conversation, name string, member bool) *slack.Channel {
+		return &slack.Channel{GroupConversation: slack.GroupConversation{Conversation: c, Name: name}, IsMember: member}
+	}
+	public := conv(slack.Conversation{}, "general", false)
+	private := conv(slack.Conversation{IsPrivate: true}, "secret", false)
+	mpim := conv(slack.Conversation{IsMpIM: true, IsPrivate: true}, "mpdm-a--b-1", false)
+	archived := conv(slack.Conversation{}, "old", true)
+	archived.IsArchived = true
+	im := conv(slack.Conversation{IsIM: true}, "", false)
+	joined := conv(slack.Conversation{IsPrivate: true}, "team", true)
+
+	tests := []struct {
+		name         string
+		ch, userView *slack.Channel
+		asUser       bool
+		hasUserToken bool
+		want         string // substring, "" means no blocker
+	}{
+		{"member", joined, nil, false, false, ""},
+		{"DM", im, nil, false, false, ""},
+		{"public non-member bot", public, nil, false, false, ""},
+		{"archived", archived, nil, false, true, "#old is archived"},
+		{"archived as user", archived, nil, true, true, "is archived"},
+		{"non-member as user", public, nil, true, true, "not a member of #general; join it"},
+		{"private bot with user token", private, nil, false, true, "Use --as user or invite the bot"},
+		{"private bot only", private, nil, false, false, "Invite the bot"},
+		{"MPDM bot only", mpim, nil, false, false, "set SLACK_USER_TOKEN"},
+		{"MPDM bot with user token", mpim, nil, false, true, "Use --as user"},
+		{"hidden from bot, MPDM", nil, mpim, false, true, "group DM (MPDM) the bot is not part of"},
+		{"hidden from bot, private", nil, private, false, true, "can't see this private channel"},
+		{"hidden, no user view", nil, nil, false, false, ""},
+	}
+	for _, tt := range tests {
+		got := PostBlocker(tt.ch, tt.userView, tt.asUser, tt.hasUserToken)
+		if tt.want == "" && got != "" || tt.want != "" && !strings.Contains(got, tt.want) {
+			t.Errorf("%s: PostBlocker = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+	if got := PostBlocker(private, nil, false, false); strings.Contains(got, "--as user") {
+		t.Errorf("suggests --as user without a user token: %q", got)
+	}
+}
+
+func TestNormalizeEmoji(t *testing.T) {
+	for in, want := range map[string]string{
+		"thumbsup":           "thumbsup",
+		":thumbsup:":         "thumbsup",
+		" :Eyes: ":           "eyes",
+		"::white_check_mark": "white_check_mark",
+		":+1::skin-tone-2:":  "+1::skin-tone-2",
+	} {
+		if got := NormalizeEmoji(in); got != want {
+			t.Errorf("NormalizeEmoji(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSearchChannelFilter(t *testing.T) {
+	tests := []struct {
+		ids  []string
+		want string
+	}{
+		{nil, ""},
+		{[]string{"C0123456789"}, " in:<#C0123456789>"},
+		{[]string{"C01", "G02"}, " in:<#C01> in:<#G02>"},
+	}
+	for _, tt := range tests {
+		if got := SearchChannelFilter(tt.ids); got != tt.want {
+			t.Errorf("SearchChannelFilter(%v) = %q, want %q", tt.ids, got, tt.want)
+		}
+	}
+}
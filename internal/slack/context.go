@@ -0,0 +1,186 @@
+package slack
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// ErrContextBudget is returned by MessageWindowCache.Around once the fetch budget is used up
+var ErrContextBudget = errors.New("context fetch budget exhausted")
+
+// ContextMessage is a channel message surrounding a search match
+type ContextMessage struct {
+	TS       string `json:"ts"`
+	UserID   string `json:"user_id,omitempty"`
+	Username string `json:"username,omitempty"`
+	Time     string `json:"time"`
+	Text     string `json:"text"`
+}
+
+// messageWindow is a contiguous, gap-free stretch of channel history
+type messageWindow struct {
+	msgs          []slack.Message // ascending by ts
+	from, to      float64         // covered ts range (inclusive)
+	startComplete bool            // nothing older than from exists
+	endComplete   bool            // nothing newer than to exists (at fetch time)
+}
+
+// MessageWindowCache fetches the messages around a timestamp and keeps the
+// fetched windows per channel, so matches close to each other share history
+// calls. Each uncached lookup costs two conversations.history calls.
+type MessageWindowCache struct {
+	history    func(*slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error)
+	windows    map[string][]*messageWindow
+	fetches    int
+	maxFetches int
+}
+
+// NewMessageWindowCache creates a cache that makes at most maxFetches history calls (0 = unbounded)
+func (c *Client) NewMessageWindowCache(maxFetches int) *MessageWindowCache {
+	return newMessageWindowCache(c.preferredReadAPI().GetConversationHistory, maxFetches)
+}
+
+func newMessageWindowCache(history func(*slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error), maxFetches int) *MessageWindowCache {
+	return &MessageWindowCache{
+		history:    history,
+		windows:    make(map[string][]*messageWindow),
+		maxFetches: maxFetches,
+	}
+}
+
+// Fetches returns the number of history calls made so far
+func (w *MessageWindowCache) Fetches() int {
+	return w.fetches
+}
+
+// Around returns up to n messages before and after ts in the channel, oldest first.
+// Thread replies are not part of channel history and are not included.
+func (w *MessageWindowCache) Around(channelID, ts string, n int) (before, after []ContextMessage, err error) {
+	at, err := strconv.ParseFloat(ts, 64)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid message timestamp %q", ts)
+	}
+
+	for _, win := range w.windows[channelID] {
+		if b, a, ok := win.around(at, n); ok {
+			return b, a, nil
+		}
+	}
+
+	if w.maxFetches > 0 && w.fetches+2 > w.maxFetches {
+		return nil, nil, ErrContextBudget
+	}
+
+	// Older messages: the n newest before ts
+	olderResp, err := w.fetch(&slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Latest:    ts,
+		Inclusive: true,
+		Limit:     n + 1,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	// Newer messages: with only oldest set, history starts right after it
+	newerResp, err := w.fetch(&slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Oldest:    ts,
+		Limit:     n,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	win := &messageWindow{
+		from:          at,
+		to:            at,
+		startComplete: !olderResp.HasMore,
+		endComplete:   !newerResp.HasMore,
+	}
+	seen := make(map[string]bool)
+	for _, m := range append(olderResp.Messages, newerResp.Messages...) {
+		if seen[m.Timestamp] {
+			continue
+		}
+		seen[m.Timestamp] = true
+		win.msgs = append(win.msgs, m)
+	}
+	sort.Slice(win.msgs, func(i, j int) bool {
+		return tsFloat(win.msgs[i].Timestamp) < tsFloat(win.msgs[j].Timestamp)
+	})
+	if len(win.msgs) > 0 {
+		win.from = min(win.from, tsFloat(win.msgs[0].Timestamp))
+		win.to = max(win.to, tsFloat(win.msgs[len(win.msgs)-1].Timestamp))
+	}
+	w.windows[channelID] = append(w.windows[channelID], win)
+
+	before, after, _ = win.around(at, n)
+	return before, after, nil
+}
+
+func (w *MessageWindowCache) fetch(params *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	for {
+		w.fetches++
+		resp, err := w.history(params)
+		if err != nil {
+			if rateLimitErr, ok := err.(*slack.RateLimitedError); ok {
+				time.Sleep(rateLimitErr.RetryAfter)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get channel history: %w", err)
+		}
+		return resp, nil
+	}
+}
+
+// around slices n messages on each side of at out of the window. ok is false
+// when the window does not contain at or cannot prove it holds n neighbours.
+func (win *messageWindow) around(at float64, n int) (before, after []ContextMessage, ok bool) {
+	if at < win.from || at > win.to {
+		return nil, nil, false
+	}
+	idx := sort.Search(len(win.msgs), func(i int) bool {
+		return tsFloat(win.msgs[i].Timestamp) >= at
+	})
+	end := idx
+	if end < len(win.msgs) && tsFloat(win.msgs[end].Timestamp) == at {
+		end++ // skip the match itself
+	}
+
+	start := max(idx-n, 0)
+	if idx-start < n && !win.startComplete {
+		return nil, nil, false
+	}
+	stop := min(end+n, len(win.msgs))
+	if stop-end < n && !win.endComplete {
+		return nil, nil, false
+	}
+
+	for _, m := range win.msgs[start:idx] {
+		before = append(before, toContextMessage(m))
+	}
+	for _, m := range win.msgs[end:stop] {
+		after = append(after, toContextMessage(m))
+	}
+	return before, after, true
+}
+
+func toContextMessage(m slack.Message) ContextMessage {
+	return ContextMessage{
+		TS:       m.Timestamp,
+		UserID:   m.User,
+		Username: m.Username,
+		Time:     parseUnixTS(m.Timestamp).Format("15:04"),
+		Text:     messageDisplayText(extractMessageText(m), convertAttachments(m.Attachments)),
+	}
+}
+
+func tsFloat(ts string) float64 {
+	f, _ := strconv.ParseFloat(ts, 64)
+	return f
+}
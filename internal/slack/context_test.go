@@ -0,0 +1,143 @@
+package slack
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/slack-go/slack"
+)
+
+// fakeHistory serves conversations.history from an in-memory channel with
+// messages at ts 1000.000000, 1001.000000, ... (count messages).
+func fakeHistory(count int, calls *int) func(*slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+	var all []slack.Message
+	for i := 0; i < count; i++ {
+		m := slack.Message{}
+		m.Timestamp = fmt.Sprintf("%d.000000", 1000+i)
+		m.User = "U1"
+		m.Text = fmt.Sprintf("msg %d", i)
+		all = append(all, m)
+	}
+	return func(p *slack.GetConversationHistoryParameters) (*slack.GetConversationHistoryResponse, error) {
+		*calls++
+		oldest, _ := strconv.ParseFloat(p.Oldest, 64)
+		latest := 1e18
+		if p.Latest != "" {
+			latest, _ = strconv.ParseFloat(p.Latest, 64)
+		}
+		var in []slack.Message
+		for _, m := range all {
+			ts := tsFloat(m.Timestamp)
+			if ts > oldest && (ts < latest || (p.Inclusive && ts == latest)) {
+				in = append(in, m)
+			}
+		}
+		resp := &slack.GetConversationHistoryResponse{}
+		if p.Latest != "" {
+			// newest first, from latest backwards
+			sort.Slice(in, func(i, j int) bool { return tsFloat(in[i].Timestamp) > tsFloat(in[j].Timestamp) })
+			if len(in) > p.Limit {
+				in, resp.HasMore = in[:p.Limit], true
+			}
+		} else {
+			// only oldest: the messages right after it
+			if len(in) > p.Limit {
+				in, resp.HasMore = in[:p.Limit], true
+			}
+			sort.Slice(in, func(i, j int) bool { return tsFloat(in[i].Timestamp) > tsFloat(in[j].Timestamp) })
+		}
+		resp.Messages = in
+		return resp, nil
+	}
+}
+
+func contextTexts(msgs []ContextMessage) []string {
+	var out []string
+	for _, m := range msgs {
+		out = append(out, m.Text)
+	}
+	return out
+}
+
+func TestMessageWindowCacheAround(t *testing.T) {
+	calls := 0
+	cache := newMessageWindowCache(fakeHistory(20, &calls), 0)
+
+	before, after, err := cache.Around("C1", "1010.000000", 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := fmt.Sprint(contextTexts(before)); got != "[msg 8 msg 9]" {
+		t.Errorf("before = %s", got)
+	}
+	if got := fmt.Sprint(contextTexts(after)); got != "[msg 11 msg 12]" {
+		t.Errorf("after = %s", got)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 history calls, got %d", calls)
+	}
+
+	// A neighbouring match with enough history inside the window is served from cache
+	before, after, err = cache.Around("C1", "1009.000000", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(contextTexts(before)) != "[msg 8]" || fmt.Sprint(contextTexts(after)) != "[msg 10]" {
+		t.Errorf("cached lookup: before=%v after=%v", contextTexts(before), contextTexts(after))
+	}
+	if calls != 2 {
+		t.Errorf("expected cached lookup, got %d calls", calls)
+	}
+
+	// Not enough neighbours in the window: fetches again
+	if _, _, err := cache.Around("C1", "1009.000000", 3); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if calls != 4 {
+		t.Errorf("expected a new fetch, got %d calls", calls)
+	}
+}
+
+func TestMessageWindowCacheChannelEdges(t *testing.T) {
+	calls := 0
+	cache := newMessageWindowCache(fakeHistory(5, &calls), 0)
+
+	before, after, err := cache.Around("C1", "1000.000000", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(before) != 0 || fmt.Sprint(contextTexts(after)) != "[msg 1 msg 2 msg 3]" {
+		t.Errorf("first message: before=%v after=%v", contextTexts(before), contextTexts(after))
+	}
+
+	before, after, err = cache.Around("C1", "1004.000000", 3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if fmt.Sprint(contextTexts(before)) != "[msg 1 msg 2 msg 3]" || len(after) != 0 {
+		t.Errorf("last message: before=%v after=%v", contextTexts(before), contextTexts(after))
+	}
+
+	// The first window holds msg 0..3 from the channel start, so msg 1 is a cache hit
+	calls = 0
+	if _, _, err := cache.Around("C1", "1001.000000", 1); err != nil || calls != 0 {
+		t.Errorf("expected cache hit, err=%v calls=%d", err, calls)
+	}
+}
+
+func TestMessageWindowCacheBudget(t *testing.T) {
+	calls := 0
+	cache := newMessageWindowCache(fakeHistory(50, &calls), 3)
+
+	if _, _, err := cache.Around("C1", "1010.000000", 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, _, err := cache.Around("C1", "1040.000000", 2); err != ErrContextBudget {
+		t.Errorf("expected ErrContextBudget, got %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("expected 2 calls, got %d", calls)
+	}
+}
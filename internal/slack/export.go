@@ -0,0 +1,171 @@
+package slack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// ExportMessage is a single message in a channel export
+type ExportMessage struct {
+	Timestamp   string              `json:"ts"`
+	Time        time.Time           `json:"time"`
+	UserID      string              `json:"user_id,omitempty"`
+	Username    string              `json:"username,omitempty"`
+	BotID       string              `json:"bot_id,omitempty"`
+	Subtype     string              `json:"subtype,omitempty"`
+	Text        string              `json:"text"`
+	ThreadTS    string              `json:"thread_ts,omitempty"`
+	ReplyCount  int                 `json:"reply_count,omitempty"`
+	Reactions   []ExportReaction    `json:"reactions,omitempty"`
+	Attachments []MessageAttachment `json:"attachments,omitempty"`
+	Files       []ThreadMessageFile `json:"files,omitempty"`
+	Replies     []ExportMessage     `json:"replies,omitempty"`
+}
+
+// ExportReaction is a reaction summary on an exported message
+type ExportReaction struct {
+	Name  string   `json:"name"`
+	Count int      `json:"count"`
+	Users []string `json:"users,omitempty"`
+}
+
+// ChannelExport is the full archive of a channel's history
+type ChannelExport struct {
+	ChannelID    string          `json:"channel_id"`
+	ChannelName  string          `json:"channel_name,omitempty"`
+	ExportedAt   time.Time       `json:"exported_at"`
+	Since        *time.Time      `json:"since,omitempty"`
+	Until        *time.Time      `json:"until,omitempty"`
+	Threads      bool            `json:"include_threads"`
+	MessageCount int             `json:"message_count"`
+	ReplyCount   int             `json:"reply_count"`
+	Messages     []ExportMessage `json:"messages"`
+}
+
+// ExportProgressFunc is called during an export with the number of messages and thread replies fetched so far
+type ExportProgressFunc func(messages, replies int)
+
+// ExportChannel paginates the full conversations.history of a channel (oldest-first),
+// optionally fetching all thread replies. since/until bound the range; zero values are unbounded.
+// Rate-limited requests are retried after the delay Slack asks for.
+func (c *Client) ExportChannel(channelID string, since, until time.Time, includeThreads bool, progress ExportProgressFunc) (*ChannelExport, error) {
+	api := c.preferredReadAPI()
+
+	exp := &ChannelExport{
+		ChannelID:  channelID,
+		ExportedAt: time.Now(),
+		Threads:    includeThreads,
+	}
+	params := &slack.GetConversationHistoryParameters{
+		ChannelID: channelID,
+		Limit:     200,
+	}
+	if !since.IsZero() {
+		exp.Since = &since
+		params.Oldest = fmt.Sprintf("%d.000000", since.Unix())
+	}
+	if !until.IsZero() {
+		exp.Until = &until
+		params.Latest = fmt.Sprintf("%d.000000", until.Unix())
+	}
+
+	var msgs []slack.Message
+	for {
+		history, err := api.GetConversationHistory(params)
+		if err != nil {
+			if rateLimitErr, ok := err.(*slack.RateLimitedError); ok {
+				time.Sleep(rateLimitErr.RetryAfter)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get channel history: %w", err)
+		}
+		msgs = append(msgs, history.Messages...)
+		if progress != nil {
+			progress(len(msgs), exp.ReplyCount)
+		}
+		if !history.HasMore || history.ResponseMetaData.NextCursor == "" {
+			break
+		}
+		params.Cursor = history.ResponseMetaData.NextCursor
+	}
+
+	// History is returned newest-first; archive oldest-first
+	for i, j := 0, len(msgs)-1; i < j; i, j = i+1, j-1 {
+		msgs[i], msgs[j] = msgs[j], msgs[i]
+	}
+
+	exp.Messages = make([]ExportMessage, 0, len(msgs))
+	for _, m := range msgs {
+		em := toExportMessage(m)
+		if includeThreads && m.ReplyCount > 0 && (m.ThreadTimestamp == "" || m.ThreadTimestamp == m.Timestamp) {
+			replies, err := c.fetchAllReplies(channelID, m.Timestamp)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range replies {
+				if r.Timestamp == m.Timestamp {
+					continue // parent is included in replies
+				}
+				em.Replies = append(em.Replies, toExportMessage(r))
+			}
+			exp.ReplyCount += len(em.Replies)
+			if progress != nil {
+				progress(len(msgs), exp.ReplyCount)
+			}
+		}
+		exp.Messages = append(exp.Messages, em)
+	}
+	exp.MessageCount = len(exp.Messages)
+
+	return exp, nil
+}
+
+// fetchAllReplies returns every message in a thread, following pagination cursors
+func (c *Client) fetchAllReplies(channelID, threadTS string) ([]slack.Message, error) {
+	api := c.preferredReadAPI()
+	params := &slack.GetConversationRepliesParameters{
+		ChannelID: channelID,
+		Timestamp: threadTS,
+		Limit:     200,
+	}
+
+	var all []slack.Message
+	for {
+		msgs, hasMore, cursor, err := api.GetConversationReplies(params)
+		if err != nil {
+			if rateLimitErr, ok := err.(*slack.RateLimitedError); ok {
+				time.Sleep(rateLimitErr.RetryAfter)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get thread replies for %s: %w", threadTS, err)
+		}
+		all = append(all, msgs...)
+		if !hasMore || cursor == "" {
+			break
+		}
+		params.Cursor = cursor
+	}
+	return all, nil
+}
+
+func toExportMessage(m slack.Message) ExportMessage {
+	em := ExportMessage{
+		Timestamp:   m.Timestamp,
+		Time:        parseUnixTS(m.Timestamp),
+		UserID:      m.User,
+		Username:    m.Username,
+		BotID:       m.BotID,
+		Subtype:     m.SubType,
+		Text:        extractMessageText(m),
+		ThreadTS:    m.ThreadTimestamp,
+		ReplyCount:  m.ReplyCount,
+		Attachments: convertAttachments(m.Attachments),
+		Files:       convertFiles(m.Files),
+	}
+	for _, r := range m.Reactions {
+		em.Reactions = append(em.Reactions, ExportReaction{Name: r.Name, Count: r.Count, Users: r.Users})
+	}
+	return em
+}
@@ -7,17 +7,36 @@ import (
 	"path/filepath"
 	"sort"
 	"time"
+
+	"github.com/slack-go/slack"
 )
 
-func indexDir() (string, error) {
+func indexDirPath() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
 	}
-	dir := filepath.Join(home, ".dex", "slack")
+	return filepath.Join(home, ".dex", "slack"), nil
+}
+
+func indexDir() (string, error) {
+	dir, err := indexDirPath()
+	if err != nil {
+		return "", err
+	}
 	return dir, os.MkdirAll(dir, 0700)
 }
 
+// IndexPath returns the location of the Slack index file without creating
+// its directory
+func IndexPath() (string, error) {
+	dir, err := indexDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "index.json"), nil
+}
+
 func indexFilePath() (string, error) {
 	dir, err := indexDir()
 	if err != nil {
@@ -86,18 +105,7 @@ func (c *Client) IndexAll(channelProgressFn, userProgressFn, groupProgressFn, me
 
 	total := len(channels)
 	for i, ch := range channels {
-		slackCh := SlackChannel{
-			ID:         ch.ID,
-			Name:       ch.Name,
-			IsPrivate:  ch.IsPrivate,
-			IsArchived: ch.IsArchived,
-			IsMember:   ch.IsMember,
-			NumMembers: ch.NumMembers,
-			Topic:      ch.Topic.Value,
-			Purpose:    ch.Purpose.Value,
-			IndexedAt:  time.Now(),
-		}
-		idx.UpsertChannel(slackCh)
+		idx.UpsertChannel(channelFromAPI(ch))
 
 		if channelProgressFn != nil {
 			channelProgressFn(i+1, total)
@@ -125,18 +133,7 @@ func (c *Client) IndexAll(channelProgressFn, userProgressFn, groupProgressFn, me
 			continue
 		}
 
-		slackUser := SlackUser{
-			ID:          u.ID,
-			Username:    u.Name,
-			DisplayName: u.Profile.DisplayName,
-			RealName:    u.RealName,
-			Email:       u.Profile.Email,
-			IsBot:       u.IsBot,
-			IsAdmin:     u.IsAdmin,
-			IsDeleted:   u.Deleted,
-			IndexedAt:   time.Now(),
-		}
-		idx.UpsertUser(slackUser)
+		idx.UpsertUser(userFromAPI(u))
 
 		if userProgressFn != nil {
 			userProgressFn(i+1, total)
@@ -148,34 +145,7 @@ func (c *Client) IndexAll(channelProgressFn, userProgressFn, groupProgressFn, me
 		return idx.Users[i].Username < idx.Users[j].Username
 	})
 
-	// Index user groups
-	groups, err := c.ListUserGroups()
-	if err != nil {
-		// Non-fatal: user groups are optional (may lack usergroups:read scope)
-		_ = err
-	} else {
-		total = len(groups)
-		for i, g := range groups {
-			ug := SlackUserGroup{
-				ID:          g.ID,
-				Handle:      g.Handle,
-				Name:        g.Name,
-				Description: g.Description,
-				UserCount:   g.UserCount,
-				IndexedAt:   time.Now(),
-			}
-			idx.UpsertUserGroup(ug)
-
-			if groupProgressFn != nil {
-				groupProgressFn(i+1, total)
-			}
-		}
-
-		// Sort user groups by handle
-		sort.Slice(idx.UserGroups, func(i, j int) bool {
-			return idx.UserGroups[i].Handle < idx.UserGroups[j].Handle
-		})
-	}
+	c.indexUserGroups(idx, groupProgressFn)
 
 	// Index channel members (public, non-archived only)
 	var memberChannels []int
@@ -184,26 +154,205 @@ func (c *Client) IndexAll(channelProgressFn, userProgressFn, groupProgressFn, me
 			memberChannels = append(memberChannels, i)
 		}
 	}
+	c.indexChannelMembers(idx, memberChannels, memberProgressFn)
+
+	idx.BuildLookupMaps()
+	return idx, nil
+}
+
+// IndexIncremental refreshes prev instead of rebuilding the index from scratch.
+//
+// The channel and user lists are still fetched in full, since the API has no
+// "changed since" filter, but only what changed since the last index is
+// rebuilt: channel members, the expensive part, are fetched only for new
+// channels and channels whose member count changed, and users keep their
+// indexed entry unless their profile was updated since then (users without an
+// updated time are kept as well). Channels and users missing from the lists
+// are removed. Without a previous full index of the same workspace this falls
+// back to IndexAll.
+func (c *Client) IndexIncremental(prev *SlackIndex, channelProgressFn, userProgressFn, groupProgressFn, memberProgressFn ProgressFunc) (*SlackIndex, error) {
+	auth, err := c.TestAuth()
+	if err != nil {
+		return nil, err
+	}
+	if prev == nil || prev.LastFullIndexAt.IsZero() || prev.TeamID != auth.TeamID {
+		return c.IndexAll(channelProgressFn, userProgressFn, groupProgressFn, memberProgressFn)
+	}
+
+	since := prev.LastFullIndexAt
+	if prev.LastIncrementalAt.After(since) {
+		since = prev.LastIncrementalAt
+	}
+
+	idx := NewSlackIndex(auth.TeamID, auth.Team)
+	idx.LastFullIndexAt = prev.LastFullIndexAt
+	idx.LastIncrementalAt = time.Now()
+
+	channels, err := c.ListChannels()
+	if err != nil {
+		return nil, err
+	}
+	if channelProgressFn != nil {
+		channelProgressFn(len(channels), len(channels))
+	}
+	current := make([]SlackChannel, 0, len(channels))
+	for _, ch := range channels {
+		current = append(current, channelFromAPI(ch))
+	}
+	var memberChannels []int
+	idx.Channels, memberChannels = mergeChannels(prev, current)
+
+	users, err := c.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	if userProgressFn != nil {
+		userProgressFn(len(users), len(users))
+	}
+	idx.Users = mergeUsers(prev, users, since)
+
+	c.indexUserGroups(idx, groupProgressFn)
+	c.indexChannelMembers(idx, memberChannels, memberProgressFn)
 
-	total = len(memberChannels)
-	for progress, ci := range memberChannels {
-		members, err := c.GetChannelMembers(idx.Channels[ci].ID)
-		if err != nil {
-			// Skip channels where we can't fetch members
-			if memberProgressFn != nil {
-				memberProgressFn(progress+1, total)
+	idx.BuildLookupMaps()
+	return idx, nil
+}
+
+// mergeChannels returns the current channel list sorted by name, carrying
+// over the indexed members from prev where the member count is unchanged.
+// Channels no longer listed are dropped. The second result holds the
+// positions of public, non-archived channels whose members must be fetched.
+func mergeChannels(prev *SlackIndex, current []SlackChannel) ([]SlackChannel, []int) {
+	if prev.ChannelsByID == nil {
+		prev.BuildLookupMaps()
+	}
+
+	merged := make([]SlackChannel, len(current))
+	copy(merged, current)
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Name < merged[j].Name
+	})
+
+	var stale []int
+	for i, ch := range merged {
+		if ch.IsPrivate || ch.IsArchived {
+			continue
+		}
+		if j, ok := prev.ChannelsByID[ch.ID]; ok {
+			old := prev.Channels[j]
+			if old.MemberIDs != nil && old.NumMembers == ch.NumMembers {
+				merged[i].MemberIDs = old.MemberIDs
+				continue
 			}
+		}
+		stale = append(stale, i)
+	}
+	return merged, stale
+}
+
+// mergeUsers returns the current user list sorted by username. Users already
+// in prev keep their entry unless Slack reports a profile update after since;
+// new users are added and deleted or missing ones dropped.
+func mergeUsers(prev *SlackIndex, users []slack.User, since time.Time) []SlackUser {
+	if prev.UsersByID == nil {
+		prev.BuildLookupMaps()
+	}
+
+	var merged []SlackUser
+	for _, u := range users {
+		if u.Deleted || u.ID == "USLACKBOT" {
 			continue
 		}
-		idx.Channels[ci].MemberIDs = members
+		if j, ok := prev.UsersByID[u.ID]; ok {
+			updated := time.Unix(int64(u.Updated), 0)
+			if u.Updated == 0 || !updated.After(since) {
+				merged = append(merged, prev.Users[j])
+				continue
+			}
+		}
+		merged = append(merged, userFromAPI(u))
+	}
+
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].Username < merged[j].Username
+	})
+	return merged
+}
 
-		if memberProgressFn != nil {
-			memberProgressFn(progress+1, total)
+// channelFromAPI converts a conversation from the API into an index entry
+func channelFromAPI(ch slack.Channel) SlackChannel {
+	return SlackChannel{
+		ID:         ch.ID,
+		Name:       ch.Name,
+		IsPrivate:  ch.IsPrivate,
+		IsArchived: ch.IsArchived,
+		IsMember:   ch.IsMember,
+		NumMembers: ch.NumMembers,
+		Topic:      ch.Topic.Value,
+		Purpose:    ch.Purpose.Value,
+		IndexedAt:  time.Now(),
+	}
+}
+
+// userFromAPI converts a user from the API into an index entry
+func userFromAPI(u slack.User) SlackUser {
+	return SlackUser{
+		ID:          u.ID,
+		Username:    u.Name,
+		DisplayName: u.Profile.DisplayName,
+		RealName:    u.RealName,
+		Email:       u.Profile.Email,
+		IsBot:       u.IsBot,
+		IsAdmin:     u.IsAdmin,
+		IsDeleted:   u.Deleted,
+		IndexedAt:   time.Now(),
+	}
+}
+
+// indexUserGroups adds all user groups to idx. Failures are ignored: user
+// groups are optional (the token may lack usergroups:read scope).
+func (c *Client) indexUserGroups(idx *SlackIndex, progressFn ProgressFunc) {
+	groups, err := c.ListUserGroups()
+	if err != nil {
+		return
+	}
+
+	total := len(groups)
+	for i, g := range groups {
+		ug := SlackUserGroup{
+			ID:          g.ID,
+			Handle:      g.Handle,
+			Name:        g.Name,
+			Description: g.Description,
+			UserCount:   g.UserCount,
+			IndexedAt:   time.Now(),
+		}
+		idx.UpsertUserGroup(ug)
+
+		if progressFn != nil {
+			progressFn(i+1, total)
 		}
 	}
 
-	idx.BuildLookupMaps()
-	return idx, nil
+	// Sort user groups by handle
+	sort.Slice(idx.UserGroups, func(i, j int) bool {
+		return idx.UserGroups[i].Handle < idx.UserGroups[j].Handle
+	})
+}
+
+// indexChannelMembers fetches the members of the channels at the given
+// positions in idx.Channels, skipping channels where that fails
+func (c *Client) indexChannelMembers(idx *SlackIndex, positions []int, progressFn ProgressFunc) {
+	total := len(positions)
+	for progress, ci := range positions {
+		if members, err := c.GetChannelMembers(idx.Channels[ci].ID); err == nil {
+			idx.Channels[ci].MemberIDs = members
+		}
+
+		if progressFn != nil {
+			progressFn(progress+1, total)
+		}
+	}
 }
 
 // ResolveChannel resolves a channel name or ID to a channel ID.
@@ -426,6 +575,16 @@ type MentionStatusCache struct {
 	Statuses map[string]MentionStatus `json:"statuses"`
 }
 
+// MentionCachePath returns the location of the mention status cache without
+// creating its directory
+func MentionCachePath() (string, error) {
+	dir, err := indexDirPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "mention_status_cache.json"), nil
+}
+
 func mentionCacheFilePath() (string, error) {
 	dir, err := indexDir()
 	if err != nil {
@@ -1,7 +1,11 @@
 package slack
 
 import (
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/slack-go/slack"
 )
 
 func TestResolveChannelRawConversationIDs(t *testing.T) {
@@ -221,3 +225,83 @@ func TestResolveGroupMentions(t *testing.T) {
 		})
 	}
 }
+
+func TestMergeChannels(t *testing.T) {
+	prev := NewSlackIndex("T1", "Team")
+	prev.UpsertChannel(SlackChannel{ID: "C1", Name: "general", NumMembers: 2, MemberIDs: []string{"U1", "U2"}})
+	prev.UpsertChannel(SlackChannel{ID: "C2", Name: "old-name", NumMembers: 1, MemberIDs: []string{"U1"}})
+	prev.UpsertChannel(SlackChannel{ID: "C3", Name: "deleted", NumMembers: 1, MemberIDs: []string{"U1"}})
+	prev.UpsertChannel(SlackChannel{ID: "C4", Name: "growing", NumMembers: 1, MemberIDs: []string{"U1"}})
+
+	current := []SlackChannel{
+		{ID: "C4", Name: "growing", NumMembers: 2},
+		{ID: "C1", Name: "general", NumMembers: 2, Topic: "new topic"},
+		{ID: "C2", Name: "new-name", NumMembers: 1},
+		{ID: "C5", Name: "brand-new", NumMembers: 3},
+		{ID: "C6", Name: "secret", IsPrivate: true, NumMembers: 4},
+	}
+
+	merged, stale := mergeChannels(prev, current)
+
+	var names []string
+	for _, ch := range merged {
+		names = append(names, ch.ID+":"+ch.Name)
+	}
+	if got, want := strings.Join(names, " "), "C5:brand-new C1:general C4:growing C2:new-name C6:secret"; got != want {
+		t.Errorf("merged = %q, want %q (renamed overrides, deleted removed, sorted)", got, want)
+	}
+
+	var staleIDs []string
+	for _, i := range stale {
+		staleIDs = append(staleIDs, merged[i].ID)
+	}
+	if got, want := strings.Join(staleIDs, " "), "C5 C4"; got != want {
+		t.Errorf("channels needing members = %q, want %q", got, want)
+	}
+
+	byID := map[string]SlackChannel{}
+	for _, ch := range merged {
+		byID[ch.ID] = ch
+	}
+	if got := byID["C1"]; got.Topic != "new topic" || len(got.MemberIDs) != 2 {
+		t.Errorf("C1 = %+v, want current metadata with carried-over members", got)
+	}
+	if got := byID["C2"]; len(got.MemberIDs) != 1 {
+		t.Errorf("renamed channel lost its members: %+v", got)
+	}
+}
+
+func TestMergeUsers(t *testing.T) {
+	since := time.Unix(1_700_000_000, 0)
+	prev := NewSlackIndex("T1", "Team")
+	prev.UpsertUser(SlackUser{ID: "U1", Username: "alice", DisplayName: "Alice (old)"})
+	prev.UpsertUser(SlackUser{ID: "U2", Username: "bob", DisplayName: "Bob (old)"})
+	prev.UpsertUser(SlackUser{ID: "U3", Username: "carol", DisplayName: "Carol (old)"})
+	prev.UpsertUser(SlackUser{ID: "U4", Username: "dave"})
+	prev.UpsertUser(SlackUser{ID: "U5", Username: "erin"})
+
+	user := func(id, name, display string, updated int64) slack.User {
+		u := slack.User{ID: id, Name: name, Updated: slack.JSONTime(updated)}
+		u.Profile.DisplayName = display
+		return u
+	}
+	deleted := user("U5", "erin", "", since.Unix()+60)
+	deleted.Deleted = true
+	users := []slack.User{
+		user("U1", "alice", "Alice (new)", since.Unix()+60), // updated: replaced
+		user("U2", "bob", "Bob (new)", since.Unix()-60),     // unchanged: kept
+		user("U3", "carol", "Carol (new)", 0),               // no updated field: kept
+		user("U6", "frank", "Frank", 0),                     // new: added
+		deleted,                                             // deleted: removed
+		// U4 missing: removed
+	}
+
+	var got []string
+	for _, u := range mergeUsers(prev, users, since) {
+		got = append(got, u.Username+"="+u.DisplayName)
+	}
+	want := "alice=Alice (new) bob=Bob (old) carol=Carol (old) frank=Frank"
+	if strings.Join(got, " ") != want {
+		t.Errorf("merged users = %q, want %q", strings.Join(got, " "), want)
+	}
+}
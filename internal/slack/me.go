@@ -0,0 +1,69 @@
+package slack
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/slack-go/slack"
+)
+
+// UserStatus is a user's custom status (text + emoji)
+type UserStatus struct {
+	Text       string     `json:"text,omitempty"`
+	Emoji      string     `json:"emoji,omitempty"`
+	Expiration *time.Time `json:"expiration,omitempty"`
+}
+
+// Reminder is an open Slack reminder
+type Reminder struct {
+	ID        string    `json:"id"`
+	Text      string    `json:"text"`
+	Time      time.Time `json:"time"`
+	Recurring bool      `json:"recurring"`
+}
+
+// GetUserStatus returns a user's custom status from their profile
+func (c *Client) GetUserStatus(userID string) (*UserStatus, error) {
+	profile, err := c.preferredReadAPI().GetUserProfile(&slack.GetUserProfileParameters{UserID: userID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user profile: %w", err)
+	}
+	status := &UserStatus{
+		Text:  profile.StatusText,
+		Emoji: profile.StatusEmoji,
+	}
+	if profile.StatusExpiration > 0 {
+		exp := time.Unix(int64(profile.StatusExpiration), 0)
+		status.Expiration = &exp
+	}
+	return status, nil
+}
+
+// ListOpenReminders returns the user's incomplete reminders sorted by time (requires user token)
+func (c *Client) ListOpenReminders() ([]Reminder, error) {
+	if c.userAPI == nil {
+		return nil, fmt.Errorf("user token not configured")
+	}
+	reminders, err := c.userAPI.ListReminders()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reminders: %w", err)
+	}
+
+	var open []Reminder
+	for _, r := range reminders {
+		if r.CompleteTS != 0 {
+			continue
+		}
+		open = append(open, Reminder{
+			ID:        r.ID,
+			Text:      r.Text,
+			Time:      time.Unix(int64(r.Time), 0),
+			Recurring: r.Recurring,
+		})
+	}
+	sort.Slice(open, func(i, j int) bool {
+		return open[i].Time.Before(open[j].Time)
+	})
+	return open, nil
+}
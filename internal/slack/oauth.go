@@ -41,18 +41,20 @@ const (
 // user_scope= is intentional. Any command that supports --as bot|user requires
 // the underlying scope to be present on both sides.
 var botAndUserScopes = []string{
-	"channels:history", // GetConversationHistory — unreads, thread, mentions scan
-	"channels:read",    // GetConversationInfo, GetConversations — index, channel resolution
-	"chat:write",       // PostMessage, UpdateMessage, DeleteMessage — send, edit, delete
-	"files:write",      // UploadFileV2 — upload
-	"groups:history",   // GetConversationHistory on private channels — unreads, thread
-	"groups:read",      // GetConversations(private_channel) — index, private channel resolution
-	"im:read",          // GetConversations(im) — DM channel listing in index
-	"im:write",         // OpenConversation — open DM before sending
-	"reactions:read",   // GetReactions — thread view; userAPI tried first, bot as fallback
-	"reactions:write",  // AddReaction — react
-	"users.profile:read", // GetUsers extended profile fields — user index
-	"users:read",       // GetUsers — user index, mention resolution
+	"channels:history",     // GetConversationHistory — unreads, thread, mentions scan
+	"channels:read",        // GetConversationInfo, GetConversations — index, channel resolution
+	"channels:write.topic", // SetTopicOfConversation, SetPurposeOfConversation — set-topic, set-purpose
+	"chat:write",           // PostMessage, UpdateMessage, DeleteMessage — send, edit, delete
+	"files:write",          // UploadFileV2 — upload
+	"groups:history",       // GetConversationHistory on private channels — unreads, thread
+	"groups:read",          // GetConversations(private_channel) — index, private channel resolution
+	"groups:write.topic",   // SetTopicOfConversation, SetPurposeOfConversation on private channels
+	"im:read",              // GetConversations(im) — DM channel listing in index
+	"im:write",             // OpenConversation — open DM before sending
+	"reactions:read",       // GetReactions — thread view; userAPI tried first, bot as fallback
+	"reactions:write",      // AddReaction — react
+	"users.profile:read",   // GetUsers extended profile fields — user index
+	"users:read",           // GetUsers — user index, mention resolution
 }
 
 // additionalBotScopes are requested only for the bot identity.
@@ -0,0 +1,36 @@
+package slack
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PollEmoji are the number reactions used as vote buttons, in option order
+var PollEmoji = []string{"one", "two", "three", "four", "five", "six", "seven", "eight", "nine", "keycap_ten"}
+
+// FormatPoll builds the mrkdwn text of a reaction poll: the question in bold,
+// then one numbered line per option. It needs 2 to len(PollEmoji) options.
+func FormatPoll(question string, options []string) (string, error) {
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", fmt.Errorf("poll question is empty")
+	}
+	if len(options) < 2 {
+		return "", fmt.Errorf("a poll needs at least 2 options, got %d", len(options))
+	}
+	if len(options) > len(PollEmoji) {
+		return "", fmt.Errorf("a poll supports at most %d options, got %d", len(PollEmoji), len(options))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, ":bar_chart: *%s*\n\n", question)
+	for i, opt := range options {
+		opt = strings.TrimSpace(opt)
+		if opt == "" {
+			return "", fmt.Errorf("option %d is empty", i+1)
+		}
+		fmt.Fprintf(&b, ":%s: %s\n", PollEmoji[i], opt)
+	}
+	b.WriteString("\n_Vote by reacting with the option's number._")
+	return b.String(), nil
+}
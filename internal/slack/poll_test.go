@@ -0,0 +1,24 @@
+package slack
+
+import "testing"
+
+func TestFormatPoll(t *testing.T) {
+	text, err := FormatPoll("Lunch?", []string{"Pizza", " Sushi "})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := ":bar_chart: *Lunch?*\n\n:one: Pizza\n:two: Sushi\n\n_Vote by reacting with the option's number._"
+	if text != want {
+		t.Errorf("FormatPoll:\ngot  %q\nwant %q", text, want)
+	}
+
+	for name, opts := range map[string][]string{
+		"one option":   {"a"},
+		"too many":     {"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11"},
+		"empty option": {"a", " "},
+	} {
+		if _, err := FormatPoll("q", opts); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
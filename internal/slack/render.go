@@ -2,12 +2,17 @@ package slack
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/codewandler/dex/internal/render"
+	"github.com/fatih/color"
 )
 
+// contextColor dims the surrounding messages shown by `dex slack search --context`
+var contextColor = color.New(color.FgHiBlack)
+
 // UnreadResult is the output of `dex slack unreads` — a list of channels
 // with their unread messages, ready for multi-format rendering.
 type UnreadResult struct {
@@ -89,6 +94,7 @@ type ThreadMessage struct {
 	Text        string                    `json:"text"`
 	Attachments []ThreadMessageAttachment `json:"attachments,omitempty"`
 	Files       []ThreadMessageFile       `json:"files,omitempty"`
+	Permalink   string                    `json:"permalink,omitempty"` // only with --url
 }
 
 // ThreadResult is the output of `dex slack thread`.
@@ -96,6 +102,7 @@ type ThreadResult struct {
 	ChannelID   string          `json:"channel_id"`
 	ChannelName string          `json:"channel_name,omitempty"`
 	ThreadTS    string          `json:"thread_ts"`
+	ThreadTime  string          `json:"thread_time,omitempty"`
 	Messages    []ThreadMessage `json:"messages"`
 	Status      string          `json:"status"` // "pending", "acked", "replied"
 	// Debug fields — only populated when --debug is set
@@ -141,13 +148,20 @@ func (r *ThreadResult) RenderText(mode render.Mode) string {
 			}
 			fmt.Fprintf(&b, "  [%d] %s @%s%s %s: %s%s\n",
 				msg.Index, label, msg.Username, meTag, msg.Timestamp, text, filesSuffix)
+			if msg.Permalink != "" {
+				fmt.Fprintf(&b, "      %s\n", msg.Permalink)
+			}
 		}
 		return b.String()
 	}
 
 	// Normal: full multi-line output
 	fmt.Fprintf(&b, "Channel: %s\n", channelLabel)
-	fmt.Fprintf(&b, "Thread:  %s\n", r.ThreadTS)
+	if r.ThreadTime != "" {
+		fmt.Fprintf(&b, "Thread:  %s (%s)\n", r.ThreadTime, r.ThreadTS)
+	} else {
+		fmt.Fprintf(&b, "Thread:  %s\n", r.ThreadTS)
+	}
 	if len(r.MyUserIDs) > 0 || len(r.MyBotIDs) > 0 {
 		fmt.Fprintf(&b, "My User IDs: %v\n", r.MyUserIDs)
 		fmt.Fprintf(&b, "My Bot IDs:  %v\n", r.MyBotIDs)
@@ -183,6 +197,9 @@ func (r *ThreadResult) RenderText(mode render.Mode) string {
 		if filesText := renderFiles(msg.Files); filesText != "" {
 			b.WriteString(filesText)
 		}
+		if msg.Permalink != "" {
+			fmt.Fprintf(&b, "    %s\n", msg.Permalink)
+		}
 	}
 
 	fmt.Fprintf(&b, "\n%s\n", strings.Repeat("─", 80))
@@ -208,11 +225,69 @@ type MentionItem struct {
 
 // MentionsResult is the output of `dex slack mentions`.
 type MentionsResult struct {
-	Target    string        `json:"target"`
-	Mentions  []MentionItem `json:"mentions"`
-	Total     int           `json:"total"`
-	Shown     int           `json:"shown"`
-	Unhandled bool          `json:"unhandled_only"`
+	Target    string         `json:"target"`
+	Mentions  []MentionItem  `json:"mentions"`
+	Total     int            `json:"total"`
+	Shown     int            `json:"shown"`
+	Unhandled bool           `json:"unhandled_only"`
+	GroupBy   string         `json:"group_by,omitempty"` // "channel" or "status"
+	Groups    []MentionGroup `json:"groups,omitempty"`
+}
+
+// MentionGroup is a bucket of mentions sharing a channel or status.
+type MentionGroup struct {
+	Key      string         `json:"key"`
+	Count    int            `json:"count"`
+	ByStatus map[string]int `json:"by_status,omitempty"` // channel groups only
+	Mentions []MentionItem  `json:"-"`
+}
+
+// mentionStatusOrder is the display order of status groups (most urgent first)
+var mentionStatusOrder = []MentionStatus{MentionStatusPending, MentionStatusAcked, MentionStatusReplied}
+
+// GroupMentions buckets mentions by "channel" or "status", keeping their order
+// within each group. Status groups are ordered Pending, Acked, Replied; channel
+// groups by their first mention, so the most recently active channel leads.
+func GroupMentions(items []MentionItem, by string) []MentionGroup {
+	var groups []MentionGroup
+	index := make(map[string]int)
+	if by == "status" {
+		for _, st := range mentionStatusOrder {
+			index[string(st)] = len(groups)
+			groups = append(groups, MentionGroup{Key: string(st)})
+		}
+	}
+
+	for _, m := range items {
+		key := m.Status
+		if by == "channel" {
+			key = m.ChannelName
+		}
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, MentionGroup{Key: key})
+		}
+		g := &groups[i]
+		g.Count++
+		g.Mentions = append(g.Mentions, m)
+		if by == "channel" {
+			if g.ByStatus == nil {
+				g.ByStatus = make(map[string]int)
+			}
+			g.ByStatus[m.Status]++
+		}
+	}
+
+	// Drop empty status buckets
+	nonEmpty := groups[:0]
+	for _, g := range groups {
+		if g.Count > 0 {
+			nonEmpty = append(nonEmpty, g)
+		}
+	}
+	return nonEmpty
 }
 
 // RenderText implements render.Renderable.
@@ -228,42 +303,27 @@ func (r *MentionsResult) RenderText(mode render.Mode) string {
 		return b.String()
 	}
 
-	if mode == render.ModeCompact {
-		fmt.Fprintf(&b, "%-19s %-20s %-15s %-8s %s\n", "TIME", "CHANNEL", "FROM", "STATUS", "MESSAGE")
-		fmt.Fprintf(&b, "%s\n", strings.Repeat("─", 100))
-		for _, m := range r.Mentions {
-			filesSuffix := renderFilesCompact(m.Files)
-			maxText := 50 - len(filesSuffix)
-			if maxText < 20 {
-				maxText = 20
+	if r.GroupBy != "" {
+		n := 0
+		for gi, g := range GroupMentions(r.Mentions, r.GroupBy) {
+			if gi > 0 {
+				b.WriteString("\n")
+			}
+			b.WriteString(mentionGroupHeader(g, r.GroupBy))
+			if mode == render.ModeCompact {
+				renderMentionTable(&b, g.Mentions)
+				continue
+			}
+			for _, m := range g.Mentions {
+				n++
+				renderMentionEntry(&b, n, m)
 			}
-			text := mentionTruncate(MessageDisplayText(m.Text, m.Attachments), maxText)
-			fmt.Fprintf(&b, "%-19s %-20s %-15s %-8s %s%s\n",
-				m.Timestamp,
-				mentionTruncate("#"+m.ChannelName, 20),
-				mentionTruncate("@"+m.Username, 15),
-				m.Status,
-				text,
-				filesSuffix,
-			)
 		}
+	} else if mode == render.ModeCompact {
+		renderMentionTable(&b, r.Mentions)
 	} else {
 		for i, m := range r.Mentions {
-			fmt.Fprintf(&b, "── %d ──────────────────────────────────────────────────────────────────────────────\n", i+1)
-			fmt.Fprintf(&b, "#%s  •  %s  •  @%s  •  [%s]\n", m.ChannelName, m.Timestamp, m.Username, m.Status)
-			if m.Permalink != "" {
-				fmt.Fprintf(&b, "%s\n", m.Permalink)
-			}
-			b.WriteString("\n")
-			b.WriteString(m.Text)
-			b.WriteString("\n")
-			if attText := renderAttachments(m.Attachments); attText != "" {
-				b.WriteString(attText)
-			}
-			if filesText := renderFiles(m.Files); filesText != "" {
-				b.WriteString(filesText)
-			}
-			b.WriteString("\n")
+			renderMentionEntry(&b, i+1, m)
 		}
 	}
 
@@ -278,6 +338,61 @@ func (r *MentionsResult) RenderText(mode render.Mode) string {
 	return b.String()
 }
 
+// mentionGroupHeader formats a group section header, e.g.
+// "═══ #incidents (3: 2 pending, 1 replied) ═══" or "═══ Pending (5) ═══".
+func mentionGroupHeader(g MentionGroup, by string) string {
+	label := fmt.Sprintf("%s (%d)", g.Key, g.Count)
+	if by == "channel" {
+		var parts []string
+		for _, st := range mentionStatusOrder {
+			if n := g.ByStatus[string(st)]; n > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", n, strings.ToLower(string(st))))
+			}
+		}
+		label = fmt.Sprintf("#%s (%d: %s)", g.Key, g.Count, strings.Join(parts, ", "))
+	}
+	return fmt.Sprintf("═══ %s ═══\n", label)
+}
+
+func renderMentionTable(b *strings.Builder, mentions []MentionItem) {
+	fmt.Fprintf(b, "%-19s %-20s %-15s %-8s %s\n", "TIME", "CHANNEL", "FROM", "STATUS", "MESSAGE")
+	fmt.Fprintf(b, "%s\n", strings.Repeat("─", 100))
+	for _, m := range mentions {
+		filesSuffix := renderFilesCompact(m.Files)
+		maxText := 50 - len(filesSuffix)
+		if maxText < 20 {
+			maxText = 20
+		}
+		text := mentionTruncate(MessageDisplayText(m.Text, m.Attachments), maxText)
+		fmt.Fprintf(b, "%-19s %-20s %-15s %-8s %s%s\n",
+			m.Timestamp,
+			mentionTruncate("#"+m.ChannelName, 20),
+			mentionTruncate("@"+m.Username, 15),
+			m.Status,
+			text,
+			filesSuffix,
+		)
+	}
+}
+
+func renderMentionEntry(b *strings.Builder, n int, m MentionItem) {
+	fmt.Fprintf(b, "── %d ──────────────────────────────────────────────────────────────────────────────\n", n)
+	fmt.Fprintf(b, "#%s  •  %s  •  @%s  •  [%s]\n", m.ChannelName, m.Timestamp, m.Username, m.Status)
+	if m.Permalink != "" {
+		fmt.Fprintf(b, "%s\n", m.Permalink)
+	}
+	b.WriteString("\n")
+	b.WriteString(m.Text)
+	b.WriteString("\n")
+	if attText := renderAttachments(m.Attachments); attText != "" {
+		b.WriteString(attText)
+	}
+	if filesText := renderFiles(m.Files); filesText != "" {
+		b.WriteString(filesText)
+	}
+	b.WriteString("\n")
+}
+
 func mentionTruncate(s string, max int) string {
 	s = strings.ReplaceAll(s, "\n", " ")
 	s = strings.Join(strings.Fields(s), " ")
@@ -305,6 +420,8 @@ type SearchItem struct {
 	Attachments []MessageAttachment `json:"attachments,omitempty"`
 	Files       []ThreadMessageFile `json:"files,omitempty"`
 	Permalink   string              `json:"permalink,omitempty"`
+	Before      []ContextMessage    `json:"context_before,omitempty"`
+	After       []ContextMessage    `json:"context_after,omitempty"`
 }
 
 // SearchResultOutput is the output of `dex slack search`.
@@ -314,6 +431,32 @@ type SearchResultOutput struct {
 	Tickets []TicketMention `json:"tickets,omitempty"`
 	Total   int             `json:"total"`
 	Shown   int             `json:"shown"`
+	Note    string          `json:"note,omitempty"`
+}
+
+// SearchTicketsOutput is the structured (-o json/yaml) output of
+// `dex slack search --tickets`: each ticket key mapped to the permalinks of
+// the messages mentioning it.
+type SearchTicketsOutput struct {
+	Query   string              `json:"query"`
+	Tickets map[string][]string `json:"tickets"`
+	Total   int                 `json:"total"`
+	Shown   int                 `json:"shown"`
+}
+
+// RenderText implements render.Renderable.
+func (r *SearchTicketsOutput) RenderText(mode render.Mode) string {
+	keys := make([]string, 0, len(r.Tickets))
+	for k := range r.Tickets {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, k := range keys {
+		fmt.Fprintf(&b, "%s\t%s\n", k, strings.Join(r.Tickets[k], " "))
+	}
+	return b.String()
 }
 
 // RenderText implements render.Renderable.
@@ -354,6 +497,9 @@ func (r *SearchResultOutput) RenderText(mode render.Mode) string {
 				maxText = 20
 			}
 			text := mentionTruncate(MessageDisplayText(res.Text, res.Attachments), maxText)
+			for _, m := range res.Before {
+				contextColor.Fprintf(&b, "%-19s %-20s %-15s %s\n", "", "┆", mentionTruncate("@"+m.Username, 15), mentionTruncate(m.Text, 60))
+			}
 			fmt.Fprintf(&b, "%-19s %-20s %-15s %s%s\n",
 				res.Timestamp,
 				mentionTruncate("#"+res.ChannelName, 20),
@@ -361,6 +507,9 @@ func (r *SearchResultOutput) RenderText(mode render.Mode) string {
 				text,
 				filesSuffix,
 			)
+			for _, m := range res.After {
+				contextColor.Fprintf(&b, "%-19s %-20s %-15s %s\n", "", "┆", mentionTruncate("@"+m.Username, 15), mentionTruncate(m.Text, 60))
+			}
 		}
 	} else {
 		for i, res := range r.Results {
@@ -370,6 +519,7 @@ func (r *SearchResultOutput) RenderText(mode render.Mode) string {
 				fmt.Fprintf(&b, "%s\n", res.Permalink)
 			}
 			b.WriteString("\n")
+			renderContextMessages(&b, res.Before)
 			b.WriteString(MessageDisplayText(res.Text, res.Attachments))
 			b.WriteString("\n")
 			if attText := renderAttachments(res.Attachments); attText != "" {
@@ -378,6 +528,7 @@ func (r *SearchResultOutput) RenderText(mode render.Mode) string {
 			if filesText := renderFiles(res.Files); filesText != "" {
 				b.WriteString(filesText)
 			}
+			renderContextMessages(&b, res.After)
 			b.WriteString("\n")
 		}
 	}
@@ -388,9 +539,20 @@ func (r *SearchResultOutput) RenderText(mode render.Mode) string {
 	} else {
 		fmt.Fprintf(&b, "Found %d results\n", len(r.Results))
 	}
+	if r.Note != "" {
+		contextColor.Fprintf(&b, "%s\n", r.Note)
+	}
 	return b.String()
 }
 
+// renderContextMessages writes surrounding messages as dimmed "┆ HH:MM @user: text" lines.
+func renderContextMessages(b *strings.Builder, msgs []ContextMessage) {
+	for _, m := range msgs {
+		text := strings.ReplaceAll(m.Text, "\n", " ")
+		contextColor.Fprintf(b, "┆ %s @%s: %s\n", m.Time, m.Username, mentionTruncate(text, 120))
+	}
+}
+
 // MarkReadResult is the output of `dex slack mark-read`.
 type MarkReadResult struct {
 	ChannelID   string `json:"channel_id"`
@@ -409,6 +571,92 @@ func (m *MarkReadResult) RenderText(mode render.Mode) string {
 	return fmt.Sprintf("Marked %s as read up to %s\n", name, m.Timestamp)
 }
 
+// MeSummary aggregates the authenticated user's personal views for 'dex slack me'
+type MeSummary struct {
+	UserID            string            `json:"user_id"`
+	Username          string            `json:"username"`
+	Team              string            `json:"team"`
+	Presence          string            `json:"presence,omitempty"`
+	Status            *UserStatus       `json:"status,omitempty"`
+	MentionsToday     int               `json:"mentions_today"`
+	UnhandledMentions int               `json:"unhandled_mentions"`
+	Reminders         []Reminder        `json:"reminders"`
+	Errors            map[string]string `json:"errors,omitempty"` // section -> error, for partial results
+}
+
+// RenderText implements render.Renderable.
+func (m *MeSummary) RenderText(mode render.Mode) string {
+	var b strings.Builder
+
+	if mode == render.ModeCompact {
+		fmt.Fprintf(&b, "%s", m.Username)
+		if m.Presence != "" {
+			fmt.Fprintf(&b, " [%s]", m.Presence)
+		}
+		if m.Status != nil && (m.Status.Emoji != "" || m.Status.Text != "") {
+			fmt.Fprintf(&b, " %s", strings.TrimSpace(m.Status.Emoji+" "+m.Status.Text))
+		}
+		fmt.Fprintf(&b, " | mentions: %d unhandled/%d today | reminders: %d\n",
+			m.UnhandledMentions, m.MentionsToday, len(m.Reminders))
+		return b.String()
+	}
+
+	fmt.Fprintf(&b, "%s (%s) @ %s\n", m.Username, m.UserID, m.Team)
+	b.WriteString("────────────────────────────────────────────────────────────────\n")
+
+	presence := m.Presence
+	if presence == "" {
+		presence = "-"
+	}
+	fmt.Fprintf(&b, "  Presence:   %s\n", presence)
+
+	status := "-"
+	if m.Status != nil && (m.Status.Emoji != "" || m.Status.Text != "") {
+		status = strings.TrimSpace(m.Status.Emoji + " " + m.Status.Text)
+		if m.Status.Expiration != nil {
+			status += fmt.Sprintf(" (until %s)", m.Status.Expiration.Local().Format("Jan 02 15:04"))
+		}
+	}
+	fmt.Fprintf(&b, "  Status:     %s\n", status)
+
+	if _, failed := m.Errors["mentions"]; failed {
+		b.WriteString("  Mentions:   unavailable\n")
+	} else {
+		fmt.Fprintf(&b, "  Mentions:   %d unhandled / %d today", m.UnhandledMentions, m.MentionsToday)
+		if m.UnhandledMentions > 0 {
+			b.WriteString("  → dex slack mentions --unhandled")
+		}
+		b.WriteString("\n")
+	}
+
+	if _, failed := m.Errors["reminders"]; failed {
+		b.WriteString("  Reminders:  unavailable\n")
+	} else {
+		fmt.Fprintf(&b, "  Reminders:  %d open\n", len(m.Reminders))
+		for _, r := range m.Reminders {
+			recurring := ""
+			if r.Recurring {
+				recurring = " (recurring)"
+			}
+			fmt.Fprintf(&b, "    • %s  %s%s\n", r.Time.Local().Format("Jan 02 15:04"), r.Text, recurring)
+		}
+	}
+
+	if len(m.Errors) > 0 {
+		b.WriteString("\n")
+		sections := make([]string, 0, len(m.Errors))
+		for section := range m.Errors {
+			sections = append(sections, section)
+		}
+		sort.Strings(sections)
+		for _, section := range sections {
+			fmt.Fprintf(&b, "  ! %s: %s\n", section, m.Errors[section])
+		}
+	}
+
+	return b.String()
+}
+
 // channelDisplayName returns a human-readable channel name.
 func channelDisplayName(ch UnreadChannel) string {
 	if ch.IsDM {
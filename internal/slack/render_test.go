@@ -0,0 +1,91 @@
+package slack
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/codewandler/dex/internal/render"
+)
+
+func TestGroupMentions(t *testing.T) {
+	items := []MentionItem{
+		{ChannelName: "incidents", Timestamp: "2026-02-04 10:05:00", Status: "Pending"},
+		{ChannelName: "random", Timestamp: "2026-02-04 10:04:00", Status: "Replied"},
+		{ChannelName: "incidents", Timestamp: "2026-02-04 10:03:00", Status: "Replied"},
+		{ChannelName: "incidents", Timestamp: "2026-02-04 10:02:00", Status: "Pending"},
+		{ChannelName: "random", Timestamp: "2026-02-04 10:01:00", Status: "Pending"},
+	}
+
+	byChannel := GroupMentions(items, "channel")
+	if len(byChannel) != 2 || byChannel[0].Key != "incidents" || byChannel[1].Key != "random" {
+		t.Fatalf("channel groups = %+v, want incidents then random", byChannel)
+	}
+	if byChannel[0].Count != 3 || byChannel[0].ByStatus["Pending"] != 2 || byChannel[0].ByStatus["Replied"] != 1 {
+		t.Errorf("incidents group = %+v", byChannel[0])
+	}
+	// Time order is kept within a group
+	if got := byChannel[0].Mentions[1].Timestamp; got != "2026-02-04 10:03:00" {
+		t.Errorf("second incidents mention = %s, want 10:03", got)
+	}
+
+	byStatus := GroupMentions(items, "status")
+	var keys []string
+	for _, g := range byStatus {
+		keys = append(keys, g.Key)
+	}
+	// Acked has no mentions and is dropped; Pending leads
+	if got := strings.Join(keys, ","); got != "Pending,Replied" {
+		t.Errorf("status groups = %s, want Pending,Replied", got)
+	}
+	if byStatus[0].Count != 3 || byStatus[0].Mentions[2].ChannelName != "random" {
+		t.Errorf("pending group = %+v", byStatus[0])
+	}
+}
+
+func TestMentionsResultGroupedHeaders(t *testing.T) {
+	r := &MentionsResult{
+		Target: "U1 (me)",
+		Mentions: []MentionItem{
+			{ChannelName: "incidents", Username: "alice", Status: "Pending", Text: "a"},
+			{ChannelName: "incidents", Username: "bob", Status: "Replied", Text: "b"},
+			{ChannelName: "random", Username: "carol", Status: "Pending", Text: "c"},
+		},
+		Shown:   3,
+		Total:   3,
+		GroupBy: "channel",
+	}
+	out := r.RenderText(render.ModeCompact)
+	for _, want := range []string{"═══ #incidents (2: 1 pending, 1 replied) ═══", "═══ #random (1: 1 pending) ═══"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestSearchOutputJSON(t *testing.T) {
+	empty, err := json.Marshal(&SearchResultOutput{Query: "nothing", Results: []SearchItem{}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(empty), `"results":[]`) {
+		t.Errorf("empty search JSON = %s, want results as []", empty)
+	}
+
+	tickets, err := json.Marshal(&SearchTicketsOutput{
+		Query: "DEV-",
+		Tickets: map[string][]string{
+			"DEV-2": {"https://example.slack.com/archives/C1/p2"},
+			"DEV-1": {"https://example.slack.com/archives/C1/p1", "https://example.slack.com/archives/C2/p3"},
+		},
+		Total: 3,
+		Shown: 3,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"query":"DEV-","tickets":{"DEV-1":["https://example.slack.com/archives/C1/p1","https://example.slack.com/archives/C2/p3"],"DEV-2":["https://example.slack.com/archives/C1/p2"]},"total":3,"shown":3}`
+	if string(tickets) != want {
+		t.Errorf("tickets JSON =\n%s\nwant\n%s", tickets, want)
+	}
+}
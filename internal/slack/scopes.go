@@ -0,0 +1,79 @@
+package slack
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// authTestURL is the auth.test endpoint; tests point it at a local server
+var authTestURL = "https://slack.com/api/auth.test"
+
+// authTestClient bounds the scopes lookup so a stalled endpoint can't hang
+// `dex slack info --scopes`
+var authTestClient = &http.Client{Timeout: 15 * time.Second}
+
+// GetTokenScopes returns the OAuth scopes granted to token, sorted. Slack
+// reports them in the X-OAuth-Scopes header of every Web API response; an
+// auth.test call is the cheapest way to get one.
+func GetTokenScopes(token string) ([]string, error) {
+	req, err := http.NewRequest("POST", authTestURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := authTestClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("auth test failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("auth test failed: HTTP %d", resp.StatusCode)
+	}
+	if !body.OK {
+		return nil, fmt.Errorf("auth test failed: %s", body.Error)
+	}
+
+	var scopes []string
+	for _, s := range strings.Split(resp.Header.Get("X-OAuth-Scopes"), ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			scopes = append(scopes, s)
+		}
+	}
+	sort.Strings(scopes)
+	return scopes, nil
+}
+
+// MissingScopes returns the scopes dex requests for the bot (or, with user,
+// the user) identity that are not in granted, sorted. Commands relying on a
+// missing scope fail with missing_scope or, for conversation lookups, with
+// errors like channel_not_found.
+func MissingScopes(granted []string, user bool) []string {
+	requested := getBotScopes()
+	if user {
+		requested = getUserScopes()
+	}
+
+	have := make(map[string]bool, len(granted))
+	for _, s := range granted {
+		have[s] = true
+	}
+
+	var missing []string
+	for _, s := range requested {
+		if !have[s] {
+			missing = append(missing, s)
+		}
+	}
+	sort.Strings(missing)
+	return missing
+}
@@ -0,0 +1,59 @@
+package slack
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestGetTokenScopes(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer xoxp-valid" {
+			fmt.Fprint(w, `{"ok":false,"error":"invalid_auth"}`)
+			return
+		}
+		w.Header().Set("X-OAuth-Scopes", "users:read,search:read, chat:write")
+		fmt.Fprint(w, `{"ok":true,"user_id":"U1"}`)
+	}))
+	defer srv.Close()
+
+	orig := authTestURL
+	authTestURL = srv.URL
+	defer func() { authTestURL = orig }()
+
+	scopes, err := GetTokenScopes("xoxp-valid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(scopes, ","); got != "chat:write,search:read,users:read" {
+		t.Errorf("scopes = %s, want sorted and trimmed", got)
+	}
+
+	if _, err := GetTokenScopes("xoxp-revoked"); err == nil || !strings.Contains(err.Error(), "invalid_auth") {
+		t.Errorf("err = %v, want invalid_auth", err)
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	missing := MissingScopes(getUserScopes(), true)
+	if len(missing) != 0 {
+		t.Errorf("all requested user scopes granted, missing = %v", missing)
+	}
+
+	var granted []string
+	for _, s := range getUserScopes() {
+		if s != "search:read" && s != "im:write" {
+			granted = append(granted, s)
+		}
+	}
+	if got := strings.Join(MissingScopes(granted, true), ","); got != "im:write,search:read" {
+		t.Errorf("missing user scopes = %s, want im:write,search:read", got)
+	}
+
+	// search:read is only requested for the user token
+	if got := MissingScopes(getBotScopes(), false); len(got) != 0 {
+		t.Errorf("missing bot scopes = %v, want none", got)
+	}
+}
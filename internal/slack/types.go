@@ -41,13 +41,14 @@ type SlackUserGroup struct {
 
 // SlackIndex holds the cached Slack data (channels, users, and user groups)
 type SlackIndex struct {
-	Version         int              `json:"version"`
-	TeamID          string           `json:"team_id"`
-	TeamName        string           `json:"team_name"`
-	LastFullIndexAt time.Time        `json:"last_full_index_at"`
-	Channels        []SlackChannel   `json:"channels"`
-	Users           []SlackUser      `json:"users"`
-	UserGroups      []SlackUserGroup `json:"user_groups,omitempty"`
+	Version           int              `json:"version"`
+	TeamID            string           `json:"team_id"`
+	TeamName          string           `json:"team_name"`
+	LastFullIndexAt   time.Time        `json:"last_full_index_at"`
+	LastIncrementalAt time.Time        `json:"last_incremental_at,omitempty"`
+	Channels          []SlackChannel   `json:"channels"`
+	Users             []SlackUser      `json:"users"`
+	UserGroups        []SlackUserGroup `json:"user_groups,omitempty"`
 	// Lookup maps (not persisted)
 	ChannelsByID       map[string]int `json:"-"`
 	ChannelsByName     map[string]int `json:"-"`